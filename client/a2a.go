@@ -0,0 +1,416 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// a2aJSONRPC is the Agent Card name of the JSON-RPC interface.
+const a2aJSONRPC = "JSONRPC"
+
+// AgentCard is an A2A Agent Card, the document describing an agent and
+// where to reach it.
+type AgentCard struct {
+	// ProtocolVersion is the A2A protocol version the agent speaks.
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+
+	// Name is the agent's name.
+	Name string `json:"name"`
+
+	// Description describes the agent.
+	Description string `json:"description,omitempty"`
+
+	// URL is the endpoint of the agent's preferred interface.
+	URL string `json:"url"`
+
+	// PreferredTransport is the transport at URL, such as "JSONRPC".
+	// Empty means JSON-RPC.
+	PreferredTransport string `json:"preferredTransport,omitempty"`
+
+	// AdditionalInterfaces are further endpoints and their transports.
+	AdditionalInterfaces []AgentInterface `json:"additionalInterfaces,omitempty"`
+
+	// Version is the agent's own version.
+	Version string `json:"version,omitempty"`
+
+	// Capabilities lists optional A2A features the agent supports.
+	Capabilities AgentCapabilities `json:"capabilities"`
+
+	// Skills are the tasks the agent can perform.
+	Skills []AgentSkill `json:"skills,omitempty"`
+
+	// DefaultInputModes and DefaultOutputModes are the media types the
+	// agent accepts and produces.
+	DefaultInputModes  []string `json:"defaultInputModes,omitempty"`
+	DefaultOutputModes []string `json:"defaultOutputModes,omitempty"`
+}
+
+// AgentInterface is an endpoint of an agent.
+type AgentInterface struct {
+	URL       string `json:"url"`
+	Transport string `json:"transport"`
+}
+
+// AgentCapabilities lists optional A2A features.
+type AgentCapabilities struct {
+	Streaming         bool `json:"streaming,omitempty"`
+	PushNotifications bool `json:"pushNotifications,omitempty"`
+}
+
+// AgentSkill is a task an agent can perform.
+type AgentSkill struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// jsonRPCEndpoint returns the agent's JSON-RPC endpoint.
+func (a *AgentCard) jsonRPCEndpoint() (string, error) {
+	if a.URL != "" && (a.PreferredTransport == "" || a.PreferredTransport == a2aJSONRPC) {
+		return a.URL, nil
+	}
+	for _, iface := range a.AdditionalInterfaces {
+		if iface.Transport == a2aJSONRPC && iface.URL != "" {
+			return iface.URL, nil
+		}
+	}
+	return "", fmt.Errorf("agent card for %q offers no JSON-RPC interface", a.Name)
+}
+
+// A2AError is a JSON-RPC error returned by an A2A agent.
+type A2AError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *A2AError) Error() string {
+	return fmt.Sprintf("A2A error %d: %s", e.Code, e.Message)
+}
+
+// A2ATransport is a Transport for the A2A binding. It reads the
+// merchant's Agent Card, then sends each operation to the card's JSON-RPC
+// interface as a message/send request whose single data part holds the
+// operation: an "action" naming it as MCPTransport names tools (such as
+// create_checkout), plus the same "id", resource body, and "meta"
+// arguments. If the agent answers with a task that is still submitted or
+// working, the task is polled with tasks/get until it settles.
+//
+// The first data part of the task's artifacts, or failing that of its
+// status message, is returned as the response body. Failed, rejected, and
+// canceled tasks are returned as errors, and auth-required tasks as 401.
+type A2ATransport struct {
+	// CardURL is the merchant's Agent Card URL.
+	CardURL string
+
+	httpClient *http.Client
+	nextID     atomic.Int64
+
+	mu       sync.Mutex
+	card     *AgentCard
+	endpoint string
+}
+
+// NewA2ATransport creates an A2ATransport for the Agent Card at cardURL.
+// A nil httpClient uses http.DefaultClient.
+func NewA2ATransport(cardURL string, httpClient *http.Client) *A2ATransport {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &A2ATransport{CardURL: cardURL, httpClient: httpClient}
+}
+
+// Binding implements Transport.
+func (t *A2ATransport) Binding() string { return TransportA2A }
+
+// AgentCard returns the merchant's Agent Card, fetching it on first use.
+func (t *A2ATransport) AgentCard(ctx context.Context) (*AgentCard, error) {
+	card, _, err := t.agent(ctx)
+	return card, err
+}
+
+// agent returns the Agent Card and its JSON-RPC endpoint.
+func (t *A2ATransport) agent(ctx context.Context) (*AgentCard, string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.card != nil {
+		return t.card, t.endpoint, nil
+	}
+
+	card, err := fetchAgentCard(ctx, t.httpClient, t.CardURL)
+	if err != nil {
+		return nil, "", err
+	}
+	endpoint, err := card.jsonRPCEndpoint()
+	if err != nil {
+		return nil, "", err
+	}
+	t.card, t.endpoint = card, endpoint
+	return card, endpoint, nil
+}
+
+// fetchAgentCard fetches and parses an Agent Card.
+func fetchAgentCard(ctx context.Context, httpClient *http.Client, cardURL string) (*AgentCard, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cardURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch agent card: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch agent card: status %d", resp.StatusCode)
+	}
+	var card AgentCard
+	if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
+		return nil, fmt.Errorf("invalid agent card: %w", err)
+	}
+	return &card, nil
+}
+
+// a2aPart is a message or artifact part.
+type a2aPart struct {
+	Kind string          `json:"kind"`
+	Text string          `json:"text,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// a2aMessage is an A2A message.
+type a2aMessage struct {
+	Kind      string    `json:"kind"`
+	Role      string    `json:"role"`
+	MessageID string    `json:"messageId"`
+	Parts     []a2aPart `json:"parts"`
+}
+
+// a2aTask is an A2A task, or a message when Kind is "message".
+type a2aTask struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Status struct {
+		State   string      `json:"state"`
+		Message *a2aMessage `json:"message"`
+	} `json:"status"`
+	Artifacts []struct {
+		Parts []a2aPart `json:"parts"`
+	} `json:"artifacts"`
+	Parts []a2aPart `json:"parts"`
+}
+
+// settled reports whether a task will not change without further input.
+func (t *a2aTask) settled() bool {
+	switch t.Status.State {
+	case "submitted", "working":
+		return false
+	}
+	return true
+}
+
+// content returns the task's result content.
+func (t *a2aTask) content() json.RawMessage {
+	var groups [][]a2aPart
+	if t.Kind == "message" {
+		groups = append(groups, t.Parts)
+	}
+	for _, a := range t.Artifacts {
+		groups = append(groups, a.Parts)
+	}
+	if t.Status.Message != nil {
+		groups = append(groups, t.Status.Message.Parts)
+	}
+	for _, parts := range groups {
+		for _, p := range parts {
+			if p.Kind == "data" && len(p.Data) > 0 {
+				return p.Data
+			}
+		}
+	}
+	for _, parts := range groups {
+		for _, p := range parts {
+			if p.Kind == "text" {
+				return textContent(p.Text, t.failed())
+			}
+		}
+	}
+	return nil
+}
+
+func (t *a2aTask) failed() bool {
+	switch t.Status.State {
+	case "failed", "rejected", "canceled":
+		return true
+	}
+	return false
+}
+
+// RoundTrip implements Transport.
+func (t *A2ATransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, err := bindOperation(req)
+	if err != nil {
+		return nil, err
+	}
+	ctx := req.Context()
+	_, endpoint, err := t.agent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]any{"action": op.name}
+	for k, v := range op.args {
+		data[k] = v
+	}
+	part, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	message := a2aMessage{
+		Kind:      "message",
+		Role:      "user",
+		MessageID: newMessageID(),
+		Parts:     []a2aPart{{Kind: "data", Data: part}},
+	}
+	params := map[string]any{
+		"message":       message,
+		"configuration": map[string]any{"blocking": true, "acceptedOutputModes": []string{"application/json"}},
+	}
+
+	var task a2aTask
+	header, err := t.call(ctx, endpoint, req.Header, "message/send", params, &task)
+	delay := asyncInitialInterval
+	for err == nil && task.Kind != "message" && !task.settled() {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		if delay *= 2; delay > asyncMaxInterval {
+			delay = asyncMaxInterval
+		}
+		id := task.ID
+		task = a2aTask{}
+		header, err = t.call(ctx, endpoint, req.Header, "tasks/get", map[string]any{"id": id}, &task)
+	}
+
+	var httpErr *a2aHTTPError
+	if errors.As(err, &httpErr) {
+		return operationResponse(req, httpErr.statusCode, httpErr.header, httpErr.body), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if task.Status.State == "auth-required" {
+		return operationResponse(req, http.StatusUnauthorized, header, task.content()), nil
+	}
+	return op.response(req, header, task.content(), task.failed()), nil
+}
+
+// a2aHTTPError is an HTTP error status from the agent, passed to the
+// client as the operation's response.
+type a2aHTTPError struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func (e *a2aHTTPError) Error() string {
+	return fmt.Sprintf("A2A agent returned status %d", e.statusCode)
+}
+
+// call sends a JSON-RPC request and decodes its result into v.
+func (t *A2ATransport) call(ctx context.Context, endpoint string, header http.Header, method string, params, v any) (http.Header, error) {
+	data, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      t.nextID.Add(1),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header = header.Clone()
+	for _, h := range []string{SignatureHeader, "Idempotency-Key", "UCP-Delta-Base", "Content-Length"} {
+		req.Header.Del(h)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &a2aHTTPError{statusCode: resp.StatusCode, header: resp.Header, body: body}
+	}
+
+	var reply struct {
+		Result json.RawMessage `json:"result"`
+		Error  *A2AError       `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return nil, fmt.Errorf("invalid A2A response: %w", err)
+	}
+	if reply.Error != nil {
+		return nil, reply.Error
+	}
+	if err := json.Unmarshal(reply.Result, v); err != nil {
+		return nil, fmt.Errorf("invalid A2A result: %w", err)
+	}
+	return resp.Header, nil
+}
+
+// FetchAgentCard fetches the Agent Card advertised by the A2A binding of
+// the merchant's shopping service.
+func (c *Client) FetchAgentCard(ctx context.Context) (*AgentCard, error) {
+	profile, err := c.GetCachedProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	service, ok := profile.UCP.Services[ServiceShopping]
+	if !ok || service.A2A == nil || service.A2A.Endpoint == "" {
+		return nil, errors.New("merchant does not advertise an A2A binding")
+	}
+	return fetchAgentCard(ctx, c.httpClient, service.A2A.Endpoint)
+}
+
+// newMessageID returns a random A2A message ID.
+func newMessageID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// asyncInitialInterval is the first wait before polling a status
+	// resource whose 202 response has no Retry-After.
+	asyncInitialInterval = 500 * time.Millisecond
+
+	// asyncMaxInterval caps the wait between polls.
+	asyncMaxInterval = 10 * time.Second
+)
+
+// WithAsyncCompletion makes the client follow 202 Accepted responses that
+// carry a Location header, as some merchants return for long-running
+// operations such as completing a checkout. The client polls the status
+// resource with GET, honoring Retry-After and otherwise backing off
+// exponentially, until it answers with anything other than 202, and then
+// handles that response as the result of the original call. The client
+// timeout for the operation covers the whole wait.
+//
+// Locations on another origin than the merchant are not followed, so
+// credentials are never sent elsewhere. Without this option a 202 is
+// decoded like any other success.
+func WithAsyncCompletion(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.asyncCompletion = enabled
+	}
+}
+
+// awaitAccepted polls the status resource of a 202 response until it is
+// no longer pending and returns the final response and its body.
+func (c *Client) awaitAccepted(ctx context.Context, req *http.Request, resp *http.Response, body []byte) (*http.Response, []byte, error) {
+	delay := asyncInitialInterval
+	for resp.StatusCode == http.StatusAccepted {
+		location := resp.Header.Get("Location")
+		if location == "" {
+			return resp, body, nil
+		}
+		target, err := req.URL.Parse(location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid async status location %q: %w", location, err)
+		}
+		if target.Scheme != req.URL.Scheme || target.Host != req.URL.Host {
+			return nil, nil, fmt.Errorf("async status location %q is not on the merchant's origin", location)
+		}
+
+		wait := delay
+		if d, ok := retryAfter(resp); ok {
+			wait = d
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, ctx.Err()
+		case <-timer.C:
+		}
+		if delay *= 2; delay > asyncMaxInterval {
+			delay = asyncMaxInterval
+		}
+
+		poll, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		poll.Header = req.Header.Clone()
+		for _, h := range []string{"Content-Type", SignatureHeader, "Idempotency-Key", "UCP-Delta-Base"} {
+			poll.Header.Del(h)
+		}
+
+		start := time.Now()
+		next, err := c.httpClient.Do(poll)
+		if err != nil {
+			return nil, nil, fmt.Errorf("request failed: %w", err)
+		}
+		body, err = io.ReadAll(next.Body)
+		next.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		recordMeta(ctx, next, time.Since(start))
+		resp = next
+	}
+	return resp, body, nil
+}
+
+// retryAfter returns the wait a response's Retry-After header asks for,
+// given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// DefaultBuyNowCurrency is the currency BuyNow uses when none is given.
+const DefaultBuyNowCurrency = "USD"
+
+// BuyNowOption configures a BuyNow call.
+type BuyNowOption func(*buyNowConfig)
+
+type buyNowConfig struct {
+	currency     string
+	context      *models.Context
+	pollInterval time.Duration
+}
+
+// WithBuyNowCurrency sets the checkout currency. Defaults to
+// DefaultBuyNowCurrency.
+func WithBuyNowCurrency(currency string) BuyNowOption {
+	return func(c *buyNowConfig) {
+		c.currency = currency
+	}
+}
+
+// WithBuyNowContext sets the buyer context sent on checkout creation.
+func WithBuyNowContext(ctx *models.Context) BuyNowOption {
+	return func(c *buyNowConfig) {
+		c.context = ctx
+	}
+}
+
+// WithBuyNowPollInterval sets how often BuyNow polls a checkout left
+// complete_in_progress. Defaults to DefaultPollInterval.
+func WithBuyNowPollInterval(interval time.Duration) BuyNowOption {
+	return func(c *buyNowConfig) {
+		c.pollInterval = interval
+	}
+}
+
+// BuyNowOutcome is the result of a BuyNow call.
+type BuyNowOutcome struct {
+	// Checkout is the last checkout observed.
+	Checkout *extensions.ExtendedCheckoutResponse
+
+	// Order is the order confirmation, or nil if the purchase did not
+	// complete.
+	Order *models.OrderConfirmation
+
+	// Blocking contains the messages that stopped the flow before
+	// completion. It is empty when Order is set.
+	Blocking []models.Message
+
+	// Escalation is set when the flow stopped because the checkout
+	// requires escalation. Pass its ResumeToken to ResumeBuyNow once the
+	// buyer has visited its ContinueURL.
+	Escalation *Escalation
+}
+
+// Completed reports whether the purchase produced an order.
+func (o *BuyNowOutcome) Completed() bool {
+	return o.Order != nil
+}
+
+// BuyNow purchases a single item in one call for simple agent purchase
+// intents: it creates a checkout with the buyer and a selected payment
+// instrument, updates it to select the first offered fulfillment
+// destination and option wherever none is selected, and completes it,
+// waiting out complete_in_progress.
+//
+// The flow stops early when the checkout requires escalation, is canceled,
+// or carries an error the agent cannot fix through the API; the outcome
+// then holds the blocking messages and a nil Order, and for escalations an
+// Escalation to resume from. Transport and API errors are returned as err.
+func (c *Client) BuyNow(ctx context.Context, itemID string, quantity int, buyer *models.BuyerWithConsentCreateRequest, instrument models.PaymentInstrument, opts ...BuyNowOption) (*BuyNowOutcome, error) {
+	cfg := buyNowConfig{currency: DefaultBuyNowCurrency, pollInterval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if quantity <= 0 {
+		quantity = 1
+	}
+
+	checkout, err := c.CreateCheckout(ctx, &extensions.ExtendedCheckoutCreateRequest{
+		LineItems: []models.LineItemCreateRequest{{Item: models.ItemCreateRequest{ID: itemID}, Quantity: quantity}},
+		Currency:  cfg.currency,
+		Payment: models.PaymentCreateRequest{
+			Instruments:          []models.PaymentInstrument{instrument},
+			SelectedInstrumentID: instrument.ID,
+		},
+		Buyer:   buyer,
+		Context: cfg.context,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.continueBuyNow(ctx, checkout, instrument, cfg)
+}
+
+// continueBuyNow drives a BuyNow checkout from its current status to
+// completion, stopping where BuyNow documents.
+func (c *Client) continueBuyNow(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse, instrument models.PaymentInstrument, cfg buyNowConfig) (*BuyNowOutcome, error) {
+	var err error
+	if checkout.Status == models.CheckoutStatusCompleteInProgress {
+		checkout, err = c.waitForStatus(ctx, checkout, cfg.pollInterval, func(s models.CheckoutStatus) bool {
+			return s != models.CheckoutStatusCompleteInProgress
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if checkout.Status == models.CheckoutStatusCompleted {
+		return c.buyNowResult(checkout), nil
+	}
+	if blocking := blockingMessages(checkout); blocking != nil {
+		return c.stopBuyNow(checkout, blocking), nil
+	}
+
+	if checkout.Status != models.CheckoutStatusReadyForComplete {
+		if req := defaultSelections(checkout, instrument); req != nil {
+			if checkout, err = c.UpdateCheckout(ctx, checkout.ID, req); err != nil {
+				return nil, err
+			}
+			if blocking := blockingMessages(checkout); blocking != nil {
+				return c.stopBuyNow(checkout, blocking), nil
+			}
+		}
+	}
+	if checkout.Status != models.CheckoutStatusReadyForComplete {
+		// Anything still outstanding needs input BuyNow cannot supply.
+		return c.stopBuyNow(checkout, outstandingMessages(checkout)), nil
+	}
+
+	checkout, _, err = c.CompleteCheckoutAndWait(ctx, checkout.ID, cfg.pollInterval)
+	if err != nil {
+		return nil, err
+	}
+	return c.buyNowResult(checkout), nil
+}
+
+// buyNowResult builds the outcome of a flow that reached completion, or
+// stopped there without an order.
+func (c *Client) buyNowResult(checkout *extensions.ExtendedCheckoutResponse) *BuyNowOutcome {
+	if checkout.Order == nil {
+		return c.stopBuyNow(checkout, outstandingMessages(checkout))
+	}
+	return &BuyNowOutcome{Checkout: checkout, Order: checkout.Order}
+}
+
+// stopBuyNow builds the outcome of a flow stopped by blocking messages.
+func (c *Client) stopBuyNow(checkout *extensions.ExtendedCheckoutResponse, blocking []models.Message) *BuyNowOutcome {
+	return &BuyNowOutcome{Checkout: checkout, Blocking: blocking, Escalation: c.Escalation(checkout)}
+}
+
+// blockingMessages returns the messages that end a BuyNow flow: every
+// message when the checkout requires escalation or is canceled, otherwise
+// the errors that are not recoverable by the agent. It returns nil when the
+// flow can continue.
+func blockingMessages(checkout *extensions.ExtendedCheckoutResponse) []models.Message {
+	switch checkout.Status {
+	case models.CheckoutStatusRequiresEscalation, models.CheckoutStatusCanceled:
+		return append([]models.Message{}, checkout.Messages...)
+	}
+	var blocking []models.Message
+	for _, msg := range checkout.Messages {
+		if msg.Type == models.MessageTypeError && msg.Severity != "" && msg.Severity != models.SeverityRecoverable {
+			blocking = append(blocking, msg)
+		}
+	}
+	return blocking
+}
+
+// outstandingMessages returns the error messages of a checkout that could
+// not be completed, or all of its messages if it has no errors.
+func outstandingMessages(checkout *extensions.ExtendedCheckoutResponse) []models.Message {
+	var errs []models.Message
+	for _, msg := range checkout.Messages {
+		if msg.Type == models.MessageTypeError {
+			errs = append(errs, msg)
+		}
+	}
+	if errs == nil {
+		return append([]models.Message{}, checkout.Messages...)
+	}
+	return errs
+}
+
+// defaultSelections builds an update selecting the first destination and
+// option of every fulfillment method and group without a selection. It
+// returns nil if there is nothing to select.
+func defaultSelections(checkout *extensions.ExtendedCheckoutResponse, instrument models.PaymentInstrument) *extensions.ExtendedCheckoutUpdateRequest {
+	if checkout.Fulfillment == nil {
+		return nil
+	}
+
+	changed := false
+	fulfillment := &models.FulfillmentUpdateRequest{}
+	for _, m := range checkout.Fulfillment.Methods {
+		method := models.FulfillmentMethodUpdateRequest{
+			ID:                    m.ID,
+			LineItemIDs:           m.LineItemIDs,
+			SelectedDestinationID: m.SelectedDestinationID,
+		}
+		if method.SelectedDestinationID == nil && len(m.Destinations) > 0 {
+			id := m.Destinations[0].ID
+			method.SelectedDestinationID = &id
+			changed = true
+		}
+		for _, g := range m.Groups {
+			group := models.FulfillmentGroupUpdateRequest{ID: g.ID, SelectedOptionID: g.SelectedOptionID}
+			if group.SelectedOptionID == nil && len(g.Options) > 0 {
+				id := g.Options[0].ID
+				group.SelectedOptionID = &id
+				changed = true
+			}
+			method.Groups = append(method.Groups, group)
+		}
+		fulfillment.Methods = append(fulfillment.Methods, method)
+	}
+	if !changed {
+		return nil
+	}
+
+	lineItems := make([]models.LineItemUpdateRequest, len(checkout.LineItems))
+	for i, li := range checkout.LineItems {
+		lineItems[i] = models.LineItemUpdateRequest{
+			ID:       li.ID,
+			Item:     models.ItemUpdateRequest{ID: li.Item.ID},
+			Quantity: li.Quantity,
+			ParentID: li.ParentID,
+		}
+	}
+	return &extensions.ExtendedCheckoutUpdateRequest{
+		ID:        checkout.ID,
+		LineItems: lineItems,
+		Currency:  checkout.Currency,
+		Payment: models.PaymentUpdateRequest{
+			Instruments:          []models.PaymentInstrument{instrument},
+			SelectedInstrumentID: instrument.ID,
+		},
+		Fulfillment: fulfillment,
+	}
+}
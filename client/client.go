@@ -18,15 +18,21 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/httpcache"
 	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
 )
 
 const (
@@ -93,6 +99,15 @@ func WithUCPAgent(profileURL string) ClientOption {
 	}
 }
 
+// WithDryRun makes mutating methods (POST, PATCH, DELETE) return a
+// *DryRunRequest error describing the request instead of sending it.
+// Read-only requests such as discovery and GetCheckout are still executed.
+func WithDryRun() ClientOption {
+	return func(c *Client) {
+		c.dryRun = true
+	}
+}
+
 // Client is a UCP REST API client.
 type Client struct {
 	baseURL         string
@@ -102,9 +117,83 @@ type Client struct {
 	accessToken     string
 	userAgent       string
 	ucpAgentProfile string
-
-	// Cached discovery profile
-	profile *models.UCPProfile
+	dryRun          bool
+	signer          crypto.Signer
+	signerKid       string
+	fulfillmentCaps *PlatformFulfillmentCapabilities
+
+	// Response conformance checking
+	responseValidation ResponseValidationMode
+	schemas            *validation.SchemaValidator
+	onNonconformance   func(*ResponseValidationError)
+
+	// Request and response schema validation
+	validator *validation.SchemaValidator
+
+	// HTTP cache for schemas and other static resources
+	httpCache *httpcache.Cache
+
+	// Checkout versions for delta responses
+	deltas *deltaCache
+
+	// Journal of open checkout sessions
+	journal SessionJournal
+
+	// Request pruning for unadvertised capabilities
+	pruneRequests bool
+	onPrune       func(PrunedSection)
+
+	// Per-operation-class timeouts
+	opTimeouts map[OperationClass]time.Duration
+
+	// Retries of failed requests; nil sends once
+	retry *RetryPolicy
+
+	// Switching from REST when it keeps failing; guarded by transportMu
+	failover     *FailoverPolicy
+	restFailures int
+	failedOver   bool
+
+	// Polling of 202 Accepted status resources
+	asyncCompletion bool
+
+	// Transport binding; nil chooses from the profile
+	transport   Transport
+	transportMu sync.Mutex
+	mcp         *MCPTransport
+	a2a         *A2ATransport
+
+	// Deprecation notice callback
+	onDeprecation func(DeprecationNotice)
+
+	// Buyer context sanitization
+	contextSanitizer *validation.Sanitizer
+
+	// Per-capability extension layouts for checkout requests
+	extensionLayouts map[models.CapabilityName][]layoutRule
+
+	// Recent checkouts and orders; nil unless WithResourceCache
+	resources *resourceCache
+
+	// Cached discovery profile, with its validator and freshness
+	profileMu      sync.RWMutex
+	profile        *models.UCPProfile
+	profileETag    string
+	profileExpires time.Time
+	profileCache   ProfileCache
+	profileTTL     time.Duration
+	revalidating   atomic.Bool
+
+	// Negotiated features, cached for featuresFor
+	platformCaps []models.CapabilityDiscovery
+	features     *NegotiatedFeatures
+	featuresFor  *models.UCPProfile
+
+	// Background profile refresh
+	refreshInterval time.Duration
+	onProfileChange func(ProfileChange)
+	stopRefresh     chan struct{}
+	closeOnce       sync.Once
 }
 
 // NewClient creates a new UCP client.
@@ -121,9 +210,10 @@ func NewClient(baseURL string, opts ...ClientOption) *Client {
 
 	if c.httpClient == nil {
 		c.httpClient = &http.Client{
-			Timeout: c.timeout,
+			Timeout: c.httpTimeout(),
 		}
 	}
+	c.startProfileRefresh()
 
 	return c
 }
@@ -131,8 +221,16 @@ func NewClient(baseURL string, opts ...ClientOption) *Client {
 // Error represents an API error response.
 type Error struct {
 	StatusCode int
+	Code       string
 	Message    string
 	Details    map[string]interface{}
+
+	// Messages contains the UCP messages from the error envelope, if any.
+	Messages []models.Message
+
+	// Attempts records each send of the request when the client has a
+	// RetryPolicy, the last being the one that returned this error.
+	Attempts []Attempt
 }
 
 func (e *Error) Error() string {
@@ -140,20 +238,110 @@ func (e *Error) Error() string {
 }
 
 // doRequest performs an HTTP request and decodes the response.
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}, opts ...RequestOption) error {
+	ctx, cancel := c.withOperationTimeout(ctx, method, path)
+	defer cancel()
+
+	req, err := c.newRequest(ctx, method, path, body, opts...)
+	if err != nil {
+		return err
+	}
+
+	if c.dryRun && method != http.MethodGet && method != http.MethodHead {
+		return renderDryRun(req)
+	}
+
+	// Execute request
+	start := time.Now()
+	transport := c.transportFor(path)
+	resp, attempts, err := c.send(ctx, transport, req)
+	if next := c.recordOutcome(transport, resp, err); next != nil {
+		req, resp, attempts, err = c.failOver(ctx, next, req, resp, attempts, err)
+	}
+	if err != nil {
+		if len(attempts) > 1 {
+			return fmt.Errorf("request failed after %d attempts: %w", len(attempts), err)
+		}
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	recordMeta(ctx, resp, time.Since(start))
+
+	if resp.StatusCode == http.StatusAccepted && c.asyncCompletion {
+		if resp, respBody, err = c.awaitAccepted(ctx, req, resp, respBody); err != nil {
+			return err
+		}
+	}
+
+	// Check for errors
+	if resp.StatusCode >= 400 {
+		if c.journal != nil {
+			c.journalResponse(req, path, resp.StatusCode, nil)
+		}
+		apiErr := parseError(resp, respBody)
+		apiErr.Attempts = attempts
+		return apiErr
+	}
+
+	if c.deltas != nil {
+		if respBody, err = c.deltas.resolve(path, resp, respBody); err != nil {
+			return err
+		}
+	}
+
+	c.notifyDeprecation(method, path, resp, respBody)
+
+	if !req.URL.Query().Has(server.FieldsParam) {
+		if err := c.checkConformance(method, path, respBody); err != nil {
+			return err
+		}
+		if err := c.validateResponse(ctx, method, path, respBody); err != nil {
+			return err
+		}
+	}
+
+	// Decode response
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	if c.journal != nil {
+		c.journalResponse(req, path, resp.StatusCode, result)
+	}
+
+	return nil
+}
+
+// newRequest builds an HTTP request with the client's standard headers.
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*http.Request, error) {
 	// Build URL
 	u, err := url.Parse(c.baseURL)
 	if err != nil {
-		return fmt.Errorf("invalid base URL: %w", err)
+		return nil, fmt.Errorf("invalid base URL: %w", err)
 	}
 	u.Path = path
 
 	// Encode body
 	var bodyReader io.Reader
+	var data []byte
 	if body != nil {
-		data, err := json.Marshal(body)
+		body, err = c.encodeExtensions(ctx, c.sanitizeBody(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		data, err = json.Marshal(body)
 		if err != nil {
-			return fmt.Errorf("failed to encode request body: %w", err)
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		if err := c.validateRequest(ctx, method, path, data); err != nil {
+			return nil, err
 		}
 		bodyReader = bytes.NewReader(data)
 	}
@@ -161,7 +349,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
@@ -178,120 +366,208 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	if c.ucpAgentProfile != "" {
 		req.Header.Set("UCP-Agent", fmt.Sprintf(`profile="%s"`, c.ucpAgentProfile))
 	}
-
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+	for _, opt := range opts {
+		opt(req)
+	}
+	if c.deltas != nil {
+		if base := c.deltas.base(method, path); base != "" {
+			req.Header.Set("UCP-Delta-Base", base)
+		}
+	}
+	if err := c.signRequest(req, data); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+	return req, nil
+}
+
+// parseError builds an *Error from an error response.
+func parseError(resp *http.Response, respBody []byte) *Error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Message:    http.StatusText(resp.StatusCode),
+	}
+	if len(respBody) == 0 {
+		return apiErr
 	}
 
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		apiErr := &Error{
-			StatusCode: resp.StatusCode,
-			Message:    http.StatusText(resp.StatusCode),
+	var errDetails map[string]interface{}
+	if json.Unmarshal(respBody, &errDetails) == nil {
+		apiErr.Details = errDetails
+		if code, ok := errDetails["error"].(string); ok {
+			apiErr.Code = code
 		}
-		if len(respBody) > 0 {
-			var errDetails map[string]interface{}
-			if json.Unmarshal(respBody, &errDetails) == nil {
-				apiErr.Details = errDetails
-				if msg, ok := errDetails["message"].(string); ok {
-					apiErr.Message = msg
-				}
-			}
+		if msg, ok := errDetails["message"].(string); ok {
+			apiErr.Message = msg
 		}
-		return apiErr
 	}
 
-	// Decode response
-	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
+	var envelope struct {
+		Messages []models.Message `json:"messages"`
+	}
+	if json.Unmarshal(respBody, &envelope) == nil && len(envelope.Messages) > 0 {
+		apiErr.Messages = envelope.Messages
+		if apiErr.Code == "" {
+			apiErr.Code = envelope.Messages[0].Code
+		}
+		if _, ok := apiErr.Details["message"]; !ok && envelope.Messages[0].Content != "" {
+			apiErr.Message = envelope.Messages[0].Content
 		}
 	}
-
-	return nil
+	return apiErr
 }
 
-// FetchProfile fetches the discovery profile from /.well-known/ucp.
-func (c *Client) FetchProfile(ctx context.Context) (*models.UCPProfile, error) {
-	var profile models.UCPProfile
-	if err := c.doRequest(ctx, http.MethodGet, WellKnownPath, nil, &profile); err != nil {
-		return nil, err
-	}
-	c.profile = &profile
-	return &profile, nil
+// FetchProfile fetches the discovery profile from /.well-known/ucp. A
+// profile already held is revalidated with If-None-Match, so an unchanged
+// profile costs a 304.
+func (c *Client) FetchProfile(ctx context.Context, opts ...RequestOption) (*models.UCPProfile, error) {
+	return c.fetchProfile(ctx, opts...)
 }
 
-// GetCachedProfile returns the cached discovery profile, fetching it if necessary.
-func (c *Client) GetCachedProfile(ctx context.Context) (*models.UCPProfile, error) {
-	if c.profile != nil {
-		return c.profile, nil
+// GetCachedProfile returns the cached discovery profile, fetching it if
+// necessary. A profile is fresh for its Cache-Control max-age or Expires,
+// else for the WithProfileTTL lifetime. A stale profile is revalidated: in
+// the background with WithProfileRefresh, while the stale profile is
+// returned, else before returning. A failed revalidation keeps the stale
+// profile.
+func (c *Client) GetCachedProfile(ctx context.Context, opts ...RequestOption) (*models.UCPProfile, error) {
+	now := time.Now()
+	held := c.cachedProfile()
+	if held.Fresh(now) {
+		return held.Profile, nil
+	}
+	if shared := c.sharedProfile(ctx); shared != nil && (held == nil || shared.Fresh(now)) {
+		c.storeProfile(shared)
+		if shared.Fresh(now) {
+			return shared.Profile, nil
+		}
+		held = shared
+	}
+	if held == nil {
+		return c.fetchProfile(ctx, opts...)
+	}
+	if c.refreshInterval > 0 {
+		c.revalidateProfile()
+		return held.Profile, nil
+	}
+	if profile, err := c.fetchProfile(ctx, opts...); err == nil {
+		return profile, nil
 	}
-	return c.FetchProfile(ctx)
+	return held.Profile, nil
 }
 
 // CreateCheckout creates a new checkout session.
-func (c *Client) CreateCheckout(ctx context.Context, req *extensions.ExtendedCheckoutCreateRequest) (*extensions.ExtendedCheckoutResponse, error) {
+func (c *Client) CreateCheckout(ctx context.Context, req *extensions.ExtendedCheckoutCreateRequest, opts ...RequestOption) (*extensions.ExtendedCheckoutResponse, error) {
+	req = c.pruneCreate(ctx, req)
 	var resp extensions.ExtendedCheckoutResponse
-	if err := c.doRequest(ctx, http.MethodPost, CheckoutSessionsPath, req, &resp); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, CheckoutSessionsPath, req, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
-// GetCheckout retrieves a checkout session by ID.
-func (c *Client) GetCheckout(ctx context.Context, id string) (*extensions.ExtendedCheckoutResponse, error) {
+// GetCheckout retrieves a checkout session by ID, from the cache enabled
+// by WithResourceCache if it holds a recent copy.
+func (c *Client) GetCheckout(ctx context.Context, id string, opts ...RequestOption) (*extensions.ExtendedCheckoutResponse, error) {
 	var resp extensions.ExtendedCheckoutResponse
-	path := fmt.Sprintf("%s/%s", CheckoutSessionsPath, id)
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &resp); err != nil {
+	path := checkoutPath(id)
+	if len(opts) == 0 && c.resources.load(path, &resp) {
+		return &resp, nil
+	}
+	checkout, err := c.fetchCheckout(ctx, id, opts...)
+	if err == nil && len(opts) == 0 {
+		c.resources.store(path, checkout)
+	}
+	return checkout, err
+}
+
+// fetchCheckout retrieves a checkout session from the merchant, bypassing
+// the resource cache, for callers watching it change.
+func (c *Client) fetchCheckout(ctx context.Context, id string, opts ...RequestOption) (*extensions.ExtendedCheckoutResponse, error) {
+	var resp extensions.ExtendedCheckoutResponse
+	if err := c.doRequest(ctx, http.MethodGet, checkoutPath(id), nil, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
 // UpdateCheckout updates a checkout session.
-func (c *Client) UpdateCheckout(ctx context.Context, id string, req *extensions.ExtendedCheckoutUpdateRequest) (*extensions.ExtendedCheckoutResponse, error) {
+func (c *Client) UpdateCheckout(ctx context.Context, id string, req *extensions.ExtendedCheckoutUpdateRequest, opts ...RequestOption) (*extensions.ExtendedCheckoutResponse, error) {
+	return c.updateCheckout(ctx, id, c.pruneUpdate(ctx, req), opts...)
+}
+
+// updateCheckout sends a checkout update as is.
+func (c *Client) updateCheckout(ctx context.Context, id string, req *extensions.ExtendedCheckoutUpdateRequest, opts ...RequestOption) (*extensions.ExtendedCheckoutResponse, error) {
+	defer c.InvalidateCheckout(id)
 	var resp extensions.ExtendedCheckoutResponse
 	path := fmt.Sprintf("%s/%s", CheckoutSessionsPath, id)
-	if err := c.doRequest(ctx, http.MethodPatch, path, req, &resp); err != nil {
+	if err := c.doRequest(ctx, http.MethodPatch, path, req, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
-// CompleteCheckout completes a checkout session.
-func (c *Client) CompleteCheckout(ctx context.Context, id string) (*extensions.ExtendedCheckoutResponse, error) {
+// CompleteCheckout completes a checkout session. Pass WithIdempotencyKey so
+// that retrying a completion whose response was lost cannot place a second
+// order.
+func (c *Client) CompleteCheckout(ctx context.Context, id string, opts ...RequestOption) (*extensions.ExtendedCheckoutResponse, error) {
+	defer c.InvalidateCheckout(id)
 	var resp extensions.ExtendedCheckoutResponse
 	path := fmt.Sprintf("%s/%s/complete", CheckoutSessionsPath, id)
-	if err := c.doRequest(ctx, http.MethodPost, path, nil, &resp); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, path, nil, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
 // CancelCheckout cancels a checkout session.
-func (c *Client) CancelCheckout(ctx context.Context, id string) (*extensions.ExtendedCheckoutResponse, error) {
+func (c *Client) CancelCheckout(ctx context.Context, id string, opts ...RequestOption) (*extensions.ExtendedCheckoutResponse, error) {
+	defer c.InvalidateCheckout(id)
 	var resp extensions.ExtendedCheckoutResponse
 	path := fmt.Sprintf("%s/%s/cancel", CheckoutSessionsPath, id)
-	if err := c.doRequest(ctx, http.MethodPost, path, nil, &resp); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, path, nil, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
-// GetOrder retrieves an order by ID.
-func (c *Client) GetOrder(ctx context.Context, id string) (*models.Order, error) {
+// GetOrder retrieves an order by ID, from the cache enabled by
+// WithResourceCache if it holds a recent copy.
+func (c *Client) GetOrder(ctx context.Context, id string, opts ...RequestOption) (*models.Order, error) {
 	var resp models.Order
-	path := fmt.Sprintf("%s/%s", OrdersPath, id)
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &resp); err != nil {
+	path := orderPath(id)
+	cached := len(opts) == 0
+	if cached && c.resources.load(path, &resp) {
+		return &resp, nil
+	}
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if cached {
+		c.resources.store(path, &resp)
+	}
+	return &resp, nil
+}
+
+// RequestOrderModification proposes a post-purchase change to an order,
+// such as a new delivery address or fewer units. The merchant accepts or
+// rejects it; a pending result is decided later and reported with an
+// order.modification_decided webhook.
+func (c *Client) RequestOrderModification(ctx context.Context, orderID string, req *models.OrderModificationRequest, opts ...RequestOption) (*models.OrderModification, error) {
+	defer c.InvalidateOrder(orderID)
+	var resp models.OrderModification
+	path := fmt.Sprintf("%s/%s/modifications", OrdersPath, orderID)
+	if err := c.doRequest(ctx, http.MethodPost, path, req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetOrderModification retrieves an order modification by ID.
+func (c *Client) GetOrderModification(ctx context.Context, orderID, id string, opts ...RequestOption) (*models.OrderModification, error) {
+	var resp models.OrderModification
+	path := fmt.Sprintf("%s/%s/modifications/%s", OrdersPath, orderID, id)
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -300,19 +576,19 @@ func (c *Client) GetOrder(ctx context.Context, id string) (*models.Order, error)
 // CreateCart creates a new shopping cart.
 // Carts provide lightweight pre-purchase exploration with estimated pricing
 // before committing to a checkout session.
-func (c *Client) CreateCart(ctx context.Context, req *models.CartCreateRequest) (*models.CartResponse, error) {
+func (c *Client) CreateCart(ctx context.Context, req *models.CartCreateRequest, opts ...RequestOption) (*models.CartResponse, error) {
 	var resp models.CartResponse
-	if err := c.doRequest(ctx, http.MethodPost, CartsPath, req, &resp); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, CartsPath, req, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
 // GetCart retrieves a cart by ID.
-func (c *Client) GetCart(ctx context.Context, id string) (*models.CartResponse, error) {
+func (c *Client) GetCart(ctx context.Context, id string, opts ...RequestOption) (*models.CartResponse, error) {
 	var resp models.CartResponse
 	path := fmt.Sprintf("%s/%s", CartsPath, id)
-	if err := c.doRequest(ctx, http.MethodGet, path, nil, &resp); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -320,19 +596,19 @@ func (c *Client) GetCart(ctx context.Context, id string) (*models.CartResponse,
 
 // UpdateCart updates a cart with new items.
 // Line items are fully replaced on update.
-func (c *Client) UpdateCart(ctx context.Context, id string, req *models.CartUpdateRequest) (*models.CartResponse, error) {
+func (c *Client) UpdateCart(ctx context.Context, id string, req *models.CartUpdateRequest, opts ...RequestOption) (*models.CartResponse, error) {
 	var resp models.CartResponse
 	path := fmt.Sprintf("%s/%s", CartsPath, id)
-	if err := c.doRequest(ctx, http.MethodPatch, path, req, &resp); err != nil {
+	if err := c.doRequest(ctx, http.MethodPatch, path, req, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
 // DeleteCart deletes a cart.
-func (c *Client) DeleteCart(ctx context.Context, id string) error {
+func (c *Client) DeleteCart(ctx context.Context, id string, opts ...RequestOption) error {
 	path := fmt.Sprintf("%s/%s", CartsPath, id)
-	if err := c.doRequest(ctx, http.MethodDelete, path, nil, nil); err != nil {
+	if err := c.doRequest(ctx, http.MethodDelete, path, nil, nil, opts...); err != nil {
 		return err
 	}
 	return nil
@@ -340,19 +616,15 @@ func (c *Client) DeleteCart(ctx context.Context, id string) error {
 
 // CreateCheckoutFromCart creates a checkout session from an existing cart.
 // This converts the cart to a checkout, using the cart's line_items, context, and buyer.
-func (c *Client) CreateCheckoutFromCart(ctx context.Context, cartID string, req *extensions.ExtendedCheckoutCreateRequest) (*extensions.ExtendedCheckoutResponse, error) {
-	// Create a wrapper that includes cart_id
-	type checkoutWithCart struct {
-		*extensions.ExtendedCheckoutCreateRequest
-		CartID string `json:"cart_id"`
-	}
-	wrapped := &checkoutWithCart{
-		ExtendedCheckoutCreateRequest: req,
-		CartID:                        cartID,
+func (c *Client) CreateCheckoutFromCart(ctx context.Context, cartID string, req *extensions.ExtendedCheckoutCreateRequest, opts ...RequestOption) (*extensions.ExtendedCheckoutResponse, error) {
+	body := extensions.ExtendedCheckoutCreateRequest{}
+	if req != nil {
+		body = *c.pruneCreate(ctx, req)
 	}
+	body.CartID = cartID
 
 	var resp extensions.ExtendedCheckoutResponse
-	if err := c.doRequest(ctx, http.MethodPost, CheckoutSessionsPath, wrapped, &resp); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, CheckoutSessionsPath, &body, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
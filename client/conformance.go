@@ -0,0 +1,110 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// ResponseValidationMode controls what happens when a response does not
+// conform to the schemas of its declared capabilities.
+type ResponseValidationMode int
+
+const (
+	// ResponseValidationOff disables conformance checking.
+	ResponseValidationOff ResponseValidationMode = iota
+
+	// ResponseValidationReport passes violations to the handler set with
+	// WithResponseValidationHandler and returns the response.
+	ResponseValidationReport
+
+	// ResponseValidationStrict fails the call with a *ResponseValidationError.
+	ResponseValidationStrict
+)
+
+// WithResponseValidation checks every response that carries
+// ucp.capabilities (checkouts, orders, carts) against the schemas of those
+// capabilities, using schemas to fetch and cache them. A nil schemas
+// creates a private validator.
+func WithResponseValidation(mode ResponseValidationMode, schemas *validation.SchemaValidator) ClientOption {
+	return func(c *Client) {
+		if schemas == nil {
+			schemas = validation.NewSchemaValidator()
+		}
+		c.responseValidation = mode
+		c.schemas = schemas
+	}
+}
+
+// WithResponseValidationHandler calls fn with the violations found in
+// ResponseValidationReport mode. Without a handler, that mode checks
+// responses but reports nothing.
+func WithResponseValidationHandler(fn func(*ResponseValidationError)) ClientOption {
+	return func(c *Client) {
+		c.onNonconformance = fn
+	}
+}
+
+// ResponseValidationError reports a response that does not conform to its
+// declared capabilities.
+type ResponseValidationError struct {
+	Method string
+	Path   string
+	Errors []validation.ValidationError
+}
+
+func (e *ResponseValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i := range e.Errors {
+		msgs[i] = e.Errors[i].Error()
+	}
+	return fmt.Sprintf("non-conformant response from %s %s: %s", e.Method, e.Path, strings.Join(msgs, "; "))
+}
+
+// checkConformance validates a response body according to the client's
+// response validation mode.
+func (c *Client) checkConformance(method, path string, body []byte) error {
+	if c.responseValidation == ResponseValidationOff || len(body) == 0 {
+		return nil
+	}
+
+	var envelope struct {
+		UCP *struct {
+			Capabilities []models.CapabilityResponse `json:"capabilities"`
+		} `json:"ucp"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.UCP == nil {
+		return nil
+	}
+
+	result := c.schemas.ValidateConformance(body, envelope.UCP.Capabilities)
+	if result.Valid {
+		return nil
+	}
+
+	err := &ResponseValidationError{Method: method, Path: path, Errors: result.Errors}
+	if c.responseValidation == ResponseValidationStrict {
+		return err
+	}
+	if c.onNonconformance != nil {
+		c.onNonconformance(err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/client"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+func TestResponseValidationModes(t *testing.T) {
+	const schemaURL = "https://ucp.dev/schemas/shopping/checkout.json"
+	merchant := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"chk_1","ucp":{"version":"2026-01-11","capabilities":[` +
+			`{"name":"dev.ucp.shopping.checkout","version":"2026-01-11","schema":"` + schemaURL + `"}]}}`))
+	}))
+	defer merchant.Close()
+	schemas := validation.NewSchemaValidator()
+	schemas.LoadSchemaFromBytes(schemaURL, []byte(`{"type":"object","required":["id","status"],"properties":{"id":{},"status":{}}}`))
+
+	tests := []struct {
+		name     string
+		mode     client.ResponseValidationMode
+		reported int
+		failed   bool
+	}{
+		{"off", client.ResponseValidationOff, 0, false},
+		{"report", client.ResponseValidationReport, 1, false},
+		{"strict", client.ResponseValidationStrict, 0, true},
+	}
+	for _, tt := range tests {
+		var reported []*client.ResponseValidationError
+		c := client.NewClient(merchant.URL,
+			client.WithResponseValidation(tt.mode, schemas),
+			client.WithResponseValidationHandler(func(err *client.ResponseValidationError) {
+				reported = append(reported, err)
+			}))
+		_, err := c.GetCheckout(context.Background(), "chk_1")
+		var validationErr *client.ResponseValidationError
+		if failed := errors.As(err, &validationErr); failed != tt.failed {
+			t.Errorf("%s: GetCheckout error = %v, want failure %v", tt.name, err, tt.failed)
+		}
+		if len(reported) != tt.reported {
+			t.Errorf("%s: %d violations reported, want %d", tt.name, len(reported), tt.reported)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/dhananjay2021/ucp-go-sdk/internal"
+)
+
+// WithDeltaResponses requests delta responses from merchants that support
+// them. The client remembers the latest version of each checkout it sees
+// and sends its token with checkout updates; a merchant may then answer
+// with a JSON merge patch, which the client applies and verifies before
+// decoding, so callers always receive the full checkout.
+func WithDeltaResponses() ClientOption {
+	return func(c *Client) {
+		c.deltas = &deltaCache{versions: make(map[string]deltaVersion)}
+	}
+}
+
+// deltaVersion is the latest known version of a checkout.
+type deltaVersion struct {
+	token string
+	doc   interface{}
+}
+
+// deltaCache tracks checkout versions for delta responses.
+type deltaCache struct {
+	mu       sync.Mutex
+	versions map[string]deltaVersion
+}
+
+// base returns the version token to send with a request, if any.
+func (d *deltaCache) base(method, path string) string {
+	if method != http.MethodPatch {
+		return ""
+	}
+	id := checkoutIDFromPath(path)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.versions[id].token
+}
+
+// resolve records the checkout version of a response and returns its full
+// body, reassembling it from the base version if the body is a delta.
+func (d *deltaCache) resolve(path string, resp *http.Response, body []byte) ([]byte, error) {
+	token := resp.Header.Get("UCP-Response-Version")
+	if token == "" || !strings.HasPrefix(path, CheckoutSessionsPath) {
+		return body, nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var doc interface{}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "application/merge-patch+json" {
+		id := checkoutIDFromPath(path)
+		prev, ok := d.versions[id]
+		if !ok || prev.token != resp.Header.Get("UCP-Delta-Base") {
+			delete(d.versions, id)
+			return nil, fmt.Errorf("delta response for unknown base version of checkout %s", id)
+		}
+		var patch interface{}
+		if err := json.Unmarshal(body, &patch); err != nil {
+			return nil, fmt.Errorf("failed to decode delta response: %w", err)
+		}
+		doc = internal.ApplyMergePatch(prev.doc, patch)
+		full, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reassemble delta response: %w", err)
+		}
+		if internal.VersionToken(full) != token {
+			delete(d.versions, id)
+			return nil, fmt.Errorf("reassembled checkout %s does not match version %s", id, token)
+		}
+		body = full
+	} else {
+		var err error
+		if doc, _, err = internal.CanonicalJSON(body); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	obj, _ := doc.(map[string]interface{})
+	id, _ := obj["id"].(string)
+	switch obj["status"] {
+	case "completed", "canceled":
+		delete(d.versions, id)
+	default:
+		d.versions[id] = deltaVersion{token: token, doc: doc}
+	}
+	return body, nil
+}
+
+// checkoutIDFromPath returns the checkout ID in a checkout session path.
+func checkoutIDFromPath(path string) string {
+	rest, ok := strings.CutPrefix(path, CheckoutSessionsPath+"/")
+	if !ok {
+		return ""
+	}
+	id, _, _ := strings.Cut(rest, "/")
+	return id
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// DeprecationNotice reports that a response was negotiated at a deprecated
+// capability version, from its Deprecation, Sunset, and Link headers and
+// its deprecated_version warning messages.
+type DeprecationNotice struct {
+	// Method and Path identify the request.
+	Method string
+	Path   string
+
+	// DeprecatedAt is when the version was deprecated, or zero if the
+	// merchant gave no date.
+	DeprecatedAt time.Time
+
+	// Sunset is when the version stops being served, or zero if unknown.
+	Sunset time.Time
+
+	// Links are URLs documenting the deprecation.
+	Links []string
+
+	// Messages are the deprecated_version warnings in the response body,
+	// naming each deprecated capability.
+	Messages []models.Message
+}
+
+// WithDeprecationHandler calls fn with a DeprecationNotice for every
+// successful response marked as using a deprecated capability version.
+func WithDeprecationHandler(fn func(DeprecationNotice)) ClientOption {
+	return func(c *Client) {
+		c.onDeprecation = fn
+	}
+}
+
+// Deprecation returns the deprecation notice carried by the response
+// headers, or nil if the response is not deprecated. Messages are not
+// populated.
+func (m *ResponseMeta) Deprecation() *DeprecationNotice {
+	return parseDeprecation(m.Header)
+}
+
+// parseDeprecation reads the deprecation headers of a response.
+func parseDeprecation(header http.Header) *DeprecationNotice {
+	value := header.Get("Deprecation")
+	if value == "" {
+		return nil
+	}
+	notice := &DeprecationNotice{}
+	if secs, ok := strings.CutPrefix(value, "@"); ok {
+		if n, err := strconv.ParseInt(secs, 10, 64); err == nil {
+			notice.DeprecatedAt = time.Unix(n, 0).UTC()
+		}
+	} else if t, err := http.ParseTime(value); err == nil {
+		// Earlier drafts used an HTTP-date.
+		notice.DeprecatedAt = t
+	}
+	if t, err := http.ParseTime(header.Get("Sunset")); err == nil {
+		notice.Sunset = t
+	}
+	for _, line := range header.Values("Link") {
+		for _, link := range strings.Split(line, ",") {
+			target, params, _ := strings.Cut(strings.TrimSpace(link), ";")
+			if strings.Contains(params, `rel="deprecation"`) || strings.Contains(params, "rel=deprecation") {
+				notice.Links = append(notice.Links, strings.Trim(strings.TrimSpace(target), "<>"))
+			}
+		}
+	}
+	return notice
+}
+
+// notifyDeprecation reports a deprecated response to the deprecation
+// handler.
+func (c *Client) notifyDeprecation(method, path string, resp *http.Response, body []byte) {
+	if c.onDeprecation == nil {
+		return
+	}
+	notice := parseDeprecation(resp.Header)
+	if notice == nil {
+		return
+	}
+	notice.Method = method
+	notice.Path = path
+
+	var envelope struct {
+		Messages []models.Message `json:"messages"`
+	}
+	if json.Unmarshal(body, &envelope) == nil {
+		for _, msg := range envelope.Messages {
+			if msg.Code == models.MessageCodeDeprecatedVersion {
+				notice.Messages = append(notice.Messages, msg)
+			}
+		}
+	}
+	c.onDeprecation(*notice)
+}
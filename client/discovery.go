@@ -15,6 +15,8 @@
 package client
 
 import (
+	"encoding/json"
+
 	"github.com/dhananjay2021/ucp-go-sdk/models"
 )
 
@@ -76,15 +78,28 @@ func GetPaymentHandler(profile *models.UCPProfile, handlerID string) *models.Pay
 	return nil
 }
 
+// remarshal converts a loosely typed value (such as a capability config map)
+// into a typed struct via JSON.
+func remarshal(in interface{}, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
 // Well-known capability names.
 const (
 	CapabilityCheckout        models.CapabilityName = "dev.ucp.shopping.checkout"
 	CapabilityOrder           models.CapabilityName = "dev.ucp.shopping.order"
+	CapabilityCart            models.CapabilityName = "dev.ucp.shopping.cart"
 	CapabilityIdentityLinking models.CapabilityName = "dev.ucp.identity_linking"
 	CapabilityFulfillment     models.CapabilityName = "dev.ucp.shopping.fulfillment"
 	CapabilityDiscount        models.CapabilityName = "dev.ucp.shopping.discount"
 	CapabilityBuyerConsent    models.CapabilityName = "dev.ucp.shopping.buyer_consent"
 	CapabilityPayment         models.CapabilityName = "dev.ucp.shopping.payment"
+	CapabilityProtection      models.CapabilityName = "dev.ucp.shopping.protection"
+	CapabilitySettlement      models.CapabilityName = "dev.ucp.shopping.settlement"
 )
 
 // Well-known service names.
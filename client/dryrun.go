@@ -0,0 +1,68 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DryRunRequest describes a request the client would have sent in dry-run
+// mode. It is returned as an error from mutating methods so callers can
+// inspect it with errors.As:
+//
+//	_, err := c.CompleteCheckout(ctx, id)
+//	var dr *client.DryRunRequest
+//	if errors.As(err, &dr) {
+//		fmt.Println(dr.Method, dr.URL, string(dr.Body))
+//	}
+type DryRunRequest struct {
+	// Method is the HTTP method.
+	Method string
+
+	// URL is the fully resolved request URL.
+	URL string
+
+	// Header contains the headers that would be sent.
+	Header http.Header
+
+	// Body is the marshaled JSON request body, or nil if there is none.
+	Body []byte
+}
+
+func (d *DryRunRequest) Error() string {
+	return fmt.Sprintf("dry run: %s %s not sent", d.Method, d.URL)
+}
+
+// renderDryRun captures a built request without executing it.
+func renderDryRun(req *http.Request) error {
+	dr := &DryRunRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return fmt.Errorf("failed to read request body: %w", err)
+		}
+		defer body.Close()
+		if dr.Body, err = io.ReadAll(body); err != nil {
+			return fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	return dr
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Typed errors for the shared error codes in models. An *Error matches one
+// with errors.Is when its code is the same, or, for responses that carry
+// no code, when its status is the code's usual status:
+//
+//	if errors.Is(err, client.ErrNotFound) { ... }
+var (
+	ErrBadRequest             = codeError(models.ErrorCodeBadRequest)
+	ErrInvalidRequest         = codeError(models.ErrorCodeInvalidRequest)
+	ErrUnauthorized           = codeError(models.ErrorCodeUnauthorized)
+	ErrForbidden              = codeError(models.ErrorCodeForbidden)
+	ErrNotFound               = codeError(models.ErrorCodeNotFound)
+	ErrMethodNotAllowed       = codeError(models.ErrorCodeMethodNotAllowed)
+	ErrConflict               = codeError(models.ErrorCodeConflict)
+	ErrIdempotencyConflict    = codeError(models.ErrorCodeIdempotencyConflict)
+	ErrIdempotencyKeyReused   = codeError(models.ErrorCodeIdempotencyKeyReused)
+	ErrLimitExceeded          = codeError(models.ErrorCodeLimitExceeded)
+	ErrCapabilityNotSupported = codeError(models.ErrorCodeCapabilityNotSupported)
+	ErrVersionUnsupported     = codeError(models.ErrorCodeVersionUnsupported)
+	ErrPaymentDeclined        = codeError(models.ErrorCodePaymentDeclined)
+	ErrInvalidSignature       = codeError(models.ErrorCodeInvalidSignature)
+	ErrNotImplemented         = codeError(models.ErrorCodeNotImplemented)
+	ErrInternal               = codeError(models.ErrorCodeInternal)
+)
+
+// codeStatus is the status a code is matched by when a response has none.
+var codeStatus = map[models.ErrorCode]int{
+	models.ErrorCodeBadRequest:       http.StatusBadRequest,
+	models.ErrorCodeUnauthorized:     http.StatusUnauthorized,
+	models.ErrorCodeForbidden:        http.StatusForbidden,
+	models.ErrorCodeNotFound:         http.StatusNotFound,
+	models.ErrorCodeMethodNotAllowed: http.StatusMethodNotAllowed,
+	models.ErrorCodeConflict:         http.StatusConflict,
+	models.ErrorCodeLimitExceeded:    http.StatusTooManyRequests,
+	models.ErrorCodeNotImplemented:   http.StatusNotImplemented,
+	models.ErrorCodeInternal:         http.StatusInternalServerError,
+}
+
+func codeError(code models.ErrorCode) *Error {
+	return &Error{Code: string(code), Message: string(code)}
+}
+
+// ErrorCode returns the error's code as a models.ErrorCode.
+func (e *Error) ErrorCode() models.ErrorCode {
+	return models.ErrorCode(e.Code)
+}
+
+// Is reports whether e matches one of the typed errors above.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.StatusCode != 0 || t.Code == "" {
+		return false
+	}
+	if e.Code != "" {
+		return e.Code == t.Code
+	}
+	status, ok := codeStatus[models.ErrorCode(t.Code)]
+	return ok && e.StatusCode == status
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Escalation captures a checkout that stopped in requires_escalation, so
+// the agent can hand the buyer the merchant's ContinueURL and pick the
+// flow back up once the buyer is done.
+type Escalation struct {
+	// Merchant is the base URL of the merchant holding the checkout.
+	Merchant string `json:"merchant"`
+
+	// CheckoutID is the escalated checkout session.
+	CheckoutID string `json:"checkout_id"`
+
+	// ContinueURL is where the buyer resolves the escalation. It may be
+	// empty if the merchant did not provide one.
+	ContinueURL string `json:"continue_url,omitempty"`
+
+	// Messages explain what the buyer must do. They are not carried in
+	// resume tokens.
+	Messages []models.Message `json:"-"`
+
+	// CapturedAt is when the escalation was observed.
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// Escalation returns the escalation a checkout is waiting on, or nil if
+// the checkout is not in requires_escalation.
+func (c *Client) Escalation(checkout *extensions.ExtendedCheckoutResponse) *Escalation {
+	if checkout == nil || checkout.Status != models.CheckoutStatusRequiresEscalation {
+		return nil
+	}
+	return &Escalation{
+		Merchant:    c.baseURL,
+		CheckoutID:  checkout.ID,
+		ContinueURL: checkout.ContinueURL,
+		Messages:    append([]models.Message(nil), checkout.Messages...),
+		CapturedAt:  time.Now().UTC(),
+	}
+}
+
+// ResumeToken encodes the escalation as an opaque, URL-safe token that can
+// be stored or passed through a buyer-facing redirect and later given to
+// ParseResumeToken or ResumeBuyNow. The token identifies the checkout but
+// grants no access to it.
+func (e *Escalation) ResumeToken() string {
+	data, _ := json.Marshal(e)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseResumeToken decodes a token produced by Escalation.ResumeToken.
+func ParseResumeToken(token string) (*Escalation, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resume token: %w", err)
+	}
+	var e Escalation
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("invalid resume token: %w", err)
+	}
+	if e.CheckoutID == "" {
+		return nil, fmt.Errorf("invalid resume token: missing checkout_id")
+	}
+	return &e, nil
+}
+
+// EscalationOption configures WaitForEscalationResolution.
+type EscalationOption func(*escalationConfig)
+
+type escalationConfig struct {
+	pollInterval time.Duration
+}
+
+// WithEscalationPollInterval sets how often WaitForEscalationResolution
+// polls. Defaults to DefaultPollInterval.
+func WithEscalationPollInterval(interval time.Duration) EscalationOption {
+	return func(c *escalationConfig) {
+		c.pollInterval = interval
+	}
+}
+
+// WaitForEscalationResolution polls a checkout until it leaves
+// requires_escalation, typically because the buyer finished on the
+// merchant's ContinueURL, or ctx is done. Bound the wait with a context
+// deadline; merchants may leave a checkout escalated until it expires.
+//
+// It returns the last checkout observed. On context expiry that checkout
+// is returned together with ctx.Err().
+func (c *Client) WaitForEscalationResolution(ctx context.Context, id string, opts ...EscalationOption) (*extensions.ExtendedCheckoutResponse, error) {
+	cfg := escalationConfig{pollInterval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.pollInterval <= 0 {
+		cfg.pollInterval = DefaultPollInterval
+	}
+
+	checkout, err := c.fetchCheckout(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.waitForStatus(ctx, checkout, cfg.pollInterval, func(s models.CheckoutStatus) bool {
+		return s != models.CheckoutStatusRequiresEscalation
+	})
+}
+
+// ResumeBuyNow continues a BuyNow flow that stopped on an escalation,
+// given the outcome's Escalation.ResumeToken. It waits for the buyer to
+// resolve the escalation, then carries on from the checkout's new status
+// exactly as BuyNow would: selecting fulfillment, completing, and waiting
+// out complete_in_progress as needed. instrument must be the instrument
+// the flow started with. The outcome may itself carry a new Escalation.
+func (c *Client) ResumeBuyNow(ctx context.Context, token string, instrument models.PaymentInstrument, opts ...BuyNowOption) (*BuyNowOutcome, error) {
+	cfg := buyNowConfig{currency: DefaultBuyNowCurrency, pollInterval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	escalation, err := ParseResumeToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if escalation.Merchant != "" && escalation.Merchant != c.baseURL {
+		return nil, fmt.Errorf("resume token is for merchant %s, not %s", escalation.Merchant, c.baseURL)
+	}
+
+	checkout, err := c.WaitForEscalationResolution(ctx, escalation.CheckoutID, WithEscalationPollInterval(cfg.pollInterval))
+	if err != nil {
+		return nil, err
+	}
+	return c.continueBuyNow(ctx, checkout, instrument, cfg)
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// DefaultFailoverThreshold is the consecutive REST failures after which a
+// client with a FailoverPolicy switches transport, when Threshold is not
+// set.
+const DefaultFailoverThreshold = 3
+
+// FailoverPolicy controls switching from the REST binding to the MCP or
+// A2A binding when REST keeps failing.
+type FailoverPolicy struct {
+	// Threshold is the number of consecutive operations that must fail
+	// over REST, each after any retries, before the client switches.
+	// Defaults to DefaultFailoverThreshold.
+	Threshold int
+
+	// OnFailover, if set, is called when the client switches transport.
+	OnFailover func(TransportFailover)
+}
+
+// TransportFailover describes a switch away from the REST binding.
+type TransportFailover struct {
+	// From is the binding given up, TransportREST.
+	From string
+
+	// To is the binding switched to, TransportMCP or TransportA2A.
+	To string
+
+	// Failures is the number of consecutive failed operations.
+	Failures int
+
+	// StatusCode is the status of the last failed operation's response,
+	// or 0 if it failed without one.
+	StatusCode int
+
+	// Err is the last failed operation's transport error, if any.
+	Err error
+}
+
+// WithTransportFailover switches the client from REST to the merchant's
+// MCP binding, or failing that its A2A binding, once Threshold operations
+// in a row have failed over REST with a transport error or a 502, 503, or
+// 504 response, so an agent can finish a purchase through a REST outage:
+//
+//	c := client.NewClient(baseURL, client.WithTransportFailover(client.FailoverPolicy{
+//		OnFailover: func(f client.TransportFailover) {
+//			log.Printf("switched from %s to %s after %d failures", f.From, f.To, f.Failures)
+//		},
+//	}))
+//
+// The operation that trips the threshold is sent again over the new
+// binding if it is safe to repeat (see RetryPolicy). Only a profile that
+// advertises another binding allows the switch, and the client stays on
+// that binding from then on. Failover has no effect with WithTransport.
+func WithTransportFailover(policy FailoverPolicy) ClientOption {
+	return func(c *Client) {
+		c.failover = &policy
+	}
+}
+
+// bindingFailed reports whether an operation's outcome counts against
+// the binding rather than the merchant's handling of the request.
+func bindingFailed(resp *http.Response, err error) bool {
+	if resp == nil {
+		return err != nil
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// recordOutcome tracks an operation sent over transport for failover and
+// returns the transport to switch to, or nil to stay.
+func (c *Client) recordOutcome(transport Transport, resp *http.Response, err error) Transport {
+	if c.failover == nil || c.transport != nil || transport.Binding() != TransportREST {
+		return nil
+	}
+	c.transportMu.Lock()
+	if !bindingFailed(resp, err) {
+		c.restFailures = 0
+		c.transportMu.Unlock()
+		return nil
+	}
+	c.restFailures++
+	failures := c.restFailures
+	threshold := c.failover.Threshold
+	if threshold <= 0 {
+		threshold = DefaultFailoverThreshold
+	}
+	if failures < threshold || c.failedOver {
+		c.transportMu.Unlock()
+		return nil
+	}
+
+	c.profileMu.RLock()
+	profile := c.profile
+	c.profileMu.RUnlock()
+	var next Transport
+	if profile != nil {
+		if service, ok := profile.UCP.Services[ServiceShopping]; ok {
+			next = c.alternateTransport(service)
+		}
+	}
+	if next == nil {
+		c.transportMu.Unlock()
+		return nil
+	}
+	c.failedOver = true
+	c.restFailures = 0
+	c.transportMu.Unlock()
+
+	if c.failover.OnFailover != nil {
+		event := TransportFailover{From: TransportREST, To: next.Binding(), Failures: failures, Err: err}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+		}
+		c.failover.OnFailover(event)
+	}
+	return next
+}
+
+// failOver sends req again over next after the REST attempt that tripped
+// failover returned resp and err, if req is safe to repeat. Otherwise it
+// returns resp and err as they are.
+func (c *Client) failOver(ctx context.Context, next Transport, req *http.Request, resp *http.Response, attempts []Attempt, err error) (*http.Request, *http.Response, []Attempt, error) {
+	if !retryable(req) {
+		return req, resp, attempts, err
+	}
+	again, rerr := c.rewind(req)
+	if rerr != nil {
+		return req, resp, attempts, err
+	}
+	if resp != nil {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	resp, more, err := c.send(ctx, next, again)
+	return again, resp, append(attempts, more...), err
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// NegotiatedFeatures answers what a merchant and platform can do together,
+// so application code need not scan capability slices. Build one with
+// NewNegotiatedFeatures or get the client's cached view from Features.
+type NegotiatedFeatures struct {
+	result   *validation.NegotiationResult
+	versions map[models.CapabilityName]models.Version
+	pickup   bool
+}
+
+// NewNegotiatedFeatures builds a feature view from a negotiation result and
+// the merchant profile it was negotiated against. The profile supplies the
+// merchant's fulfillment configuration; it may be nil.
+func NewNegotiatedFeatures(result *validation.NegotiationResult, profile *models.UCPProfile) *NegotiatedFeatures {
+	f := &NegotiatedFeatures{
+		result:   result,
+		versions: make(map[models.CapabilityName]models.Version, len(result.CommonCapabilities)),
+	}
+	for _, cap := range result.CommonCapabilities {
+		f.versions[cap.Name] = cap.Version
+	}
+	f.pickup = f.Has(CapabilityFulfillment) && allowsPickup(MerchantFulfillmentConfig(profile))
+	return f
+}
+
+// allowsPickup reports whether a merchant's fulfillment configuration
+// permits pickup. Without method combinations every method is allowed.
+func allowsPickup(cfg *models.MerchantFulfillmentConfig) bool {
+	if cfg == nil || len(cfg.AllowsMethodCombinations) == 0 {
+		return true
+	}
+	for _, combination := range cfg.AllowsMethodCombinations {
+		for _, t := range combination {
+			if t == models.FulfillmentMethodTypePickup {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Result returns the underlying negotiation result.
+func (f *NegotiatedFeatures) Result() *validation.NegotiationResult {
+	return f.result
+}
+
+// Version returns the negotiated protocol version.
+func (f *NegotiatedFeatures) Version() models.Version {
+	return f.result.NegotiatedVersion
+}
+
+// Has reports whether a capability was negotiated.
+func (f *NegotiatedFeatures) Has(name models.CapabilityName) bool {
+	_, ok := f.versions[name]
+	return ok
+}
+
+// CapabilityVersion returns the negotiated version of a capability, or ""
+// if it was not negotiated.
+func (f *NegotiatedFeatures) CapabilityVersion(name models.CapabilityName) models.Version {
+	return f.versions[name]
+}
+
+// SupportsCheckout reports whether checkout sessions can be created.
+func (f *NegotiatedFeatures) SupportsCheckout() bool {
+	return f.Has(CapabilityCheckout)
+}
+
+// SupportsOrders reports whether orders can be retrieved.
+func (f *NegotiatedFeatures) SupportsOrders() bool {
+	return f.Has(CapabilityOrder)
+}
+
+// SupportsDiscounts reports whether discount codes can be submitted.
+func (f *NegotiatedFeatures) SupportsDiscounts() bool {
+	return f.Has(CapabilityDiscount)
+}
+
+// SupportsFulfillment reports whether fulfillment methods can be selected.
+func (f *NegotiatedFeatures) SupportsFulfillment() bool {
+	return f.Has(CapabilityFulfillment)
+}
+
+// SupportsPickup reports whether pickup fulfillment is available: the
+// fulfillment capability was negotiated, the merchant's method combinations
+// include pickup, and the platform (if it declared fulfillment
+// capabilities) can render pickup.
+func (f *NegotiatedFeatures) SupportsPickup() bool {
+	return f.pickup
+}
+
+// SupportsCartConversion reports whether carts can be created and
+// converted into checkout sessions.
+func (f *NegotiatedFeatures) SupportsCartConversion() bool {
+	return f.Has(CapabilityCart) && f.Has(CapabilityCheckout)
+}
+
+// SupportsBuyerConsent reports whether buyer consent can be collected.
+func (f *NegotiatedFeatures) SupportsBuyerConsent() bool {
+	return f.Has(CapabilityBuyerConsent)
+}
+
+// SupportsProtection reports whether protection plans can be offered.
+func (f *NegotiatedFeatures) SupportsProtection() bool {
+	return f.Has(CapabilityProtection)
+}
+
+// SupportsIdentityLinking reports whether buyer accounts can be linked.
+func (f *NegotiatedFeatures) SupportsIdentityLinking() bool {
+	return f.Has(CapabilityIdentityLinking)
+}
+
+// WithPlatformCapabilities declares the capabilities the platform
+// implements, which Features negotiates against the merchant profile.
+// Without it every capability the merchant advertises is assumed
+// supported.
+func WithPlatformCapabilities(caps []models.CapabilityDiscovery) ClientOption {
+	return func(c *Client) {
+		c.platformCaps = caps
+	}
+}
+
+// Features negotiates the platform's capabilities against the cached
+// merchant profile and returns the resulting feature view. The view is
+// cached alongside the profile and rebuilt when the profile changes.
+func (c *Client) Features(ctx context.Context) (*NegotiatedFeatures, error) {
+	profile, err := c.GetCachedProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.profileMu.Lock()
+	defer c.profileMu.Unlock()
+	if c.features != nil && c.featuresFor == profile {
+		return c.features, nil
+	}
+
+	platformCaps := c.platformCaps
+	if platformCaps == nil {
+		platformCaps = profile.UCP.Capabilities
+	}
+	result := validation.NewCapabilityNegotiator(platformCaps).Negotiate(profile, nil)
+	features := NewNegotiatedFeatures(result, profile)
+	if c.fulfillmentCaps != nil && !c.fulfillmentCaps.SupportsPickup {
+		features.pickup = false
+	}
+	c.features, c.featuresFor = features, profile
+	return features, nil
+}
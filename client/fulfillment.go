@@ -0,0 +1,111 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// PlatformFulfillmentCapabilities describes what fulfillment experiences the
+// calling platform can render.
+type PlatformFulfillmentCapabilities struct {
+	models.PlatformFulfillmentConfig
+
+	// SupportsPickup indicates the platform can present pickup methods.
+	SupportsPickup bool `json:"supports_pickup,omitempty"`
+}
+
+// WithFulfillmentCapabilities declares the platform's fulfillment capabilities.
+// They are used by FilterFulfillment and FulfillmentCapabilityDeclaration.
+func WithFulfillmentCapabilities(caps PlatformFulfillmentCapabilities) ClientOption {
+	return func(c *Client) {
+		c.fulfillmentCaps = &caps
+	}
+}
+
+// FulfillmentCapabilities returns the declared platform fulfillment
+// capabilities, or nil if none were declared.
+func (c *Client) FulfillmentCapabilities() *PlatformFulfillmentCapabilities {
+	return c.fulfillmentCaps
+}
+
+// FulfillmentCapabilityDeclaration returns the fulfillment capability entry a
+// platform publishes in its own profile for negotiation, carrying the
+// declared capabilities as config. It returns nil if none were declared.
+func (c *Client) FulfillmentCapabilityDeclaration(version models.Version) *models.CapabilityDiscovery {
+	if c.fulfillmentCaps == nil {
+		return nil
+	}
+	config := map[string]interface{}{
+		"supports_multi_group": c.fulfillmentCaps.SupportsMultiGroup,
+		"supports_pickup":      c.fulfillmentCaps.SupportsPickup,
+	}
+	return &models.CapabilityDiscovery{
+		CapabilityBase: models.CapabilityBase{
+			Name:    CapabilityFulfillment,
+			Version: version,
+			Extends: CapabilityCheckout,
+			Config:  config,
+		},
+	}
+}
+
+// FilterFulfillment returns a copy of a checkout's fulfillment trimmed to
+// what the platform can render: pickup methods are dropped unless pickup is
+// supported, and methods are limited to their first group unless multiple
+// groups are supported. With no declared capabilities the fulfillment is
+// returned unchanged.
+func (c *Client) FilterFulfillment(f *models.FulfillmentResponse) *models.FulfillmentResponse {
+	return FilterFulfillment(f, c.fulfillmentCaps)
+}
+
+// FilterFulfillment trims fulfillment to the given platform capabilities.
+// See Client.FilterFulfillment.
+func FilterFulfillment(f *models.FulfillmentResponse, caps *PlatformFulfillmentCapabilities) *models.FulfillmentResponse {
+	if f == nil || caps == nil {
+		return f
+	}
+	out := &models.FulfillmentResponse{}
+	for _, m := range f.Methods {
+		if m.Type == models.FulfillmentMethodTypePickup && !caps.SupportsPickup {
+			continue
+		}
+		if !caps.SupportsMultiGroup && len(m.Groups) > 1 {
+			m.Groups = m.Groups[:1]
+		}
+		out.Methods = append(out.Methods, m)
+	}
+	for _, am := range f.AvailableMethods {
+		if am.Type == models.FulfillmentMethodTypePickup && !caps.SupportsPickup {
+			continue
+		}
+		out.AvailableMethods = append(out.AvailableMethods, am)
+	}
+	return out
+}
+
+// MerchantFulfillmentConfig extracts the merchant's fulfillment configuration
+// from the fulfillment capability's config in a discovery profile.
+func MerchantFulfillmentConfig(profile *models.UCPProfile) *models.MerchantFulfillmentConfig {
+	capability := GetCapability(profile, CapabilityFulfillment)
+	if capability == nil || capability.Config == nil {
+		return nil
+	}
+	var cfg models.MerchantFulfillmentConfig
+	if err := remarshal(capability.Config, &cfg); err != nil {
+		return nil
+	}
+	return &cfg
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// JournalEntry records an open checkout session.
+type JournalEntry struct {
+	// CheckoutID is the merchant's checkout session ID.
+	CheckoutID string `json:"checkout_id"`
+
+	// Status is the last status the merchant reported.
+	Status models.CheckoutStatus `json:"status"`
+
+	// IdempotencyKeys are the Idempotency-Key headers sent for the
+	// session, oldest first.
+	IdempotencyKeys []string `json:"idempotency_keys,omitempty"`
+
+	// UpdatedAt is when the entry was last written.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SessionJournal persists open checkout sessions so that an agent that
+// crashes mid-purchase can resume or cancel them on restart. See
+// WithSessionJournal.
+type SessionJournal interface {
+	// Load returns the entry for a checkout, or nil if there is none.
+	Load(ctx context.Context, checkoutID string) (*JournalEntry, error)
+
+	// Save creates or replaces the entry for entry.CheckoutID.
+	Save(ctx context.Context, entry JournalEntry) error
+
+	// Remove deletes the entry for a checkout, if any.
+	Remove(ctx context.Context, checkoutID string) error
+
+	// Entries returns all entries.
+	Entries(ctx context.Context) ([]JournalEntry, error)
+}
+
+// WithSessionJournal makes the client record every checkout session it
+// creates or touches in j: the session ID, last known status, and
+// idempotency keys used. Sessions are removed from the journal once
+// completed, canceled, or no longer found. Journal errors do not fail the
+// request that triggered them.
+func WithSessionJournal(j SessionJournal) ClientOption {
+	return func(c *Client) {
+		c.journal = j
+	}
+}
+
+// ResumeCheckouts fetches the current state of every journaled checkout,
+// dropping sessions the merchant has completed, canceled, or forgotten,
+// and returns the ones still open.
+func (c *Client) ResumeCheckouts(ctx context.Context) ([]*extensions.ExtendedCheckoutResponse, error) {
+	if c.journal == nil {
+		return nil, errors.New("no session journal configured")
+	}
+	entries, err := c.journal.Entries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session journal: %w", err)
+	}
+
+	var open []*extensions.ExtendedCheckoutResponse
+	for _, entry := range entries {
+		checkout, err := c.fetchCheckout(ctx, entry.CheckoutID)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return open, err
+		}
+		if !terminalStatus(checkout.Status) {
+			open = append(open, checkout)
+		}
+	}
+	return open, nil
+}
+
+// CancelJournaledCheckouts cancels every open journaled checkout. It
+// attempts all sessions and returns the errors joined.
+func (c *Client) CancelJournaledCheckouts(ctx context.Context) error {
+	open, err := c.ResumeCheckouts(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, checkout := range open {
+		if _, err := c.CancelCheckout(ctx, checkout.ID); err != nil {
+			errs = append(errs, fmt.Errorf("cancel %s: %w", checkout.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// journalResponse records the outcome of a checkout request in the
+// session journal.
+func (c *Client) journalResponse(req *http.Request, path string, statusCode int, result interface{}) {
+	ctx := req.Context()
+	id := checkoutIDFromPath(path)
+	checkout, ok := result.(*extensions.ExtendedCheckoutResponse)
+	if ok && statusCode < 400 {
+		id = checkout.ID
+	}
+	if id == "" {
+		return
+	}
+
+	if statusCode == http.StatusNotFound {
+		c.journal.Remove(ctx, id)
+		return
+	}
+	if !ok || statusCode >= 400 {
+		return
+	}
+	if terminalStatus(checkout.Status) {
+		c.journal.Remove(ctx, id)
+		return
+	}
+
+	entry, err := c.journal.Load(ctx, id)
+	if err != nil {
+		return
+	}
+	if entry == nil {
+		entry = &JournalEntry{CheckoutID: id}
+	}
+	entry.Status = checkout.Status
+	entry.UpdatedAt = time.Now().UTC()
+	if key := req.Header.Get("Idempotency-Key"); key != "" && !containsString(entry.IdempotencyKeys, key) {
+		entry.IdempotencyKeys = append(entry.IdempotencyKeys, key)
+	}
+	c.journal.Save(ctx, *entry)
+}
+
+// terminalStatus reports whether a checkout can no longer change.
+func terminalStatus(status models.CheckoutStatus) bool {
+	return status == models.CheckoutStatusCompleted || status == models.CheckoutStatusCanceled
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// FileJournal is a SessionJournal stored as a JSON file. Every change
+// rewrites the file atomically. It is safe for concurrent use within a
+// process.
+type FileJournal struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]JournalEntry
+}
+
+// OpenFileJournal opens the journal at path, creating it on first write
+// if it does not exist.
+func OpenFileJournal(path string) (*FileJournal, error) {
+	j := &FileJournal{path: path, entries: make(map[string]JournalEntry)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session journal: %w", err)
+	}
+	var entries []JournalEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse session journal %s: %w", path, err)
+	}
+	for _, e := range entries {
+		j.entries[e.CheckoutID] = e
+	}
+	return j, nil
+}
+
+// Load implements SessionJournal.
+func (j *FileJournal) Load(ctx context.Context, checkoutID string) (*JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	e, ok := j.entries[checkoutID]
+	if !ok {
+		return nil, nil
+	}
+	e.IdempotencyKeys = append([]string(nil), e.IdempotencyKeys...)
+	return &e, nil
+}
+
+// Save implements SessionJournal.
+func (j *FileJournal) Save(ctx context.Context, entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries[entry.CheckoutID] = entry
+	return j.flush()
+}
+
+// Remove implements SessionJournal.
+func (j *FileJournal) Remove(ctx context.Context, checkoutID string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.entries[checkoutID]; !ok {
+		return nil
+	}
+	delete(j.entries, checkoutID)
+	return j.flush()
+}
+
+// Entries implements SessionJournal. Entries are ordered by checkout ID.
+func (j *FileJournal) Entries(ctx context.Context) ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.sorted(), nil
+}
+
+func (j *FileJournal) sorted() []JournalEntry {
+	entries := make([]JournalEntry, 0, len(j.entries))
+	for _, e := range j.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].CheckoutID < entries[b].CheckoutID
+	})
+	return entries
+}
+
+// flush writes the journal to disk through a temporary file, so a crash
+// mid-write leaves the previous contents intact.
+func (j *FileJournal) flush() error {
+	data, err := json.MarshalIndent(j.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write session journal: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if syncErr := tmp.Sync(); err == nil {
+		err = syncErr
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), j.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write session journal: %w", err)
+	}
+	return nil
+}
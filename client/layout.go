@@ -0,0 +1,147 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// ExtensionLayout is where an extension's fields are placed in checkout
+// requests.
+type ExtensionLayout int
+
+const (
+	// ExtensionLayoutFlat places extension fields where the base schema
+	// composes them, e.g. $.discounts or $.buyer.consent. It is the
+	// default.
+	ExtensionLayoutFlat ExtensionLayout = iota
+
+	// ExtensionLayoutNamespaced moves extension fields under $.extensions,
+	// keyed by capability name, e.g.
+	// $.extensions["dev.ucp.shopping.discount"].
+	ExtensionLayoutNamespaced
+)
+
+// extensionsField is the request field holding namespaced extensions.
+const extensionsField = "extensions"
+
+// layoutRule applies a layout from a capability version onwards.
+type layoutRule struct {
+	since  models.Version
+	layout ExtensionLayout
+}
+
+// WithExtensionLayout encodes the request fields of an extension
+// capability with layout when the merchant's negotiated version of it is
+// since or later (all versions if since is empty). Rules for the same
+// capability combine, the latest applicable since winning, so a merchant
+// that moved an extension from flat to namespaced in a given release can
+// be described with one rule. Affects the extensions request pruning
+// covers: fulfillment, discounts, buyer consent, and protection.
+func WithExtensionLayout(capability models.CapabilityName, since models.Version, layout ExtensionLayout) ClientOption {
+	return func(c *Client) {
+		if c.extensionLayouts == nil {
+			c.extensionLayouts = make(map[models.CapabilityName][]layoutRule)
+		}
+		c.extensionLayouts[capability] = append(c.extensionLayouts[capability], layoutRule{since: since, layout: layout})
+	}
+}
+
+// extensionLayout returns the layout for a capability at a version.
+func (c *Client) extensionLayout(capability models.CapabilityName, version models.Version) ExtensionLayout {
+	layout := ExtensionLayoutFlat
+	var best models.Version
+	found := false
+	for _, rule := range c.extensionLayouts[capability] {
+		if rule.since > version {
+			continue
+		}
+		if !found || rule.since >= best {
+			layout, best, found = rule.layout, rule.since, true
+		}
+	}
+	return layout
+}
+
+// encodeExtensions returns a checkout create or update body with each
+// extension laid out as configured for the merchant's negotiated version.
+// Bodies with nothing to move, and all bodies when no layouts are
+// configured or the profile is unavailable, are returned as is.
+func (c *Client) encodeExtensions(ctx context.Context, body interface{}) (interface{}, error) {
+	if len(c.extensionLayouts) == 0 {
+		return body, nil
+	}
+	switch body.(type) {
+	case *extensions.ExtendedCheckoutCreateRequest, *extensions.ExtendedCheckoutUpdateRequest:
+	default:
+		return body, nil
+	}
+	features, err := c.Features(ctx)
+	if err != nil {
+		return body, nil
+	}
+
+	var namespaced []PrunedSection
+	for _, p := range prunable {
+		if c.extensionLayout(p.Capability, features.CapabilityVersion(p.Capability)) == ExtensionLayoutNamespaced {
+			namespaced = append(namespaced, p)
+		}
+	}
+	if len(namespaced) == 0 {
+		return body, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to encode extensions: %w", err)
+	}
+	moved := make(map[string]interface{})
+	for _, p := range namespaced {
+		if v, ok := takeField(doc, strings.Split(strings.TrimPrefix(p.Path, "$."), ".")); ok {
+			moved[string(p.Capability)] = v
+		}
+	}
+	if len(moved) == 0 {
+		return body, nil
+	}
+	doc[extensionsField] = moved
+	return doc, nil
+}
+
+// takeField removes and returns the value at path in doc.
+func takeField(doc map[string]interface{}, path []string) (interface{}, bool) {
+	for len(path) > 1 {
+		next, ok := doc[path[0]].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		doc, path = next, path[1:]
+	}
+	v, ok := doc[path[0]]
+	if ok {
+		delete(doc, path[0])
+	}
+	return v, ok
+}
@@ -0,0 +1,311 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// MCPProtocolVersion is the MCP revision MCPTransport requests when it
+// initializes a session.
+const MCPProtocolVersion = "2025-06-18"
+
+// maxEventBytes bounds one server-sent event from an MCP endpoint.
+const maxEventBytes = 8 << 20
+
+// MCPTransport is a Transport for the MCP binding. It calls the merchant's
+// UCP tools with JSON-RPC 2.0 over MCP's streamable HTTP transport,
+// initializing a session on first use and again if the merchant expires
+// it.
+//
+// Each operation maps to a tool named for its action and resource:
+// create_checkout, get_checkout, update_checkout, complete_checkout,
+// cancel_checkout, get_order, create_cart, get_cart, update_cart, and
+// delete_cart. Arguments carry the resource ID as "id", the request body
+// under the resource name ("checkout" or "cart"), and a "meta" object
+// with the platform's "ucp-agent" profile and any "idempotency-key". The
+// tool's structured content is returned as the response body, unwrapped
+// from a single resource-named key if the merchant nests it. Request
+// signatures cover REST bodies only and are not sent.
+type MCPTransport struct {
+	// Endpoint is the merchant's MCP endpoint.
+	Endpoint string
+
+	httpClient *http.Client
+	nextID     atomic.Int64
+
+	mu              sync.Mutex
+	initialized     bool
+	session         string
+	protocolVersion string
+}
+
+// NewMCPTransport creates an MCPTransport for endpoint. A nil httpClient
+// uses http.DefaultClient.
+func NewMCPTransport(endpoint string, httpClient *http.Client) *MCPTransport {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &MCPTransport{Endpoint: endpoint, httpClient: httpClient}
+}
+
+// MCPError is a JSON-RPC error returned by an MCP endpoint.
+type MCPError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *MCPError) Error() string {
+	return fmt.Sprintf("MCP error %d: %s", e.Code, e.Message)
+}
+
+// Binding implements Transport.
+func (t *MCPTransport) Binding() string { return TransportMCP }
+
+// RoundTrip implements Transport.
+func (t *MCPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, err := bindOperation(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, header, err := t.call(req.Context(), req.Header, "tools/call", map[string]any{"name": op.name, "arguments": op.args})
+	var httpErr *mcpHTTPError
+	if errors.As(err, &httpErr) {
+		return operationResponse(req, httpErr.statusCode, httpErr.header, httpErr.body), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		StructuredContent json.RawMessage `json:"structuredContent"`
+		IsError           bool            `json:"isError"`
+	}
+	if err := json.Unmarshal(result, &res); err != nil {
+		return nil, fmt.Errorf("invalid MCP tool result: %w", err)
+	}
+
+	content := res.StructuredContent
+	if len(content) == 0 {
+		for _, c := range res.Content {
+			if c.Type == "text" {
+				content = textContent(c.Text, res.IsError)
+				break
+			}
+		}
+	}
+	return op.response(req, header, content, res.IsError), nil
+}
+
+// errMCPSessionExpired reports that the endpoint no longer knows the
+// session.
+var errMCPSessionExpired = errors.New("MCP session expired")
+
+// mcpHTTPError is an HTTP error status from the endpoint, passed to the
+// client as the operation's response.
+type mcpHTTPError struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func (e *mcpHTTPError) Error() string {
+	return fmt.Sprintf("MCP endpoint returned status %d", e.statusCode)
+}
+
+// call sends a JSON-RPC request in the current session, starting a new
+// session once if the endpoint has expired it.
+func (t *MCPTransport) call(ctx context.Context, header http.Header, method string, params any) (json.RawMessage, http.Header, error) {
+	for attempt := 0; ; attempt++ {
+		session, version, err := t.ensureSession(ctx, header)
+		if err != nil {
+			return nil, nil, err
+		}
+		result, respHeader, err := t.post(ctx, header, session, version, method, params, false)
+		if errors.Is(err, errMCPSessionExpired) && attempt == 0 {
+			t.mu.Lock()
+			if t.session == session {
+				t.initialized = false
+			}
+			t.mu.Unlock()
+			continue
+		}
+		return result, respHeader, err
+	}
+}
+
+// ensureSession initializes a session if there is none and returns its ID
+// and protocol version.
+func (t *MCPTransport) ensureSession(ctx context.Context, header http.Header) (string, string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.initialized {
+		return t.session, t.protocolVersion, nil
+	}
+
+	params := map[string]any{
+		"protocolVersion": MCPProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]string{"name": "ucp-go-sdk", "version": "1.0"},
+	}
+	result, respHeader, err := t.post(ctx, header, "", "", "initialize", params, false)
+	if err != nil {
+		return "", "", fmt.Errorf("MCP initialize failed: %w", err)
+	}
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	json.Unmarshal(result, &init)
+	session := respHeader.Get("Mcp-Session-Id")
+	if _, _, err := t.post(ctx, header, session, init.ProtocolVersion, "notifications/initialized", nil, true); err != nil {
+		return "", "", fmt.Errorf("MCP initialize failed: %w", err)
+	}
+	t.initialized, t.session, t.protocolVersion = true, session, init.ProtocolVersion
+	return session, init.ProtocolVersion, nil
+}
+
+// post sends one JSON-RPC message and, unless it is a notification,
+// returns the result of the matching response.
+func (t *MCPTransport) post(ctx context.Context, header http.Header, session, version, method string, params any, notify bool) (json.RawMessage, http.Header, error) {
+	msg := map[string]any{"jsonrpc": "2.0", "method": method}
+	if params != nil {
+		msg["params"] = params
+	}
+	var id int64
+	if !notify {
+		id = t.nextID.Add(1)
+		msg["id"] = id
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header = header.Clone()
+	for _, h := range []string{SignatureHeader, "Idempotency-Key", "UCP-Delta-Base", "Content-Length"} {
+		req.Header.Del(h)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if session != "" {
+		req.Header.Set("Mcp-Session-Id", session)
+	}
+	if version != "" {
+		req.Header.Set("MCP-Protocol-Version", version)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound && session != "" {
+		return nil, nil, errMCPSessionExpired
+	}
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, nil, &mcpHTTPError{statusCode: resp.StatusCode, header: resp.Header, body: body}
+	}
+	if notify {
+		return nil, resp.Header, nil
+	}
+
+	var reply *jsonrpcResponse
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" {
+		reply, err = readEventStream(resp.Body, id)
+	} else {
+		reply = new(jsonrpcResponse)
+		err = json.NewDecoder(resp.Body).Decode(reply)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid MCP response: %w", err)
+	}
+	if reply.Error != nil {
+		return nil, nil, reply.Error
+	}
+	return reply.Result, resp.Header, nil
+}
+
+// jsonrpcResponse is a JSON-RPC 2.0 response.
+type jsonrpcResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *MCPError       `json:"error"`
+}
+
+// readEventStream reads server-sent events until the response to id
+// arrives, skipping the endpoint's requests and notifications.
+func readEventStream(r io.Reader, id int64) (*jsonrpcResponse, error) {
+	want := strconv.FormatInt(id, 10)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventBytes)
+	var data strings.Builder
+	dispatch := func() *jsonrpcResponse {
+		defer data.Reset()
+		var reply jsonrpcResponse
+		if json.Unmarshal([]byte(data.String()), &reply) == nil && string(reply.ID) == want {
+			return &reply
+		}
+		return nil
+	}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(value, " "))
+			continue
+		}
+		if line == "" && data.Len() > 0 {
+			if reply := dispatch(); reply != nil {
+				return reply, nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if data.Len() > 0 {
+		if reply := dispatch(); reply != nil {
+			return reply, nil
+		}
+	}
+	return nil, errors.New("event stream ended without a response")
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// DefaultMaxMediaBytes bounds the size of media fetched by FetchMedia.
+const DefaultMaxMediaBytes = 5 << 20
+
+// DefaultMediaTypes are the content types FetchMedia accepts by default.
+// SVG is excluded because it can carry script.
+var DefaultMediaTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+var (
+	// ErrMediaTooLarge is returned when media exceeds the size limit.
+	ErrMediaTooLarge = errors.New("media exceeds size limit")
+
+	// ErrMediaType is returned when media is not of an allowed type.
+	ErrMediaType = errors.New("media type not allowed")
+
+	// ErrMediaChecksum is returned when media does not match the expected
+	// checksum.
+	ErrMediaChecksum = errors.New("media checksum mismatch")
+)
+
+// Media is a fetched image.
+type Media struct {
+	// URL is the resolved URL the media was fetched from.
+	URL string
+
+	// ContentType is the type sniffed from the content, not the type the
+	// server claimed.
+	ContentType string
+
+	// Data is the media content. It is a private copy the caller owns.
+	Data []byte
+
+	// SHA256 is the hex-encoded SHA-256 digest of Data.
+	SHA256 string
+}
+
+// MediaOption configures FetchMedia.
+type MediaOption func(*mediaOptions)
+
+type mediaOptions struct {
+	maxBytes int64
+	types    []string
+	checksum string
+}
+
+// WithMaxMediaBytes sets the size limit. Defaults to DefaultMaxMediaBytes.
+func WithMaxMediaBytes(n int64) MediaOption {
+	return func(o *mediaOptions) {
+		o.maxBytes = n
+	}
+}
+
+// WithMediaTypes sets the accepted content types. Defaults to
+// DefaultMediaTypes.
+func WithMediaTypes(types ...string) MediaOption {
+	return func(o *mediaOptions) {
+		o.types = types
+	}
+}
+
+// WithMediaChecksum requires the media's SHA-256 digest to equal the given
+// hex string.
+func WithMediaChecksum(sha256Hex string) MediaOption {
+	return func(o *mediaOptions) {
+		o.checksum = strings.ToLower(sha256Hex)
+	}
+}
+
+// FetchMedia fetches an image, such as an item's image_url or an
+// instrument's rich_card_art, for display in an agent UI. Only HTTP(S)
+// URLs are fetched, no credentials are sent, and responses go through the
+// client's HTTP cache. The body is read up to the size limit, and its type
+// is sniffed from the content and checked against the accepted types, so a
+// mislabeled or oversized response is rejected rather than rendered.
+func (c *Client) FetchMedia(ctx context.Context, mediaURL string, opts ...MediaOption) (*Media, error) {
+	o := mediaOptions{maxBytes: DefaultMaxMediaBytes, types: DefaultMediaTypes}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	resolved, err := c.resolveResourceURL(mediaURL)
+	if err != nil {
+		return nil, err
+	}
+	if u, _ := url.Parse(resolved); u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("media URL must be HTTP(S): %q", mediaURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resolved, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", resolved, err)
+	}
+	resp, err := c.resourceCache().Client(c.httpClient).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", resolved, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: status %d", resolved, resp.StatusCode)
+	}
+	if resp.ContentLength > o.maxBytes {
+		return nil, fmt.Errorf("%w: %s is %d bytes", ErrMediaTooLarge, resolved, resp.ContentLength)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, o.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", resolved, err)
+	}
+	if int64(len(data)) > o.maxBytes {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrMediaTooLarge, resolved, o.maxBytes)
+	}
+
+	contentType := http.DetectContentType(data)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	if !containsString(o.types, contentType) {
+		return nil, fmt.Errorf("%w: %s is %s", ErrMediaType, resolved, contentType)
+	}
+
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	if o.checksum != "" && digest != o.checksum {
+		return nil, fmt.Errorf("%w: %s", ErrMediaChecksum, resolved)
+	}
+	return &Media{URL: resolved, ContentType: contentType, Data: data, SHA256: digest}, nil
+}
+
+// FetchItemImage fetches an item's image_url with FetchMedia.
+func (c *Client) FetchItemImage(ctx context.Context, item models.ItemResponse, opts ...MediaOption) (*Media, error) {
+	if item.ImageURL == "" {
+		return nil, fmt.Errorf("item %s has no image_url", item.ID)
+	}
+	return c.FetchMedia(ctx, item.ImageURL, opts...)
+}
+
+// FetchCardArt fetches an instrument's rich_card_art with FetchMedia.
+func (c *Client) FetchCardArt(ctx context.Context, instrument models.PaymentInstrument, opts ...MediaOption) (*Media, error) {
+	if instrument.RichCardArt == "" {
+		return nil, fmt.Errorf("instrument %s has no rich_card_art", instrument.ID)
+	}
+	return c.FetchMedia(ctx, instrument.RichCardArt, opts...)
+}
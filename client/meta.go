@@ -0,0 +1,86 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMeta contains HTTP metadata about a completed request.
+type ResponseMeta struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// Header contains the response headers.
+	Header http.Header
+
+	// Latency is the time from sending the request to reading the full response.
+	Latency time.Duration
+}
+
+// RequestID returns the X-Request-ID response header.
+func (m *ResponseMeta) RequestID() string {
+	return m.Header.Get("X-Request-ID")
+}
+
+// IdempotentReplay reports whether the merchant replayed a cached response
+// for a repeated Idempotency-Key.
+func (m *ResponseMeta) IdempotentReplay() bool {
+	v, _ := strconv.ParseBool(m.Header.Get("Idempotent-Replayed"))
+	return v
+}
+
+// RateLimitRemaining returns the RateLimit-Remaining (or X-RateLimit-Remaining)
+// header value and whether it was present.
+func (m *ResponseMeta) RateLimitRemaining() (int, bool) {
+	v := m.Header.Get("RateLimit-Remaining")
+	if v == "" {
+		v = m.Header.Get("X-RateLimit-Remaining")
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+type metaKey struct{}
+
+// WithCaptureMeta returns a context that records response metadata into meta
+// for requests made with it. When a call performs several requests, meta
+// reflects the last one.
+//
+//	var meta client.ResponseMeta
+//	checkout, err := c.CreateCheckout(client.WithCaptureMeta(ctx, &meta), req)
+//	log.Println(meta.RequestID(), meta.Latency)
+func WithCaptureMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, metaKey{}, meta)
+}
+
+// recordMeta stores response metadata if the context requested it.
+func recordMeta(ctx context.Context, resp *http.Response, latency time.Duration) {
+	meta, ok := ctx.Value(metaKey{}).(*ResponseMeta)
+	if !ok || meta == nil {
+		return
+	}
+	*meta = ResponseMeta{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Latency:    latency,
+	}
+}
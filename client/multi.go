@@ -0,0 +1,461 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// ErrMultiCheckoutNotReady is returned by MultiCheckout.Complete when not
+// every checkout is ready_for_complete.
+var ErrMultiCheckoutNotReady = errors.New("multi-merchant checkout is not ready to complete")
+
+// ErrNoBaskets is returned by CreateMultiCheckout when given no baskets.
+var ErrNoBaskets = errors.New("multi-merchant checkout needs at least one basket")
+
+// MultiCheckoutStatus is the combined status of a MultiCheckout.
+type MultiCheckoutStatus string
+
+const (
+	// MultiCheckoutIncomplete means at least one checkout still needs
+	// input.
+	MultiCheckoutIncomplete MultiCheckoutStatus = "incomplete"
+
+	// MultiCheckoutRequiresEscalation means at least one checkout needs
+	// the buyer on the merchant's ContinueURL.
+	MultiCheckoutRequiresEscalation MultiCheckoutStatus = "requires_escalation"
+
+	// MultiCheckoutReadyForComplete means every checkout can be completed.
+	MultiCheckoutReadyForComplete MultiCheckoutStatus = "ready_for_complete"
+
+	// MultiCheckoutCompleted means every checkout produced an order.
+	MultiCheckoutCompleted MultiCheckoutStatus = "completed"
+
+	// MultiCheckoutCanceled means every checkout was canceled.
+	MultiCheckoutCanceled MultiCheckoutStatus = "canceled"
+
+	// MultiCheckoutFailed means a checkout could not be created or
+	// refreshed, or the checkouts ended in a mix of completed and
+	// canceled.
+	MultiCheckoutFailed MultiCheckoutStatus = "failed"
+)
+
+// MerchantBasket is one merchant's share of a cross-merchant basket.
+type MerchantBasket struct {
+	// Client talks to the merchant.
+	Client *Client
+
+	// LineItems are the items bought from the merchant.
+	LineItems []models.LineItemCreateRequest
+
+	// Instrument, if set, replaces the profile's instrument for this
+	// merchant, for instruments tokenized per merchant handler.
+	Instrument *models.PaymentInstrument
+}
+
+// BuyerProfile is the buyer and payment details shared by every checkout
+// of a MultiCheckout.
+type BuyerProfile struct {
+	// Buyer is sent on every checkout.
+	Buyer *models.BuyerWithConsentCreateRequest
+
+	// Instrument is selected on every checkout unless a basket overrides
+	// it.
+	Instrument models.PaymentInstrument
+
+	// Currency is the currency of every checkout. Defaults to
+	// DefaultBuyNowCurrency.
+	Currency string
+
+	// Context is the buyer context sent on checkout creation.
+	Context *models.Context
+}
+
+// MerchantCheckout is one merchant's checkout within a MultiCheckout.
+type MerchantCheckout struct {
+	// Merchant is the base URL of the merchant.
+	Merchant string
+
+	// Checkout is the last checkout observed, or nil if creation failed.
+	Checkout *extensions.ExtendedCheckoutResponse
+
+	// Order is the order confirmation once the checkout completes.
+	Order *models.OrderConfirmation
+
+	// Blocking contains the messages keeping the checkout from
+	// ready_for_complete after Prepare, or from completing after Complete.
+	Blocking []models.Message
+
+	// Err is the last error from a request for this checkout.
+	Err error
+
+	// CancelErr is the error from a compensating cancellation, if it
+	// failed.
+	CancelErr error
+
+	client     *Client
+	instrument models.PaymentInstrument
+}
+
+// DefaultRollbackTimeout bounds the cancellations a failed
+// MultiCheckout.Complete makes to roll back.
+const DefaultRollbackTimeout = 30 * time.Second
+
+// MultiCheckoutOption configures CreateMultiCheckout.
+type MultiCheckoutOption func(*MultiCheckout)
+
+// WithMultiCheckoutPollInterval sets how often checkouts left
+// complete_in_progress are polled. Defaults to DefaultPollInterval.
+func WithMultiCheckoutPollInterval(interval time.Duration) MultiCheckoutOption {
+	return func(m *MultiCheckout) {
+		m.pollInterval = interval
+	}
+}
+
+// WithMultiCheckoutRollbackTimeout bounds the cancellations a failed
+// Complete makes to roll back. Defaults to DefaultRollbackTimeout.
+func WithMultiCheckoutRollbackTimeout(timeout time.Duration) MultiCheckoutOption {
+	return func(m *MultiCheckout) {
+		m.rollbackTimeout = timeout
+	}
+}
+
+// MultiCheckout orchestrates one purchase across several merchants: a
+// checkout per merchant, driven together and completed all or nothing.
+// Requests to different merchants run in parallel. A MultiCheckout is not
+// safe for concurrent use.
+type MultiCheckout struct {
+	// Checkouts holds one entry per basket, in basket order.
+	Checkouts []*MerchantCheckout
+
+	pollInterval    time.Duration
+	rollbackTimeout time.Duration
+}
+
+// CreateMultiCheckout creates a checkout with every merchant in parallel,
+// applying the shared buyer profile to each. Failed creations are recorded
+// on their MerchantCheckout and joined into the returned error; the
+// MultiCheckout is returned either way so the caller can Cancel the
+// checkouts that were created. With no baskets, or a basket without a
+// Client, it returns a nil MultiCheckout and an error without creating any.
+func CreateMultiCheckout(ctx context.Context, baskets []MerchantBasket, profile BuyerProfile, opts ...MultiCheckoutOption) (*MultiCheckout, error) {
+	if len(baskets) == 0 {
+		return nil, ErrNoBaskets
+	}
+	for i, basket := range baskets {
+		if basket.Client == nil {
+			return nil, fmt.Errorf("basket %d has no Client", i)
+		}
+	}
+
+	m := &MultiCheckout{pollInterval: DefaultPollInterval, rollbackTimeout: DefaultRollbackTimeout}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.pollInterval <= 0 {
+		m.pollInterval = DefaultPollInterval
+	}
+	if m.rollbackTimeout <= 0 {
+		m.rollbackTimeout = DefaultRollbackTimeout
+	}
+	if profile.Currency == "" {
+		profile.Currency = DefaultBuyNowCurrency
+	}
+
+	for _, basket := range baskets {
+		leg := &MerchantCheckout{Merchant: basket.Client.baseURL, client: basket.Client, instrument: profile.Instrument}
+		if basket.Instrument != nil {
+			leg.instrument = *basket.Instrument
+		}
+		m.Checkouts = append(m.Checkouts, leg)
+	}
+
+	m.each(m.Checkouts, func(i int, leg *MerchantCheckout) {
+		leg.Checkout, leg.Err = leg.client.CreateCheckout(ctx, &extensions.ExtendedCheckoutCreateRequest{
+			LineItems: baskets[i].LineItems,
+			Currency:  profile.Currency,
+			Payment: models.PaymentCreateRequest{
+				Instruments:          []models.PaymentInstrument{leg.instrument},
+				SelectedInstrumentID: leg.instrument.ID,
+			},
+			Buyer:   profile.Buyer,
+			Context: profile.Context,
+		})
+	})
+	return m, m.err()
+}
+
+// Status returns the combined status of the checkouts.
+func (m *MultiCheckout) Status() MultiCheckoutStatus {
+	var completed, canceled, ready int
+	escalated := false
+	for _, leg := range m.Checkouts {
+		if leg.Checkout == nil {
+			return MultiCheckoutFailed
+		}
+		switch leg.Checkout.Status {
+		case models.CheckoutStatusCompleted:
+			completed++
+		case models.CheckoutStatusCanceled:
+			canceled++
+		case models.CheckoutStatusReadyForComplete:
+			ready++
+		case models.CheckoutStatusRequiresEscalation:
+			escalated = true
+		}
+	}
+
+	n := len(m.Checkouts)
+	switch {
+	case completed == n:
+		return MultiCheckoutCompleted
+	case canceled == n:
+		return MultiCheckoutCanceled
+	case completed > 0 || canceled > 0:
+		return MultiCheckoutFailed
+	case escalated:
+		return MultiCheckoutRequiresEscalation
+	case ready == n:
+		return MultiCheckoutReadyForComplete
+	}
+	return MultiCheckoutIncomplete
+}
+
+// Prepare moves every open checkout toward ready_for_complete the way
+// BuyNow does, selecting the first offered fulfillment destination and
+// option wherever none is selected. Checkouts that still are not ready
+// have their Blocking messages set. It returns the combined status and the
+// request errors joined.
+func (m *MultiCheckout) Prepare(ctx context.Context) (MultiCheckoutStatus, error) {
+	m.each(m.open(), func(_ int, leg *MerchantCheckout) {
+		leg.Blocking, leg.Err = nil, nil
+		if leg.Checkout.Status == models.CheckoutStatusReadyForComplete {
+			return
+		}
+		if blocking := blockingMessages(leg.Checkout); blocking != nil {
+			leg.Blocking = blocking
+			return
+		}
+		if req := defaultSelections(leg.Checkout, leg.instrument); req != nil {
+			checkout, err := leg.client.UpdateCheckout(ctx, leg.Checkout.ID, req)
+			if err != nil {
+				leg.Err = err
+				return
+			}
+			leg.Checkout = checkout
+		}
+		if leg.Checkout.Status != models.CheckoutStatusReadyForComplete {
+			leg.Blocking = outstandingMessages(leg.Checkout)
+		}
+	})
+	return m.Status(), m.err()
+}
+
+// Complete completes every checkout, or none. It first refreshes all
+// checkouts and, if any is no longer ready_for_complete, cancels them all
+// without completing any. It then completes them in parallel, waiting out
+// complete_in_progress; if any fails to produce an order, every checkout
+// that has not completed is canceled.
+//
+// A failure after some merchants have already accepted their completion
+// cannot be fully undone through the checkout API: those orders stand and
+// are reported in the MultiCheckoutError's Completed for the caller to
+// reverse with the merchant. Complete returns ErrMultiCheckoutNotReady,
+// without canceling anything, if the status is not ready_for_complete
+// when it is called.
+func (m *MultiCheckout) Complete(ctx context.Context) error {
+	if status := m.Status(); status != MultiCheckoutReadyForComplete {
+		return fmt.Errorf("%w: status %s", ErrMultiCheckoutNotReady, status)
+	}
+
+	m.each(m.Checkouts, func(_ int, leg *MerchantCheckout) {
+		leg.Blocking = nil
+		leg.Checkout, leg.Err = refreshCheckout(ctx, leg)
+		if leg.Err == nil && leg.Checkout.Status != models.CheckoutStatusReadyForComplete {
+			leg.Blocking = outstandingMessages(leg.Checkout)
+		}
+	})
+	if m.err() != nil || m.Status() != MultiCheckoutReadyForComplete {
+		return m.rollback(ctx)
+	}
+
+	m.each(m.Checkouts, func(_ int, leg *MerchantCheckout) {
+		checkout, order, err := leg.client.CompleteCheckoutAndWait(ctx, leg.Checkout.ID, m.pollInterval)
+		if checkout != nil {
+			leg.Checkout = checkout
+		}
+		leg.Order, leg.Err = order, err
+		if err == nil && order == nil {
+			leg.Blocking = outstandingMessages(checkout)
+		}
+	})
+	if m.Status() != MultiCheckoutCompleted {
+		return m.rollback(ctx)
+	}
+	return nil
+}
+
+// Cancel cancels every checkout that is not yet completed or canceled, in
+// parallel, and returns the errors joined.
+func (m *MultiCheckout) Cancel(ctx context.Context) error {
+	open := m.open()
+	m.cancel(ctx, open)
+	var errs []error
+	for _, leg := range open {
+		if leg.CancelErr != nil {
+			errs = append(errs, fmt.Errorf("cancel %s at %s: %w", leg.Checkout.ID, leg.Merchant, leg.CancelErr))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MultiCheckoutError reports a MultiCheckout.Complete that did not
+// complete every checkout.
+type MultiCheckoutError struct {
+	// Failed are the checkouts that could not be completed. Each has Err
+	// or Blocking explaining why.
+	Failed []*MerchantCheckout
+
+	// Completed are the checkouts that completed before the failure was
+	// known. Their orders must be reversed with the merchant.
+	Completed []*MerchantCheckout
+
+	// Uncanceled are the checkouts whose compensating cancellation failed;
+	// see their CancelErr. They expire on the merchant's schedule.
+	Uncanceled []*MerchantCheckout
+}
+
+// Error implements the error interface.
+func (e *MultiCheckoutError) Error() string {
+	var b strings.Builder
+	b.WriteString("multi-merchant checkout failed")
+	for _, leg := range e.Failed {
+		fmt.Fprintf(&b, "; %s: ", leg.Merchant)
+		switch {
+		case leg.Err != nil:
+			b.WriteString(leg.Err.Error())
+		case leg.Checkout != nil:
+			fmt.Fprintf(&b, "checkout %s is %s", leg.Checkout.ID, leg.Checkout.Status)
+		}
+	}
+	if n := len(e.Completed); n > 0 {
+		fmt.Fprintf(&b, "; %d completed order(s) need reversal", n)
+	}
+	if n := len(e.Uncanceled); n > 0 {
+		fmt.Fprintf(&b, "; %d checkout(s) could not be canceled", n)
+	}
+	return b.String()
+}
+
+// Unwrap returns the request errors of the failed checkouts.
+func (e *MultiCheckoutError) Unwrap() []error {
+	var errs []error
+	for _, leg := range e.Failed {
+		if leg.Err != nil {
+			errs = append(errs, leg.Err)
+		}
+	}
+	return errs
+}
+
+// rollback cancels every open checkout after a failed Complete and
+// reports what happened to each. The cancellations run even if ctx is
+// done, which is often why Complete failed, bounded by rollbackTimeout.
+func (m *MultiCheckout) rollback(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), m.rollbackTimeout)
+	defer cancel()
+	open := m.open()
+	m.cancel(ctx, open)
+
+	e := &MultiCheckoutError{}
+	for _, leg := range m.Checkouts {
+		switch {
+		case leg.Order != nil:
+			e.Completed = append(e.Completed, leg)
+			continue
+		case leg.CancelErr != nil:
+			e.Uncanceled = append(e.Uncanceled, leg)
+		}
+		if leg.Err != nil || leg.Blocking != nil {
+			e.Failed = append(e.Failed, leg)
+		}
+	}
+	return e
+}
+
+// cancel cancels the given checkouts in parallel, recording each result.
+func (m *MultiCheckout) cancel(ctx context.Context, legs []*MerchantCheckout) {
+	m.each(legs, func(_ int, leg *MerchantCheckout) {
+		checkout, err := leg.client.CancelCheckout(ctx, leg.Checkout.ID)
+		leg.CancelErr = err
+		if err == nil {
+			leg.Checkout = checkout
+		}
+	})
+}
+
+// open returns the checkouts that exist and are not in a terminal status.
+func (m *MultiCheckout) open() []*MerchantCheckout {
+	var open []*MerchantCheckout
+	for _, leg := range m.Checkouts {
+		if leg.Checkout != nil && !IsTerminalStatus(leg.Checkout.Status) {
+			open = append(open, leg)
+		}
+	}
+	return open
+}
+
+// err joins the request errors of all checkouts.
+func (m *MultiCheckout) err() error {
+	var errs []error
+	for _, leg := range m.Checkouts {
+		if leg.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", leg.Merchant, leg.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// each runs fn for every checkout in its own goroutine and waits for all
+// of them. fn may only modify the checkout it is given.
+func (m *MultiCheckout) each(legs []*MerchantCheckout, fn func(i int, leg *MerchantCheckout)) {
+	var wg sync.WaitGroup
+	for i, leg := range legs {
+		wg.Add(1)
+		go func(i int, leg *MerchantCheckout) {
+			defer wg.Done()
+			fn(i, leg)
+		}(i, leg)
+	}
+	wg.Wait()
+}
+
+// refreshCheckout fetches the current state of a checkout, keeping the
+// last observed state on error.
+func refreshCheckout(ctx context.Context, leg *MerchantCheckout) (*extensions.ExtendedCheckoutResponse, error) {
+	checkout, err := leg.client.fetchCheckout(ctx, leg.Checkout.ID)
+	if err != nil {
+		return leg.Checkout, err
+	}
+	return checkout, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/client"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/scenarios"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+func TestMultiCheckoutRollsBackAfterContextCanceled(t *testing.T) {
+	ctx := context.Background()
+	var baskets []client.MerchantBasket
+	for range 2 {
+		srv, err := scenarios.NewScenarioServer(server.Config{}, "../scenarios/testdata/basic.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		merchant := httptest.NewServer(srv)
+		defer merchant.Close()
+		baskets = append(baskets, client.MerchantBasket{
+			Client:    client.NewClient(merchant.URL),
+			LineItems: []models.LineItemCreateRequest{{Item: models.ItemCreateRequest{ID: "PROD-001"}, Quantity: 1}},
+		})
+	}
+	m, err := client.CreateMultiCheckout(ctx, baskets, client.BuyerProfile{
+		Buyer:      &models.BuyerWithConsentCreateRequest{Email: "buyer@example.com"},
+		Instrument: models.PaymentInstrument{ID: "pi_1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, err := m.Prepare(ctx); err != nil || status != client.MultiCheckoutReadyForComplete {
+		t.Fatalf("Prepare = %s, %v", status, err)
+	}
+
+	// The caller gives up before completing; the rollback must still
+	// cancel both checkouts.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	var multiErr *client.MultiCheckoutError
+	if err := m.Complete(canceled); !errors.As(err, &multiErr) {
+		t.Fatalf("Complete = %v, want a MultiCheckoutError", err)
+	}
+	if len(multiErr.Uncanceled) != 0 {
+		t.Errorf("%d checkouts left open: %v", len(multiErr.Uncanceled), multiErr)
+	}
+	if status := m.Status(); status != client.MultiCheckoutCanceled {
+		t.Errorf("status = %s, want canceled", status)
+	}
+}
+
+func TestCreateMultiCheckoutRejectsInvalidBaskets(t *testing.T) {
+	tests := []struct {
+		name    string
+		baskets []client.MerchantBasket
+	}{
+		{"no baskets", nil},
+		{"basket without a client", []client.MerchantBasket{{Client: client.NewClient("https://merchant.example.com")}, {}}},
+	}
+	for _, tt := range tests {
+		m, err := client.CreateMultiCheckout(context.Background(), tt.baskets, client.BuyerProfile{})
+		if m != nil || err == nil {
+			t.Errorf("%s: got %+v, %v; want an error", tt.name, m, err)
+		}
+	}
+}
@@ -14,7 +14,12 @@
 
 package client
 
-import "net/http"
+import (
+	"net/http"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
 
 // RequestOption is a function that modifies an HTTP request.
 type RequestOption func(*http.Request)
@@ -46,3 +51,21 @@ func WithHeader(key, value string) RequestOption {
 		r.Header.Set(key, value)
 	}
 }
+
+// WithFields asks the merchant to return only the named top-level fields
+// of a checkout or order fetched with GetCheckout or GetOrder, such as
+// "status" and "messages" when polling. The ucp and id fields are always
+// returned; the rest of the result is left zero. Responses to such
+// requests are not checked against schemas or for conformance, since they
+// are partial by design. Merchants that do not support field selection
+// return the full resource.
+func WithFields(fields ...string) RequestOption {
+	return func(r *http.Request) {
+		if r.Method != http.MethodGet || len(fields) == 0 {
+			return
+		}
+		q := r.URL.Query()
+		q.Set(server.FieldsParam, strings.Join(fields, ","))
+		r.URL.RawQuery = q.Encode()
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// ErrCapabilityMismatch is matched by the CapabilityMismatchError
+// SupportsOperation returns when a merchant advertises a capability whose
+// endpoints it does not serve.
+var ErrCapabilityMismatch = errors.New("advertised capabilities do not match endpoints")
+
+// CapabilityMismatchError reports an operation whose capability the
+// merchant advertises without routing its endpoint.
+type CapabilityMismatchError struct {
+	Operation  server.Operation
+	Capability models.CapabilityName
+
+	// Method and Path are the probed endpoint.
+	Method string
+	Path   string
+}
+
+func (e *CapabilityMismatchError) Error() string {
+	return fmt.Sprintf("merchant advertises %s but does not route %s %s", e.Capability, e.Method, e.Path)
+}
+
+// Unwrap returns ErrCapabilityMismatch.
+func (e *CapabilityMismatchError) Unwrap() error {
+	return ErrCapabilityMismatch
+}
+
+// probedOperation is the endpoint and capability of an operation.
+type probedOperation struct {
+	method     string
+	path       string
+	capability models.CapabilityName
+}
+
+// probeID stands in for resource IDs in probed paths.
+const probeID = "probe"
+
+// probedOperations lists the operations SupportsOperation can check.
+var probedOperations = map[server.Operation]probedOperation{
+	server.OperationCreateCheckout:           {http.MethodPost, CheckoutSessionsPath, CapabilityCheckout},
+	server.OperationGetCheckout:              {http.MethodGet, CheckoutSessionsPath + "/" + probeID, CapabilityCheckout},
+	server.OperationUpdateCheckout:           {http.MethodPatch, CheckoutSessionsPath + "/" + probeID, CapabilityCheckout},
+	server.OperationCompleteCheckout:         {http.MethodPost, CheckoutSessionsPath + "/" + probeID + "/complete", CapabilityCheckout},
+	server.OperationCancelCheckout:           {http.MethodPost, CheckoutSessionsPath + "/" + probeID + "/cancel", CapabilityCheckout},
+	server.OperationGetOrder:                 {http.MethodGet, OrdersPath + "/" + probeID, CapabilityOrder},
+	server.OperationRequestOrderModification: {http.MethodPost, OrdersPath + "/" + probeID + "/modifications", CapabilityOrder},
+	server.OperationGetOrderModification:     {http.MethodGet, OrdersPath + "/" + probeID + "/modifications/" + probeID, CapabilityOrder},
+	server.OperationCreateCart:               {http.MethodPost, CartsPath, CapabilityCart},
+	server.OperationGetCart:                  {http.MethodGet, CartsPath + "/" + probeID, CapabilityCart},
+	server.OperationUpdateCart:               {http.MethodPatch, CartsPath + "/" + probeID, CapabilityCart},
+	server.OperationDeleteCart:               {http.MethodDelete, CartsPath + "/" + probeID, CapabilityCart},
+}
+
+// SupportsOperation reports whether the merchant can perform op: its
+// profile must advertise the operation's capability and, over REST, its
+// endpoint must exist. The endpoint is checked with an OPTIONS request to
+// the operation's route: an Allow header listing the method means the
+// route exists, and a 404 that it does not. When the probe is
+// inconclusive, because the merchant does not answer OPTIONS or is reached
+// over another transport, the profile alone decides.
+//
+// A merchant that advertises the capability without routing the endpoint
+// gets false with a *CapabilityMismatchError, so callers can tell a
+// misconfigured merchant from one that simply lacks the feature. Routes
+// the profile does not advertise are not used.
+func (c *Client) SupportsOperation(ctx context.Context, op server.Operation) (bool, error) {
+	probe, ok := probedOperations[op]
+	if !ok {
+		return false, fmt.Errorf("cannot probe operation %q", op)
+	}
+	profile, err := c.GetCachedProfile(ctx)
+	if err != nil {
+		return false, err
+	}
+	advertised := HasCapability(profile, probe.capability)
+
+	if c.transportFor(probe.path).Binding() != TransportREST {
+		return advertised, nil
+	}
+	routed, conclusive, err := c.probeRoute(ctx, probe.method, probe.path)
+	if err != nil {
+		return false, err
+	}
+	if !conclusive {
+		return advertised, nil
+	}
+	if advertised && !routed {
+		return false, &CapabilityMismatchError{
+			Operation:  op,
+			Capability: probe.capability,
+			Method:     probe.method,
+			Path:       probe.path,
+		}
+	}
+	return advertised && routed, nil
+}
+
+// probeRoute sends OPTIONS to path and reports whether it routes method,
+// and whether the response settled the question.
+func (c *Client) probeRoute(ctx context.Context, method, path string) (routed, conclusive bool, err error) {
+	req, err := c.newRequest(ctx, http.MethodOptions, path, nil)
+	if err != nil {
+		return false, false, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to probe %s: %w", path, err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, true, nil
+	}
+	allow := resp.Header.Get("Allow")
+	if allow == "" || resp.StatusCode >= 300 {
+		return false, false, nil
+	}
+	for _, m := range strings.Split(allow, ",") {
+		if strings.EqualFold(strings.TrimSpace(m), method) {
+			return true, true, nil
+		}
+	}
+	return false, true, nil
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// DefaultProfileTTL is how long a discovery profile stays fresh when the
+// merchant sends no Cache-Control max-age or Expires header.
+const DefaultProfileTTL = 5 * time.Minute
+
+// CachedProfile is a discovery profile with the caching metadata it was
+// served with.
+type CachedProfile struct {
+	// Profile is the merchant's discovery profile.
+	Profile *models.UCPProfile `json:"profile"`
+
+	// ETag is the profile's validator, sent as If-None-Match when the
+	// profile is revalidated.
+	ETag string `json:"etag,omitempty"`
+
+	// Expires is when the profile goes stale.
+	Expires time.Time `json:"expires"`
+}
+
+// Fresh reports whether the profile can be used at now without
+// revalidating it.
+func (p *CachedProfile) Fresh(now time.Time) bool {
+	return p != nil && now.Before(p.Expires)
+}
+
+// ProfileCache stores discovery profiles by merchant base URL. Share one
+// cache between the clients of a multi-merchant platform, or back it with
+// a networked store such as redisstore.ProfileCache to share it across
+// processes. Implementations must be safe for concurrent use.
+type ProfileCache interface {
+	// Get returns the profile cached for baseURL, or nil if there is none.
+	// Stale profiles may be returned; they are revalidated by ETag.
+	Get(ctx context.Context, baseURL string) (*CachedProfile, error)
+
+	// Set caches the profile for baseURL.
+	Set(ctx context.Context, baseURL string, profile *CachedProfile) error
+
+	// Delete removes the profile cached for baseURL.
+	Delete(ctx context.Context, baseURL string) error
+}
+
+// WithProfileCache makes GetCachedProfile consult cache before fetching a
+// merchant's profile, and store what it fetches there. Without it, each
+// client caches its own profile in memory.
+func WithProfileCache(cache ProfileCache) ClientOption {
+	return func(c *Client) {
+		c.profileCache = cache
+	}
+}
+
+// WithProfileTTL replaces DefaultProfileTTL. Merchant Cache-Control and
+// Expires headers take precedence.
+func WithProfileTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.profileTTL = ttl
+	}
+}
+
+// MemoryProfileCache is an in-process ProfileCache.
+type MemoryProfileCache struct {
+	mu       sync.RWMutex
+	profiles map[string]*CachedProfile
+}
+
+// NewMemoryProfileCache creates an empty MemoryProfileCache.
+func NewMemoryProfileCache() *MemoryProfileCache {
+	return &MemoryProfileCache{profiles: make(map[string]*CachedProfile)}
+}
+
+// Get implements ProfileCache.
+func (m *MemoryProfileCache) Get(_ context.Context, baseURL string) (*CachedProfile, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.profiles[baseURL], nil
+}
+
+// Set implements ProfileCache.
+func (m *MemoryProfileCache) Set(_ context.Context, baseURL string, profile *CachedProfile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.profiles[baseURL] = profile
+	return nil
+}
+
+// Delete implements ProfileCache.
+func (m *MemoryProfileCache) Delete(_ context.Context, baseURL string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.profiles, baseURL)
+	return nil
+}
+
+// InvalidateProfile marks the cached discovery profile stale, here and in
+// the ProfileCache, so the next GetCachedProfile revalidates it.
+func (c *Client) InvalidateProfile(ctx context.Context) error {
+	c.profileMu.Lock()
+	c.profileExpires = time.Time{}
+	c.profileMu.Unlock()
+	if c.profileCache == nil {
+		return nil
+	}
+	return c.profileCache.Delete(ctx, c.baseURL)
+}
+
+// cachedProfile returns the profile this client holds, or nil.
+func (c *Client) cachedProfile() *CachedProfile {
+	c.profileMu.RLock()
+	defer c.profileMu.RUnlock()
+	if c.profile == nil {
+		return nil
+	}
+	return &CachedProfile{Profile: c.profile, ETag: c.profileETag, Expires: c.profileExpires}
+}
+
+// sharedProfile returns the profile in the ProfileCache, or nil. Cache
+// errors count as misses; the profile is fetched instead.
+func (c *Client) sharedProfile(ctx context.Context) *CachedProfile {
+	if c.profileCache == nil {
+		return nil
+	}
+	shared, err := c.profileCache.Get(ctx, c.baseURL)
+	if err != nil || shared == nil || shared.Profile == nil {
+		return nil
+	}
+	return shared
+}
+
+// fetchProfile fetches the profile, revalidating the one held by ETag so
+// an unchanged profile costs a 304.
+func (c *Client) fetchProfile(ctx context.Context, opts ...RequestOption) (*models.UCPProfile, error) {
+	held := c.cachedProfile()
+	if held != nil && held.ETag != "" {
+		opts = append(slices.Clip(opts), WithHeader("If-None-Match", held.ETag))
+	}
+
+	// Capture the caching headers, passing them on to the caller's
+	// WithCaptureMeta if there is one.
+	var meta ResponseMeta
+	var profile models.UCPProfile
+	err := c.doRequest(WithCaptureMeta(ctx, &meta), http.MethodGet, WellKnownPath, nil, &profile, opts...)
+	if caller, ok := ctx.Value(metaKey{}).(*ResponseMeta); ok && caller != nil && meta.Header != nil {
+		*caller = meta
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cc := parseCacheControl(meta.Header)
+	fetched := &CachedProfile{
+		Profile: &profile,
+		ETag:    meta.Header.Get("ETag"),
+		Expires: c.profileExpiry(cc, meta.Header),
+	}
+	if meta.StatusCode == http.StatusNotModified && held != nil {
+		fetched.Profile = held.Profile
+		if fetched.ETag == "" {
+			fetched.ETag = held.ETag
+		}
+	}
+	c.storeProfile(fetched)
+	if c.profileCache != nil && !cc.has("no-store") {
+		// A cache that cannot be written to only costs other clients a
+		// fetch.
+		c.profileCache.Set(ctx, c.baseURL, fetched)
+	}
+	return fetched.Profile, nil
+}
+
+// revalidateProfile refreshes a stale profile on a background goroutine,
+// at most one at a time.
+func (c *Client) revalidateProfile() {
+	if !c.revalidating.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer c.revalidating.Store(false)
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		defer cancel()
+		c.fetchProfile(ctx)
+	}()
+}
+
+// profileExpiry computes when a profile fetched now goes stale.
+func (c *Client) profileExpiry(cc cacheControl, header http.Header) time.Time {
+	now := time.Now()
+	if cc.has("no-store") || cc.has("no-cache") {
+		return now
+	}
+	if v, ok := cc["max-age"]; ok {
+		secs, err := strconv.Atoi(v)
+		if err != nil {
+			return now
+		}
+		return now.Add(time.Duration(secs) * time.Second)
+	}
+	if v := header.Get("Expires"); v != "" {
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			return now
+		}
+		if date, err := http.ParseTime(header.Get("Date")); err == nil {
+			// Measure the lifetime against the merchant's clock.
+			return now.Add(expires.Sub(date))
+		}
+		return expires
+	}
+	if c.profileTTL > 0 {
+		return now.Add(c.profileTTL)
+	}
+	return now.Add(DefaultProfileTTL)
+}
+
+// cacheControl holds parsed Cache-Control directives.
+type cacheControl map[string]string
+
+func parseCacheControl(header http.Header) cacheControl {
+	cc := cacheControl{}
+	for _, line := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(line, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name == "" {
+				continue
+			}
+			cc[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return cc
+}
+
+func (cc cacheControl) has(directive string) bool {
+	_, ok := cc[directive]
+	return ok
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// PrunedSection describes a request field dropped because the merchant does
+// not advertise the capability it belongs to.
+type PrunedSection struct {
+	// Section is the request section the field belongs to.
+	Section UpdateSection
+
+	// Path is the JSONPath of the dropped field.
+	Path string
+
+	// Capability is the capability the merchant does not advertise.
+	Capability models.CapabilityName
+}
+
+// WithRequestPruning makes checkout create and update requests drop fields
+// for extensions that are not active after negotiation (for example,
+// discounts when dev.ucp.shopping.discount is absent from the merchant's
+// profile, or from the platform's with WithPlatformCapabilities) instead
+// of risking a 400. onPrune, if non-nil, is called for each dropped field.
+// The caller's request is not modified. If the profile cannot be fetched,
+// requests are sent unchanged.
+//
+// Pruning happens only with this option; WithPlatformCapabilities alone
+// changes negotiation but never the requests sent.
+func WithRequestPruning(onPrune func(PrunedSection)) ClientOption {
+	return func(c *Client) {
+		c.pruneRequests = true
+		c.onPrune = onPrune
+	}
+}
+
+// prunable lists the optional request fields and their capabilities.
+var prunable = []PrunedSection{
+	{Section: SectionFulfillment, Path: "$.fulfillment", Capability: CapabilityFulfillment},
+	{Section: SectionDiscounts, Path: "$.discounts", Capability: CapabilityDiscount},
+	{Section: SectionBuyer, Path: "$.buyer.consent", Capability: CapabilityBuyerConsent},
+	{Section: SectionProtection, Path: "$.protection", Capability: CapabilityProtection},
+}
+
+// unsupported returns the prunable fields whose capability was not
+// negotiated, or nil when pruning is off or the profile is unavailable.
+func (c *Client) unsupported(ctx context.Context) []PrunedSection {
+	if !c.pruneRequests {
+		return nil
+	}
+	features, err := c.Features(ctx)
+	if err != nil {
+		return nil
+	}
+	var missing []PrunedSection
+	for _, p := range prunable {
+		if !features.Has(p.Capability) {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
+
+// pruneCreate returns req without fields for unsupported capabilities.
+func (c *Client) pruneCreate(ctx context.Context, req *extensions.ExtendedCheckoutCreateRequest) *extensions.ExtendedCheckoutCreateRequest {
+	missing := c.unsupported(ctx)
+	if req == nil || len(missing) == 0 {
+		return req
+	}
+	out := *req
+	c.prune(missing, map[UpdateSection]func() bool{
+		SectionFulfillment: func() bool { return clearField(&out.Fulfillment) },
+		SectionDiscounts:   func() bool { return clearField(&out.Discounts) },
+		SectionProtection:  func() bool { return clearField(&out.Protection) },
+		SectionBuyer: func() bool {
+			return clearConsent(&out.Buyer, func(b *models.BuyerWithConsentCreateRequest) **models.Consent { return &b.Consent })
+		},
+	})
+	return &out
+}
+
+// pruneUpdate returns req without fields for unsupported capabilities.
+func (c *Client) pruneUpdate(ctx context.Context, req *extensions.ExtendedCheckoutUpdateRequest) *extensions.ExtendedCheckoutUpdateRequest {
+	missing := c.unsupported(ctx)
+	if req == nil || len(missing) == 0 {
+		return req
+	}
+	out := *req
+	c.prune(missing, map[UpdateSection]func() bool{
+		SectionFulfillment: func() bool { return clearField(&out.Fulfillment) },
+		SectionDiscounts:   func() bool { return clearField(&out.Discounts) },
+		SectionProtection:  func() bool { return clearField(&out.Protection) },
+		SectionBuyer: func() bool {
+			return clearConsent(&out.Buyer, func(b *models.BuyerWithConsentUpdateRequest) **models.Consent { return &b.Consent })
+		},
+	})
+	return &out
+}
+
+// prune clears the missing sections of a request copy with the matching
+// func in fields, which reports whether the section held a value, and
+// passes each dropped field to the pruning callback.
+func (c *Client) prune(missing []PrunedSection, fields map[UpdateSection]func() bool) {
+	for _, p := range missing {
+		if drop, ok := fields[p.Section]; ok && drop() && c.onPrune != nil {
+			c.onPrune(p)
+		}
+	}
+}
+
+// clearField sets *field to nil and reports whether it was set.
+func clearField[T any](field **T) bool {
+	set := *field != nil
+	*field = nil
+	return set
+}
+
+// clearConsent replaces *buyer with a copy without consent and reports
+// whether it had any. The caller's buyer is not modified.
+func clearConsent[B any](buyer **B, consent func(*B) **models.Consent) bool {
+	if *buyer == nil || *consent(*buyer) == nil {
+		return false
+	}
+	b := **buyer
+	*consent(&b) = nil
+	*buyer = &b
+	return true
+}
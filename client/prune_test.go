@@ -0,0 +1,85 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/client"
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/scenarios"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+func TestRequestPruningIsExplicit(t *testing.T) {
+	srv, err := scenarios.NewScenarioServer(server.Config{
+		Capabilities: []models.CapabilityDiscovery{
+			{CapabilityBase: models.CapabilityBase{Name: client.CapabilityCheckout, Version: scenarios.DefaultVersion}},
+			{CapabilityBase: models.CapabilityBase{Name: client.CapabilityDiscount, Version: scenarios.DefaultVersion, Extends: client.CapabilityCheckout}},
+		},
+	}, "../scenarios/testdata/basic.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sentDiscounts bool
+	merchant := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			sentDiscounts = bytes.Contains(body, []byte(`"discounts"`))
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		srv.ServeHTTP(w, r)
+	}))
+	defer merchant.Close()
+
+	// The platform does not implement discounts, so negotiation drops them.
+	platformCaps := client.WithPlatformCapabilities([]models.CapabilityDiscovery{
+		{CapabilityBase: models.CapabilityBase{Name: client.CapabilityCheckout, Version: scenarios.DefaultVersion}},
+	})
+	var pruned []client.PrunedSection
+	tests := []struct {
+		name   string
+		opts   []client.ClientOption
+		sent   bool
+		pruned int
+	}{
+		{"no options", nil, true, 0},
+		{"platform capabilities only", []client.ClientOption{platformCaps}, true, 0},
+		{"pruning", []client.ClientOption{platformCaps, client.WithRequestPruning(func(p client.PrunedSection) {
+			pruned = append(pruned, p)
+		})}, false, 1},
+	}
+	for _, tt := range tests {
+		pruned = nil
+		c := client.NewClient(merchant.URL, tt.opts...)
+		_, err := c.CreateCheckout(context.Background(), &extensions.ExtendedCheckoutCreateRequest{
+			Currency:  "USD",
+			LineItems: []models.LineItemCreateRequest{{Item: models.ItemCreateRequest{ID: "PROD-001"}, Quantity: 1}},
+			Discounts: &models.DiscountsCreateRequest{Codes: []string{"SAVE5"}},
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if sentDiscounts != tt.sent || len(pruned) != tt.pruned {
+			t.Errorf("%s: sent discounts %v, %d pruned; want %v, %d", tt.name, sentDiscounts, len(pruned), tt.sent, tt.pruned)
+		}
+	}
+}
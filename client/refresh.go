@@ -0,0 +1,108 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// ProfileChange describes how a merchant's discovery profile changed
+// between two fetches. The embedded diff says what to react to:
+// CapabilityChanged calls for renegotiating, KeysChanged for re-pinning
+// signing keys, and Endpoints for re-pointing transports.
+type ProfileChange struct {
+	// Previous is the profile before the refresh.
+	Previous *models.UCPProfile
+
+	// Current is the refreshed profile.
+	Current *models.UCPProfile
+
+	validation.ProfileDiff
+}
+
+// WithProfileRefresh makes the client refresh its cached discovery profile
+// every interval on a background goroutine, revalidating it by ETag.
+// GetCachedProfile keeps serving the cached profile while refreshes run,
+// revalidates a stale profile in the background rather than blocking, and
+// a failed refresh keeps the stale profile. onChange, if non-nil, is called from the refresh
+// goroutine whenever a fetch changes the merchant's capabilities,
+// endpoints, or signing keys. Call Close to stop refreshing.
+func WithProfileRefresh(interval time.Duration, onChange func(ProfileChange)) ClientOption {
+	return func(c *Client) {
+		c.refreshInterval = interval
+		c.onProfileChange = onChange
+	}
+}
+
+// Close stops the background profile refresh started by
+// WithProfileRefresh. It is safe to call more than once.
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		if c.stopRefresh != nil {
+			close(c.stopRefresh)
+		}
+	})
+}
+
+// startProfileRefresh launches the refresh goroutine.
+func (c *Client) startProfileRefresh() {
+	if c.refreshInterval <= 0 {
+		return
+	}
+	c.stopRefresh = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(c.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stopRefresh:
+				return
+			case <-ticker.C:
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+			c.FetchProfile(ctx)
+			cancel()
+		}
+	}()
+}
+
+// storeProfile caches a fetched profile and reports a change to the
+// onChange callback. Negotiated features survive a refresh that leaves the
+// capabilities and protocol version alone.
+func (c *Client) storeProfile(cached *CachedProfile) {
+	profile := cached.Profile
+	c.profileMu.Lock()
+	previous := c.profile
+	c.profile = profile
+	c.profileETag = cached.ETag
+	c.profileExpires = cached.Expires
+	var diff *validation.ProfileDiff
+	if previous != nil {
+		diff = validation.DiffProfiles(previous, profile)
+		if c.featuresFor == previous && !diff.CapabilityChanged() && previous.UCP.Version == profile.UCP.Version {
+			c.featuresFor = profile
+		}
+	}
+	c.profileMu.Unlock()
+
+	if c.onProfileChange == nil || diff == nil || diff.Empty() {
+		return
+	}
+	c.onProfileChange(ProfileChange{Previous: previous, Current: profile, ProfileDiff: *diff})
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultResourceCacheEntries bounds the number of checkouts and
+	// orders a resource cache holds.
+	DefaultResourceCacheEntries = 256
+
+	// DefaultCheckoutCacheTTL is how long a cached checkout is served.
+	DefaultCheckoutCacheTTL = 5 * time.Second
+
+	// DefaultOrderCacheTTL is how long a cached order is served.
+	DefaultOrderCacheTTL = 30 * time.Second
+)
+
+// ResourceCacheConfig configures the cache enabled by WithResourceCache.
+type ResourceCacheConfig struct {
+	// MaxEntries bounds the cached checkouts and orders; the least
+	// recently used are evicted. Defaults to DefaultResourceCacheEntries.
+	MaxEntries int
+
+	// CheckoutTTL is how long GetCheckout serves a cached checkout.
+	// Defaults to DefaultCheckoutCacheTTL.
+	CheckoutTTL time.Duration
+
+	// OrderTTL is how long GetOrder serves a cached order. Defaults to
+	// DefaultOrderCacheTTL.
+	OrderTTL time.Duration
+}
+
+// WithResourceCache makes GetCheckout and GetOrder serve recent responses
+// from memory, saving merchant calls when an agent rereads a checkout
+// across conversation turns. Updating, completing, or canceling a
+// checkout, or requesting an order modification, through the client drops
+// the cached copy. Calls with RequestOptions bypass the cache, so pass
+// WithHeader("Cache-Control", "no-cache") to force a fresh read. Changes
+// made elsewhere, such as ones reported by webhooks, are not seen until
+// the TTL passes or InvalidateCheckout or InvalidateOrder is called.
+func WithResourceCache(config ResourceCacheConfig) ClientOption {
+	return func(c *Client) {
+		if config.MaxEntries <= 0 {
+			config.MaxEntries = DefaultResourceCacheEntries
+		}
+		if config.CheckoutTTL <= 0 {
+			config.CheckoutTTL = DefaultCheckoutCacheTTL
+		}
+		if config.OrderTTL <= 0 {
+			config.OrderTTL = DefaultOrderCacheTTL
+		}
+		c.resources = &resourceCache{
+			config:  config,
+			entries: make(map[string]*list.Element),
+			lru:     list.New(),
+		}
+	}
+}
+
+// InvalidateCheckout drops the cached copy of a checkout.
+func (c *Client) InvalidateCheckout(id string) {
+	c.resources.invalidate(checkoutPath(id))
+}
+
+// InvalidateOrder drops the cached copy of an order.
+func (c *Client) InvalidateOrder(id string) {
+	c.resources.invalidate(orderPath(id))
+}
+
+func checkoutPath(id string) string { return fmt.Sprintf("%s/%s", CheckoutSessionsPath, id) }
+func orderPath(id string) string    { return fmt.Sprintf("%s/%s", OrdersPath, id) }
+
+// resourceCache is a size-bounded LRU of resource responses keyed by
+// path. Responses are kept as JSON, so callers never share a cached value.
+// A nil cache caches nothing.
+type resourceCache struct {
+	config  ResourceCacheConfig
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type resourceEntry struct {
+	path    string
+	body    []byte
+	expires time.Time
+}
+
+// load decodes the cached response for path into result, reporting
+// whether there was a fresh one.
+func (rc *resourceCache) load(path string, result any) bool {
+	if rc == nil {
+		return false
+	}
+	rc.mu.Lock()
+	elem, ok := rc.entries[path]
+	var body []byte
+	if ok {
+		entry := elem.Value.(*resourceEntry)
+		if time.Now().Before(entry.expires) {
+			rc.lru.MoveToFront(elem)
+			body = entry.body
+		} else {
+			rc.lru.Remove(elem)
+			delete(rc.entries, path)
+		}
+	}
+	rc.mu.Unlock()
+	return body != nil && json.Unmarshal(body, result) == nil
+}
+
+// store caches a response for path, for the order or checkout TTL.
+func (rc *resourceCache) store(path string, result any) {
+	if rc == nil {
+		return
+	}
+	body, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	ttl := rc.config.CheckoutTTL
+	if hasPathPrefix(path, OrdersPath) {
+		ttl = rc.config.OrderTTL
+	}
+	entry := &resourceEntry{path: path, body: body, expires: time.Now().Add(ttl)}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if elem, ok := rc.entries[path]; ok {
+		elem.Value = entry
+		rc.lru.MoveToFront(elem)
+		return
+	}
+	rc.entries[path] = rc.lru.PushFront(entry)
+	for rc.lru.Len() > rc.config.MaxEntries {
+		oldest := rc.lru.Back()
+		rc.lru.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*resourceEntry).path)
+	}
+}
+
+// invalidate drops the cached response for path.
+func (rc *resourceCache) invalidate(path string) {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if elem, ok := rc.entries[path]; ok {
+		rc.lru.Remove(elem)
+		delete(rc.entries, path)
+	}
+}
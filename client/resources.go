@@ -0,0 +1,82 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/dhananjay2021/ucp-go-sdk/httpcache"
+)
+
+// WithHTTPCache sets the HTTP cache for static resources fetched by
+// FetchServiceSchema and FetchResource. Defaults to httpcache.Default.
+func WithHTTPCache(cache *httpcache.Cache) ClientOption {
+	return func(c *Client) {
+		c.httpCache = cache
+	}
+}
+
+// FetchServiceSchema returns the REST schema (an OpenAPI document) the
+// merchant's discovery profile declares for a service, such as
+// ServiceShopping. The document is fetched through the client's HTTP
+// cache, so repeated calls are served locally while it is fresh.
+func (c *Client) FetchServiceSchema(ctx context.Context, serviceName string) ([]byte, error) {
+	profile, err := c.GetCachedProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	service, ok := profile.UCP.Services[serviceName]
+	if !ok || service.Rest == nil || service.Rest.Schema == "" {
+		return nil, fmt.Errorf("service %s declares no REST schema", serviceName)
+	}
+	return c.FetchResource(ctx, service.Rest.Schema)
+}
+
+// FetchResource fetches a static resource, such as a schema or card art,
+// through the client's HTTP cache. A relative URL is resolved against the
+// client's base URL. No credentials are sent.
+func (c *Client) FetchResource(ctx context.Context, resourceURL string) ([]byte, error) {
+	u, err := c.resolveResourceURL(resourceURL)
+	if err != nil {
+		return nil, err
+	}
+	return c.resourceCache().Get(ctx, c.httpClient, u)
+}
+
+// resolveResourceURL resolves a resource URL against the client's base URL.
+func (c *Client) resolveResourceURL(resourceURL string) (string, error) {
+	ref, err := url.Parse(resourceURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid resource URL %q: %w", resourceURL, err)
+	}
+	if !ref.IsAbs() {
+		base, err := url.Parse(c.baseURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid base URL %q: %w", c.baseURL, err)
+		}
+		ref = base.ResolveReference(ref)
+	}
+	return ref.String(), nil
+}
+
+// resourceCache returns the client's HTTP cache, or httpcache.Default.
+func (c *Client) resourceCache() *httpcache.Cache {
+	if c.httpCache == nil {
+		return httpcache.Default
+	}
+	return c.httpCache
+}
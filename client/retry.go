@@ -0,0 +1,216 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultRetryAttempts is the attempts a RetryPolicy makes, counting
+	// the first, when MaxAttempts is not set.
+	DefaultRetryAttempts = 3
+
+	// DefaultRetryBackoff is the first wait of the default exponential
+	// backoff.
+	DefaultRetryBackoff = 500 * time.Millisecond
+
+	// DefaultMaxRetryBackoff caps the waits of the default exponential
+	// backoff.
+	DefaultMaxRetryBackoff = 10 * time.Second
+)
+
+// RetryPolicy controls how the client retries failed requests.
+//
+// Only requests that are safe to repeat are retried: GET, HEAD, OPTIONS,
+// PUT, and DELETE always, and POST and PATCH only when sent with an
+// Idempotency-Key (see WithIdempotencyKey), so a merchant never creates or
+// completes a checkout twice. A Retry-After header on the response
+// overrides Backoff, and no retry is made if its wait would outlast the
+// request's deadline.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent, counting the
+	// first. Defaults to DefaultRetryAttempts; 1 disables retries.
+	MaxAttempts int
+
+	// Backoff returns the wait before retry n, starting at 1, when the
+	// response has no Retry-After. Defaults to ExponentialBackoff with
+	// DefaultRetryBackoff and DefaultMaxRetryBackoff.
+	Backoff func(n int) time.Duration
+
+	// RetryOn reports whether an attempt should be retried, given its
+	// response or, with a nil response, its transport error. Defaults to
+	// DefaultRetryOn.
+	RetryOn func(resp *http.Response, err error) bool
+}
+
+// Attempt describes one send of a request made under a RetryPolicy.
+type Attempt struct {
+	// StatusCode is the response status, or 0 if the request failed
+	// without a response.
+	StatusCode int
+
+	// Err is the transport error, if any.
+	Err error
+
+	// Latency is the time until the response headers arrived.
+	Latency time.Duration
+
+	// Wait is the pause before the next attempt; zero for the last.
+	Wait time.Duration
+}
+
+// WithRetryPolicy retries failed requests under policy:
+//
+//	c := client.NewClient(baseURL, client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 4}))
+//	_, err := c.CompleteCheckout(ctx, id, client.WithIdempotencyKey(key))
+//	var apiErr *client.Error
+//	if errors.As(err, &apiErr) {
+//		log.Printf("gave up after %d attempts", len(apiErr.Attempts))
+//	}
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+// DefaultRetryOn retries transport errors, 429 Too Many Requests, and 5xx
+// responses other than 501 Not Implemented.
+func DefaultRetryOn(resp *http.Response, err error) bool {
+	if resp == nil {
+		return err != nil
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+}
+
+// ExponentialBackoff returns a Backoff that waits base before the first
+// retry and doubles the wait for each retry after it, up to max.
+func ExponentialBackoff(base, max time.Duration) func(n int) time.Duration {
+	return func(n int) time.Duration {
+		wait := base
+		for i := 1; i < n && wait < max; i++ {
+			wait *= 2
+		}
+		return min(wait, max)
+	}
+}
+
+// retryable reports whether req may be sent again.
+func retryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch:
+		return req.Header.Get("Idempotency-Key") != ""
+	}
+	return true
+}
+
+// send sends req over transport, retrying under the client's retry
+// policy. Without a policy it sends once and reports no attempts.
+func (c *Client) send(ctx context.Context, transport Transport, req *http.Request) (*http.Response, []Attempt, error) {
+	policy := c.retry
+	if policy == nil {
+		resp, err := transport.RoundTrip(req)
+		return resp, nil, err
+	}
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultRetryAttempts
+	}
+	retryOn := policy.RetryOn
+	if retryOn == nil {
+		retryOn = DefaultRetryOn
+	}
+	backoff := policy.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff(DefaultRetryBackoff, DefaultMaxRetryBackoff)
+	}
+
+	var attempts []Attempt
+	for n := 1; ; n++ {
+		start := time.Now()
+		resp, err := transport.RoundTrip(req)
+		attempt := Attempt{Err: err, Latency: time.Since(start)}
+		if resp != nil {
+			attempt.StatusCode = resp.StatusCode
+		}
+		attempts = append(attempts, attempt)
+		if n >= maxAttempts || ctx.Err() != nil || !retryable(req) || !retryOn(resp, err) {
+			return resp, attempts, err
+		}
+
+		wait := backoff(n)
+		if resp != nil {
+			if d, ok := retryAfter(resp); ok {
+				wait = d
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return resp, attempts, err
+		}
+		next, rerr := c.rewind(req)
+		if rerr != nil {
+			return resp, attempts, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		attempts[len(attempts)-1].Wait = wait
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempts, ctx.Err()
+		case <-timer.C:
+		}
+		req = next
+	}
+}
+
+// rewind returns a copy of req with a fresh body and signature, for
+// sending again.
+func (c *Client) rewind(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	var data []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, http.ErrBodyNotAllowed
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		if c.signer == nil {
+			next.Body = body
+			return next, nil
+		}
+		data, err = io.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = io.NopCloser(bytes.NewReader(data))
+	}
+	if err := c.signRequest(next, data); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/scenarios"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// Sandbox item IDs that trigger deterministic scenarios in the fake merchant
+// behind NewSandboxClient. Any other catalog item behaves normally.
+const (
+	// SandboxItemDecline causes CompleteCheckout to fail with payment_declined.
+	SandboxItemDecline = "PROD-DECLINE"
+
+	// SandboxItemOutOfStock is always out of stock.
+	SandboxItemOutOfStock = "PROD-OOS"
+
+	// SandboxItemEscalate puts the checkout into requires_escalation.
+	SandboxItemEscalate = "PROD-ESCALATE"
+
+	// SandboxItemSlow makes completion return complete_in_progress; the
+	// checkout completes on the next GetCheckout.
+	SandboxItemSlow = "PROD-SLOW"
+
+	// SandboxItemServerError makes checkout creation fail with a 500.
+	SandboxItemServerError = "PROD-ERROR"
+)
+
+// SandboxBaseURL is the base URL reported by sandbox clients.
+const SandboxBaseURL = "https://sandbox.ucp.invalid"
+
+// sandboxVersion is the protocol version advertised by the sandbox merchant.
+const sandboxVersion models.Version = "2026-01-11"
+
+// NewSandboxClient returns a Client wired to an in-process fake merchant, so
+// agent flows can be developed and tested entirely offline. The merchant
+// offers a small catalog (PROD-001, PROD-002, and the Sandbox* scenario
+// items), 8.75% flat tax, standard and express shipping, the discount code
+// SANDBOX10, and the deterministic failure scenarios described on the
+// Sandbox* constants. It is built from SandboxScenario.
+//
+// Options are applied after the sandbox transport, so WithHTTPClient
+// replaces it.
+func NewSandboxClient(opts ...ClientOption) *Client {
+	transport := &handlerTransport{handler: newSandboxMerchant()}
+	opts = append([]ClientOption{WithHTTPClient(&http.Client{Transport: transport})}, opts...)
+	return NewClient(SandboxBaseURL, opts...)
+}
+
+// handlerTransport serves requests from an http.Handler without a network.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	in := req.Clone(req.Context())
+	if in.Host == "" {
+		in.Host = in.URL.Host
+	}
+	if in.URL.Scheme == "https" {
+		in.TLS = &tls.ConnectionState{HandshakeComplete: true}
+	}
+
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, in)
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+// SandboxScenario returns the scenario behind NewSandboxClient.
+func SandboxScenario() *scenarios.Scenario {
+	outOfStock := 0
+	return &scenarios.Scenario{
+		Name:     "sandbox",
+		Version:  sandboxVersion,
+		Currency: "USD",
+		Items: []scenarios.Item{
+			{ID: "PROD-001", Title: "Wireless Headphones", Price: 14999},
+			{ID: "PROD-002", Title: "Phone Case", Price: 2999},
+			{ID: SandboxItemDecline, Title: "Declined Item", Price: 1000},
+			{ID: SandboxItemOutOfStock, Title: "Out of Stock Item", Price: 1000, Stock: &outOfStock},
+			{ID: SandboxItemEscalate, Title: "Restricted Item", Price: 1000},
+			{ID: SandboxItemSlow, Title: "Slow Item", Price: 1000},
+			{ID: SandboxItemServerError, Title: "Broken Item", Price: 1000},
+		},
+		Tax: &scenarios.Tax{RateBasisPoints: 875},
+		Shipping: []scenarios.ShippingOption{
+			{ID: "standard", Title: "Standard Shipping", Carrier: "Sandbox Post", Amount: 599},
+			{ID: "express", Title: "Express Shipping", Carrier: "Sandbox Post", Amount: 1499},
+			{ID: "pickup", Title: "In-store pickup", Pickup: true},
+		},
+		Discounts: []scenarios.Discount{
+			{Code: "SANDBOX10", Title: "10% off", PercentOff: 10},
+		},
+		Triggers: []scenarios.Trigger{
+			{
+				ItemID: SandboxItemDecline,
+				On:     []scenarios.Stage{scenarios.StageComplete},
+				Error: &scenarios.TriggerError{
+					Status:  http.StatusPaymentRequired,
+					Code:    string(models.ErrorCodePaymentDeclined),
+					Message: "The payment was declined",
+				},
+				Messages: []models.Message{{
+					Type:     models.MessageTypeError,
+					Code:     string(models.ErrorCodePaymentDeclined),
+					Content:  "The payment was declined",
+					Severity: models.SeverityRequiresBuyerInput,
+					Path:     "$.payment",
+				}},
+			},
+			{
+				ItemID: SandboxItemEscalate,
+				On:     []scenarios.Stage{scenarios.StageCreate, scenarios.StageUpdate},
+				Status: models.CheckoutStatusRequiresEscalation,
+				Messages: []models.Message{{
+					Type:     models.MessageTypeError,
+					Code:     "age_verification_required",
+					Content:  "Buyer must verify their age on the merchant site",
+					Severity: models.SeverityRequiresBuyerReview,
+				}},
+			},
+			{
+				ItemID: SandboxItemSlow,
+				On:     []scenarios.Stage{scenarios.StageComplete},
+				Status: models.CheckoutStatusCompleteInProgress,
+			},
+			{
+				ItemID: SandboxItemServerError,
+				On:     []scenarios.Stage{scenarios.StageCreate},
+				Error: &scenarios.TriggerError{
+					Status:  http.StatusInternalServerError,
+					Code:    string(models.ErrorCodeInternal),
+					Message: "sandbox: simulated server error",
+				},
+			},
+		},
+	}
+}
+
+func newSandboxMerchant() http.Handler {
+	return scenarios.NewServer(server.Config{
+		Version: sandboxVersion,
+		Capabilities: []models.CapabilityDiscovery{
+			{CapabilityBase: models.CapabilityBase{Name: CapabilityCheckout, Version: sandboxVersion}},
+			{CapabilityBase: models.CapabilityBase{Name: CapabilityOrder, Version: sandboxVersion}},
+			{CapabilityBase: models.CapabilityBase{Name: CapabilityFulfillment, Version: sandboxVersion, Extends: CapabilityCheckout}},
+			{CapabilityBase: models.CapabilityBase{Name: CapabilityDiscount, Version: sandboxVersion, Extends: CapabilityCheckout}},
+		},
+		Services: models.Services{
+			ServiceShopping: models.UCPService{
+				Version: sandboxVersion,
+				Rest:    &models.RestTransport{Endpoint: SandboxBaseURL},
+			},
+		},
+		PaymentHandlers: []models.PaymentHandlerResponse{{
+			ID:                "sandbox",
+			Name:              "dev.ucp.tokenization",
+			Version:           string(sandboxVersion),
+			InstrumentSchemas: []string{models.InstrumentSchemaCard},
+			Config:            map[string]interface{}{"environment": "sandbox"},
+		}},
+	}, SandboxScenario())
+}
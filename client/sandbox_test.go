@@ -0,0 +1,43 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/client"
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+func TestSandboxDeclineIsPaymentDeclined(t *testing.T) {
+	ctx := context.Background()
+	c := client.NewSandboxClient()
+	checkout, err := c.CreateCheckout(ctx, &extensions.ExtendedCheckoutCreateRequest{
+		Currency:  "USD",
+		LineItems: []models.LineItemCreateRequest{{Item: models.ItemCreateRequest{ID: client.SandboxItemDecline}, Quantity: 1}},
+		Buyer:     &models.BuyerWithConsentCreateRequest{Email: "buyer@example.com"},
+		Payment:   models.PaymentCreateRequest{SelectedInstrumentID: "pi_1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.CompleteCheckout(ctx, checkout.ID)
+	if !errors.Is(err, client.ErrPaymentDeclined) {
+		t.Fatalf("CompleteCheckout = %v, want ErrPaymentDeclined", err)
+	}
+}
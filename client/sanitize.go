@@ -0,0 +1,68 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// WithContextSanitizer sanitizes the buyer context of checkout and cart
+// requests with s before they are sent, so LLM-authored intent text is
+// bounded and free of control characters (and, if s redacts PII, of
+// personal data) by the time a merchant sees it. The caller's requests
+// are not modified. Pass validation.DefaultSanitizer for the defaults.
+func WithContextSanitizer(s *validation.Sanitizer) ClientOption {
+	return func(c *Client) {
+		c.contextSanitizer = s
+	}
+}
+
+// sanitizeBody returns body with its buyer context sanitized, copying the
+// request rather than modifying it. Other bodies are returned as is.
+func (c *Client) sanitizeBody(body interface{}) interface{} {
+	s := c.contextSanitizer
+	if s == nil {
+		return body
+	}
+	switch req := body.(type) {
+	case *extensions.ExtendedCheckoutCreateRequest:
+		if req != nil && req.Context != nil {
+			out := *req
+			out.Context = s.Context(req.Context)
+			return &out
+		}
+	case *extensions.ExtendedCheckoutUpdateRequest:
+		if req != nil && req.Context != nil {
+			out := *req
+			out.Context = s.Context(req.Context)
+			return &out
+		}
+	case *models.CartCreateRequest:
+		if req != nil && req.Context != nil {
+			out := *req
+			out.Context = s.Context(req.Context)
+			return &out
+		}
+	case *models.CartUpdateRequest:
+		if req != nil && req.Context != nil {
+			out := *req
+			out.Context = s.Context(req.Context)
+			return &out
+		}
+	}
+	return body
+}
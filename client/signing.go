@@ -0,0 +1,58 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto"
+	"net/http"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/internal"
+)
+
+// SignatureHeader carries a detached JWS over the request.
+const SignatureHeader = "X-Detached-JWT"
+
+// WithRequestSigning signs every state-changing request (POST, PUT, PATCH,
+// DELETE) with an ECDSA P-256 or RSA key, sending a detached JWS in
+// X-Detached-JWT. The signature covers the method, request URI, a
+// timestamp and nonce, and a digest of the body, so it cannot be replayed
+// against another checkout; retries are signed afresh. The public key must
+// be published under kid in the platform profile named by WithUCPAgent,
+// where merchants enforcing server.RequestSignatureMiddleware look it up.
+func WithRequestSigning(key crypto.Signer, kid string) ClientOption {
+	return func(c *Client) {
+		c.signer = key
+		c.signerKid = kid
+	}
+}
+
+// signRequest sets the signature header on a state-changing request.
+func (c *Client) signRequest(req *http.Request, body []byte) error {
+	if c.signer == nil {
+		return nil
+	}
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil
+	}
+	sig, err := internal.SignRequest(c.signer, c.signerKid, req.Method, req.URL.RequestURI(), body, time.Now())
+	if err != nil {
+		return err
+	}
+	req.Header.Set(SignatureHeader, sig)
+	return nil
+}
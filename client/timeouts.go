@@ -0,0 +1,100 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OperationClass groups client calls that share a timeout.
+type OperationClass string
+
+const (
+	// OpDiscovery is fetching the discovery profile.
+	OpDiscovery OperationClass = "discovery"
+
+	// OpRead is retrieving checkouts, orders, and carts.
+	OpRead OperationClass = "read"
+
+	// OpWrite is creating, updating, and canceling checkouts and carts.
+	OpWrite OperationClass = "write"
+
+	// OpComplete is completing a checkout, which may wait on payment
+	// authorization.
+	OpComplete OperationClass = "complete"
+)
+
+// WithOperationTimeout overrides the client timeout for one class of
+// operation, for example a short deadline for discovery and a long one
+// for CompleteCheckout. The override is applied as a context deadline, so
+// a shorter deadline on the caller's context still wins.
+//
+// The default HTTP client's own timeout is raised to the longest
+// configured timeout; a client passed to WithHTTPClient keeps its own,
+// which caps every operation.
+func WithOperationTimeout(class OperationClass, timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if c.opTimeouts == nil {
+			c.opTimeouts = make(map[OperationClass]time.Duration)
+		}
+		c.opTimeouts[class] = timeout
+	}
+}
+
+// operationClass classifies a request.
+func operationClass(method, path string) OperationClass {
+	switch {
+	case path == WellKnownPath:
+		return OpDiscovery
+	case method == http.MethodPost && strings.HasSuffix(path, "/complete"):
+		return OpComplete
+	case method == http.MethodGet || method == http.MethodHead:
+		return OpRead
+	}
+	return OpWrite
+}
+
+// withOperationTimeout bounds ctx by the timeout configured for the
+// request's operation class. Once any override is set, classes without
+// one are bounded by the client timeout, since the default HTTP client no
+// longer enforces it.
+func (c *Client) withOperationTimeout(ctx context.Context, method, path string) (context.Context, context.CancelFunc) {
+	if len(c.opTimeouts) == 0 {
+		return ctx, func() {}
+	}
+	timeout, ok := c.opTimeouts[operationClass(method, path)]
+	if !ok {
+		timeout = c.timeout
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// httpTimeout returns the timeout for the default HTTP client: the client
+// timeout, or the longest operation timeout if that is longer.
+func (c *Client) httpTimeout() time.Duration {
+	timeout := c.timeout
+	for _, t := range c.opTimeouts {
+		if t > timeout {
+			timeout = t
+		}
+	}
+	return timeout
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// Transport binding names, as keyed in a service's discovery entry.
+const (
+	// TransportREST is the REST binding.
+	TransportREST = "rest"
+
+	// TransportMCP is the MCP binding.
+	TransportMCP = "mcp"
+
+	// TransportA2A is the A2A binding.
+	TransportA2A = "a2a"
+)
+
+// Transport carries the client's operations to the merchant over one
+// transport binding. The client builds every operation as a REST request
+// (method, path under the base URL, JSON body, and headers) and reads the
+// result as a REST response, so validation, error handling, and the other
+// client features work the same over every binding; transports for other
+// bindings translate in both directions.
+type Transport interface {
+	// Binding returns the binding name, such as TransportREST.
+	Binding() string
+
+	// RoundTrip performs one operation.
+	RoundTrip(req *http.Request) (*http.Response, error)
+}
+
+// WithTransport sends every operation except discovery over t instead of
+// choosing a transport from the merchant's profile.
+func WithTransport(t Transport) ClientOption {
+	return func(c *Client) {
+		c.transport = t
+	}
+}
+
+// restTransport sends requests as they are with the client's HTTP client.
+type restTransport struct {
+	client *Client
+}
+
+func (t restTransport) Binding() string { return TransportREST }
+
+func (t restTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.client.httpClient.Do(req)
+}
+
+// transportFor returns the transport for a request path. Discovery always
+// uses REST. Otherwise, once the profile has been fetched, a merchant
+// whose shopping service does not advertise REST is reached over MCP, or
+// failing that A2A; before that, and whenever REST is advertised, requests
+// go over REST, unless the client has failed over from it (see
+// WithTransportFailover).
+func (c *Client) transportFor(path string) Transport {
+	if path == WellKnownPath {
+		return restTransport{c}
+	}
+	if c.transport != nil {
+		return c.transport
+	}
+
+	c.profileMu.RLock()
+	profile := c.profile
+	c.profileMu.RUnlock()
+	if profile == nil {
+		return restTransport{c}
+	}
+	service, ok := profile.UCP.Services[ServiceShopping]
+	if !ok {
+		return restTransport{c}
+	}
+
+	c.transportMu.Lock()
+	defer c.transportMu.Unlock()
+	if service.Rest != nil && !c.failedOver {
+		return restTransport{c}
+	}
+	if t := c.alternateTransport(service); t != nil {
+		return t
+	}
+	return restTransport{c}
+}
+
+// alternateTransport returns the transport for the service's MCP binding,
+// or failing that its A2A binding, or nil if it advertises neither.
+// c.transportMu must be held.
+func (c *Client) alternateTransport(service models.UCPService) Transport {
+	switch {
+	case service.MCP != nil && service.MCP.Endpoint != "":
+		if c.mcp == nil || c.mcp.Endpoint != service.MCP.Endpoint {
+			c.mcp = NewMCPTransport(service.MCP.Endpoint, c.httpClient)
+		}
+		return c.mcp
+	case service.A2A != nil && service.A2A.Endpoint != "":
+		if c.a2a == nil || c.a2a.CardURL != service.A2A.Endpoint {
+			c.a2a = NewA2ATransport(service.A2A.Endpoint, c.httpClient)
+		}
+		return c.a2a
+	}
+	return nil
+}
+
+// boundOperation is a REST request recast as a named operation, for
+// bindings that call tools or skills rather than resources.
+type boundOperation struct {
+	// name is the operation's action and resource, e.g. create_checkout.
+	name string
+
+	// resource is the resource name: checkout, order, or cart.
+	resource string
+
+	// args carry the resource ID as "id", the request body under the
+	// resource name, and a "meta" object with the platform's "ucp-agent"
+	// profile and any "idempotency-key".
+	args map[string]any
+}
+
+// bindOperation recasts a client request as a named operation, consuming
+// its body.
+func bindOperation(req *http.Request) (*boundOperation, error) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	op := &boundOperation{}
+	switch parts[0] {
+	case strings.TrimPrefix(CheckoutSessionsPath, "/"):
+		op.resource = "checkout"
+	case strings.TrimPrefix(OrdersPath, "/"):
+		op.resource = "order"
+	case strings.TrimPrefix(CartsPath, "/"):
+		op.resource = "cart"
+	}
+
+	var action string
+	switch method := req.Method; {
+	case op.resource == "":
+	case len(parts) == 1 && method == http.MethodPost:
+		action = "create"
+	case len(parts) == 2 && method == http.MethodGet:
+		action = "get"
+	case len(parts) == 2 && (method == http.MethodPatch || method == http.MethodPut):
+		action = "update"
+	case len(parts) == 2 && method == http.MethodDelete:
+		action = "delete"
+	case len(parts) == 3 && method == http.MethodPost && (parts[2] == "complete" || parts[2] == "cancel"):
+		action = parts[2]
+	}
+	if action == "" {
+		return nil, fmt.Errorf("no UCP operation for %s %s", req.Method, req.URL.Path)
+	}
+	op.name = action + "_" + op.resource
+
+	meta := map[string]any{}
+	if profile, err := server.ParseUCPAgent(req.Header.Get(server.UCPAgentHeader)); err == nil {
+		meta["ucp-agent"] = map[string]string{"profile": profile}
+	}
+	if key := req.Header.Get("Idempotency-Key"); key != "" {
+		meta["idempotency-key"] = key
+	}
+	op.args = map[string]any{"meta": meta}
+	if len(parts) > 1 {
+		op.args["id"] = parts[1]
+	}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(body) > 0 {
+			op.args[op.resource] = json.RawMessage(body)
+		}
+	}
+	return op, nil
+}
+
+// response builds the REST response for an operation's result content,
+// unwrapped from a single resource-named key if the merchant nests it.
+// Errors get the status their code usually has, defaulting to 400.
+func (op *boundOperation) response(req *http.Request, header http.Header, content json.RawMessage, isError bool) *http.Response {
+	body := []byte(content)
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(content, &fields) == nil && len(fields) == 1 {
+		if inner, ok := fields[op.resource]; ok && bytes.HasPrefix(bytes.TrimSpace(inner), []byte("{")) {
+			body = inner
+		}
+	}
+
+	status := http.StatusOK
+	if isError {
+		status = http.StatusBadRequest
+		var envelope struct {
+			Error    string           `json:"error"`
+			Messages []models.Message `json:"messages"`
+		}
+		json.Unmarshal(body, &envelope)
+		code := envelope.Error
+		if code == "" && len(envelope.Messages) > 0 {
+			code = envelope.Messages[0].Code
+		}
+		if s, ok := codeStatus[models.ErrorCode(code)]; ok {
+			status = s
+		}
+	}
+	return operationResponse(req, status, header, body)
+}
+
+// textContent returns text result content as JSON: as is if it is JSON,
+// and otherwise, for errors, as the message of an error body.
+func textContent(text string, isError bool) json.RawMessage {
+	if json.Valid([]byte(text)) {
+		return json.RawMessage(text)
+	}
+	if !isError {
+		return nil
+	}
+	body, _ := json.Marshal(map[string]string{"message": text})
+	return body
+}
+
+// operationResponse builds the REST response the client reads.
+func operationResponse(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	header = header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("Content-Type", "application/json")
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// UpdateSection identifies a top-level field group of a checkout update
+// request.
+type UpdateSection string
+
+const (
+	// SectionLineItems is the line_items field group.
+	SectionLineItems UpdateSection = "line_items"
+
+	// SectionBuyer is the buyer field group.
+	SectionBuyer UpdateSection = "buyer"
+
+	// SectionFulfillment is the fulfillment field group.
+	SectionFulfillment UpdateSection = "fulfillment"
+
+	// SectionPayment is the payment field group.
+	SectionPayment UpdateSection = "payment"
+
+	// SectionDiscounts is the discounts field group.
+	SectionDiscounts UpdateSection = "discounts"
+
+	// SectionProtection is the protection field group.
+	SectionProtection UpdateSection = "protection"
+)
+
+// UpdateResult correlates the messages of a checkout update response with
+// the sections of the request that produced it, so callers can tell which
+// parts of a partially successful update were applied:
+//
+//	result, err := c.UpdateCheckoutResult(ctx, id, req)
+//	if err == nil && result.Rejected(client.SectionFulfillment) {
+//		for _, msg := range result.Messages(client.SectionFulfillment) {
+//			log.Println(msg.Content)
+//		}
+//	}
+type UpdateResult struct {
+	// Checkout is the checkout returned by the merchant.
+	Checkout *extensions.ExtendedCheckoutResponse
+
+	sent     map[UpdateSection]bool
+	messages map[UpdateSection][]models.Message
+}
+
+// NewUpdateResult builds an UpdateResult from an update request and the
+// checkout the merchant returned for it.
+func NewUpdateResult(req *extensions.ExtendedCheckoutUpdateRequest, checkout *extensions.ExtendedCheckoutResponse) *UpdateResult {
+	r := &UpdateResult{
+		Checkout: checkout,
+		sent:     make(map[UpdateSection]bool),
+		messages: make(map[UpdateSection][]models.Message),
+	}
+	if req != nil {
+		r.sent[SectionLineItems] = len(req.LineItems) > 0
+		r.sent[SectionBuyer] = req.Buyer != nil
+		r.sent[SectionFulfillment] = req.Fulfillment != nil
+		r.sent[SectionPayment] = req.Payment.SelectedInstrumentID != "" || len(req.Payment.Instruments) > 0
+		r.sent[SectionDiscounts] = req.Discounts != nil
+		r.sent[SectionProtection] = req.Protection != nil
+	}
+	if checkout != nil {
+		for _, msg := range checkout.Messages {
+			if section := sectionOf(msg.Path); section != "" {
+				r.messages[section] = append(r.messages[section], msg)
+			}
+		}
+	}
+	return r
+}
+
+// Sent reports whether the request included the section.
+func (r *UpdateResult) Sent(section UpdateSection) bool {
+	return r.sent[section]
+}
+
+// Accepted reports whether the section was sent and the merchant raised no
+// error or warning against it.
+func (r *UpdateResult) Accepted(section UpdateSection) bool {
+	return r.sent[section] && !r.Rejected(section)
+}
+
+// Rejected reports whether the merchant raised an error or warning whose
+// path points into the section.
+func (r *UpdateResult) Rejected(section UpdateSection) bool {
+	for _, msg := range r.messages[section] {
+		if msg.Type == models.MessageTypeError || msg.Type == models.MessageTypeWarning {
+			return true
+		}
+	}
+	return false
+}
+
+// Messages returns the response messages whose path points into the section.
+func (r *UpdateResult) Messages(section UpdateSection) []models.Message {
+	return r.messages[section]
+}
+
+// UpdateCheckoutResult updates a checkout session and correlates the
+// response messages with the request sections. Sections dropped by
+// WithRequestPruning are not reported as sent.
+func (c *Client) UpdateCheckoutResult(ctx context.Context, id string, req *extensions.ExtendedCheckoutUpdateRequest, opts ...RequestOption) (*UpdateResult, error) {
+	req = c.pruneUpdate(ctx, req)
+	checkout, err := c.updateCheckout(ctx, id, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewUpdateResult(req, checkout), nil
+}
+
+// sectionOf returns the top-level section a JSONPath refers to, accepting
+// both dot ("$.buyer.email") and bracket ("$['buyer']") notation.
+func sectionOf(path string) UpdateSection {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok {
+		return ""
+	}
+	var name string
+	switch {
+	case strings.HasPrefix(rest, "."):
+		name = rest[1:]
+		if i := strings.IndexAny(name, ".["); i >= 0 {
+			name = name[:i]
+		}
+	case strings.HasPrefix(rest, "['"), strings.HasPrefix(rest, `["`):
+		quote := rest[1]
+		name = rest[2:]
+		i := strings.IndexByte(name, quote)
+		if i < 0 {
+			return ""
+		}
+		name = name[:i]
+	default:
+		return ""
+	}
+	return UpdateSection(name)
+}
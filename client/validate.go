@@ -0,0 +1,240 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// Directions reported by ValidationFailedError.
+const (
+	ValidationDirectionRequest  = "request"
+	ValidationDirectionResponse = "response"
+)
+
+// WithValidation validates every checkout, order and cart request body
+// before it is sent, and every response body before it is decoded,
+// against the JSON Schemas advertised in the merchant's discovery profile.
+// A payload that fails fails the call with a *ValidationFailedError. A nil
+// validator creates a private one.
+//
+// Responses are validated against the schema of the resource capability
+// and, through their $defs entry for it, of each extension of it that the
+// response declares. Requests are validated against the request variants
+// of the same schemas, named by inserting ".create_req" or ".update_req"
+// before the ".json" suffix; a request schema the merchant does not
+// publish is skipped.
+func WithValidation(validator *validation.SchemaValidator) ClientOption {
+	return func(c *Client) {
+		if validator == nil {
+			validator = validation.NewSchemaValidator()
+		}
+		c.validator = validator
+	}
+}
+
+// ValidationFailedError reports a request or response body that does not
+// validate against a schema advertised by the merchant.
+type ValidationFailedError struct {
+	// Direction is ValidationDirectionRequest or ValidationDirectionResponse.
+	Direction string
+
+	Method string
+	Path   string
+
+	// Capability is the capability whose schema failed.
+	Capability models.CapabilityName
+
+	// SchemaURL is the schema, with a fragment for extension $defs.
+	SchemaURL string
+
+	Errors []validation.ValidationError
+}
+
+func (e *ValidationFailedError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i := range e.Errors {
+		msgs[i] = e.Errors[i].Error()
+	}
+	return fmt.Sprintf("invalid %s body for %s %s against %s: %s", e.Direction, e.Method, e.Path, e.SchemaURL, strings.Join(msgs, "; "))
+}
+
+// validationTarget is a schema a payload must satisfy.
+type validationTarget struct {
+	capability models.CapabilityName
+	schemaURL  string
+}
+
+// resourceCapability returns the capability that serves path, or "" for
+// paths that are not validated.
+func resourceCapability(path string) models.CapabilityName {
+	switch {
+	case hasPathPrefix(path, CheckoutSessionsPath):
+		return CapabilityCheckout
+	case hasPathPrefix(path, OrdersPath):
+		// Sub-resources of an order, such as modifications, are not
+		// orders.
+		if strings.Count(strings.TrimPrefix(path, OrdersPath), "/") > 1 {
+			return ""
+		}
+		return CapabilityOrder
+	case hasPathPrefix(path, CartsPath):
+		return CapabilityCart
+	}
+	return ""
+}
+
+func hasPathPrefix(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// requestSchemaSuffix names the request schema variant for a request, or
+// "" for requests whose bodies are not validated.
+func requestSchemaSuffix(method, path string) string {
+	switch method {
+	case http.MethodPost:
+		if path == CheckoutSessionsPath || path == CartsPath {
+			return ".create_req"
+		}
+	case http.MethodPut, http.MethodPatch:
+		return ".update_req"
+	}
+	return ""
+}
+
+// validationTargets returns the schemas for a payload on path: the
+// resource capability's own schema and the $defs entry of each extension
+// of it in the profile. A non-nil declared limits extensions to those
+// names. suffix selects a request schema variant.
+func (c *Client) validationTargets(ctx context.Context, path, suffix string, declared map[models.CapabilityName]bool) []validationTarget {
+	resource := resourceCapability(path)
+	if resource == "" {
+		return nil
+	}
+	profile, err := c.GetCachedProfile(ctx)
+	if err != nil {
+		return nil
+	}
+
+	var targets []validationTarget
+	for _, capability := range profile.UCP.Capabilities {
+		if capability.Schema == "" {
+			continue
+		}
+		schemaURL := capability.Schema
+		if suffix != "" {
+			if !strings.HasSuffix(schemaURL, ".json") {
+				continue
+			}
+			schemaURL = strings.TrimSuffix(schemaURL, ".json") + suffix + ".json"
+		}
+		switch {
+		case capability.Name == resource:
+			if _, err := c.validator.LoadSchema(schemaURL); err != nil {
+				continue
+			}
+		case capability.Extends == resource && (declared == nil || declared[capability.Name]):
+			if !c.hasSchemaDef(schemaURL, string(resource)) {
+				continue
+			}
+			schemaURL += "#/$defs/" + string(resource)
+		default:
+			continue
+		}
+		targets = append(targets, validationTarget{capability: capability.Name, schemaURL: schemaURL})
+	}
+	return targets
+}
+
+// hasSchemaDef reports whether the schema at schemaURL loads and has a
+// $defs entry named name.
+func (c *Client) hasSchemaDef(schemaURL, name string) bool {
+	raw, err := c.validator.LoadSchema(schemaURL)
+	if err != nil {
+		return false
+	}
+	var schema struct {
+		Defs map[string]json.RawMessage `json:"$defs"`
+	}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return false
+	}
+	_, ok := schema.Defs[name]
+	return ok
+}
+
+// validatePayload checks data against each target, failing on the first
+// schema it does not satisfy.
+func (c *Client) validatePayload(direction, method, path string, data []byte, targets []validationTarget) error {
+	for _, t := range targets {
+		result := c.validator.ValidatePayload(t.schemaURL, data)
+		if result.Valid {
+			continue
+		}
+		return &ValidationFailedError{
+			Direction:  direction,
+			Method:     method,
+			Path:       path,
+			Capability: t.capability,
+			SchemaURL:  t.schemaURL,
+			Errors:     result.Errors,
+		}
+	}
+	return nil
+}
+
+// validateRequest validates an encoded request body when WithValidation
+// is set.
+func (c *Client) validateRequest(ctx context.Context, method, path string, data []byte) error {
+	if c.validator == nil || len(data) == 0 {
+		return nil
+	}
+	suffix := requestSchemaSuffix(method, path)
+	if suffix == "" {
+		return nil
+	}
+	targets := c.validationTargets(ctx, path, suffix, nil)
+	return c.validatePayload(ValidationDirectionRequest, method, path, data, targets)
+}
+
+// validateResponse validates a response body when WithValidation is set.
+// Extensions are limited to those the response declares in
+// ucp.capabilities, when it declares any.
+func (c *Client) validateResponse(ctx context.Context, method, path string, body []byte) error {
+	if c.validator == nil || len(body) == 0 || resourceCapability(path) == "" {
+		return nil
+	}
+	var envelope struct {
+		UCP *struct {
+			Capabilities []models.CapabilityResponse `json:"capabilities"`
+		} `json:"ucp"`
+	}
+	var declared map[models.CapabilityName]bool
+	if json.Unmarshal(body, &envelope) == nil && envelope.UCP != nil && len(envelope.UCP.Capabilities) > 0 {
+		declared = make(map[models.CapabilityName]bool, len(envelope.UCP.Capabilities))
+		for _, capability := range envelope.UCP.Capabilities {
+			declared[capability.Name] = true
+		}
+	}
+	targets := c.validationTargets(ctx, path, "", declared)
+	return c.validatePayload(ValidationDirectionResponse, method, path, body, targets)
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// DefaultPollInterval is the polling interval used when none is given.
+const DefaultPollInterval = 2 * time.Second
+
+// IsTerminalStatus reports whether a checkout status is final.
+func IsTerminalStatus(status models.CheckoutStatus) bool {
+	return status == models.CheckoutStatusCompleted || status == models.CheckoutStatusCanceled
+}
+
+// CompleteCheckoutAndWait completes a checkout and, if the merchant responds
+// with complete_in_progress, polls GetCheckout every pollInterval until the
+// checkout leaves that state (normally reaching completed or canceled) or
+// ctx is done.
+//
+// It returns the last checkout observed and its order confirmation, which is
+// nil unless the checkout completed. On context expiry the last observed
+// checkout is returned together with ctx.Err(). opts apply to the complete
+// request only.
+func (c *Client) CompleteCheckoutAndWait(ctx context.Context, id string, pollInterval time.Duration, opts ...RequestOption) (*extensions.ExtendedCheckoutResponse, *models.OrderConfirmation, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	checkout, err := c.CompleteCheckout(ctx, id, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	checkout, err = c.waitForStatus(ctx, checkout, pollInterval, func(s models.CheckoutStatus) bool {
+		return s != models.CheckoutStatusCompleteInProgress
+	})
+	if err != nil {
+		return checkout, nil, err
+	}
+	return checkout, checkout.Order, nil
+}
+
+// waitForStatus polls a checkout until done reports true for its status.
+func (c *Client) waitForStatus(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse, pollInterval time.Duration, done func(models.CheckoutStatus) bool) (*extensions.ExtendedCheckoutResponse, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for !done(checkout.Status) {
+		select {
+		case <-ctx.Done():
+			return checkout, ctx.Err()
+		case <-ticker.C:
+		}
+
+		next, err := c.fetchCheckout(ctx, checkout.ID)
+		if err != nil {
+			return checkout, err
+		}
+		checkout = next
+	}
+	return checkout, nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/internal"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// maxWebhookBodyBytes bounds the webhook bodies a Receiver reads.
+const maxWebhookBodyBytes = 1 << 20
+
+// keyRefreshInterval limits how often a failed signature check refetches
+// the merchant profile, so forged requests cannot force a fetch each.
+const keyRefreshInterval = time.Minute
+
+// WebhookHandler handles one verified webhook event. Returning an error
+// answers 500, so the merchant redelivers; handlers should therefore be
+// idempotent on event.ID.
+type WebhookHandler func(ctx context.Context, event *models.WebhookEvent) error
+
+// Receiver is an http.Handler for a merchant's webhooks. It verifies each
+// delivery's X-Detached-JWT against the signing_keys in the merchant's
+// cached discovery profile, refetching the profile when a signature does
+// not verify in case the keys rotated, then decodes the event and calls
+// the handler registered for its type. Events without a handler are
+// acknowledged and dropped.
+//
+// It also answers the merchant's registration challenge, signing the
+// echo when the client was created WithRequestSigning.
+type Receiver struct {
+	client *Client
+
+	mu          sync.Mutex
+	handlers    map[string]WebhookHandler
+	verifier    *server.WebhookVerifier
+	verifierOf  *models.UCPProfile
+	refreshedAt time.Time
+}
+
+// NewReceiver creates a Receiver for the merchant c talks to.
+func (c *Client) NewReceiver() *Receiver {
+	return &Receiver{client: c, handlers: make(map[string]WebhookHandler)}
+}
+
+// Handle registers a handler for an event type, replacing any previous
+// one.
+func (r *Receiver) Handle(eventType string, handler WebhookHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[eventType] = handler
+}
+
+// OnOrderCreated handles order.created events.
+func (r *Receiver) OnOrderCreated(fn func(ctx context.Context, order *extensions.ExtendedOrder) error) {
+	r.Handle(models.WebhookEventOrderCreated, typedHandler(fn))
+}
+
+// OnOrderUpdated handles order.updated events.
+func (r *Receiver) OnOrderUpdated(fn func(ctx context.Context, order *extensions.ExtendedOrder) error) {
+	r.Handle(models.WebhookEventOrderUpdated, typedHandler(fn))
+}
+
+// OnOrderFulfillmentEventAppended handles order.fulfillment_event_appended
+// events.
+func (r *Receiver) OnOrderFulfillmentEventAppended(fn func(ctx context.Context, event *models.OrderFulfillmentEventAppendedEvent) error) {
+	r.Handle(models.WebhookEventOrderFulfillmentEventAppended, typedHandler(fn))
+}
+
+// OnOrderAdjustmentAdded handles order.adjustment_added events.
+func (r *Receiver) OnOrderAdjustmentAdded(fn func(ctx context.Context, event *models.OrderAdjustmentAddedEvent) error) {
+	r.Handle(models.WebhookEventOrderAdjustmentAdded, typedHandler(fn))
+}
+
+// OnOrderModificationDecided handles order.modification_decided events.
+func (r *Receiver) OnOrderModificationDecided(fn func(ctx context.Context, event *models.OrderModificationDecidedEvent) error) {
+	r.Handle(models.WebhookEventOrderModificationDecided, typedHandler(fn))
+}
+
+// OnCheckoutReadyForComplete handles checkout.ready_for_complete events.
+func (r *Receiver) OnCheckoutReadyForComplete(fn func(ctx context.Context, event *models.CheckoutReadyForCompleteEvent) error) {
+	r.Handle(models.WebhookEventCheckoutReadyForComplete, typedHandler(fn))
+}
+
+// OnCheckoutRequiresEscalation handles checkout.requires_escalation
+// events.
+func (r *Receiver) OnCheckoutRequiresEscalation(fn func(ctx context.Context, event *models.CheckoutRequiresEscalationEvent) error) {
+	r.Handle(models.WebhookEventCheckoutRequiresEscalation, typedHandler(fn))
+}
+
+// OnCheckoutExpired handles checkout.expired events.
+func (r *Receiver) OnCheckoutExpired(fn func(ctx context.Context, event *models.CheckoutExpiredEvent) error) {
+	r.Handle(models.WebhookEventCheckoutExpired, typedHandler(fn))
+}
+
+// webhookDataError reports event data that does not decode; it is
+// answered 400, since redelivering it cannot help.
+type webhookDataError struct{ err error }
+
+func (e *webhookDataError) Error() string { return "invalid event data: " + e.err.Error() }
+
+// typedHandler adapts a handler of decoded event data to a WebhookHandler.
+func typedHandler[T any](fn func(ctx context.Context, data *T) error) WebhookHandler {
+	return func(ctx context.Context, event *models.WebhookEvent) error {
+		var data T
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return &webhookDataError{err}
+		}
+		return fn(ctx, &data)
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		server.WriteError(w, http.StatusMethodNotAllowed, string(models.ErrorCodeMethodNotAllowed), "Webhooks must be POSTed")
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxWebhookBodyBytes+1))
+	if err != nil {
+		server.WriteError(w, http.StatusBadRequest, string(models.ErrorCodeBadRequest), "Failed to read webhook body")
+		return
+	}
+	if len(body) > maxWebhookBodyBytes {
+		server.WriteError(w, http.StatusRequestEntityTooLarge, string(models.ErrorCodeRequestTooLarge), "Webhook body too large")
+		return
+	}
+
+	var challenge server.WebhookChallenge
+	if json.Unmarshal(body, &challenge) == nil && challenge.Type == "challenge" && challenge.Challenge != "" {
+		r.answerChallenge(w, challenge)
+		return
+	}
+
+	if err := r.verify(req.Context(), req.Header.Get(server.SignatureHeader), body); err != nil {
+		server.WriteError(w, http.StatusUnauthorized, string(models.ErrorCodeInvalidSignature), err.Error())
+		return
+	}
+
+	var event models.WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.Type == "" {
+		server.WriteError(w, http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Webhook body is not an event")
+		return
+	}
+
+	r.mu.Lock()
+	handler := r.handlers[event.Type]
+	r.mu.Unlock()
+	if handler != nil {
+		var dataErr *webhookDataError
+		if err := handler(req.Context(), &event); errors.As(err, &dataErr) {
+			server.WriteError(w, http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), err.Error())
+			return
+		} else if err != nil {
+			server.WriteError(w, http.StatusInternalServerError, string(models.ErrorCodeInternal), "Webhook handler failed")
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// answerChallenge echoes a registration challenge.
+func (r *Receiver) answerChallenge(w http.ResponseWriter, challenge server.WebhookChallenge) {
+	body, err := json.Marshal(challenge)
+	if err != nil {
+		server.WriteError(w, http.StatusInternalServerError, string(models.ErrorCodeInternal), err.Error())
+		return
+	}
+	if c := r.client; c.signer != nil {
+		sig, err := internal.SignDetached(c.signer, c.signerKid, body)
+		if err != nil {
+			server.WriteError(w, http.StatusInternalServerError, string(models.ErrorCodeInternal), err.Error())
+			return
+		}
+		w.Header().Set(server.SignatureHeader, sig)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// verify checks a signature against the merchant's signing keys, retrying
+// once against a refetched profile.
+func (r *Receiver) verify(ctx context.Context, sig string, body []byte) error {
+	if sig == "" {
+		return errors.New("missing " + server.SignatureHeader + " header")
+	}
+	profile, err := r.client.GetCachedProfile(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch merchant signing keys: %w", err)
+	}
+	verifier, err := r.verifierFor(profile)
+	if err != nil {
+		return err
+	}
+	err = verifier.VerifySignature(sig, body)
+	if err == nil || !r.mayRefresh() {
+		return err
+	}
+
+	if profile, err = r.client.FetchProfile(ctx); err != nil {
+		return fmt.Errorf("failed to fetch merchant signing keys: %w", err)
+	}
+	if verifier, err = r.verifierFor(profile); err != nil {
+		return err
+	}
+	return verifier.VerifySignature(sig, body)
+}
+
+// verifierFor returns a verifier for a profile's signing keys, cached
+// until the profile changes.
+func (r *Receiver) verifierFor(profile *models.UCPProfile) (*server.WebhookVerifier, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.verifier != nil && r.verifierOf == profile {
+		return r.verifier, nil
+	}
+	verifier, err := server.NewWebhookVerifier(profile.SigningKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merchant signing keys: %w", err)
+	}
+	r.verifier, r.verifierOf = verifier, profile
+	return verifier, nil
+}
+
+// mayRefresh reports whether a failed check may refetch the profile, and
+// if so records that it did.
+func (r *Receiver) mayRefresh() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if now.Sub(r.refreshedAt) < keyRefreshInterval {
+		return false
+	}
+	r.refreshedAt = now
+	return true
+}
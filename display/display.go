@@ -0,0 +1,248 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// currencyInfo holds display data for an ISO 4217 currency.
+type currencyInfo struct {
+	symbol string
+	digits int
+}
+
+// currencySymbols is a CLDR-lite table of common currency symbols.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CAD": "CA$",
+	"AUD": "A$",
+	"NZD": "NZ$",
+	"CHF": "CHF",
+	"CNY": "CN¥",
+	"INR": "₹",
+	"KRW": "₩",
+	"BRL": "R$",
+	"MXN": "MX$",
+	"SEK": "kr",
+	"NOK": "kr",
+	"DKK": "kr",
+	"PLN": "zł",
+	"SGD": "S$",
+	"HKD": "HK$",
+	"KWD": "KD",
+	"BHD": "BD",
+}
+
+// localeInfo holds number formatting conventions for a locale.
+type localeInfo struct {
+	decimal      string
+	group        string
+	symbolSuffix bool
+}
+
+// locales is a CLDR-lite table of number formatting conventions.
+var locales = map[string]localeInfo{
+	"en":    {".", ",", false},
+	"ja":    {".", ",", false},
+	"zh":    {".", ",", false},
+	"ko":    {".", ",", false},
+	"de":    {",", ".", true},
+	"de-CH": {".", "’", false},
+	"fr":    {",", " ", true},
+	"fr-CH": {".", " ", true},
+	"es":    {",", ".", true},
+	"es-MX": {".", ",", false},
+	"it":    {",", ".", true},
+	"nl":    {",", ".", false},
+	"pt":    {",", " ", true},
+	"pt-BR": {",", ".", false},
+	"sv":    {",", " ", true},
+	"pl":    {",", " ", true},
+}
+
+// MinorDigits returns the number of minor-unit digits for a currency
+// (2 for USD, 0 for JPY, 3 for KWD), as models.MinorDigits.
+func MinorDigits(currency string) int {
+	return models.MinorDigits(currency)
+}
+
+// CurrencySymbol returns the display symbol for a currency.
+func CurrencySymbol(currency string) string {
+	return lookupCurrency(currency).symbol
+}
+
+// Currencies returns the ISO 4217 codes with display data, sorted.
+func Currencies() []string {
+	codes := make([]string, 0, len(currencySymbols))
+	for code := range currencySymbols {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// FormatAmount formats a minor-unit amount for display in a locale
+// (a BCP 47 tag such as "en-US"; empty means English).
+func FormatAmount(amount int, currency, locale string) string {
+	cur := lookupCurrency(currency)
+	loc := lookupLocale(locale)
+
+	negative := amount < 0
+	if negative {
+		amount = -amount
+	}
+
+	number := formatNumber(amount, cur.digits, loc)
+
+	var b strings.Builder
+	if negative {
+		b.WriteByte('-')
+	}
+	if loc.symbolSuffix {
+		b.WriteString(number)
+		b.WriteString(" ")
+		b.WriteString(cur.symbol)
+	} else {
+		b.WriteString(cur.symbol)
+		b.WriteString(number)
+	}
+	return b.String()
+}
+
+// FormatMoney formats m for display in a locale, as FormatAmount.
+func FormatMoney(m models.Money, locale string) string {
+	return FormatAmount(m.Amount, m.Currency, locale)
+}
+
+// FormatNumber formats a minor-unit amount without a currency symbol.
+func FormatNumber(amount int, currency, locale string) string {
+	cur := lookupCurrency(currency)
+	loc := lookupLocale(locale)
+	if amount < 0 {
+		return "-" + formatNumber(-amount, cur.digits, loc)
+	}
+	return formatNumber(amount, cur.digits, loc)
+}
+
+// ParseAmount parses a user-entered amount in a locale back to minor units.
+// Currency symbols, ISO codes, and whitespace are ignored. Input with more
+// fractional digits than the currency allows is rejected.
+func ParseAmount(s, currency, locale string) (int, error) {
+	cur := lookupCurrency(currency)
+	loc := lookupLocale(locale)
+
+	cleaned := strings.TrimSpace(s)
+	cleaned = strings.ReplaceAll(cleaned, cur.symbol, "")
+	cleaned = strings.ReplaceAll(cleaned, strings.ToUpper(currency), "")
+
+	negative := false
+	var digits strings.Builder
+	fraction := -1
+	for _, r := range cleaned {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+			if fraction >= 0 {
+				fraction++
+			}
+		case string(r) == loc.decimal:
+			if fraction >= 0 {
+				return 0, fmt.Errorf("invalid amount %q: multiple decimal separators", s)
+			}
+			fraction = 0
+		case string(r) == loc.group, r == ' ', r == ' ', r == ' ':
+			// Grouping separators and spaces are ignored.
+		case r == '-' && digits.Len() == 0:
+			negative = true
+		default:
+			return 0, fmt.Errorf("invalid amount %q: unexpected character %q", s, r)
+		}
+	}
+
+	if digits.Len() == 0 {
+		return 0, fmt.Errorf("invalid amount %q: no digits", s)
+	}
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > cur.digits {
+		return 0, fmt.Errorf("invalid amount %q: %s allows %d decimal places", s, currency, cur.digits)
+	}
+
+	n, err := strconv.Atoi(digits.String())
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+	for i := fraction; i < cur.digits; i++ {
+		n *= 10
+	}
+	if negative {
+		n = -n
+	}
+	return n, nil
+}
+
+// formatNumber renders a non-negative minor-unit amount with separators.
+func formatNumber(amount, digits int, loc localeInfo) string {
+	s := strconv.Itoa(amount)
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+
+	intPart, fracPart := s[:len(s)-digits], s[len(s)-digits:]
+
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(loc.group)
+		}
+		b.WriteRune(r)
+	}
+	if digits > 0 {
+		b.WriteString(loc.decimal)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+func lookupCurrency(code string) currencyInfo {
+	code = strings.ToUpper(code)
+	symbol, ok := currencySymbols[code]
+	if !ok {
+		symbol = code
+	}
+	return currencyInfo{symbol: symbol, digits: models.MinorDigits(code)}
+}
+
+func lookupLocale(tag string) localeInfo {
+	tag = strings.ReplaceAll(tag, "_", "-")
+	if info, ok := locales[tag]; ok {
+		return info
+	}
+	lang, _, _ := strings.Cut(tag, "-")
+	if info, ok := locales[strings.ToLower(lang)]; ok {
+		return info
+	}
+	return locales["en"]
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/display"
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+func TestFormatAndParseAmount(t *testing.T) {
+	tests := []struct {
+		amount   int
+		currency string
+		locale   string
+		want     string
+	}{
+		{14999, "USD", "en-US", "$149.99"},
+		{123456789, "USD", "en", "$1,234,567.89"},
+		{5, "USD", "en-US", "$0.05"},
+		{-2500, "USD", "en-US", "-$25.00"},
+		{14999, "EUR", "de-DE", "149,99 €"},
+		{1500, "JPY", "ja-JP", "¥1,500"},
+		{1234, "KWD", "en", "KD1.234"},
+		{999, "XYZ", "en", "XYZ9.99"},
+	}
+
+	for _, tt := range tests {
+		got := display.FormatAmount(tt.amount, tt.currency, tt.locale)
+		if got != tt.want {
+			t.Errorf("FormatAmount(%d, %s, %s) = %q, want %q", tt.amount, tt.currency, tt.locale, got, tt.want)
+		}
+
+		parsed, err := display.ParseAmount(got, tt.currency, tt.locale)
+		if err != nil {
+			t.Errorf("ParseAmount(%q) error: %v", got, err)
+			continue
+		}
+		if parsed != tt.amount {
+			t.Errorf("ParseAmount(%q) = %d, want %d", got, parsed, tt.amount)
+		}
+	}
+}
+
+func TestParseAmountRejectsInvalidInput(t *testing.T) {
+	for _, in := range []string{"", "abc", "1.2.3", "1.999"} {
+		if _, err := display.ParseAmount(in, "USD", "en-US"); err == nil {
+			t.Errorf("ParseAmount(%q) expected error", in)
+		}
+	}
+}
+
+func TestSummarizeCheckout(t *testing.T) {
+	selected := "express"
+	checkout := &extensions.ExtendedCheckoutResponse{
+		ID:       "chk_1",
+		Status:   models.CheckoutStatusIncomplete,
+		Currency: "EUR",
+		LineItems: []models.LineItemResponse{{
+			ID:       "li_1",
+			Item:     models.ItemResponse{ID: "shoe", Title: "Running Shoe", Price: 4999},
+			Quantity: 2,
+		}},
+		Totals: []models.TotalResponse{
+			{Type: models.TotalTypeTotal, Amount: 10498},
+			{Type: models.TotalTypeSubtotal, Amount: 9998},
+			{Type: models.TotalTypeFulfillment, Amount: 500},
+		},
+		Fulfillment: &models.FulfillmentResponse{Methods: []models.FulfillmentMethodResponse{{
+			Type: models.FulfillmentMethodTypeShipping,
+			Groups: []models.FulfillmentGroupResponse{{
+				SelectedOptionID: &selected,
+				Options: []models.FulfillmentOptionResponse{{
+					ID: "express", Title: "Express", Totals: []models.TotalResponse{{Type: models.TotalTypeTotal, Amount: 500}},
+				}},
+			}},
+		}}},
+		Messages: []models.Message{
+			{Type: models.MessageTypeError, Content: "Email is required"},
+			{Type: models.MessageTypeInfo, Content: "Free returns"},
+		},
+		Context: &models.Context{Locale: "de-DE"},
+	}
+
+	got := display.SummarizeCheckout(checkout)
+	want := `Bestellvorgang: weitere Angaben nötig
+
+Artikel:
+- 2 × Running Shoe — 99,98 €
+
+Zwischensumme: 99,98 €
+Versand: 5,00 €
+Gesamt: 104,98 €
+
+Lieferung:
+- Express — 5,00 €
+
+Handlungsbedarf:
+- Email is required`
+	want = strings.ReplaceAll(want, " €", "\u00a0€")
+	if got.Content != want {
+		t.Errorf("SummarizeCheckout() =\n%s\nwant\n%s", got.Content, want)
+	}
+	if got.ContentType != models.ContentTypePlain {
+		t.Errorf("ContentType = %q, want plain", got.ContentType)
+	}
+
+	md := display.SummarizeCheckout(checkout, display.WithLocale("en-US"), display.WithMarkdown())
+	if md.ContentType != models.ContentTypeMarkdown || !strings.Contains(md.Content, "**Total: €104.98**") {
+		t.Errorf("markdown summary = %q", md.Content)
+	}
+}
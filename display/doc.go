@@ -0,0 +1,34 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package display converts UCP minor-unit amounts to and from localized
+// strings, and renders checkouts and orders as short summaries for agents
+// to read back to buyers.
+//
+// UCP amounts are integers in the currency's minor unit (14999 USD cents is
+// $149.99). This package provides small built-in tables of currency symbols,
+// minor-unit exponents, and locale separators so agent UIs can present
+// amounts naturally without pulling in a full CLDR dependency:
+//
+//	display.FormatAmount(14999, "USD", "en-US") // "$149.99"
+//	display.FormatAmount(14999, "EUR", "de-DE") // "149,99 €"
+//	display.ParseAmount("149,99 €", "EUR", "de-DE") // 14999
+//
+// Unknown currencies use their ISO code as the symbol and two minor digits;
+// unknown locales fall back to their language and then to English.
+//
+// SummarizeCheckout and SummarizeOrder return an info Message in plain
+// text or, WithMarkdown, markdown; labels are translated for English,
+// German, French, and Spanish.
+package display
@@ -0,0 +1,577 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// SummaryOption configures SummarizeCheckout and SummarizeOrder.
+type SummaryOption func(*summaryConfig)
+
+type summaryConfig struct {
+	locale      string
+	contentType models.ContentType
+	location    *time.Location
+}
+
+// WithLocale sets the BCP 47 locale for amounts and labels. Defaults to
+// the checkout's context locale, then English.
+func WithLocale(locale string) SummaryOption {
+	return func(c *summaryConfig) {
+		c.locale = locale
+	}
+}
+
+// WithMarkdown renders the summary as markdown instead of plain text.
+func WithMarkdown() SummaryOption {
+	return func(c *summaryConfig) {
+		c.contentType = models.ContentTypeMarkdown
+	}
+}
+
+// WithTimeZone sets the zone times are shown in. Defaults to the
+// checkout's context timezone, then UTC.
+func WithTimeZone(loc *time.Location) SummaryOption {
+	return func(c *summaryConfig) {
+		c.location = loc
+	}
+}
+
+// SummarizeCheckout renders a short summary of a checkout for an agent to
+// read back to the buyer: its status, items, totals, selected delivery,
+// and any error messages blocking completion. The result is an info
+// Message whose ContentType is plain unless WithMarkdown is given.
+func SummarizeCheckout(checkout *extensions.ExtendedCheckoutResponse, opts ...SummaryOption) models.Message {
+	w := newSummaryWriter(checkout.Context, opts)
+	l := w.labels
+
+	w.title(fmt.Sprintf("%s: %s", l.checkout, l.statusLabel(checkout.Status)))
+
+	w.section(l.items)
+	for _, item := range checkout.LineItems {
+		w.lineItem(item.Quantity, item.Item, item.Totals, checkout.Currency, "")
+	}
+
+	w.totals(checkout.Totals, checkout.Currency)
+
+	if checkout.Fulfillment != nil {
+		var lines []string
+		for _, method := range checkout.Fulfillment.Methods {
+			for _, group := range method.Groups {
+				lines = append(lines, w.deliveryOption(group, checkout.Currency))
+			}
+		}
+		if len(lines) > 0 {
+			w.section(l.delivery)
+			for _, line := range lines {
+				w.bullet(line)
+			}
+		}
+	}
+
+	var blocking []models.Message
+	for _, m := range checkout.Messages {
+		if m.Type == models.MessageTypeError {
+			blocking = append(blocking, m)
+		}
+	}
+	if len(blocking) > 0 {
+		w.section(l.attention)
+		for _, m := range blocking {
+			w.bullet(w.message(m))
+		}
+	}
+
+	if checkout.Order != nil {
+		w.blank()
+		w.line(fmt.Sprintf("%s: %s", l.order, w.link(checkout.Order.ID, checkout.Order.PermalinkURL)))
+	} else if checkout.ExpiresAt != nil && !isTerminal(checkout.Status) {
+		w.blank()
+		w.line(fmt.Sprintf("%s: %s", l.expires, checkout.ExpiresAt.In(w.location).Format("2006-01-02 15:04 MST")))
+	}
+	return w.result()
+}
+
+// SummarizeOrder renders a short summary of an order for an agent to read
+// back to the buyer: its items and their fulfillment progress, totals,
+// delivery expectations, the latest fulfillment event, and adjustments
+// such as refunds. The result is an info Message whose ContentType is
+// plain unless WithMarkdown is given.
+func SummarizeOrder(order *models.Order, opts ...SummaryOption) models.Message {
+	w := newSummaryWriter(nil, opts)
+	l := w.labels
+
+	w.title(fmt.Sprintf("%s %s", l.order, w.link(order.ID, order.PermalinkURL)))
+
+	w.section(l.items)
+	for _, item := range order.LineItems {
+		progress := fmt.Sprintf("%d/%d %s", item.Quantity.Fulfilled, item.Quantity.Total, l.fulfilled)
+		w.lineItem(item.Quantity.Total, item.Item, item.Totals, order.Currency, progress)
+	}
+
+	w.totals(order.Totals, order.Currency)
+
+	if len(order.Fulfillment.Expectations) > 0 {
+		w.section(l.delivery)
+		for _, e := range order.Fulfillment.Expectations {
+			w.bullet(w.expectation(e))
+		}
+	}
+
+	if events := order.Fulfillment.Events; len(events) > 0 {
+		latest := events[0]
+		for _, e := range events[1:] {
+			if e.OccurredAt.After(latest.OccurredAt) {
+				latest = e
+			}
+		}
+		w.blank()
+		w.line(fmt.Sprintf("%s: %s", l.latest, w.fulfillmentEvent(latest)))
+	}
+
+	if len(order.Adjustments) > 0 {
+		w.section(l.adjustments)
+		for _, a := range order.Adjustments {
+			w.bullet(w.adjustment(a, order.Currency))
+		}
+	}
+	return w.result()
+}
+
+// summaryWriter accumulates a summary in the configured format.
+type summaryWriter struct {
+	b        strings.Builder
+	locale   string
+	markdown bool
+	location *time.Location
+	labels   *summaryLabels
+}
+
+func newSummaryWriter(ctx *models.Context, opts []SummaryOption) *summaryWriter {
+	cfg := summaryConfig{contentType: models.ContentTypePlain}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.locale == "" && ctx != nil {
+		cfg.locale = ctx.Locale
+	}
+	if cfg.location == nil && ctx != nil && ctx.Timezone != "" {
+		cfg.location, _ = time.LoadLocation(ctx.Timezone)
+	}
+	if cfg.location == nil {
+		cfg.location = time.UTC
+	}
+	return &summaryWriter{
+		locale:   cfg.locale,
+		markdown: cfg.contentType == models.ContentTypeMarkdown,
+		location: cfg.location,
+		labels:   lookupLabels(cfg.locale),
+	}
+}
+
+// result returns the finished summary.
+func (w *summaryWriter) result() models.Message {
+	contentType := models.ContentTypePlain
+	if w.markdown {
+		contentType = models.ContentTypeMarkdown
+	}
+	return models.Message{
+		Type:        models.MessageTypeInfo,
+		Content:     strings.TrimRight(w.b.String(), "\n"),
+		ContentType: contentType,
+	}
+}
+
+func (w *summaryWriter) line(s string) {
+	w.b.WriteString(s)
+	w.b.WriteByte('\n')
+}
+
+func (w *summaryWriter) blank() {
+	w.b.WriteByte('\n')
+}
+
+func (w *summaryWriter) title(s string) {
+	if w.markdown {
+		s = "**" + s + "**"
+	}
+	w.line(s)
+}
+
+func (w *summaryWriter) section(name string) {
+	w.blank()
+	if w.markdown {
+		w.line("**" + name + "**")
+		return
+	}
+	w.line(name + ":")
+}
+
+func (w *summaryWriter) bullet(s string) {
+	w.line("- " + s)
+}
+
+// text escapes merchant-supplied text for markdown output.
+func (w *summaryWriter) text(s string) string {
+	if !w.markdown {
+		return s
+	}
+	return markdownEscaper.Replace(s)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`, "#", `\#`)
+
+// link renders label, linked to url in markdown and followed by it in
+// plain text.
+func (w *summaryWriter) link(label, url string) string {
+	switch {
+	case url == "":
+		return w.text(label)
+	case w.markdown:
+		return "[" + w.text(label) + "](" + url + ")"
+	}
+	return label + " (" + url + ")"
+}
+
+func (w *summaryWriter) amount(amount int, currency string) string {
+	return FormatAmount(amount, currency, w.locale)
+}
+
+func (w *summaryWriter) date(t time.Time) string {
+	return t.In(w.location).Format(time.DateOnly)
+}
+
+// lineItem writes "2 × Title — $19.98", with an optional note.
+func (w *summaryWriter) lineItem(quantity int, item models.ItemResponse, totals []models.TotalResponse, currency, note string) {
+	amount, ok := findTotal(totals, models.TotalTypeTotal)
+	if !ok {
+		amount = item.Price * quantity
+	}
+	s := fmt.Sprintf("%d × %s — %s", quantity, w.text(item.Title), w.amount(amount, currency))
+	if note != "" {
+		s += " (" + note + ")"
+	}
+	w.bullet(s)
+}
+
+// totals writes each total, with the grand total last and emphasized.
+func (w *summaryWriter) totals(totals []models.TotalResponse, currency string) {
+	if len(totals) == 0 {
+		return
+	}
+	w.blank()
+	var grand *models.TotalResponse
+	for i, t := range totals {
+		if t.Type == models.TotalTypeTotal {
+			grand = &totals[i]
+			continue
+		}
+		w.line(fmt.Sprintf("%s: %s", w.totalLabel(t), w.amount(t.Amount, currency)))
+	}
+	if grand != nil {
+		s := fmt.Sprintf("%s: %s", w.totalLabel(*grand), w.amount(grand.Amount, currency))
+		if w.markdown {
+			s = "**" + s + "**"
+		}
+		w.line(s)
+	}
+}
+
+func (w *summaryWriter) totalLabel(t models.TotalResponse) string {
+	if t.DisplayText != "" {
+		return w.text(t.DisplayText)
+	}
+	if label, ok := w.labels.total[t.Type]; ok {
+		return label
+	}
+	return string(t.Type)
+}
+
+// deliveryOption describes a fulfillment group's selected option.
+func (w *summaryWriter) deliveryOption(group models.FulfillmentGroupResponse, currency string) string {
+	if group.SelectedOptionID != nil {
+		for _, o := range group.Options {
+			if o.ID != *group.SelectedOptionID {
+				continue
+			}
+			s := w.text(o.Title)
+			if o.Carrier != "" {
+				s += " (" + w.text(o.Carrier) + ")"
+			}
+			if amount, ok := findTotal(o.Totals, models.TotalTypeTotal); ok {
+				s += " — " + w.amount(amount, currency)
+			}
+			if window := w.window(o.EarliestFulfillmentTime, o.LatestFulfillmentTime); window != "" {
+				s += ", " + w.labels.arrives + " " + window
+			}
+			return s
+		}
+	}
+	return fmt.Sprintf(w.labels.chooseDelivery, len(group.Options))
+}
+
+// window renders a delivery date range.
+func (w *summaryWriter) window(earliest, latest *time.Time) string {
+	switch {
+	case earliest != nil && latest != nil && w.date(*earliest) != w.date(*latest):
+		return w.date(*earliest) + " – " + w.date(*latest)
+	case latest != nil:
+		return w.date(*latest)
+	case earliest != nil:
+		return w.date(*earliest)
+	}
+	return ""
+}
+
+// expectation describes an order's delivery expectation.
+func (w *summaryWriter) expectation(e models.Expectation) string {
+	s := w.labels.methodLabel(string(e.MethodType))
+	if place := placeName(e.Destination); place != "" {
+		s += " → " + w.text(place)
+	}
+	switch {
+	case e.Description != "":
+		s += ": " + w.text(e.Description)
+	case e.FulfillableOn != "":
+		s += ": " + w.text(e.FulfillableOn)
+	}
+	return s
+}
+
+// fulfillmentEvent describes a fulfillment event.
+func (w *summaryWriter) fulfillmentEvent(e models.FulfillmentEvent) string {
+	s := w.text(strings.ReplaceAll(e.Type, "_", " "))
+	if e.Carrier != "" {
+		s += " (" + w.text(e.Carrier) + ")"
+	}
+	if !e.OccurredAt.IsZero() {
+		s += ", " + w.date(e.OccurredAt)
+	}
+	if e.TrackingNumber != "" || e.TrackingURL != "" {
+		label := e.TrackingNumber
+		if label == "" {
+			label = e.TrackingURL
+		}
+		s += ", " + w.labels.tracking + " " + w.link(label, e.TrackingURL)
+	}
+	if e.Description != "" {
+		s += " — " + w.text(e.Description)
+	}
+	return s
+}
+
+// adjustment describes an order adjustment.
+func (w *summaryWriter) adjustment(a models.Adjustment, currency string) string {
+	s := w.text(strings.ReplaceAll(a.Type, "_", " "))
+	if a.Amount != 0 {
+		s += " " + w.amount(a.Amount, currency)
+	}
+	if a.Status != "" {
+		s += " (" + w.labels.adjustmentLabel(a.Status) + ")"
+	}
+	if a.Description != "" {
+		s += " — " + w.text(a.Description)
+	}
+	return s
+}
+
+// message renders a checkout message, keeping markdown content when the
+// summary is markdown.
+func (w *summaryWriter) message(m models.Message) string {
+	if m.ContentType == models.ContentTypeMarkdown && w.markdown {
+		return m.Content
+	}
+	return w.text(m.Content)
+}
+
+func findTotal(totals []models.TotalResponse, typ models.TotalType) (int, bool) {
+	for _, t := range totals {
+		if t.Type == typ {
+			return t.Amount, true
+		}
+	}
+	return 0, false
+}
+
+// placeName returns "Locality, Country" for an address.
+func placeName(a models.PostalAddress) string {
+	var parts []string
+	for _, p := range []string{a.AddressLocality, a.AddressCountry} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func isTerminal(status models.CheckoutStatus) bool {
+	return status == models.CheckoutStatusCompleted || status == models.CheckoutStatusCanceled
+}
+
+// summaryLabels are the fixed words of a summary in one language.
+type summaryLabels struct {
+	checkout, order, items, delivery, attention, expires string
+	fulfilled, arrives, latest, tracking, adjustments    string
+
+	// chooseDelivery is a format taking the number of options.
+	chooseDelivery string
+
+	status     map[models.CheckoutStatus]string
+	total      map[models.TotalType]string
+	method     map[string]string
+	adjustment map[models.AdjustmentStatus]string
+}
+
+func (l *summaryLabels) statusLabel(s models.CheckoutStatus) string {
+	if label, ok := l.status[s]; ok {
+		return label
+	}
+	return strings.ReplaceAll(string(s), "_", " ")
+}
+
+func (l *summaryLabels) methodLabel(m string) string {
+	if label, ok := l.method[m]; ok {
+		return label
+	}
+	return m
+}
+
+func (l *summaryLabels) adjustmentLabel(s models.AdjustmentStatus) string {
+	if label, ok := l.adjustment[s]; ok {
+		return label
+	}
+	return string(s)
+}
+
+// summaryLanguages holds labels by language subtag.
+var summaryLanguages = map[string]*summaryLabels{
+	"en": {
+		checkout: "Checkout", order: "Order", items: "Items", delivery: "Delivery",
+		attention: "Needs attention", expires: "Expires", fulfilled: "fulfilled",
+		arrives: "arrives", latest: "Latest update", tracking: "tracking",
+		adjustments: "Adjustments", chooseDelivery: "%d options, none selected yet",
+		status: map[models.CheckoutStatus]string{
+			models.CheckoutStatusIncomplete:         "needs more information",
+			models.CheckoutStatusRequiresEscalation: "needs buyer action",
+			models.CheckoutStatusReadyForComplete:   "ready to complete",
+			models.CheckoutStatusCompleteInProgress: "completing",
+			models.CheckoutStatusCompleted:          "completed",
+			models.CheckoutStatusCanceled:           "canceled",
+		},
+		total: map[models.TotalType]string{
+			models.TotalTypeSubtotal: "Subtotal", models.TotalTypeTax: "Tax",
+			models.TotalTypeFee: "Fees", models.TotalTypeDiscount: "Discount",
+			models.TotalTypeItemsDiscount: "Item discounts", models.TotalTypeFulfillment: "Shipping",
+			models.TotalTypeTotal: "Total",
+		},
+		method: map[string]string{"shipping": "Shipping", "pickup": "Pickup", "digital": "Digital delivery"},
+		adjustment: map[models.AdjustmentStatus]string{
+			models.AdjustmentStatusPending: "pending", models.AdjustmentStatusCompleted: "completed",
+			models.AdjustmentStatusFailed: "failed",
+		},
+	},
+	"de": {
+		checkout: "Bestellvorgang", order: "Bestellung", items: "Artikel", delivery: "Lieferung",
+		attention: "Handlungsbedarf", expires: "Läuft ab", fulfilled: "geliefert",
+		arrives: "Ankunft", latest: "Letzte Aktualisierung", tracking: "Sendungsnummer",
+		adjustments: "Anpassungen", chooseDelivery: "%d Optionen, noch keine ausgewählt",
+		status: map[models.CheckoutStatus]string{
+			models.CheckoutStatusIncomplete:         "weitere Angaben nötig",
+			models.CheckoutStatusRequiresEscalation: "Aktion des Käufers nötig",
+			models.CheckoutStatusReadyForComplete:   "bereit zum Abschluss",
+			models.CheckoutStatusCompleteInProgress: "wird abgeschlossen",
+			models.CheckoutStatusCompleted:          "abgeschlossen",
+			models.CheckoutStatusCanceled:           "storniert",
+		},
+		total: map[models.TotalType]string{
+			models.TotalTypeSubtotal: "Zwischensumme", models.TotalTypeTax: "Steuer",
+			models.TotalTypeFee: "Gebühren", models.TotalTypeDiscount: "Rabatt",
+			models.TotalTypeItemsDiscount: "Artikelrabatte", models.TotalTypeFulfillment: "Versand",
+			models.TotalTypeTotal: "Gesamt",
+		},
+		method: map[string]string{"shipping": "Versand", "pickup": "Abholung", "digital": "Digitale Lieferung"},
+		adjustment: map[models.AdjustmentStatus]string{
+			models.AdjustmentStatusPending: "ausstehend", models.AdjustmentStatusCompleted: "abgeschlossen",
+			models.AdjustmentStatusFailed: "fehlgeschlagen",
+		},
+	},
+	"fr": {
+		checkout: "Paiement", order: "Commande", items: "Articles", delivery: "Livraison",
+		attention: "Action requise", expires: "Expire le", fulfilled: "livrés",
+		arrives: "arrivée", latest: "Dernière mise à jour", tracking: "suivi",
+		adjustments: "Ajustements", chooseDelivery: "%d options, aucune sélectionnée",
+		status: map[models.CheckoutStatus]string{
+			models.CheckoutStatusIncomplete:         "informations manquantes",
+			models.CheckoutStatusRequiresEscalation: "action de l'acheteur requise",
+			models.CheckoutStatusReadyForComplete:   "prêt à finaliser",
+			models.CheckoutStatusCompleteInProgress: "en cours de finalisation",
+			models.CheckoutStatusCompleted:          "finalisé",
+			models.CheckoutStatusCanceled:           "annulé",
+		},
+		total: map[models.TotalType]string{
+			models.TotalTypeSubtotal: "Sous-total", models.TotalTypeTax: "Taxes",
+			models.TotalTypeFee: "Frais", models.TotalTypeDiscount: "Remise",
+			models.TotalTypeItemsDiscount: "Remises sur articles", models.TotalTypeFulfillment: "Livraison",
+			models.TotalTypeTotal: "Total",
+		},
+		method: map[string]string{"shipping": "Livraison", "pickup": "Retrait", "digital": "Livraison numérique"},
+		adjustment: map[models.AdjustmentStatus]string{
+			models.AdjustmentStatusPending: "en attente", models.AdjustmentStatusCompleted: "effectué",
+			models.AdjustmentStatusFailed: "échoué",
+		},
+	},
+	"es": {
+		checkout: "Compra", order: "Pedido", items: "Artículos", delivery: "Entrega",
+		attention: "Requiere atención", expires: "Vence", fulfilled: "entregados",
+		arrives: "llega", latest: "Última actualización", tracking: "seguimiento",
+		adjustments: "Ajustes", chooseDelivery: "%d opciones, ninguna seleccionada",
+		status: map[models.CheckoutStatus]string{
+			models.CheckoutStatusIncomplete:         "falta información",
+			models.CheckoutStatusRequiresEscalation: "requiere acción del comprador",
+			models.CheckoutStatusReadyForComplete:   "lista para completar",
+			models.CheckoutStatusCompleteInProgress: "completándose",
+			models.CheckoutStatusCompleted:          "completada",
+			models.CheckoutStatusCanceled:           "cancelada",
+		},
+		total: map[models.TotalType]string{
+			models.TotalTypeSubtotal: "Subtotal", models.TotalTypeTax: "Impuestos",
+			models.TotalTypeFee: "Cargos", models.TotalTypeDiscount: "Descuento",
+			models.TotalTypeItemsDiscount: "Descuentos en artículos", models.TotalTypeFulfillment: "Envío",
+			models.TotalTypeTotal: "Total",
+		},
+		method: map[string]string{"shipping": "Envío", "pickup": "Recogida", "digital": "Entrega digital"},
+		adjustment: map[models.AdjustmentStatus]string{
+			models.AdjustmentStatusPending: "pendiente", models.AdjustmentStatusCompleted: "completado",
+			models.AdjustmentStatusFailed: "fallido",
+		},
+	},
+}
+
+// lookupLabels returns the labels for a locale's language, falling back
+// to English.
+func lookupLabels(tag string) *summaryLabels {
+	lang, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	if labels, ok := summaryLanguages[strings.ToLower(lang)]; ok {
+		return labels
+	}
+	return summaryLanguages["en"]
+}
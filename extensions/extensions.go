@@ -67,6 +67,12 @@ type ExtendedCheckoutResponse struct {
 	// ExpiresAt is the RFC 3339 expiry timestamp.
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 
+	// CreatedAt is when the checkout session was created (RFC 3339).
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// UpdatedAt is when the checkout session was last modified (RFC 3339).
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+
 	// ContinueURL is for checkout handoff and session recovery.
 	ContinueURL string `json:"continue_url,omitempty"`
 
@@ -82,6 +88,9 @@ type ExtendedCheckoutResponse struct {
 	// Discounts contains applied discounts (extension).
 	Discounts *models.DiscountsResponse `json:"discounts,omitempty"`
 
+	// Protection contains protection plan offers and selections (extension).
+	Protection *models.ProtectionResponse `json:"protection,omitempty"`
+
 	// Platform contains platform configuration.
 	Platform *PlatformConfig `json:"platform,omitempty"`
 
@@ -112,8 +121,16 @@ type ExtendedCheckoutCreateRequest struct {
 	// Discounts contains discount codes to apply (extension).
 	Discounts *models.DiscountsCreateRequest `json:"discounts,omitempty"`
 
+	// Protection contains protection plans to add (extension).
+	Protection *models.ProtectionCreateRequest `json:"protection,omitempty"`
+
 	// Context provides buyer signals for localization (country, region, postal_code, intent).
 	Context *models.Context `json:"context,omitempty"`
+
+	// CartID converts an existing cart to a checkout. When set, the business
+	// uses the cart's line_items, context, and buyer and ignores overlapping
+	// fields in this request.
+	CartID string `json:"cart_id,omitempty"`
 }
 
 // ExtendedCheckoutUpdateRequest combines base checkout update with extensions.
@@ -139,6 +156,9 @@ type ExtendedCheckoutUpdateRequest struct {
 	// Discounts contains discount updates (extension).
 	Discounts *models.DiscountsUpdateRequest `json:"discounts,omitempty"`
 
+	// Protection contains protection plan selections (extension).
+	Protection *models.ProtectionUpdateRequest `json:"protection,omitempty"`
+
 	// Context provides buyer signals for localization.
 	Context *models.Context `json:"context,omitempty"`
 }
@@ -149,6 +169,10 @@ type ExtendedOrder struct {
 
 	// Discounts contains applied discounts.
 	Discounts *models.DiscountsResponse `json:"discounts,omitempty"`
+
+	// Settlement describes how a platform-captured payment is split
+	// (extension).
+	Settlement *models.Settlement `json:"settlement,omitempty"`
 }
 
 // CheckoutWithFulfillmentCreateRequest is a checkout create request with fulfillment.
@@ -199,6 +223,30 @@ type CheckoutWithDiscountResponse struct {
 	Discounts *models.DiscountsResponse `json:"discounts,omitempty"`
 }
 
+// CheckoutWithProtectionCreateRequest is a checkout create request with protection plans.
+type CheckoutWithProtectionCreateRequest struct {
+	models.CheckoutCreateRequest
+
+	// Protection contains protection plans to add.
+	Protection *models.ProtectionCreateRequest `json:"protection,omitempty"`
+}
+
+// CheckoutWithProtectionUpdateRequest is a checkout update request with protection plans.
+type CheckoutWithProtectionUpdateRequest struct {
+	models.CheckoutUpdateRequest
+
+	// Protection contains protection plan selections.
+	Protection *models.ProtectionUpdateRequest `json:"protection,omitempty"`
+}
+
+// CheckoutWithProtectionResponse is a checkout response with protection plans.
+type CheckoutWithProtectionResponse struct {
+	models.CheckoutResponse
+
+	// Protection contains protection plan offers and selections.
+	Protection *models.ProtectionResponse `json:"protection,omitempty"`
+}
+
 // CheckoutWithBuyerConsentCreateRequest is a checkout create request with buyer consent.
 type CheckoutWithBuyerConsentCreateRequest struct {
 	models.CheckoutCreateRequest
@@ -0,0 +1,179 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// SnapshotFormat identifies checkout snapshots.
+const SnapshotFormat = "ucp.checkout_snapshot"
+
+// SnapshotVersion is the snapshot format version written by
+// EncodeSnapshot. DecodeSnapshot reads this and all earlier versions.
+const SnapshotVersion = 1
+
+var (
+	// ErrInvalidSnapshot is returned for data that is not a checkout
+	// snapshot or fails its checksum.
+	ErrInvalidSnapshot = errors.New("invalid checkout snapshot")
+
+	// ErrSnapshotVersion is returned for snapshots written by a newer
+	// format version than this package reads.
+	ErrSnapshotVersion = errors.New("unsupported checkout snapshot version")
+)
+
+// snapshot is the persisted envelope. Field names are part of the format
+// and must not change within a version.
+type snapshot struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+
+	// ProtocolVersion is the UCP version of the checkout, so a restored
+	// session can be migrated if the server has moved on since.
+	ProtocolVersion models.Version `json:"protocol_version,omitempty"`
+
+	Checkout json.RawMessage `json:"checkout"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+
+	// Checksum is the hex SHA-256 of Checkout, a newline, and Metadata.
+	Checksum string `json:"checksum"`
+}
+
+// SnapshotOption configures EncodeSnapshot.
+type SnapshotOption func(*snapshotConfig)
+
+type snapshotConfig struct {
+	compress bool
+}
+
+// WithSnapshotCompression gzips the snapshot, for stores where size
+// matters more than readability. DecodeSnapshot detects compression
+// itself.
+func WithSnapshotCompression() SnapshotOption {
+	return func(c *snapshotConfig) {
+		c.compress = true
+	}
+}
+
+// EncodeSnapshot serializes a checkout session, together with any internal
+// metadata the merchant keeps alongside it (inventory holds, payment
+// references, and the like), into a stable, versioned form suitable for
+// Redis or SQL. metadata must be JSON-encodable and may be nil.
+//
+// The output is deterministic for equal inputs and carries a checksum, so
+// it can be compared and verified without decoding the checkout. Store it
+// as opaque bytes: stores that normalize JSON, such as PostgreSQL jsonb,
+// invalidate the checksum.
+func EncodeSnapshot(checkout *ExtendedCheckoutResponse, metadata any, opts ...SnapshotOption) ([]byte, error) {
+	if checkout == nil {
+		return nil, fmt.Errorf("%w: nil checkout", ErrInvalidSnapshot)
+	}
+	var cfg snapshotConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	body, err := json.Marshal(checkout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode checkout: %w", err)
+	}
+	snap := snapshot{
+		Format:          SnapshotFormat,
+		Version:         SnapshotVersion,
+		ProtocolVersion: checkout.UCP.Version,
+		Checkout:        body,
+	}
+	if metadata != nil {
+		if snap.Metadata, err = json.Marshal(metadata); err != nil {
+			return nil, fmt.Errorf("failed to encode snapshot metadata: %w", err)
+		}
+	}
+	snap.Checksum = snap.checksum()
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if !cfg.compress {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(data)
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress snapshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeSnapshot restores a checkout written by EncodeSnapshot, decoding
+// its metadata into metadata if that is non-nil. Compressed and plain
+// snapshots are both accepted.
+func DecodeSnapshot(data []byte, metadata any) (*ExtendedCheckoutResponse, error) {
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+		}
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+		}
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if snap.Format != SnapshotFormat {
+		return nil, fmt.Errorf("%w: format %q", ErrInvalidSnapshot, snap.Format)
+	}
+	if snap.Version < 1 || snap.Version > SnapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrSnapshotVersion, snap.Version)
+	}
+	if snap.checksum() != snap.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidSnapshot)
+	}
+
+	var checkout ExtendedCheckoutResponse
+	if err := json.Unmarshal(snap.Checkout, &checkout); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if metadata != nil && len(snap.Metadata) > 0 {
+		if err := json.Unmarshal(snap.Metadata, metadata); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot metadata: %w", err)
+		}
+	}
+	return &checkout, nil
+}
+
+// checksum computes the snapshot's checksum over its checkout and
+// metadata.
+func (s *snapshot) checksum() string {
+	h := sha256.New()
+	h.Write(s.Checkout)
+	h.Write([]byte{'\n'})
+	h.Write(s.Metadata)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,425 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpcache provides an HTTP cache for static resources such as
+// JSON schemas, OpenAPI documents, and card art.
+//
+// A Cache keeps successful GET responses in memory, and optionally on
+// disk, and honors Cache-Control and Expires for freshness. Stale entries
+// with an ETag or Last-Modified validator are revalidated with a
+// conditional request, so an unchanged resource costs a 304 rather than a
+// full download.
+package httpcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTTL is the freshness lifetime of a response that carries no
+	// Cache-Control max-age or Expires header.
+	DefaultTTL = 5 * time.Minute
+
+	// DefaultMaxEntries bounds the number of responses held in memory.
+	DefaultMaxEntries = 1024
+
+	// DefaultMaxBodyBytes bounds the size of a response body that is
+	// cached or returned by Get.
+	DefaultMaxBodyBytes = 8 << 20
+
+	// FromCacheHeader is set to "1" on responses served from the cache,
+	// including those revalidated with a 304.
+	FromCacheHeader = "X-From-Cache"
+)
+
+// Default is the cache shared by the SDK's schema and discovery fetches.
+var Default = New()
+
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithDir also persists cached responses as files in dir, so they survive
+// process restarts. The directory is created on first write.
+func WithDir(dir string) Option {
+	return func(c *Cache) {
+		c.dir = dir
+	}
+}
+
+// WithDefaultTTL sets the freshness lifetime of responses without explicit
+// freshness information. Zero makes such responses revalidate every time.
+func WithDefaultTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.defaultTTL = ttl
+	}
+}
+
+// WithMaxEntries bounds the number of responses held in memory.
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+// WithMaxBodyBytes bounds the size of a response body that is cached or
+// returned by Get. Larger responses pass through Transport uncached.
+func WithMaxBodyBytes(n int64) Option {
+	return func(c *Cache) {
+		c.maxBody = n
+	}
+}
+
+// WithClock sets the clock used for freshness. Defaults to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(c *Cache) {
+		c.now = now
+	}
+}
+
+// Cache is an HTTP response cache. It is safe for concurrent use.
+type Cache struct {
+	mu         sync.RWMutex
+	entries    map[string]*entry
+	dir        string
+	defaultTTL time.Duration
+	maxEntries int
+	maxBody    int64
+	now        func() time.Time
+}
+
+// New creates an in-memory cache.
+func New(opts ...Option) *Cache {
+	c := &Cache{
+		entries:    make(map[string]*entry),
+		defaultTTL: DefaultTTL,
+		maxEntries: DefaultMaxEntries,
+		maxBody:    DefaultMaxBodyBytes,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// entry is a stored response. Entries are immutable once stored.
+type entry struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	Expires    time.Time   `json:"expires"`
+}
+
+// Transport returns an http.RoundTripper that serves GET requests from the
+// cache and sends everything else to base. A nil base uses
+// http.DefaultTransport.
+func (c *Cache) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{cache: c, base: base}
+}
+
+// Client returns a copy of base whose transport goes through the cache. A
+// nil base is treated as an empty http.Client.
+func (c *Cache) Client(base *http.Client) *http.Client {
+	client := &http.Client{}
+	if base != nil {
+		*client = *base
+	}
+	client.Transport = c.Transport(client.Transport)
+	return client
+}
+
+// Get fetches url through the cache using client (nil for a default
+// client) and returns the body of a 200 response. Bodies larger than the
+// cache's maximum body size are an error.
+func (c *Cache) Get(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+	resp, err := c.Client(client).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: status %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBody+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	if int64(len(body)) > c.maxBody {
+		return nil, fmt.Errorf("failed to read %s: body exceeds %d bytes", url, c.maxBody)
+	}
+	return body, nil
+}
+
+// Purge removes all cached responses, in memory and on disk.
+func (c *Cache) Purge() error {
+	c.mu.Lock()
+	c.entries = make(map[string]*entry)
+	c.mu.Unlock()
+	if c.dir == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+type transport struct {
+	cache *Cache
+	base  http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" || req.Header.Get("Authorization") != "" {
+		return t.base.RoundTrip(req)
+	}
+	c := t.cache
+	key := req.URL.String()
+	reqCC := parseCacheControl(req.Header)
+	if reqCC.has("no-store") {
+		return t.base.RoundTrip(req)
+	}
+
+	cached := c.load(key)
+	if cached != nil && !reqCC.has("no-cache") && c.now().Before(cached.Expires) {
+		return cached.response(req), nil
+	}
+
+	out := req
+	if cached != nil {
+		etag, modified := cached.Header.Get("ETag"), cached.Header.Get("Last-Modified")
+		if etag != "" || modified != "" {
+			out = req.Clone(req.Context())
+			if etag != "" {
+				out.Header.Set("If-None-Match", etag)
+			}
+			if modified != "" {
+				out.Header.Set("If-Modified-Since", modified)
+			}
+		}
+	}
+
+	resp, err := t.base.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		header := cached.Header.Clone()
+		for k, v := range resp.Header {
+			if k != "Content-Length" {
+				header[k] = v
+			}
+		}
+		refreshed := &entry{StatusCode: cached.StatusCode, Header: header, Body: cached.Body}
+		refreshed.Expires = c.expires(parseCacheControl(header), header)
+		c.store(key, refreshed)
+		return refreshed.response(req), nil
+	}
+
+	respCC := parseCacheControl(resp.Header)
+	if resp.StatusCode != http.StatusOK || respCC.has("no-store") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBody+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(body)) > c.maxBody {
+		// Too large to cache; hand back what was read and the rest unread.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	c.store(key, &entry{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		Expires:    c.expires(respCC, resp.Header),
+	})
+	return resp, nil
+}
+
+// expires computes when a response stored now goes stale.
+func (c *Cache) expires(cc cacheControl, header http.Header) time.Time {
+	now := c.now()
+	if cc.has("no-cache") {
+		return now
+	}
+	if v, ok := cc["max-age"]; ok {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return now.Add(time.Duration(secs) * time.Second)
+		}
+		return now
+	}
+	if v := header.Get("Expires"); v != "" {
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			return now
+		}
+		if date, err := http.ParseTime(header.Get("Date")); err == nil {
+			// Measure the lifetime against the server's clock.
+			return now.Add(expires.Sub(date))
+		}
+		return expires
+	}
+	return now.Add(c.defaultTTL)
+}
+
+// load returns the entry for key from memory or disk, or nil.
+func (c *Cache) load(key string) *entry {
+	c.mu.RLock()
+	e := c.entries[key]
+	c.mu.RUnlock()
+	if e != nil || c.dir == "" {
+		return e
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil
+	}
+	e = &entry{}
+	if json.Unmarshal(data, e) != nil {
+		return nil
+	}
+	c.remember(key, e)
+	return e
+}
+
+// store saves an entry in memory and, with a directory, on disk. Disk
+// errors are ignored; the entry is still cached in memory.
+func (c *Cache) store(key string, e *entry) {
+	c.remember(key, e)
+	if c.dir == "" {
+		return
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return
+	}
+	// Write to a temporary file and rename, so concurrent readers never
+	// see a partial entry.
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// remember saves an entry in memory.
+func (c *Cache) remember(key string, e *entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		// Evict an arbitrary entry; it is refetched (or read from disk)
+		// on next use.
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = e
+}
+
+// path returns the disk file for key.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// response builds an http.Response from a stored entry.
+func (e *entry) response(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	header.Set(FromCacheHeader, "1")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheControl holds parsed Cache-Control directives.
+type cacheControl map[string]string
+
+func parseCacheControl(header http.Header) cacheControl {
+	cc := cacheControl{}
+	for _, line := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(line, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name == "" {
+				continue
+			}
+			cc[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return cc
+}
+
+func (cc cacheControl) has(directive string) bool {
+	_, ok := cc[directive]
+	return ok
+}
@@ -31,55 +31,3 @@ func DefaultHTTPClient() *http.Client {
 		},
 	}
 }
-
-// RetryableClient wraps an HTTP client with retry logic.
-type RetryableClient struct {
-	client     *http.Client
-	maxRetries int
-	backoff    time.Duration
-}
-
-// NewRetryableClient creates a new retryable HTTP client.
-func NewRetryableClient(client *http.Client, maxRetries int, backoff time.Duration) *RetryableClient {
-	if client == nil {
-		client = DefaultHTTPClient()
-	}
-	return &RetryableClient{
-		client:     client,
-		maxRetries: maxRetries,
-		backoff:    backoff,
-	}
-}
-
-// Do executes an HTTP request with retry logic.
-func (c *RetryableClient) Do(req *http.Request) (*http.Response, error) {
-	var lastErr error
-	for i := 0; i <= c.maxRetries; i++ {
-		resp, err := c.client.Do(req)
-		if err != nil {
-			lastErr = err
-			time.Sleep(c.backoff * time.Duration(i+1))
-			continue
-		}
-
-		// Retry on server errors
-		if resp.StatusCode >= 500 {
-			resp.Body.Close()
-			lastErr = &ServerError{StatusCode: resp.StatusCode}
-			time.Sleep(c.backoff * time.Duration(i+1))
-			continue
-		}
-
-		return resp, nil
-	}
-	return nil, lastErr
-}
-
-// ServerError represents a server-side error.
-type ServerError struct {
-	StatusCode int
-}
-
-func (e *ServerError) Error() string {
-	return http.StatusText(e.StatusCode)
-}
@@ -0,0 +1,167 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// SignDetached returns a detached JWS (header..signature) over payload,
+// signed with an ECDSA P-256 key (ES256) or an RSA key (RS256) and
+// identified by kid. It is the form checked by the webhook verifier.
+func SignDetached(key crypto.Signer, kid string, payload []byte) (string, error) {
+	return signDetached(key, map[string]any{"kid": kid}, payload)
+}
+
+// RequestSigningInput is the payload of a request signature: the method,
+// request URI, signing time, nonce, and body digest, one per line, so the
+// signature verifies only for the request it was made for.
+func RequestSigningInput(method, requestURI string, iat int64, nonce string, body []byte) []byte {
+	digest := sha256.Sum256(body)
+	return []byte(strings.Join([]string{
+		"ucp-request-v1",
+		method,
+		requestURI,
+		strconv.FormatInt(iat, 10),
+		nonce,
+		base64.RawURLEncoding.EncodeToString(digest[:]),
+	}, "\n"))
+}
+
+// SignRequest returns a detached JWS over the RequestSigningInput of a
+// request, with iat and nonce in its protected header for the verifier.
+func SignRequest(key crypto.Signer, kid, method, requestURI string, body []byte, now time.Time) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	nonce := hex.EncodeToString(b)
+	iat := now.Unix()
+	header := map[string]any{"kid": kid, "iat": iat, "nonce": nonce}
+	return signDetached(key, header, RequestSigningInput(method, requestURI, iat, nonce, body))
+}
+
+// RequestClaims returns the iat and nonce in the protected header of a
+// request signature made by SignRequest.
+func RequestClaims(sig string) (iat int64, nonce string, err error) {
+	protected, _, ok := strings.Cut(sig, ".")
+	if !ok {
+		return 0, "", errors.New("invalid JWS format")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(protected)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to decode JWS header: %w", err)
+	}
+	var header struct {
+		Iat   *int64 `json:"iat"`
+		Nonce string `json:"nonce"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return 0, "", fmt.Errorf("failed to parse JWS header: %w", err)
+	}
+	if header.Iat == nil || header.Nonce == "" {
+		return 0, "", errors.New("JWS header lacks iat and nonce")
+	}
+	return *header.Iat, header.Nonce, nil
+}
+
+// signDetached signs payload under a protected header, to which it adds
+// alg.
+func signDetached(key crypto.Signer, header map[string]any, payload []byte) (string, error) {
+	var alg string
+	switch pub := key.Public().(type) {
+	case *ecdsa.PublicKey:
+		if pub.Curve.Params().BitSize != 256 {
+			return "", errors.New("ES256 requires a P-256 key")
+		}
+		alg = "ES256"
+	case *rsa.PublicKey:
+		alg = "RS256"
+	default:
+		return "", fmt.Errorf("unsupported key type %T", pub)
+	}
+
+	header["alg"] = alg
+	encoded, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(encoded)
+	signingInput := protected + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(signingInput))
+
+	sig, err := key.Sign(rand.Reader, hash[:], crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign: %w", err)
+	}
+	if alg == "ES256" {
+		// crypto.Signer returns ASN.1; JWS wants R || S, 32 bytes each.
+		var rs struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(sig, &rs); err != nil {
+			return "", fmt.Errorf("failed to decode ECDSA signature: %w", err)
+		}
+		raw := make([]byte, 64)
+		rs.R.FillBytes(raw[:32])
+		rs.S.FillBytes(raw[32:])
+		sig = raw
+	}
+	return protected + ".." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// PublicJWK returns the JWK for an ECDSA P-256 or RSA public key, marked
+// for signature use with the matching algorithm.
+func PublicJWK(kid string, key crypto.PublicKey) (models.JWK, error) {
+	switch pub := key.(type) {
+	case *ecdsa.PublicKey:
+		if pub.Curve.Params().BitSize != 256 {
+			return models.JWK{}, errors.New("ES256 requires a P-256 key")
+		}
+		x := make([]byte, 32)
+		y := make([]byte, 32)
+		pub.X.FillBytes(x)
+		pub.Y.FillBytes(y)
+		return models.JWK{
+			Kid: kid, Kty: "EC", Crv: "P-256",
+			X:   base64.RawURLEncoding.EncodeToString(x),
+			Y:   base64.RawURLEncoding.EncodeToString(y),
+			Use: "sig", Alg: "ES256",
+		}, nil
+	case *rsa.PublicKey:
+		return models.JWK{
+			Kid: kid, Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			Use: "sig", Alg: "RS256",
+		}, nil
+	default:
+		return models.JWK{}, fmt.Errorf("unsupported key type %T", key)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+)
+
+// CanonicalJSON decodes a JSON document into its generic form with null
+// object members removed, and returns that form with its canonical
+// encoding (sorted keys, no insignificant whitespace). Dropping nulls keeps
+// documents comparable after a merge patch, which cannot express them.
+func CanonicalJSON(data []byte) (interface{}, []byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	doc = dropNulls(doc)
+	canonical, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return doc, canonical, nil
+}
+
+// VersionToken returns the version token of a canonical JSON encoding.
+func VersionToken(canonical []byte) string {
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:16])
+}
+
+// MergePatch returns the RFC 7396 merge patch that turns from into to.
+// Both documents must be in the generic form returned by CanonicalJSON.
+func MergePatch(from, to interface{}) interface{} {
+	fromObj, ok1 := from.(map[string]interface{})
+	toObj, ok2 := to.(map[string]interface{})
+	if !ok1 || !ok2 {
+		return to
+	}
+	patch := make(map[string]interface{})
+	for k := range fromObj {
+		if _, ok := toObj[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, v := range toObj {
+		old, ok := fromObj[k]
+		if ok && reflect.DeepEqual(old, v) {
+			continue
+		}
+		if ok {
+			if _, isObj := v.(map[string]interface{}); isObj {
+				patch[k] = MergePatch(old, v)
+				continue
+			}
+		}
+		patch[k] = v
+	}
+	return patch
+}
+
+// ApplyMergePatch applies an RFC 7396 merge patch to a document in generic
+// form and returns the result. doc is not modified.
+func ApplyMergePatch(doc, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	docObj, _ := doc.(map[string]interface{})
+	out := make(map[string]interface{}, len(docObj)+len(patchObj))
+	for k, v := range docObj {
+		out[k] = v
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		out[k] = ApplyMergePatch(out[k], v)
+	}
+	return out
+}
+
+// dropNulls removes null object members, recursively.
+func dropNulls(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if e == nil {
+				delete(v, k)
+				continue
+			}
+			v[k] = dropNulls(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = dropNulls(e)
+		}
+	}
+	return v
+}
@@ -35,6 +35,9 @@ type BuyerWithConsent struct {
 
 	// Consent contains consent tracking fields.
 	Consent *Consent `json:"consent,omitempty"`
+
+	// Notifications is how the buyer wants order updates delivered.
+	Notifications *NotificationPreferences `json:"notifications,omitempty"`
 }
 
 // BuyerWithConsentCreateRequest represents buyer with consent in a create request.
@@ -56,6 +59,9 @@ type BuyerWithConsentCreateRequest struct {
 
 	// Consent contains consent tracking fields.
 	Consent *Consent `json:"consent,omitempty"`
+
+	// Notifications is how the buyer wants order updates delivered.
+	Notifications *NotificationPreferences `json:"notifications,omitempty"`
 }
 
 // BuyerWithConsentUpdateRequest represents buyer with consent in an update request.
@@ -77,6 +83,9 @@ type BuyerWithConsentUpdateRequest struct {
 
 	// Consent contains consent tracking fields.
 	Consent *Consent `json:"consent,omitempty"`
+
+	// Notifications is how the buyer wants order updates delivered.
+	Notifications *NotificationPreferences `json:"notifications,omitempty"`
 }
 
 // BuyerWithConsentResponse represents buyer with consent in a response.
@@ -98,4 +107,7 @@ type BuyerWithConsentResponse struct {
 
 	// Consent contains consent tracking fields.
 	Consent *Consent `json:"consent,omitempty"`
+
+	// Notifications is how the buyer wants order updates delivered.
+	Notifications *NotificationPreferences `json:"notifications,omitempty"`
 }
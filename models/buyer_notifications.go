@@ -0,0 +1,90 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "time"
+
+// NotificationChannel is a way order updates reach the buyer.
+type NotificationChannel string
+
+const (
+	// NotificationChannelEmail sends updates to the buyer's email address.
+	NotificationChannelEmail NotificationChannel = "email"
+
+	// NotificationChannelSMS sends updates to the buyer's phone number.
+	NotificationChannelSMS NotificationChannel = "sms"
+
+	// NotificationChannelPlatform leaves delivery to the platform, which
+	// relays the order webhooks it receives.
+	NotificationChannelPlatform NotificationChannel = "platform"
+
+	// NotificationChannelNone opts out of merchant-sent updates.
+	NotificationChannelNone NotificationChannel = "none"
+)
+
+// IsValid reports whether c is a known channel.
+func (c NotificationChannel) IsValid() bool {
+	switch c {
+	case NotificationChannelEmail, NotificationChannelSMS, NotificationChannelPlatform, NotificationChannelNone:
+		return true
+	}
+	return false
+}
+
+// RequiresConsent reports whether the merchant may only use c with the
+// buyer's recorded consent: SMS, which messaging rules require opt-in for.
+func (c NotificationChannel) RequiresConsent() bool {
+	return c == NotificationChannelSMS
+}
+
+// NotificationPreferences is how the buyer wants order updates delivered.
+type NotificationPreferences struct {
+	// Channels are the channels to use, most preferred first. A sole
+	// NotificationChannelNone opts out of updates.
+	Channels []NotificationChannel `json:"channels"`
+
+	// Marketing reports whether the buyer also accepts marketing on these
+	// channels. It needs consent.marketing on the buyer.
+	Marketing *bool `json:"marketing,omitempty"`
+
+	// Consent records the buyer's agreement to be contacted on channels
+	// that require it.
+	Consent *NotificationConsent `json:"consent,omitempty"`
+}
+
+// NotificationConsent records the buyer agreeing to be contacted.
+type NotificationConsent struct {
+	// Channels are the channels the buyer agreed to.
+	Channels []NotificationChannel `json:"channels"`
+
+	// GrantedAt is when the buyer agreed.
+	GrantedAt time.Time `json:"granted_at"`
+
+	// Disclosure is the URL of the terms shown to the buyer.
+	Disclosure string `json:"disclosure,omitempty"`
+}
+
+// Covers reports whether the consent includes channel.
+func (c *NotificationConsent) Covers(channel NotificationChannel) bool {
+	if c == nil {
+		return false
+	}
+	for _, ch := range c.Channels {
+		if ch == channel {
+			return true
+		}
+	}
+	return false
+}
@@ -14,6 +14,8 @@
 
 package models
 
+import "time"
+
 // CartCreateRequest represents a request to create a new cart session.
 // Carts provide lightweight pre-purchase exploration with estimated pricing.
 type CartCreateRequest struct {
@@ -82,6 +84,12 @@ type CartResponse struct {
 
 	// ExpiresAt is the cart expiry timestamp (RFC 3339).
 	ExpiresAt string `json:"expires_at,omitempty"`
+
+	// CreatedAt is when the cart was created (RFC 3339).
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// UpdatedAt is when the cart was last modified (RFC 3339).
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
 // CartWithCheckout extends CheckoutCreateRequest to support cart-to-checkout conversion.
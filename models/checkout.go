@@ -90,6 +90,12 @@ type CheckoutResponse struct {
 	// ExpiresAt is the RFC 3339 expiry timestamp.
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 
+	// CreatedAt is when the checkout session was created (RFC 3339).
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// UpdatedAt is when the checkout session was last modified (RFC 3339).
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+
 	// ContinueURL is for checkout handoff and session recovery.
 	ContinueURL string `json:"continue_url,omitempty"`
 
@@ -0,0 +1,176 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrIllegalTransition is matched by the TransitionError returned for a
+// checkout status change the state machine does not allow.
+var ErrIllegalTransition = errors.New("illegal checkout status transition")
+
+// TransitionError reports an illegal checkout status change.
+type TransitionError struct {
+	From CheckoutStatus
+	To   CheckoutStatus
+}
+
+func (e *TransitionError) Error() string {
+	from := string(e.From)
+	if from == "" {
+		from = "new"
+	}
+	return fmt.Sprintf("cannot move checkout from %s to %s", from, e.To)
+}
+
+// Unwrap returns ErrIllegalTransition.
+func (e *TransitionError) Unwrap() error {
+	return ErrIllegalTransition
+}
+
+// checkoutTransitions lists the statuses each status may move to. The
+// empty status is a checkout being created. A checkout moves freely among
+// incomplete, requires_escalation, and ready_for_complete while the buyer
+// edits it; completion starts only from ready_for_complete and may pass
+// through complete_in_progress, which can still escalate (e.g. for a 3DS
+// challenge) or fail to canceled. Any open checkout may be canceled, and
+// completed and canceled are final.
+var checkoutTransitions = map[CheckoutStatus][]CheckoutStatus{
+	"": {
+		CheckoutStatusIncomplete, CheckoutStatusRequiresEscalation, CheckoutStatusReadyForComplete,
+	},
+	CheckoutStatusIncomplete: {
+		CheckoutStatusRequiresEscalation, CheckoutStatusReadyForComplete, CheckoutStatusCanceled,
+	},
+	CheckoutStatusRequiresEscalation: {
+		CheckoutStatusIncomplete, CheckoutStatusReadyForComplete, CheckoutStatusCanceled,
+	},
+	CheckoutStatusReadyForComplete: {
+		CheckoutStatusIncomplete, CheckoutStatusRequiresEscalation, CheckoutStatusCompleteInProgress,
+		CheckoutStatusCompleted, CheckoutStatusCanceled,
+	},
+	CheckoutStatusCompleteInProgress: {
+		CheckoutStatusRequiresEscalation, CheckoutStatusCompleted, CheckoutStatusCanceled,
+	},
+}
+
+// IsTerminal reports whether s is completed or canceled, after which a
+// checkout never changes status.
+func (s CheckoutStatus) IsTerminal() bool {
+	return s == CheckoutStatusCompleted || s == CheckoutStatusCanceled
+}
+
+// IsOpen reports whether a checkout in status s can still be edited by the
+// buyer: incomplete, requires_escalation, or ready_for_complete.
+func (s CheckoutStatus) IsOpen() bool {
+	switch s {
+	case CheckoutStatusIncomplete, CheckoutStatusRequiresEscalation, CheckoutStatusReadyForComplete:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a checkout may move from s to to.
+// Staying in the same status is always allowed.
+func (s CheckoutStatus) CanTransitionTo(to CheckoutStatus) bool {
+	if s == to {
+		return true
+	}
+	for _, next := range checkoutTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// DeriveCheckoutStatus returns the status an open checkout should have
+// given its messages: requires_escalation if any error needs the buyer's
+// input or review, incomplete if any other error remains, and
+// ready_for_complete otherwise.
+func DeriveCheckoutStatus(messages []Message) CheckoutStatus {
+	status := CheckoutStatusReadyForComplete
+	for _, m := range messages {
+		if m.Type != MessageTypeError {
+			continue
+		}
+		switch m.Severity {
+		case SeverityRequiresBuyerInput, SeverityRequiresBuyerReview:
+			return CheckoutStatusRequiresEscalation
+		}
+		status = CheckoutStatusIncomplete
+	}
+	return status
+}
+
+// CheckoutTransitionHook is a side effect of a checkout status change,
+// such as placing an order on completion. Returning an error aborts the
+// transition.
+type CheckoutTransitionHook func(ctx context.Context, from, to CheckoutStatus) error
+
+// AnyCheckoutStatus passed to CheckoutFlow.On matches every status,
+// including the empty status of a checkout being created.
+const AnyCheckoutStatus CheckoutStatus = "*"
+
+// checkoutHook is a hook registered for a transition.
+type checkoutHook struct {
+	from, to CheckoutStatus
+	fn       CheckoutTransitionHook
+}
+
+// CheckoutFlow enforces the checkout status state machine and runs hooks
+// on status changes. The zero value enforces transitions without hooks.
+//
+//	var flow models.CheckoutFlow
+//	flow.On(models.AnyCheckoutStatus, models.CheckoutStatusCompleted, placeOrder)
+//	if err := flow.Transition(ctx, &checkout.Status, models.CheckoutStatusCompleted); err != nil {
+//		return err
+//	}
+type CheckoutFlow struct {
+	hooks []checkoutHook
+}
+
+// On registers fn to run when a checkout moves from from to to. An empty
+// from matches only a checkout being created; AnyCheckoutStatus matches
+// any status. Hooks run in registration order.
+func (f *CheckoutFlow) On(from, to CheckoutStatus, fn CheckoutTransitionHook) {
+	f.hooks = append(f.hooks, checkoutHook{from: from, to: to, fn: fn})
+}
+
+// Transition moves *status to to. It returns a *TransitionError, leaving
+// *status unchanged, if the move is illegal, and the first hook error if a
+// hook fails. Transitioning to the current status is a no-op that runs no
+// hooks.
+func (f *CheckoutFlow) Transition(ctx context.Context, status *CheckoutStatus, to CheckoutStatus) error {
+	from := *status
+	if from == to {
+		return nil
+	}
+	if !from.CanTransitionTo(to) {
+		return &TransitionError{From: from, To: to}
+	}
+	for _, h := range f.hooks {
+		if (h.from == AnyCheckoutStatus || h.from == from) && (h.to == AnyCheckoutStatus || h.to == to) {
+			if err := h.fn(ctx, from, to); err != nil {
+				return err
+			}
+		}
+	}
+	*status = to
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+func TestCheckoutFlowTransition(t *testing.T) {
+	tests := []struct {
+		from, to models.CheckoutStatus
+		legal    bool
+	}{
+		{"", models.CheckoutStatusIncomplete, true},
+		{"", models.CheckoutStatusCompleted, false},
+		{models.CheckoutStatusIncomplete, models.CheckoutStatusReadyForComplete, true},
+		{models.CheckoutStatusIncomplete, models.CheckoutStatusCompleted, false},
+		{models.CheckoutStatusReadyForComplete, models.CheckoutStatusCompleteInProgress, true},
+		{models.CheckoutStatusCompleteInProgress, models.CheckoutStatusIncomplete, false},
+		{models.CheckoutStatusCompleteInProgress, models.CheckoutStatusCompleted, true},
+		{models.CheckoutStatusRequiresEscalation, models.CheckoutStatusCanceled, true},
+		{models.CheckoutStatusCompleted, models.CheckoutStatusCanceled, false},
+		{models.CheckoutStatusCanceled, models.CheckoutStatusIncomplete, false},
+		{models.CheckoutStatusCanceled, models.CheckoutStatusCanceled, true},
+	}
+
+	var flow models.CheckoutFlow
+	for _, tt := range tests {
+		status := tt.from
+		err := flow.Transition(context.Background(), &status, tt.to)
+		if tt.legal {
+			if err != nil || status != tt.to {
+				t.Errorf("%q -> %q: status %q, err %v; want legal", tt.from, tt.to, status, err)
+			}
+			continue
+		}
+		if !errors.Is(err, models.ErrIllegalTransition) || status != tt.from {
+			t.Errorf("%q -> %q: status %q, err %v; want ErrIllegalTransition", tt.from, tt.to, status, err)
+		}
+	}
+}
+
+func TestCheckoutFlowHooks(t *testing.T) {
+	var flow models.CheckoutFlow
+	var calls []string
+	flow.On(models.AnyCheckoutStatus, models.CheckoutStatusCompleted, func(ctx context.Context, from, to models.CheckoutStatus) error {
+		calls = append(calls, "completed from "+string(from))
+		return nil
+	})
+	declined := errors.New("declined")
+	flow.On(models.CheckoutStatusCompleteInProgress, models.AnyCheckoutStatus, func(ctx context.Context, from, to models.CheckoutStatus) error {
+		return declined
+	})
+
+	status := models.CheckoutStatusReadyForComplete
+	if err := flow.Transition(context.Background(), &status, models.CheckoutStatusCompleted); err != nil {
+		t.Fatalf("Transition() = %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "completed from ready_for_complete" {
+		t.Errorf("hook calls = %v", calls)
+	}
+
+	status = models.CheckoutStatusCompleteInProgress
+	if err := flow.Transition(context.Background(), &status, models.CheckoutStatusCanceled); err != declined {
+		t.Errorf("Transition() = %v, want hook error", err)
+	}
+	if status != models.CheckoutStatusCompleteInProgress {
+		t.Errorf("status = %q after failed hook, want unchanged", status)
+	}
+}
+
+func TestCheckoutFlowCreationHook(t *testing.T) {
+	var flow models.CheckoutFlow
+	var created, any int
+	flow.On("", models.AnyCheckoutStatus, func(ctx context.Context, from, to models.CheckoutStatus) error {
+		created++
+		return nil
+	})
+	flow.On(models.AnyCheckoutStatus, models.AnyCheckoutStatus, func(ctx context.Context, from, to models.CheckoutStatus) error {
+		any++
+		return nil
+	})
+
+	var status models.CheckoutStatus
+	for _, to := range []models.CheckoutStatus{models.CheckoutStatusIncomplete, models.CheckoutStatusReadyForComplete, models.CheckoutStatusCanceled} {
+		if err := flow.Transition(context.Background(), &status, to); err != nil {
+			t.Fatalf("Transition(%q) = %v", to, err)
+		}
+	}
+	if created != 1 || any != 3 {
+		t.Errorf("creation hook ran %d times, wildcard hook %d; want 1 and 3", created, any)
+	}
+}
+
+func TestDeriveCheckoutStatus(t *testing.T) {
+	warning := models.Message{Type: models.MessageTypeWarning, Code: "backordered"}
+	missing := models.Message{Type: models.MessageTypeError, Code: "missing", Severity: models.SeverityRecoverable}
+	review := models.Message{Type: models.MessageTypeError, Code: "age_check", Severity: models.SeverityRequiresBuyerReview}
+
+	if got := models.DeriveCheckoutStatus([]models.Message{warning}); got != models.CheckoutStatusReadyForComplete {
+		t.Errorf("warning only: got %q", got)
+	}
+	if got := models.DeriveCheckoutStatus([]models.Message{missing}); got != models.CheckoutStatusIncomplete {
+		t.Errorf("recoverable error: got %q", got)
+	}
+	if got := models.DeriveCheckoutStatus([]models.Message{missing, review}); got != models.CheckoutStatusRequiresEscalation {
+		t.Errorf("review error: got %q", got)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrNegativeDiscount is returned when a discount amount is negative.
+	ErrNegativeDiscount = errors.New("discount amount is negative")
+
+	// ErrDiscountExceedsLineItems is returned when a discount is larger
+	// than the line items it is allocated to.
+	ErrDiscountExceedsLineItems = errors.New("discount exceeds line items")
+
+	// ErrUnknownAllocationMethod is returned for an allocation method other
+	// than each or across.
+	ErrUnknownAllocationMethod = errors.New("unknown allocation method")
+)
+
+// AllocateDiscount splits a discount's Amount across the checkout's top-level
+// line items (those without a ParentID; components are covered by their
+// root) and returns one DiscountAllocation per line item receiving a
+// non-zero share, with paths of the form "$.line_items[i]".
+//
+// With AllocationMethodEach every unit receives an equal share, so lines are
+// weighted by quantity. With AllocationMethodAcross (the default when Method
+// is empty) lines are weighted by their subtotal, taken from the subtotal
+// total or else item price times quantity.
+//
+// Shares are computed in minor units by the largest remainder method: each
+// line first receives the floor of its exact share, and the minor units
+// left over go one each to the lines with the largest fractional parts,
+// earlier lines first on ties. The allocations always sum to Amount, so
+// merchants and platforms that both use this function agree exactly.
+func AllocateDiscount(discount AppliedDiscount, items []LineItemResponse) ([]DiscountAllocation, error) {
+	if discount.Amount < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrNegativeDiscount, discount.Amount)
+	}
+
+	weights := make([]int64, len(items))
+	values := make([]int64, len(items))
+	var total, totalValue int64
+	for i, li := range items {
+		if li.ParentID != "" || li.Quantity <= 0 {
+			continue
+		}
+		values[i] = int64(lineSubtotal(li))
+		switch discount.Method {
+		case AllocationMethodEach:
+			weights[i] = int64(li.Quantity)
+		case AllocationMethodAcross, "":
+			weights[i] = values[i]
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownAllocationMethod, discount.Method)
+		}
+		total += weights[i]
+		totalValue += values[i]
+	}
+	if discount.Amount == 0 {
+		return nil, nil
+	}
+	if total == 0 || int64(discount.Amount) > totalValue {
+		return nil, fmt.Errorf("%w: %d > %d", ErrDiscountExceedsLineItems, discount.Amount, totalValue)
+	}
+
+	shares := make([]int64, len(items))
+	for amount := int64(discount.Amount); amount > 0; {
+		distribute(shares, weights, amount, total)
+
+		// A line cannot be discounted below zero: cap it at its value and
+		// spread the excess over the remaining lines.
+		amount = 0
+		for i, share := range shares {
+			if weights[i] > 0 && share > values[i] {
+				amount += share - values[i]
+				shares[i] = values[i]
+				total -= weights[i]
+				weights[i] = 0
+			} else if weights[i] > 0 && share == values[i] {
+				total -= weights[i]
+				weights[i] = 0
+			}
+		}
+	}
+
+	var allocations []DiscountAllocation
+	for i, share := range shares {
+		if share > 0 {
+			allocations = append(allocations, DiscountAllocation{
+				Path:   fmt.Sprintf("$.line_items[%d]", i),
+				Amount: int(share),
+			})
+		}
+	}
+	return allocations, nil
+}
+
+// distribute adds amount to shares in proportion to weights, which sum to
+// total, by the largest remainder method.
+func distribute(shares, weights []int64, amount, total int64) {
+	remainders := make([]int64, len(shares))
+	left := amount
+	for i, w := range weights {
+		share := amount * w / total
+		shares[i] += share
+		remainders[i] = amount * w % total
+		left -= share
+	}
+
+	order := make([]int, len(shares))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for _, i := range order {
+		if left == 0 {
+			break
+		}
+		if weights[i] > 0 {
+			shares[i]++
+			left--
+		}
+	}
+}
+
+// lineSubtotal returns a line item's subtotal total, or its item price
+// times quantity if it has none.
+func lineSubtotal(li LineItemResponse) int {
+	for _, t := range li.Totals {
+		if t.Type == TotalTypeSubtotal {
+			return t.Amount
+		}
+	}
+	return li.Item.Price * li.Quantity
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+func pricedItem(id string, price, quantity int) models.LineItemResponse {
+	return models.LineItemResponse{
+		ID:       id,
+		Item:     models.ItemResponse{ID: id, Price: price},
+		Quantity: quantity,
+	}
+}
+
+func TestAllocateDiscount(t *testing.T) {
+	tests := []struct {
+		name     string
+		discount models.AppliedDiscount
+		items    []models.LineItemResponse
+		want     []int
+	}{
+		{
+			name:     "across by value",
+			discount: models.AppliedDiscount{Amount: 1000, Method: models.AllocationMethodAcross},
+			items:    []models.LineItemResponse{pricedItem("a", 3000, 1), pricedItem("b", 1000, 1)},
+			want:     []int{750, 250},
+		},
+		{
+			name:     "across remainder to largest fraction",
+			discount: models.AppliedDiscount{Amount: 100},
+			items:    []models.LineItemResponse{pricedItem("a", 1000, 1), pricedItem("b", 1000, 1), pricedItem("c", 1000, 1)},
+			want:     []int{34, 33, 33},
+		},
+		{
+			name:     "each by quantity",
+			discount: models.AppliedDiscount{Amount: 1000, Method: models.AllocationMethodEach},
+			items:    []models.LineItemResponse{pricedItem("a", 500, 3), pricedItem("b", 9000, 1)},
+			want:     []int{750, 250},
+		},
+		{
+			name:     "each capped at line value",
+			discount: models.AppliedDiscount{Amount: 1000, Method: models.AllocationMethodEach},
+			items:    []models.LineItemResponse{pricedItem("a", 100, 1), pricedItem("b", 5000, 1), pricedItem("c", 5000, 1)},
+			want:     []int{100, 450, 450},
+		},
+		{
+			name:     "components skipped",
+			discount: models.AppliedDiscount{Amount: 99},
+			items:    []models.LineItemResponse{pricedItem("kit", 1000, 1), lineItem("lens", "kit", 800)},
+			want:     []int{99},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocations, err := models.AllocateDiscount(tt.discount, tt.items)
+			if err != nil {
+				t.Fatalf("AllocateDiscount() error = %v", err)
+			}
+			var got []int
+			sum := 0
+			for _, a := range allocations {
+				got = append(got, a.Amount)
+				sum += a.Amount
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AllocateDiscount() = %v, want %v", got, tt.want)
+			}
+			if sum != tt.discount.Amount {
+				t.Errorf("allocations sum to %d, want %d", sum, tt.discount.Amount)
+			}
+		})
+	}
+}
+
+func TestAllocateDiscountErrors(t *testing.T) {
+	items := []models.LineItemResponse{pricedItem("a", 1000, 1)}
+	tests := []struct {
+		name     string
+		discount models.AppliedDiscount
+		want     error
+	}{
+		{"negative", models.AppliedDiscount{Amount: -1}, models.ErrNegativeDiscount},
+		{"too large", models.AppliedDiscount{Amount: 1001}, models.ErrDiscountExceedsLineItems},
+		{"unknown method", models.AppliedDiscount{Amount: 1, Method: "bogus"}, models.ErrUnknownAllocationMethod},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := models.AllocateDiscount(tt.discount, items); !errors.Is(err, tt.want) {
+				t.Errorf("AllocateDiscount() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
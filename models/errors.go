@@ -0,0 +1,131 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// API error codes carried in the "error" field and message code of error
+// responses. The server's APIError constructors emit them and the
+// client's typed errors match on them, so both sides share one taxonomy.
+const (
+	// ErrorCodeBadRequest indicates a malformed or unacceptable request.
+	ErrorCodeBadRequest ErrorCode = "bad_request"
+
+	// ErrorCodeInvalidRequest indicates the request body could not be
+	// parsed or does not conform to the schema.
+	ErrorCodeInvalidRequest ErrorCode = "invalid_request"
+
+	// ErrorCodeUnknownField indicates the request body has a field the
+	// server does not recognize (strict mode).
+	ErrorCodeUnknownField ErrorCode = "unknown_field"
+
+	// ErrorCodeInvalidField indicates a request field fails validation.
+	ErrorCodeInvalidField ErrorCode = "invalid_field"
+
+	// ErrorCodeMissingHeader indicates a required header is absent.
+	ErrorCodeMissingHeader ErrorCode = "missing_header"
+
+	// ErrorCodeUnsupportedMediaType indicates the request Content-Type is
+	// not accepted.
+	ErrorCodeUnsupportedMediaType ErrorCode = "unsupported_media_type"
+
+	// ErrorCodeRequestTooLarge indicates the request body exceeds the
+	// server's limit.
+	ErrorCodeRequestTooLarge ErrorCode = "request_too_large"
+
+	// ErrorCodeUnauthorized indicates missing or invalid credentials.
+	ErrorCodeUnauthorized ErrorCode = "unauthorized"
+
+	// ErrorCodeMissingAPIKey indicates the X-API-Key header is absent.
+	ErrorCodeMissingAPIKey ErrorCode = "missing_api_key"
+
+	// ErrorCodeInvalidAPIKey indicates the API key is not recognized.
+	ErrorCodeInvalidAPIKey ErrorCode = "invalid_api_key"
+
+	// ErrorCodeMissingAuthorization indicates the Authorization header is
+	// absent.
+	ErrorCodeMissingAuthorization ErrorCode = "missing_authorization"
+
+	// ErrorCodeInvalidAuthorization indicates a malformed Authorization
+	// header.
+	ErrorCodeInvalidAuthorization ErrorCode = "invalid_authorization"
+
+	// ErrorCodeInvalidToken indicates the access token was rejected.
+	ErrorCodeInvalidToken ErrorCode = "invalid_token"
+
+	// ErrorCodeAuthError indicates credentials could not be checked.
+	ErrorCodeAuthError ErrorCode = "auth_error"
+
+	// ErrorCodeMissingSignature indicates a required request signature is
+	// absent.
+	ErrorCodeMissingSignature ErrorCode = "missing_signature"
+
+	// ErrorCodeInvalidSignature indicates a request signature failed
+	// verification.
+	ErrorCodeInvalidSignature ErrorCode = "invalid_signature"
+
+	// ErrorCodeForbidden indicates the caller may not perform the request.
+	ErrorCodeForbidden ErrorCode = "forbidden"
+
+	// ErrorCodeLinkExpired indicates a signed URL has expired.
+	ErrorCodeLinkExpired ErrorCode = "link_expired"
+
+	// ErrorCodeNotFound indicates the resource does not exist.
+	ErrorCodeNotFound ErrorCode = "not_found"
+
+	// ErrorCodeMethodNotAllowed indicates the route does not accept the
+	// request method.
+	ErrorCodeMethodNotAllowed ErrorCode = "method_not_allowed"
+
+	// ErrorCodeConflict indicates the request conflicts with the
+	// resource's current state.
+	ErrorCodeConflict ErrorCode = "conflict"
+
+	// ErrorCodeIdempotencyConflict indicates an idempotency key was reused
+	// while the original request is in flight.
+	ErrorCodeIdempotencyConflict ErrorCode = "idempotency_conflict"
+
+	// ErrorCodeIdempotencyKeyReused indicates an idempotency key was reused
+	// with a different request body.
+	ErrorCodeIdempotencyKeyReused ErrorCode = "idempotency_key_reused"
+
+	// ErrorCodeLimitExceeded indicates the caller exceeded a quota or
+	// spending limit set by the merchant.
+	ErrorCodeLimitExceeded ErrorCode = "limit_exceeded"
+
+	// ErrorCodeCapabilityNotSupported indicates the request depends on a
+	// capability the server does not support.
+	ErrorCodeCapabilityNotSupported ErrorCode = "capability_not_supported"
+
+	// ErrorCodeVersionUnsupported indicates the requested protocol version
+	// is not supported.
+	ErrorCodeVersionUnsupported ErrorCode = "version_unsupported"
+
+	// ErrorCodeInvalidFulfillment indicates the fulfillment selection
+	// violates the merchant's fulfillment policy.
+	ErrorCodeInvalidFulfillment ErrorCode = "invalid_fulfillment"
+
+	// ErrorCodeChallengeFailed indicates a webhook callback did not echo
+	// its challenge.
+	ErrorCodeChallengeFailed ErrorCode = "challenge_failed"
+
+	// ErrorCodeNonconformantResponse indicates a response failed strict
+	// mode verification.
+	ErrorCodeNonconformantResponse ErrorCode = "nonconformant_response"
+
+	// ErrorCodeInternal indicates an unexpected server error.
+	ErrorCodeInternal ErrorCode = "internal_error"
+
+	// ErrorCodeNotImplemented indicates the operation is not implemented.
+	ErrorCodeNotImplemented ErrorCode = "not_implemented"
+)
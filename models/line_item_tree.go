@@ -0,0 +1,142 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrDuplicateLineItemID is returned when two line items share an ID.
+	ErrDuplicateLineItemID = errors.New("duplicate line item id")
+
+	// ErrLineItemParentNotFound is returned when a ParentID does not match
+	// any line item.
+	ErrLineItemParentNotFound = errors.New("line item parent not found")
+
+	// ErrLineItemCycle is returned when ParentID references form a cycle.
+	ErrLineItemCycle = errors.New("line item parent cycle")
+)
+
+// LineItemNode is a line item with its nested components, such as the
+// contents of a bundle or kit.
+type LineItemNode struct {
+	// LineItem is the line item at this node.
+	LineItem LineItemResponse
+
+	// Children are the line items whose ParentID is this line item's ID.
+	Children []*LineItemNode
+}
+
+// ValidateLineItemTree checks that line item IDs are unique and that every
+// ParentID refers to an existing line item without forming a cycle.
+func ValidateLineItemTree(items []LineItemResponse) error {
+	parents := make(map[string]string, len(items))
+	for _, li := range items {
+		if _, ok := parents[li.ID]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateLineItemID, li.ID)
+		}
+		parents[li.ID] = li.ParentID
+	}
+	for _, li := range items {
+		if li.ParentID == "" {
+			continue
+		}
+		if _, ok := parents[li.ParentID]; !ok {
+			return fmt.Errorf("%w: %s references %s", ErrLineItemParentNotFound, li.ID, li.ParentID)
+		}
+		// A chain longer than the number of items must revisit a node.
+		id := li.ID
+		for steps := 0; parents[id] != ""; steps++ {
+			if steps == len(items) {
+				return fmt.Errorf("%w: %s", ErrLineItemCycle, li.ID)
+			}
+			id = parents[id]
+		}
+	}
+	return nil
+}
+
+// BuildLineItemTree nests line items under their parents. Roots and
+// children keep the order in which they appear in items.
+func BuildLineItemTree(items []LineItemResponse) ([]*LineItemNode, error) {
+	if err := ValidateLineItemTree(items); err != nil {
+		return nil, err
+	}
+	nodes := make(map[string]*LineItemNode, len(items))
+	for _, li := range items {
+		nodes[li.ID] = &LineItemNode{LineItem: li}
+	}
+	var roots []*LineItemNode
+	for _, li := range items {
+		node := nodes[li.ID]
+		if li.ParentID == "" {
+			roots = append(roots, node)
+			continue
+		}
+		parent := nodes[li.ParentID]
+		parent.Children = append(parent.Children, node)
+	}
+	return roots, nil
+}
+
+// FlattenLineItemTree returns the line items of a tree depth-first, each
+// parent immediately followed by its components.
+func FlattenLineItemTree(roots []*LineItemNode) []LineItemResponse {
+	var items []LineItemResponse
+	var walk func(nodes []*LineItemNode)
+	walk = func(nodes []*LineItemNode) {
+		for _, n := range nodes {
+			items = append(items, n.LineItem)
+			walk(n.Children)
+		}
+	}
+	walk(roots)
+	return items
+}
+
+// RollUpLineItemTotals adds each node's component totals into its own,
+// type by type, so a bundle reports the combined amounts of its contents
+// plus anything priced on the bundle itself. Use it when components carry
+// the prices; a bundle already priced as a whole would be counted twice.
+func RollUpLineItemTotals(roots []*LineItemNode) {
+	for _, n := range roots {
+		RollUpLineItemTotals(n.Children)
+		for _, child := range n.Children {
+			n.LineItem.Totals = addTotals(n.LineItem.Totals, child.LineItem.Totals)
+		}
+	}
+}
+
+// addTotals returns totals with each entry of extra added to the entry of
+// the same type, appending types not yet present.
+func addTotals(totals, extra []TotalResponse) []TotalResponse {
+	out := append([]TotalResponse(nil), totals...)
+	for _, e := range extra {
+		found := false
+		for i := range out {
+			if out[i].Type == e.Type {
+				out[i].Amount += e.Amount
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, TotalResponse{Type: e.Type, Amount: e.Amount})
+		}
+	}
+	return out
+}
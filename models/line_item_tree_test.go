@@ -0,0 +1,93 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+func lineItem(id, parentID string, subtotal int) models.LineItemResponse {
+	return models.LineItemResponse{
+		ID:       id,
+		ParentID: parentID,
+		Quantity: 1,
+		Totals:   []models.TotalResponse{{Type: models.TotalTypeSubtotal, Amount: subtotal}},
+	}
+}
+
+func TestBuildLineItemTree(t *testing.T) {
+	items := []models.LineItemResponse{
+		lineItem("lens", "kit", 20000),
+		lineItem("kit", "", 0),
+		lineItem("strap", "kit", 1500),
+		lineItem("cap", "lens", 500),
+		lineItem("bag", "", 3000),
+	}
+
+	roots, err := models.BuildLineItemTree(items)
+	if err != nil {
+		t.Fatalf("BuildLineItemTree: %v", err)
+	}
+	if len(roots) != 2 || roots[0].LineItem.ID != "kit" || roots[1].LineItem.ID != "bag" {
+		t.Fatalf("unexpected roots: %+v", roots)
+	}
+
+	models.RollUpLineItemTotals(roots)
+	if got := roots[0].LineItem.Totals[0].Amount; got != 22000 {
+		t.Errorf("kit subtotal = %d, want 22000", got)
+	}
+	if got := roots[0].Children[0].LineItem.Totals[0].Amount; got != 20500 {
+		t.Errorf("lens subtotal = %d, want 20500", got)
+	}
+
+	var order []string
+	for _, li := range models.FlattenLineItemTree(roots) {
+		order = append(order, li.ID)
+	}
+	want := []string{"kit", "lens", "cap", "strap", "bag"}
+	if len(order) != len(want) {
+		t.Fatalf("flattened = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("flattened = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestValidateLineItemTree(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []models.LineItemResponse
+		want  error
+	}{
+		{"duplicate", []models.LineItemResponse{lineItem("a", "", 0), lineItem("a", "", 0)}, models.ErrDuplicateLineItemID},
+		{"missing parent", []models.LineItemResponse{lineItem("a", "x", 0)}, models.ErrLineItemParentNotFound},
+		{"self cycle", []models.LineItemResponse{lineItem("a", "a", 0)}, models.ErrLineItemCycle},
+		{"cycle", []models.LineItemResponse{lineItem("a", "b", 0), lineItem("b", "c", 0), lineItem("c", "a", 0)}, models.ErrLineItemCycle},
+		{"valid", []models.LineItemResponse{lineItem("a", "", 0), lineItem("b", "a", 0)}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := models.ValidateLineItemTree(tt.items)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ValidateLineItemTree() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when combining amounts in different
+// currencies.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// minorDigits lists the ISO 4217 currencies whose minor unit is not
+// cents.
+var minorDigits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// MinorDigits returns the number of minor-unit digits of an ISO 4217
+// currency: 2 for USD, 0 for JPY, 3 for KWD. Unknown codes have 2.
+func MinorDigits(currency string) int {
+	if digits, ok := minorDigits[strings.ToUpper(currency)]; ok {
+		return digits
+	}
+	return 2
+}
+
+// Money is an amount in minor currency units, such as cents, together
+// with its ISO 4217 currency.
+type Money struct {
+	// Amount is the value in minor currency units.
+	Amount int `json:"amount"`
+
+	// Currency is the ISO 4217 currency code.
+	Currency string `json:"currency"`
+}
+
+// NewMoney returns amount minor units of currency.
+func NewMoney(amount int, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// Add returns m + o. It fails with ErrCurrencyMismatch if the currencies
+// differ; a zero Money of either is compatible with any currency.
+func (m Money) Add(o Money) (Money, error) {
+	currency, err := m.common(o)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: currency}, nil
+}
+
+// Sub returns m - o, failing like Add.
+func (m Money) Sub(o Money) (Money, error) {
+	return m.Add(o.Neg())
+}
+
+// Mul returns m times n, e.g. a unit price times a quantity.
+func (m Money) Mul(n int) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Cmp compares m and o, returning -1, 0, or +1. It fails like Add.
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.common(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Decimal returns the amount in major units with the currency's minor
+// digits, e.g. "12.34" for 1234 USD and "1234" for 1234 JPY.
+func (m Money) Decimal() string {
+	digits := MinorDigits(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	s := strconv.Itoa(amount)
+	if digits == 0 {
+		return sign + s
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+// String formats m as its decimal amount and currency code, e.g.
+// "12.34 USD". Use display.FormatMoney for buyer-facing text.
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.Currency
+}
+
+// common returns the currency of a sum of m and o.
+func (m Money) common(o Money) (string, error) {
+	switch {
+	case strings.EqualFold(m.Currency, o.Currency):
+		return m.Currency, nil
+	case m.Currency == "" && m.Amount == 0:
+		return o.Currency, nil
+	case o.Currency == "" && o.Amount == 0:
+		return m.Currency, nil
+	}
+	return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+}
+
+// SumTotals returns the sum of the amounts of the totals of the given
+// types. Types may repeat in totals, e.g. several fees.
+func SumTotals(totals []TotalResponse, types ...TotalType) int {
+	sum := 0
+	for _, t := range totals {
+		for _, typ := range types {
+			if t.Type == typ {
+				sum += t.Amount
+				break
+			}
+		}
+	}
+	return sum
+}
+
+// ComputeTotal returns the grand total implied by the other totals:
+// subtotal + tax + fulfillment + fees - discounts. Discount amounts are
+// positive values that reduce the total.
+func ComputeTotal(totals []TotalResponse) int {
+	return SumTotals(totals, TotalTypeSubtotal, TotalTypeTax, TotalTypeFulfillment, TotalTypeFee) -
+		SumTotals(totals, TotalTypeDiscount, TotalTypeItemsDiscount)
+}
+
+// Total returns the sum of the checkout's totals of a type in its
+// currency, e.g. c.Total(TotalTypeTotal).
+func (c *CheckoutResponse) Total(typ TotalType) Money {
+	return NewMoney(SumTotals(c.Totals, typ), c.Currency)
+}
+
+// Total returns the sum of the cart's totals of a type in its currency.
+func (c *CartResponse) Total(typ TotalType) Money {
+	return NewMoney(SumTotals(c.Totals, typ), c.Currency)
+}
+
+// Total returns the sum of the order's totals of a type in its currency.
+func (o *Order) Total(typ TotalType) Money {
+	return NewMoney(SumTotals(o.Totals, typ), o.Currency)
+}
+
+// TotalsBuilder builds a totals breakdown whose grand total is always
+// consistent with its parts. Entries are emitted in a fixed order:
+// subtotal, items_discount, discount, fulfillment, tax, fees, then total.
+type TotalsBuilder struct {
+	entries map[TotalType][]TotalResponse
+}
+
+// NewTotalsBuilder creates an empty TotalsBuilder.
+func NewTotalsBuilder() *TotalsBuilder {
+	return &TotalsBuilder{entries: make(map[TotalType][]TotalResponse)}
+}
+
+// Subtotal sets the subtotal.
+func (b *TotalsBuilder) Subtotal(amount int) *TotalsBuilder {
+	return b.set(TotalTypeSubtotal, amount)
+}
+
+// ItemsDiscount sets the discount applied to items, as a positive amount.
+func (b *TotalsBuilder) ItemsDiscount(amount int) *TotalsBuilder {
+	return b.set(TotalTypeItemsDiscount, amount)
+}
+
+// Discount sets the order-level discount, as a positive amount.
+func (b *TotalsBuilder) Discount(amount int) *TotalsBuilder {
+	return b.set(TotalTypeDiscount, amount)
+}
+
+// Fulfillment sets the fulfillment cost.
+func (b *TotalsBuilder) Fulfillment(amount int) *TotalsBuilder {
+	return b.set(TotalTypeFulfillment, amount)
+}
+
+// Tax sets the tax.
+func (b *TotalsBuilder) Tax(amount int) *TotalsBuilder {
+	return b.set(TotalTypeTax, amount)
+}
+
+// Fee adds a fee with the text to display against it.
+func (b *TotalsBuilder) Fee(amount int, displayText string) *TotalsBuilder {
+	b.entries[TotalTypeFee] = append(b.entries[TotalTypeFee], TotalResponse{Type: TotalTypeFee, Amount: amount, DisplayText: displayText})
+	return b
+}
+
+// Build returns the totals, ending with the computed grand total.
+func (b *TotalsBuilder) Build() []TotalResponse {
+	var totals []TotalResponse
+	for _, typ := range []TotalType{TotalTypeSubtotal, TotalTypeItemsDiscount, TotalTypeDiscount, TotalTypeFulfillment, TotalTypeTax, TotalTypeFee} {
+		totals = append(totals, b.entries[typ]...)
+	}
+	return append(totals, TotalResponse{Type: TotalTypeTotal, Amount: ComputeTotal(totals)})
+}
+
+// set replaces the single entry of a type.
+func (b *TotalsBuilder) set(typ TotalType, amount int) *TotalsBuilder {
+	b.entries[typ] = []TotalResponse{{Type: typ, Amount: amount}}
+	return b
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		money models.Money
+		want  string
+	}{
+		{models.NewMoney(1234, "usd"), "12.34 USD"},
+		{models.NewMoney(5, "USD"), "0.05 USD"},
+		{models.NewMoney(-250, "EUR"), "-2.50 EUR"},
+		{models.NewMoney(1234, "JPY"), "1234 JPY"},
+		{models.NewMoney(1234, "KWD"), "1.234 KWD"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("%#v.String() = %q, want %q", tt.money, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	price := models.NewMoney(1500, "USD")
+	sum, err := price.Mul(2).Add(models.NewMoney(250, "USD"))
+	if err != nil || sum != models.NewMoney(3250, "USD") {
+		t.Fatalf("Add = %v, %v; want 32.50 USD", sum, err)
+	}
+	if sum, err := (models.Money{}).Add(price); err != nil || sum != price {
+		t.Errorf("zero Add = %v, %v; want %v", sum, err, price)
+	}
+	if _, err := price.Sub(models.NewMoney(100, "EUR")); !errors.Is(err, models.ErrCurrencyMismatch) {
+		t.Errorf("Sub across currencies: err = %v, want ErrCurrencyMismatch", err)
+	}
+}
+
+func TestTotalsBuilder(t *testing.T) {
+	totals := models.NewTotalsBuilder().
+		Tax(800).
+		Subtotal(10000).
+		Discount(1000).
+		Fulfillment(500).
+		Fee(150, "Service fee").
+		Build()
+
+	want := []models.TotalType{
+		models.TotalTypeSubtotal, models.TotalTypeDiscount, models.TotalTypeFulfillment,
+		models.TotalTypeTax, models.TotalTypeFee, models.TotalTypeTotal,
+	}
+	if len(totals) != len(want) {
+		t.Fatalf("Build() = %v, want types %v", totals, want)
+	}
+	for i, typ := range want {
+		if totals[i].Type != typ {
+			t.Errorf("totals[%d].Type = %s, want %s", i, totals[i].Type, typ)
+		}
+	}
+
+	checkout := models.CheckoutResponse{Currency: "USD", Totals: totals}
+	if got := checkout.Total(models.TotalTypeTotal); got != models.NewMoney(10450, "USD") {
+		t.Errorf("Total(total) = %v, want 104.50 USD", got)
+	}
+	if got := models.SumTotals(totals, models.TotalTypeTax, models.TotalTypeFee); got != 950 {
+		t.Errorf("SumTotals(tax, fee) = %d, want 950", got)
+	}
+}
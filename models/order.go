@@ -196,4 +196,10 @@ type Order struct {
 
 	// Adjustments lists order adjustments (refunds, returns, etc.).
 	Adjustments []Adjustment `json:"adjustments,omitempty"`
+
+	// CreatedAt is when the order was created (RFC 3339).
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// UpdatedAt is when the order was last modified (RFC 3339).
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
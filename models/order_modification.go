@@ -0,0 +1,107 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "time"
+
+// OrderModificationType is the kind of post-purchase change a buyer asks
+// for.
+type OrderModificationType string
+
+const (
+	// OrderModificationAddressChange changes the destination of items not
+	// yet shipped.
+	OrderModificationAddressChange OrderModificationType = "address_change"
+
+	// OrderModificationQuantityReduction removes units of line items not
+	// yet fulfilled.
+	OrderModificationQuantityReduction OrderModificationType = "quantity_reduction"
+)
+
+// OrderModificationStatus is the merchant's decision on a modification.
+type OrderModificationStatus string
+
+const (
+	// OrderModificationStatusPending indicates the merchant has not decided.
+	OrderModificationStatusPending OrderModificationStatus = "pending"
+
+	// OrderModificationStatusAccepted indicates the change was made.
+	OrderModificationStatusAccepted OrderModificationStatus = "accepted"
+
+	// OrderModificationStatusRejected indicates the change was refused.
+	OrderModificationStatusRejected OrderModificationStatus = "rejected"
+)
+
+// OrderModificationRequest is a buyer-initiated change to a placed order,
+// proposed to the merchant.
+type OrderModificationRequest struct {
+	// Type is the kind of change.
+	Type OrderModificationType `json:"type"`
+
+	// LineItems are the order line items to reduce and, for each, the
+	// number of units to remove. Required for quantity_reduction.
+	LineItems []AdjustmentLineItem `json:"line_items,omitempty"`
+
+	// Destination is the new delivery address. Required for
+	// address_change.
+	Destination *PostalAddress `json:"destination,omitempty"`
+
+	// ExpectationIDs optionally limits an address_change to these
+	// fulfillment expectations. Empty means every unshipped expectation.
+	ExpectationIDs []string `json:"expectation_ids,omitempty"`
+
+	// Reason is the buyer's reason for the change.
+	Reason string `json:"reason,omitempty"`
+}
+
+// OrderModification is a proposed order change and the merchant's
+// decision on it.
+type OrderModification struct {
+	// ID is the modification identifier.
+	ID string `json:"id"`
+
+	// OrderID is the modified order.
+	OrderID string `json:"order_id"`
+
+	// Status is the merchant's decision.
+	Status OrderModificationStatus `json:"status"`
+
+	// Request is the proposed change.
+	Request OrderModificationRequest `json:"request"`
+
+	// Adjustments are the adjustments appended to the order when the
+	// modification was accepted, e.g. the refund for removed units.
+	Adjustments []Adjustment `json:"adjustments,omitempty"`
+
+	// RejectionReason explains a rejected modification, e.g. that the
+	// items have already shipped.
+	RejectionReason string `json:"rejection_reason,omitempty"`
+
+	// CreatedAt is when the modification was requested.
+	CreatedAt time.Time `json:"created_at"`
+
+	// DecidedAt is when the merchant accepted or rejected it.
+	DecidedAt *time.Time `json:"decided_at,omitempty"`
+}
+
+// OrderModificationDecidedEvent is the data of an
+// order.modification_decided event.
+type OrderModificationDecidedEvent struct {
+	// OrderID is the order the modification was requested for.
+	OrderID string `json:"order_id"`
+
+	// Modification is the decided modification.
+	Modification OrderModification `json:"modification"`
+}
@@ -184,8 +184,8 @@ type CardPaymentInstrument struct {
 	RichCardArt string `json:"rich_card_art,omitempty"`
 }
 
-// PaymentInstrument represents a payment instrument (currently only cards supported).
-// For JSON marshaling, this uses the card payment instrument structure.
+// PaymentInstrument represents a payment instrument (cards and digital wallets).
+// For JSON marshaling, card and wallet fields are flattened onto one structure.
 type PaymentInstrument struct {
 	// ID is a unique identifier for this instrument instance.
 	ID string `json:"id"`
@@ -219,6 +219,15 @@ type PaymentInstrument struct {
 
 	// RichCardArt is an optional URI to card art.
 	RichCardArt string `json:"rich_card_art,omitempty"`
+
+	// Wallet identifies the wallet provider (for wallet instruments).
+	Wallet WalletType `json:"wallet,omitempty"`
+
+	// WalletToken is the encrypted wallet payment token (for wallet instruments).
+	WalletToken *WalletPaymentToken `json:"token,omitempty"`
+
+	// WalletDisplay contains wallet display information (for wallet instruments).
+	WalletDisplay *WalletDisplay `json:"display,omitempty"`
 }
 
 // TokenCredentialCreateRequest represents a request to create a token credential.
@@ -0,0 +1,73 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// ProtectionPlanOffer is a warranty or product protection plan offered for
+// a line item.
+type ProtectionPlanOffer struct {
+	// ID is a unique identifier for this offer.
+	ID string `json:"id"`
+
+	// LineItemID is the line item the plan protects.
+	LineItemID string `json:"line_item_id"`
+
+	// Provider is the company underwriting the plan.
+	Provider string `json:"provider"`
+
+	// Title is the human-readable plan name (e.g., "2-Year Accident Protection").
+	Title string `json:"title"`
+
+	// TermMonths is the coverage term in months.
+	TermMonths int `json:"term_months"`
+
+	// Price is the plan price per protected unit in minor (cents) currency units.
+	Price int `json:"price"`
+
+	// TermsURL links to the plan's terms and conditions.
+	TermsURL string `json:"terms_url,omitempty"`
+}
+
+// ProtectionSelection selects a protection plan offer for a line item.
+type ProtectionSelection struct {
+	// LineItemID is the protected line item.
+	LineItemID string `json:"line_item_id"`
+
+	// OfferID is the selected offer.
+	OfferID string `json:"offer_id"`
+}
+
+// ProtectionCreateRequest represents protection plans in a checkout create request.
+type ProtectionCreateRequest struct {
+	// Selections are the offers to add. Line items are referenced by item ID,
+	// since line item IDs are not yet assigned.
+	Selections []ProtectionSelection `json:"selections,omitempty"`
+}
+
+// ProtectionUpdateRequest represents protection plans in a checkout update request.
+type ProtectionUpdateRequest struct {
+	// Selections are the offers to add (replaces previous selections).
+	Selections []ProtectionSelection `json:"selections"`
+}
+
+// ProtectionResponse represents protection plans in a checkout response.
+// Each selected plan also appears as a child line item whose ParentID is
+// the protected line item.
+type ProtectionResponse struct {
+	// Offers are the plans available for the checkout's line items.
+	Offers []ProtectionPlanOffer `json:"offers,omitempty"`
+
+	// Selections are the selected plans.
+	Selections []ProtectionSelection `json:"selections,omitempty"`
+}
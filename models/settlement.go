@@ -0,0 +1,96 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "time"
+
+// SettlementSplitType identifies what a settlement split pays for.
+type SettlementSplitType string
+
+const (
+	// SettlementSplitPlatformFee is the facilitating platform's fee.
+	SettlementSplitPlatformFee SettlementSplitType = "platform_fee"
+
+	// SettlementSplitSellerPayout is the amount paid out to the seller.
+	SettlementSplitSellerPayout SettlementSplitType = "seller_payout"
+
+	// SettlementSplitTaxWithheld is tax withheld by the platform for
+	// remittance to a tax authority.
+	SettlementSplitTaxWithheld SettlementSplitType = "tax_withheld"
+)
+
+// SettlementStatus is the payout state of a settlement.
+type SettlementStatus string
+
+const (
+	// SettlementStatusPending means funds are captured but not paid out.
+	SettlementStatusPending SettlementStatus = "pending"
+
+	// SettlementStatusPaid means every split has been paid out.
+	SettlementStatusPaid SettlementStatus = "paid"
+
+	// SettlementStatusReversed means the payout was reversed, for example
+	// after a refund or chargeback.
+	SettlementStatusReversed SettlementStatus = "reversed"
+)
+
+// SettlementSplit is one share of a captured payment.
+type SettlementSplit struct {
+	// Type is what the split pays for.
+	Type SettlementSplitType `json:"type"`
+
+	// Amount is the split amount in minor (cents) currency units.
+	Amount int `json:"amount"`
+
+	// Recipient identifies who receives the split, such as a seller
+	// account or tax authority.
+	Recipient string `json:"recipient,omitempty"`
+
+	// Description is a human-readable explanation of the split.
+	Description string `json:"description,omitempty"`
+}
+
+// Settlement describes how a payment captured by a platform on the
+// merchant's behalf is divided between platform, seller, and tax
+// authorities. It is attached to orders by the settlement extension.
+type Settlement struct {
+	// Facilitator identifies the platform that captured the payment.
+	Facilitator string `json:"facilitator,omitempty"`
+
+	// Currency is the ISO 4217 currency of all amounts.
+	Currency string `json:"currency"`
+
+	// CapturedAmount is the amount captured from the buyer in minor
+	// (cents) currency units. Splits must sum to it.
+	CapturedAmount int `json:"captured_amount"`
+
+	// Splits divide the captured amount.
+	Splits []SettlementSplit `json:"splits"`
+
+	// Status is the payout state.
+	Status SettlementStatus `json:"status,omitempty"`
+
+	// SettledAt is when the splits were paid out.
+	SettledAt *time.Time `json:"settled_at,omitempty"`
+}
+
+// SplitTotal returns the sum of the settlement's splits.
+func (s *Settlement) SplitTotal() int {
+	total := 0
+	for _, split := range s.Splits {
+		total += split.Amount
+	}
+	return total
+}
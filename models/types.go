@@ -76,13 +76,45 @@ const (
 	// ErrorCodeItemUnavailable indicates the item is not available.
 	ErrorCodeItemUnavailable ErrorCode = "item_unavailable"
 
+	// ErrorCodeLimitedQuantity indicates fewer units are available than
+	// were requested.
+	ErrorCodeLimitedQuantity ErrorCode = "limited_quantity"
+
+	// ErrorCodeBackordered indicates the item is sold but ships once it is
+	// restocked.
+	ErrorCodeBackordered ErrorCode = "backordered"
+
 	// ErrorCodeAddressUndeliverable indicates the address cannot be delivered to.
 	ErrorCodeAddressUndeliverable ErrorCode = "address_undeliverable"
 
-	// ErrorCodePaymentFailed indicates payment processing failed.
+	// ErrorCodePaymentFailed indicates payment processing failed. A
+	// declined payment is ErrorCodePaymentDeclined.
 	ErrorCodePaymentFailed ErrorCode = "payment_failed"
+
+	// ErrorCodeMissing indicates a required field is missing.
+	ErrorCodeMissing ErrorCode = "missing"
+
+	// ErrorCodeInvalid indicates a field value is invalid.
+	ErrorCodeInvalid ErrorCode = "invalid"
+
+	// ErrorCodePaymentDeclined indicates the payment was declined.
+	ErrorCodePaymentDeclined ErrorCode = "payment_declined"
+
+	// ErrorCodeRequiresSignIn indicates the buyer must sign in.
+	ErrorCodeRequiresSignIn ErrorCode = "requires_sign_in"
+
+	// ErrorCodeRequires3DS indicates the payment requires 3-D Secure.
+	ErrorCodeRequires3DS ErrorCode = "requires_3ds"
+
+	// ErrorCodeRequiresIdentityLinking indicates the buyer must link an
+	// account.
+	ErrorCodeRequiresIdentityLinking ErrorCode = "requires_identity_linking"
 )
 
+// MessageCodeDeprecatedVersion is the code of the warning Message a server
+// adds when a response is negotiated at a deprecated capability version.
+const MessageCodeDeprecatedVersion = "deprecated_version"
+
 // AvailablePaymentInstrument represents an instrument type available from a payment handler.
 type AvailablePaymentInstrument struct {
 	// Type is the instrument type identifier (e.g., "card", "gift_card").
@@ -123,8 +155,60 @@ type Context struct {
 	// Intent describes the buyer's purpose (e.g., "looking for a gift under $50").
 	// Informs relevance, recommendations, and personalization.
 	Intent string `json:"intent,omitempty"`
+
+	// Locale is the buyer's preferred language as a BCP 47 tag (e.g., "en-US").
+	Locale string `json:"locale,omitempty"`
+
+	// Currency is the buyer's preferred ISO 4217 currency code (e.g., "EUR").
+	Currency string `json:"currency,omitempty"`
+
+	// Timezone is the buyer's IANA time zone (e.g., "America/Los_Angeles").
+	Timezone string `json:"timezone,omitempty"`
+
+	// DeviceClass is the class of device the buyer is using.
+	DeviceClass DeviceClass `json:"device_class,omitempty"`
+
+	// Channel is the surface through which the buyer is interacting.
+	Channel Channel `json:"channel,omitempty"`
 }
 
+// DeviceClass represents the class of device a buyer is using.
+type DeviceClass string
+
+const (
+	// DeviceClassDesktop is a desktop or laptop computer.
+	DeviceClassDesktop DeviceClass = "desktop"
+
+	// DeviceClassMobile is a phone.
+	DeviceClassMobile DeviceClass = "mobile"
+
+	// DeviceClassTablet is a tablet.
+	DeviceClassTablet DeviceClass = "tablet"
+
+	// DeviceClassVoice is a voice assistant or smart speaker.
+	DeviceClassVoice DeviceClass = "voice"
+
+	// DeviceClassOther is any other device.
+	DeviceClassOther DeviceClass = "other"
+)
+
+// Channel represents the surface through which a buyer is interacting.
+type Channel string
+
+const (
+	// ChannelWeb is a web browser.
+	ChannelWeb Channel = "web"
+
+	// ChannelApp is a native application.
+	ChannelApp Channel = "app"
+
+	// ChannelChat is a conversational agent.
+	ChannelChat Channel = "chat"
+
+	// ChannelVoice is a voice interface.
+	ChannelVoice Channel = "voice"
+)
+
 // TotalType represents the type of total categorization.
 type TotalType string
 
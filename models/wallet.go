@@ -0,0 +1,103 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "encoding/json"
+
+const (
+	// PaymentInstrumentTypeWallet indicates a digital wallet payment instrument
+	// (e.g., Apple Pay, Google Pay).
+	PaymentInstrumentTypeWallet PaymentInstrumentType = "wallet"
+)
+
+// Instrument schema URLs referenced from PaymentHandlerResponse.InstrumentSchemas.
+const (
+	// InstrumentSchemaCard is the card payment instrument schema.
+	InstrumentSchemaCard = "https://ucp.dev/schemas/shopping/types/card_payment_instrument.json"
+
+	// InstrumentSchemaWallet is the digital wallet payment instrument schema.
+	InstrumentSchemaWallet = "https://ucp.dev/schemas/shopping/types/wallet_payment_instrument.json"
+)
+
+// WalletType identifies the wallet provider that produced a payment token.
+type WalletType string
+
+const (
+	// WalletTypeApplePay indicates an Apple Pay token.
+	WalletTypeApplePay WalletType = "apple_pay"
+
+	// WalletTypeGooglePay indicates a Google Pay token.
+	WalletTypeGooglePay WalletType = "google_pay"
+)
+
+// WalletPaymentToken is the encrypted payment token container returned by
+// a wallet. The payload is passed through opaquely to the payment processor.
+type WalletPaymentToken struct {
+	// Version is the wallet's token format version (e.g., "EC_v1", "ECv2").
+	Version string `json:"version,omitempty"`
+
+	// Data is the encrypted payment data.
+	Data string `json:"data"`
+
+	// Signature is the wallet's signature over the payment data, if separate.
+	Signature string `json:"signature,omitempty"`
+
+	// Header contains wallet-specific header fields (ephemeral keys, transaction IDs).
+	Header json.RawMessage `json:"header,omitempty"`
+}
+
+// WalletDisplay contains display information for a wallet payment instrument.
+type WalletDisplay struct {
+	// Network is the underlying card network (e.g., visa, mastercard).
+	Network string `json:"network,omitempty"`
+
+	// Description is the wallet-provided display name (e.g., "Visa 1234").
+	Description string `json:"description,omitempty"`
+
+	// DeviceAccountSuffix is the last digits of the device account number (DPAN).
+	DeviceAccountSuffix string `json:"device_account_suffix,omitempty"`
+
+	// CardArt is an optional URI to a rich image representing the card.
+	CardArt string `json:"card_art,omitempty"`
+}
+
+// WalletPaymentInstrument represents a payment instrument sourced from a digital wallet.
+type WalletPaymentInstrument struct {
+	PaymentInstrumentBase
+
+	// Wallet identifies the wallet provider.
+	Wallet WalletType `json:"wallet"`
+
+	// Token is the encrypted wallet payment token.
+	Token *WalletPaymentToken `json:"token,omitempty"`
+
+	// Display contains display information for this instrument.
+	Display *WalletDisplay `json:"display,omitempty"`
+}
+
+// ToPaymentInstrument converts a wallet instrument into the generic
+// PaymentInstrument used in checkout requests.
+func (w WalletPaymentInstrument) ToPaymentInstrument() PaymentInstrument {
+	return PaymentInstrument{
+		ID:             w.ID,
+		HandlerID:      w.HandlerID,
+		Type:           PaymentInstrumentTypeWallet,
+		BillingAddress: w.BillingAddress,
+		Credential:     w.Credential,
+		Wallet:         w.Wallet,
+		WalletToken:    w.Token,
+		WalletDisplay:  w.Display,
+	}
+}
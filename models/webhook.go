@@ -0,0 +1,139 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Checkout lifecycle webhook event types.
+const (
+	// WebhookEventCheckoutReadyForComplete is sent when a checkout reaches
+	// ready_for_complete. Its data is a CheckoutReadyForCompleteEvent.
+	WebhookEventCheckoutReadyForComplete = "checkout.ready_for_complete"
+
+	// WebhookEventCheckoutRequiresEscalation is sent when a checkout
+	// reaches requires_escalation. Its data is a
+	// CheckoutRequiresEscalationEvent.
+	WebhookEventCheckoutRequiresEscalation = "checkout.requires_escalation"
+
+	// WebhookEventCheckoutExpired is sent when a checkout is canceled
+	// because it expired. Its data is a CheckoutExpiredEvent.
+	WebhookEventCheckoutExpired = "checkout.expired"
+)
+
+// Order webhook event types.
+const (
+	// WebhookEventOrderCreated is sent when an order is placed. Its data
+	// is the order, as returned by the order endpoint.
+	WebhookEventOrderCreated = "order.created"
+
+	// WebhookEventOrderUpdated is sent when an order changes. Its data is
+	// the updated order.
+	WebhookEventOrderUpdated = "order.updated"
+
+	// WebhookEventOrderFulfillmentEventAppended is sent when a fulfillment
+	// event is appended to an order. Its data is an
+	// OrderFulfillmentEventAppendedEvent.
+	WebhookEventOrderFulfillmentEventAppended = "order.fulfillment_event_appended"
+
+	// WebhookEventOrderAdjustmentAdded is sent when an adjustment is added
+	// to an order. Its data is an OrderAdjustmentAddedEvent.
+	WebhookEventOrderAdjustmentAdded = "order.adjustment_added"
+
+	// WebhookEventOrderModificationDecided is sent when the merchant
+	// accepts or rejects a modification that was left pending. Its data is
+	// an OrderModificationDecidedEvent.
+	WebhookEventOrderModificationDecided = "order.modification_decided"
+)
+
+// WebhookEvent is the envelope of a webhook delivery.
+type WebhookEvent struct {
+	// ID uniquely identifies the event, for deduplicating redeliveries.
+	ID string `json:"id"`
+
+	// Type is the event type, such as WebhookEventCheckoutExpired.
+	Type string `json:"type"`
+
+	// CreatedAt is when the event occurred.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Data is the event payload; its shape depends on Type.
+	Data json.RawMessage `json:"data"`
+
+	// Platform is the UCP-Agent profile URL of the platform that owns the
+	// checkout or order. Events are only delivered to its subscriptions.
+	// It is not sent.
+	Platform string `json:"-"`
+}
+
+// CheckoutReadyForCompleteEvent is the data of a
+// checkout.ready_for_complete event.
+type CheckoutReadyForCompleteEvent struct {
+	// CheckoutID is the checkout that can now be completed.
+	CheckoutID string `json:"checkout_id"`
+
+	// Currency is the checkout currency.
+	Currency string `json:"currency"`
+
+	// Totals are the checkout totals the buyer would be charged.
+	Totals []TotalResponse `json:"totals"`
+
+	// ExpiresAt is when the checkout expires, if it does.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CheckoutRequiresEscalationEvent is the data of a
+// checkout.requires_escalation event.
+type CheckoutRequiresEscalationEvent struct {
+	// CheckoutID is the escalated checkout.
+	CheckoutID string `json:"checkout_id"`
+
+	// ContinueURL is where the buyer resolves the escalation.
+	ContinueURL string `json:"continue_url,omitempty"`
+
+	// Messages explain what the buyer must do.
+	Messages []Message `json:"messages,omitempty"`
+}
+
+// CheckoutExpiredEvent is the data of a checkout.expired event.
+type CheckoutExpiredEvent struct {
+	// CheckoutID is the expired checkout.
+	CheckoutID string `json:"checkout_id"`
+
+	// ExpiresAt is when the checkout expired.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// OrderFulfillmentEventAppendedEvent is the data of an
+// order.fulfillment_event_appended event.
+type OrderFulfillmentEventAppendedEvent struct {
+	// OrderID is the order the event was appended to.
+	OrderID string `json:"order_id"`
+
+	// Event is the appended fulfillment event.
+	Event FulfillmentEvent `json:"event"`
+}
+
+// OrderAdjustmentAddedEvent is the data of an order.adjustment_added
+// event.
+type OrderAdjustmentAddedEvent struct {
+	// OrderID is the adjusted order.
+	OrderID string `json:"order_id"`
+
+	// Adjustment is the added adjustment.
+	Adjustment Adjustment `json:"adjustment"`
+}
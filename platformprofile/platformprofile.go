@@ -0,0 +1,204 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package platformprofile generates and serves a platform's own discovery
+// profile.
+//
+// Merchants identify a calling platform by fetching the profile named in
+// its UCP-Agent header. A Profile builds that document from the
+// capabilities the platform supports as a caller and its signing keys,
+// serves it, and produces the client option that advertises the same URL:
+//
+//	profile, err := platformprofile.New("https://agent.example/.well-known/ucp", "2026-01-11",
+//		platformprofile.WithCapabilities(checkout, fulfillment),
+//		platformprofile.WithSigningKey("agent-1", key.Public()))
+//	profile.Mount(mux)
+//	c := client.NewClient(merchantURL, profile.ClientOption(),
+//		client.WithRequestSigning(key, "agent-1"))
+package platformprofile
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dhananjay2021/ucp-go-sdk/client"
+	"github.com/dhananjay2021/ucp-go-sdk/internal"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// DefaultMaxAge is the Cache-Control max-age, in seconds, of the served
+// profile.
+const DefaultMaxAge = 300
+
+// Profile is a platform's discovery profile. It is immutable once built
+// and safe for concurrent use.
+type Profile struct {
+	url          string
+	path         string
+	capabilities []models.CapabilityDiscovery
+	keys         []models.JWK
+	maxAge       int
+
+	doc  []byte
+	etag string
+	err  error
+}
+
+// Option configures a Profile.
+type Option func(*Profile)
+
+// WithCapabilities declares the capabilities the platform supports as a
+// caller. They are published in the profile and negotiated against
+// merchant profiles by clients built with ClientOption.
+func WithCapabilities(caps ...models.CapabilityDiscovery) Option {
+	return func(p *Profile) {
+		p.capabilities = append(p.capabilities, caps...)
+	}
+}
+
+// WithSigningKey publishes an ECDSA P-256 or RSA public key under kid, for
+// merchants verifying the platform's signatures.
+func WithSigningKey(kid string, key crypto.PublicKey) Option {
+	return func(p *Profile) {
+		jwk, err := internal.PublicJWK(kid, key)
+		if err != nil {
+			p.err = fmt.Errorf("signing key %s: %w", kid, err)
+			return
+		}
+		p.keys = append(p.keys, jwk)
+	}
+}
+
+// WithSigningKeys publishes keys already in JWK form.
+func WithSigningKeys(keys ...models.JWK) Option {
+	return func(p *Profile) {
+		p.keys = append(p.keys, keys...)
+	}
+}
+
+// WithMaxAge sets the Cache-Control max-age, in seconds, of the served
+// profile. Defaults to DefaultMaxAge.
+func WithMaxAge(seconds int) Option {
+	return func(p *Profile) {
+		p.maxAge = seconds
+	}
+}
+
+// New builds the profile to be served at profileURL, which must be an
+// absolute HTTP(S) URL. A URL without a path is served at
+// client.WellKnownPath. The signing keys must pass
+// validation.ValidateSigningKeyset.
+func New(profileURL string, version models.Version, opts ...Option) (*Profile, error) {
+	u, err := url.Parse(profileURL)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, fmt.Errorf("profile URL must be an absolute HTTP(S) URL: %q", profileURL)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = client.WellKnownPath
+	}
+	if !version.IsValid() {
+		return nil, fmt.Errorf("invalid version: %q", version)
+	}
+
+	p := &Profile{url: u.String(), path: u.Path, maxAge: DefaultMaxAge}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if err := validation.ValidateSigningKeyset(p.keys, 0); err != nil {
+		return nil, err
+	}
+
+	p.doc, err = json.Marshal(models.UCPProfile{
+		UCP: models.DiscoveryProfile{
+			Version:      version,
+			Capabilities: p.capabilities,
+		},
+		SigningKeys: p.keys,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode profile: %w", err)
+	}
+	sum := sha256.Sum256(p.doc)
+	p.etag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	return p, nil
+}
+
+// URL returns the profile URL sent in the UCP-Agent header.
+func (p *Profile) URL() string {
+	return p.url
+}
+
+// Path returns the path the profile is served at.
+func (p *Profile) Path() string {
+	return p.path
+}
+
+// Capabilities returns the declared caller capabilities.
+func (p *Profile) Capabilities() []models.CapabilityDiscovery {
+	return append([]models.CapabilityDiscovery(nil), p.capabilities...)
+}
+
+// Document returns a copy of the profile document.
+func (p *Profile) Document() *models.UCPProfile {
+	var doc models.UCPProfile
+	json.Unmarshal(p.doc, &doc)
+	return &doc
+}
+
+// ClientOption returns a client option that identifies the platform with
+// this profile's URL and negotiates with its capabilities, so the header a
+// merchant receives always names the document being served.
+func (p *Profile) ClientOption() client.ClientOption {
+	agent := client.WithUCPAgent(p.url)
+	caps := client.WithPlatformCapabilities(p.Capabilities())
+	return func(c *client.Client) {
+		agent(c)
+		caps(c)
+	}
+}
+
+// Mount registers the profile on mux at its path.
+func (p *Profile) Mount(mux *http.ServeMux) {
+	mux.Handle("GET "+p.path, p)
+}
+
+// ServeHTTP serves the profile document, answering conditional requests
+// with 304 Not Modified.
+func (p *Profile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", p.maxAge))
+	w.Header().Set("ETag", p.etag)
+	if r.Header.Get("If-None-Match") == p.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		w.Write(p.doc)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package roundtrip holds the wire-level compatibility suite for the client
+// and server packages.
+//
+// The tests drive a server.Server backed by a scenarios merchant with
+// client.Client for every operation and check each exchange three ways:
+// the request and response bodies must match golden files in testdata,
+// the request must survive decoding into the type the server reads, and
+// the response must survive decoding into the type the client returns.
+// Regenerate golden files after an intentional wire change with:
+//
+//	go test ./roundtrip -update
+package roundtrip
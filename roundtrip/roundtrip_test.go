@@ -0,0 +1,363 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtrip_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/client"
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/scenarios"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+	"github.com/dhananjay2021/ucp-go-sdk/server/ucpmem"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+const version models.Version = "2026-01-11"
+
+// exchange is one recorded HTTP request/response pair.
+type exchange struct {
+	method   string
+	path     string
+	request  []byte
+	status   int
+	response []byte
+}
+
+// baseURL is the merchant address; requests never leave the process, so
+// URLs in golden files are stable.
+const baseURL = "http://merchant.test"
+
+// recorder is an http.RoundTripper that serves requests from a handler
+// in-process and captures each exchange.
+type recorder struct {
+	handler   http.Handler
+	exchanges []exchange
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	in := req.Clone(req.Context())
+	in.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := httptest.NewRecorder()
+	r.handler.ServeHTTP(rec, in)
+
+	r.exchanges = append(r.exchanges, exchange{
+		method:   req.Method,
+		path:     req.URL.Path,
+		request:  body,
+		status:   rec.Code,
+		response: rec.Body.Bytes(),
+	})
+
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+// last returns the most recent exchange.
+func (r *recorder) last(t *testing.T) exchange {
+	t.Helper()
+	if len(r.exchanges) == 0 {
+		t.Fatal("no exchange recorded")
+	}
+	return r.exchanges[len(r.exchanges)-1]
+}
+
+// now is the fixed clock for created_at and updated_at stamps, so golden
+// files are stable.
+func now() time.Time {
+	return time.Date(2026, 1, 11, 12, 0, 0, 0, time.UTC)
+}
+
+func newServer() http.Handler {
+	config := server.Config{
+		Version: version,
+		Capabilities: []models.CapabilityDiscovery{
+			{CapabilityBase: models.CapabilityBase{Name: client.CapabilityCheckout, Version: version}},
+			{CapabilityBase: models.CapabilityBase{Name: client.CapabilityOrder, Version: version}},
+			{CapabilityBase: models.CapabilityBase{Name: client.CapabilityFulfillment, Version: version, Extends: client.CapabilityCheckout}},
+			{CapabilityBase: models.CapabilityBase{Name: client.CapabilityDiscount, Version: version, Extends: client.CapabilityCheckout}},
+		},
+		Services: models.Services{
+			client.ServiceShopping: models.UCPService{
+				Version: version,
+				Rest:    &models.RestTransport{Schema: "https://ucp.dev/services/shopping/rest.openapi.json", Endpoint: "/"},
+			},
+		},
+		PaymentHandlers: []models.PaymentHandlerResponse{{
+			ID:                "tok",
+			Name:              "dev.ucp.tokenization",
+			Version:           string(version),
+			InstrumentSchemas: []string{models.InstrumentSchemaCard},
+		}},
+		Clock: server.NewFakeClock(now()),
+	}
+	scenario := &scenarios.Scenario{
+		Items: []scenarios.Item{
+			{ID: "PROD-001", Title: "Wireless Headphones", Price: 14999, ImageURL: "https://example.com/p1.jpg"},
+			{ID: "PROD-002", Title: "Phone Case", Price: 2999},
+		},
+		Tax:       &scenarios.Tax{RateBasisPoints: 1000},
+		Shipping:  []scenarios.ShippingOption{{ID: "standard", Title: "Standard", Carrier: "Post", Amount: 500}},
+		Discounts: []scenarios.Discount{{Code: "SAVE5", Title: "$5 off", AmountOff: 500}},
+	}
+	merchant := scenario.MerchantConfig()
+	var ids atomic.Int64
+	merchant.NewID = func(prefix string) string {
+		return fmt.Sprintf("%s-%d", prefix, ids.Add(1))
+	}
+	srv, _ := ucpmem.NewServer(config, merchant)
+	return srv
+}
+
+func TestRoundTrip(t *testing.T) {
+	rec := &recorder{handler: newServer()}
+	c := client.NewClient(baseURL,
+		client.WithHTTPClient(&http.Client{Transport: rec}),
+		client.WithUCPAgent("https://platform.example/.well-known/ucp"),
+	)
+	ctx := context.Background()
+
+	// check compares the last exchange against its golden file, and
+	// verifies that the server's request type and the client's result
+	// preserve every field on the wire.
+	check := func(name string, serverReq interface{}, clientResult interface{}) {
+		t.Helper()
+		ex := rec.last(t)
+		compareGolden(t, name, ex)
+		if serverReq != nil && len(ex.request) > 0 {
+			assertLossless(t, name+" request", ex.request, serverReq)
+		}
+		if clientResult != nil && ex.status < 400 {
+			got, err := json.Marshal(clientResult)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			assertSameJSON(t, name+" response", ex.response, got)
+		}
+	}
+
+	profile, err := c.FetchProfile(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("discovery", nil, profile)
+
+	cart, err := c.CreateCart(ctx, &models.CartCreateRequest{
+		LineItems: []models.LineItemCreateRequest{{Item: models.ItemCreateRequest{ID: "PROD-001"}, Quantity: 1}},
+		Context:   &models.Context{AddressCountry: "US", Locale: "en-US"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("create_cart", &models.CartCreateRequest{}, cart)
+
+	got, err := c.GetCart(ctx, cart.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("get_cart", nil, got)
+
+	cart, err = c.UpdateCart(ctx, cart.ID, &models.CartUpdateRequest{
+		ID: cart.ID,
+		LineItems: []models.LineItemCreateRequest{
+			{Item: models.ItemCreateRequest{ID: "PROD-001"}, Quantity: 1},
+			{Item: models.ItemCreateRequest{ID: "PROD-002"}, Quantity: 2},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("update_cart", &models.CartUpdateRequest{}, cart)
+
+	checkout, err := c.CreateCheckoutFromCart(ctx, cart.ID, &extensions.ExtendedCheckoutCreateRequest{
+		Currency: "USD",
+		Buyer:    &models.BuyerWithConsentCreateRequest{Email: "buyer@example.com", FullName: "Jane Doe"},
+		Payment: models.PaymentCreateRequest{
+			SelectedInstrumentID: "pi_1",
+			Instruments: []models.PaymentInstrument{{
+				ID:        "pi_1",
+				HandlerID: "tok",
+				Type:      models.PaymentInstrumentTypeCard,
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("create_checkout_from_cart", &extensions.ExtendedCheckoutCreateRequest{}, checkout)
+	if len(checkout.LineItems) != 2 {
+		t.Errorf("checkout from cart has %d line items, want 2", len(checkout.LineItems))
+	}
+
+	checkout, err = c.GetCheckout(ctx, checkout.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("get_checkout", nil, checkout)
+
+	checkout, err = c.UpdateCheckout(ctx, checkout.ID, &extensions.ExtendedCheckoutUpdateRequest{
+		ID:       checkout.ID,
+		Currency: "USD",
+		Fulfillment: &models.FulfillmentUpdateRequest{Methods: []models.FulfillmentMethodUpdateRequest{{
+			ID: "ship",
+			Destinations: []models.FulfillmentDestinationRequest{{
+				PostalAddress: models.PostalAddress{StreetAddress: "1 Main St", AddressLocality: "Springfield", AddressRegion: "IL", PostalCode: "62701", AddressCountry: "US"},
+			}},
+		}}},
+		Discounts: &models.DiscountsUpdateRequest{Codes: []string{"SAVE5"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("update_checkout", &extensions.ExtendedCheckoutUpdateRequest{}, checkout)
+
+	checkout, err = c.CompleteCheckout(ctx, checkout.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("complete_checkout", nil, checkout)
+	if checkout.Order == nil {
+		t.Fatal("completed checkout has no order")
+	}
+
+	order, err := c.GetOrder(ctx, checkout.Order.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("get_order", nil, order)
+
+	other, err := c.CreateCheckout(ctx, &extensions.ExtendedCheckoutCreateRequest{
+		Currency:  "USD",
+		LineItems: []models.LineItemCreateRequest{{Item: models.ItemCreateRequest{ID: "PROD-002"}, Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("create_checkout", &extensions.ExtendedCheckoutCreateRequest{}, other)
+
+	other, err = c.CancelCheckout(ctx, other.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("cancel_checkout", nil, other)
+
+	if err := c.DeleteCart(ctx, cart.ID); err != nil {
+		t.Fatal(err)
+	}
+	check("delete_cart", nil, nil)
+
+	_, err = c.GetCheckout(ctx, "missing")
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *client.Error, got %v", err)
+	}
+	check("error_not_found", nil, nil)
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != "not_found" || len(apiErr.Messages) != 1 {
+		t.Errorf("unexpected error decoding: %+v", apiErr)
+	}
+}
+
+// compareGolden checks an exchange against testdata/<name>.golden.
+func compareGolden(t *testing.T, name string, ex exchange) {
+	t.Helper()
+	got := formatExchange(t, ex)
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%s: %v (run with -update to create)", name, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s: wire format drifted from %s\n--- got ---\n%s\n--- want ---\n%s", name, path, got, want)
+	}
+}
+
+// formatExchange renders an exchange with indented JSON bodies.
+func formatExchange(t *testing.T, ex exchange) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s\n", ex.method, ex.path)
+	writeIndented(t, &buf, ex.request)
+	fmt.Fprintf(&buf, "\n%d\n", ex.status)
+	writeIndented(t, &buf, ex.response)
+	return buf.Bytes()
+}
+
+func writeIndented(t *testing.T, buf *bytes.Buffer, body []byte) {
+	t.Helper()
+	if len(bytes.TrimSpace(body)) == 0 {
+		return
+	}
+	if err := json.Indent(buf, bytes.TrimSpace(body), "", "  "); err != nil {
+		t.Fatalf("invalid JSON body %q: %v", body, err)
+	}
+	buf.WriteByte('\n')
+}
+
+// assertLossless decodes wire into v and checks that re-encoding yields
+// the same JSON, i.e. that v's type knows every field on the wire.
+func assertLossless(t *testing.T, name string, wire []byte, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(wire, v); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	got, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	assertSameJSON(t, name, wire, got)
+}
+
+// assertSameJSON reports whether two documents are semantically equal.
+func assertSameJSON(t *testing.T, name string, want, got []byte) {
+	t.Helper()
+	var w, g interface{}
+	if err := json.Unmarshal(want, &w); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if !reflect.DeepEqual(w, g) {
+		t.Errorf("%s: fields lost in decoding\nwire:    %s\ndecoded: %s", name, want, got)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scenarios builds fully functioning test merchants from
+// declarative scenario files.
+//
+// A scenario defines catalog items, tax rates, shipping options, discount
+// codes, forced behaviors triggered by item IDs, and artificial latency:
+//
+//	{
+//	  "version": "2026-01-11",
+//	  "currency": "USD",
+//	  "items": [
+//	    {"id": "PROD-001", "title": "Headphones", "price": 14999},
+//	    {"id": "PROD-OOS", "title": "Sold Out", "price": 1000, "stock": 0}
+//	  ],
+//	  "tax": {"rate_basis_points": 875, "rates": {"US-CA": 725}},
+//	  "shipping": [{"id": "standard", "title": "Standard", "amount": 599}],
+//	  "discounts": [{"code": "SAVE10", "title": "10% off", "percent_off": 10}],
+//	  "triggers": [
+//	    {"item_id": "PROD-DECLINE", "on": ["complete"],
+//	     "error": {"status": 402, "code": "payment_declined", "message": "Declined"}}
+//	  ],
+//	  "latency": "150ms"
+//	}
+//
+// Scenario files are JSON so that the same fixtures can be shared across
+// SDKs without additional dependencies; YAML fixtures must be converted
+// first.
+//
+//	srv, err := scenarios.NewScenarioServer(config, "testdata/merchant.json")
+package scenarios
@@ -0,0 +1,174 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenarios
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+	"github.com/dhananjay2021/ucp-go-sdk/server/ucpmem"
+)
+
+// DefaultVersion is used when neither the scenario nor the server config
+// specifies a protocol version.
+const DefaultVersion models.Version = "2026-01-11"
+
+// NewScenarioServer loads a scenario file and builds a merchant server
+// from it. See NewServer.
+func NewScenarioServer(config server.Config, scenarioFile string) (*server.Server, error) {
+	s, err := LoadFile(scenarioFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewServer(config, s), nil
+}
+
+// NewServer builds a merchant server backed by the scenario, using an
+// ucpmem.Merchant with the scenario's catalog, tax, shipping, and
+// discounts and a hook applying its triggers. config supplies discovery
+// data (capabilities, services, payment handlers). The scenario's version
+// fills config.Version when unset.
+func NewServer(config server.Config, s *Scenario) *server.Server {
+	if config.Version == "" {
+		config.Version = s.Version
+	}
+	if config.Version == "" {
+		config.Version = DefaultVersion
+	}
+
+	srv, _ := ucpmem.NewServer(config, s.MerchantConfig())
+	if s.Latency > 0 {
+		delay := latencyMiddleware(time.Duration(s.Latency))
+		srv.Use(server.GroupCheckout, delay)
+		srv.Use(server.GroupOrder, delay)
+		srv.Use(server.GroupCart, delay)
+	}
+	return srv
+}
+
+// MerchantConfig converts the scenario to an ucpmem configuration.
+func (s *Scenario) MerchantConfig() ucpmem.Config {
+	catalog := server.NewMapCatalog()
+	for _, item := range s.Items {
+		catalog.Add(server.CatalogItem{ID: item.ID, Title: item.Title, Price: item.Price, ImageURL: item.ImageURL})
+		if item.Stock != nil {
+			catalog.SetStock(item.ID, *item.Stock)
+		}
+	}
+
+	config := ucpmem.Config{
+		Catalog:      catalog,
+		RateProvider: shippingRates(s.Shipping),
+		Currency:     s.Currency,
+		Hook:         s.hook,
+	}
+	if s.Tax != nil {
+		config.TaxCalculator = &server.FlatRateTaxCalculator{RateBasisPoints: s.Tax.RateBasisPoints, Rates: s.Tax.Rates}
+	}
+	for _, d := range s.Discounts {
+		config.Discounts = append(config.Discounts, ucpmem.Discount{
+			Code: d.Code, Title: d.Title, PercentOff: d.PercentOff, AmountOff: d.AmountOff,
+		})
+	}
+	return config
+}
+
+// latencyMiddleware delays requests by d, or until the request is canceled.
+func latencyMiddleware(d time.Duration) server.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t := time.NewTimer(d)
+			defer t.Stop()
+			select {
+			case <-t.C:
+				next.ServeHTTP(w, r)
+			case <-r.Context().Done():
+			}
+		})
+	}
+}
+
+// shippingRates offers the scenario's shipping options at fixed prices.
+type shippingRates []ShippingOption
+
+func (o shippingRates) Rates(ctx context.Context, method models.FulfillmentMethodType, lineItems []models.LineItemResponse, destination *models.PostalAddress) ([]models.FulfillmentOptionResponse, error) {
+	pickup := method == models.FulfillmentMethodTypePickup
+	var options []models.FulfillmentOptionResponse
+	for _, opt := range o {
+		if opt.Pickup != pickup {
+			continue
+		}
+		options = append(options, models.FulfillmentOptionResponse{
+			ID:      opt.ID,
+			Title:   opt.Title,
+			Carrier: opt.Carrier,
+			Totals:  []models.TotalResponse{{Type: models.TotalTypeTotal, Amount: opt.Amount}},
+		})
+	}
+	return options, nil
+}
+
+// hook applies the triggers that fire for a checkout at stage.
+func (s *Scenario) hook(ctx context.Context, stage ucpmem.Stage, checkout *extensions.ExtendedCheckoutResponse) error {
+	var fired []Trigger
+	for _, t := range s.Triggers {
+		if !hasItem(checkout.LineItems, t.ItemID) {
+			continue
+		}
+		for _, on := range t.On {
+			if on == stage {
+				fired = append(fired, t)
+				break
+			}
+		}
+	}
+
+	for _, t := range fired {
+		if t.Error != nil {
+			return server.NewAPIError(t.Error.Status, t.Error.Code, t.Error.Message).WithMessages(t.Messages...)
+		}
+	}
+
+	for _, t := range fired {
+		checkout.Messages = append(checkout.Messages, t.Messages...)
+	}
+	for _, t := range fired {
+		switch {
+		case t.Status == models.CheckoutStatusRequiresEscalation:
+			checkout.Status = t.Status
+			checkout.ContinueURL = t.ContinueURL
+			if checkout.ContinueURL == "" {
+				checkout.ContinueURL = server.ResolveURL(ctx, "/continue/"+checkout.ID)
+			}
+			return nil
+		case t.Status == models.CheckoutStatusCompleteInProgress && stage == StageComplete:
+			checkout.Status = t.Status
+		}
+	}
+	return nil
+}
+
+func hasItem(items []models.LineItemResponse, id string) bool {
+	for _, li := range items {
+		if li.Item.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenarios
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server/ucpmem"
+)
+
+// Scenario describes a test merchant.
+type Scenario struct {
+	// Name is an optional label for the scenario.
+	Name string `json:"name,omitempty"`
+
+	// Version is the protocol version reported in responses.
+	Version models.Version `json:"version,omitempty"`
+
+	// Currency is the ISO 4217 currency used for carts. Defaults to USD.
+	Currency string `json:"currency,omitempty"`
+
+	// Items is the product catalog.
+	Items []Item `json:"items"`
+
+	// Tax configures flat-rate tax. No tax is charged when nil.
+	Tax *Tax `json:"tax,omitempty"`
+
+	// Shipping lists the fulfillment options offered.
+	Shipping []ShippingOption `json:"shipping,omitempty"`
+
+	// Discounts lists the accepted discount codes.
+	Discounts []Discount `json:"discounts,omitempty"`
+
+	// Triggers force behaviors when a checkout contains specific items.
+	Triggers []Trigger `json:"triggers,omitempty"`
+
+	// Latency is added to every checkout, order, and cart request.
+	Latency Duration `json:"latency,omitempty"`
+}
+
+// Item is a catalog product.
+type Item struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Price    int    `json:"price"`
+	ImageURL string `json:"image_url,omitempty"`
+
+	// Stock limits the available quantity; unlimited when nil.
+	Stock *int `json:"stock,omitempty"`
+}
+
+// Tax configures flat-rate tax, mirroring server.FlatRateTaxCalculator.
+type Tax struct {
+	RateBasisPoints int            `json:"rate_basis_points"`
+	Rates           map[string]int `json:"rates,omitempty"`
+}
+
+// ShippingOption is a fulfillment option with a fixed price.
+type ShippingOption struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Carrier string `json:"carrier,omitempty"`
+	Amount  int    `json:"amount"`
+
+	// Pickup offers the option for pickup methods instead of shipping.
+	Pickup bool `json:"pickup,omitempty"`
+}
+
+// Discount is a code-based discount. Exactly one of PercentOff or
+// AmountOff should be set.
+type Discount struct {
+	Code       string `json:"code"`
+	Title      string `json:"title"`
+	PercentOff int    `json:"percent_off,omitempty"`
+	AmountOff  int    `json:"amount_off,omitempty"`
+}
+
+// Stage is a point in the checkout lifecycle where triggers fire.
+type Stage = ucpmem.Stage
+
+const (
+	// StageCreate fires when a checkout is created.
+	StageCreate = ucpmem.StageCreate
+
+	// StageUpdate fires when a checkout is updated.
+	StageUpdate = ucpmem.StageUpdate
+
+	// StageComplete fires when completion is requested.
+	StageComplete = ucpmem.StageComplete
+)
+
+// Trigger forces a behavior at the given stages when a checkout contains
+// ItemID. Error takes precedence over Status and Messages.
+type Trigger struct {
+	ItemID string  `json:"item_id"`
+	On     []Stage `json:"on"`
+
+	// Error fails the request.
+	Error *TriggerError `json:"error,omitempty"`
+
+	// Status forces the checkout status. requires_escalation is honored at
+	// any stage; complete_in_progress only at completion, after which the
+	// checkout completes on the next GET.
+	Status models.CheckoutStatus `json:"status,omitempty"`
+
+	// ContinueURL is set with requires_escalation; defaults to a merchant URL.
+	ContinueURL string `json:"continue_url,omitempty"`
+
+	// Messages are injected into the checkout, or into the error response.
+	Messages []models.Message `json:"messages,omitempty"`
+}
+
+// TriggerError is an error response forced by a trigger.
+type TriggerError struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Duration is a time.Duration that unmarshals from a Go duration string
+// ("250ms") or a number of milliseconds.
+type Duration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var ms float64
+	if err := json.Unmarshal(data, &ms); err == nil {
+		*d = Duration(ms * float64(time.Millisecond))
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string or milliseconds: %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Parse decodes and validates a JSON scenario.
+func Parse(data []byte) (*Scenario, error) {
+	var s Scenario
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("scenarios: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// LoadFile reads and validates a JSON scenario file.
+func LoadFile(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("scenarios: %w", err)
+	}
+	return Parse(data)
+}
+
+// Validate checks the scenario for internal consistency.
+func (s *Scenario) Validate() error {
+	items := make(map[string]bool, len(s.Items))
+	for i, item := range s.Items {
+		if item.ID == "" {
+			return fmt.Errorf("scenarios: items[%d]: id is required", i)
+		}
+		if items[item.ID] {
+			return fmt.Errorf("scenarios: items[%d]: duplicate id %q", i, item.ID)
+		}
+		if item.Price < 0 {
+			return fmt.Errorf("scenarios: items[%d]: price must not be negative", i)
+		}
+		items[item.ID] = true
+	}
+
+	for i, d := range s.Discounts {
+		if d.Code == "" {
+			return fmt.Errorf("scenarios: discounts[%d]: code is required", i)
+		}
+		if (d.PercentOff == 0) == (d.AmountOff == 0) {
+			return fmt.Errorf("scenarios: discounts[%d]: exactly one of percent_off or amount_off is required", i)
+		}
+		if d.PercentOff < 0 || d.PercentOff > 100 || d.AmountOff < 0 {
+			return fmt.Errorf("scenarios: discounts[%d]: discount out of range", i)
+		}
+	}
+
+	for i, t := range s.Triggers {
+		if !items[t.ItemID] {
+			return fmt.Errorf("scenarios: triggers[%d]: unknown item %q", i, t.ItemID)
+		}
+		if len(t.On) == 0 {
+			return fmt.Errorf("scenarios: triggers[%d]: on is required", i)
+		}
+		for _, stage := range t.On {
+			switch stage {
+			case StageCreate, StageUpdate, StageComplete:
+			default:
+				return fmt.Errorf("scenarios: triggers[%d]: unknown stage %q", i, stage)
+			}
+		}
+		switch t.Status {
+		case "", models.CheckoutStatusRequiresEscalation, models.CheckoutStatusCompleteInProgress:
+		default:
+			return fmt.Errorf("scenarios: triggers[%d]: unsupported status %q", i, t.Status)
+		}
+		if t.Error != nil && (t.Error.Status < 400 || t.Error.Status > 599) {
+			return fmt.Errorf("scenarios: triggers[%d]: error status must be 4xx or 5xx", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenarios_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/scenarios"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+func TestParseRejectsInvalidScenarios(t *testing.T) {
+	tests := map[string]string{
+		"duplicate item":  `{"items":[{"id":"A"},{"id":"A"}]}`,
+		"unknown trigger": `{"items":[{"id":"A"}],"triggers":[{"item_id":"B","on":["create"]}]}`,
+		"unknown stage":   `{"items":[{"id":"A"}],"triggers":[{"item_id":"A","on":["ship"]}]}`,
+		"two discounts":   `{"items":[],"discounts":[{"code":"X","percent_off":5,"amount_off":5}]}`,
+		"bad latency":     `{"items":[],"latency":"soon"}`,
+	}
+	for name, data := range tests {
+		if _, err := scenarios.Parse([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestScenarioServerCheckoutFlow(t *testing.T) {
+	srv, err := scenarios.NewScenarioServer(server.Config{}, "testdata/basic.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(itemID string) *extensions.ExtendedCheckoutResponse {
+		t.Helper()
+		body, _ := json.Marshal(extensions.ExtendedCheckoutCreateRequest{
+			Currency:  "USD",
+			LineItems: []models.LineItemCreateRequest{{Item: models.ItemCreateRequest{ID: itemID}, Quantity: 1}},
+			Buyer:     &models.BuyerWithConsentCreateRequest{Email: "buyer@example.com"},
+			Payment:   models.PaymentCreateRequest{SelectedInstrumentID: "pi_1"},
+			Discounts: &models.DiscountsCreateRequest{Codes: []string{"save5"}},
+		})
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/checkout-sessions", bytes.NewReader(body)))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("create %s: status %d: %s", itemID, rec.Code, rec.Body)
+		}
+		var checkout extensions.ExtendedCheckoutResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &checkout); err != nil {
+			t.Fatal(err)
+		}
+		return &checkout
+	}
+	complete := func(id string) int {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/checkout-sessions/"+id+"/complete", nil))
+		return rec.Code
+	}
+
+	checkout := create("PROD-001")
+	if checkout.Status != models.CheckoutStatusReadyForComplete {
+		t.Fatalf("status = %s, messages = %+v", checkout.Status, checkout.Messages)
+	}
+	want := map[models.TotalType]int{
+		models.TotalTypeSubtotal: 14999,
+		models.TotalTypeDiscount: 500,
+		models.TotalTypeTax:      1499,
+		models.TotalTypeTotal:    15998,
+	}
+	for _, total := range checkout.Totals {
+		if w, ok := want[total.Type]; ok && total.Amount != w {
+			t.Errorf("%s = %d, want %d", total.Type, total.Amount, w)
+		}
+	}
+	if code := complete(checkout.ID); code != http.StatusOK {
+		t.Errorf("complete: status %d", code)
+	}
+
+	if oos := create("PROD-OOS"); oos.Status != models.CheckoutStatusIncomplete {
+		t.Errorf("out of stock status = %s", oos.Status)
+	}
+
+	if code := complete(create("PROD-DECLINE").ID); code != http.StatusPaymentRequired {
+		t.Errorf("decline: status %d, want 402", code)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxBodyBytes is the default limit for buffered request bodies (1 MiB).
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+const rawBodyKey contextKey = "raw_body"
+
+// RawBody returns the raw request body buffered by the server, or nil if
+// the request had no body.
+//
+// The server reads the full body into memory before dispatching to typed
+// handlers so that signature verification and custom parsing remain possible
+// after JSON decoding. Bodies larger than Config.MaxBodyBytes are rejected
+// with 413 before any handler runs. The returned slice must not be modified.
+func RawBody(ctx context.Context) []byte {
+	if body, ok := ctx.Value(rawBodyKey).([]byte); ok {
+		return body
+	}
+	return nil
+}
+
+// bufferBody reads the request body up to limit bytes, stores it in the
+// request context, and replaces r.Body with a fresh reader over the buffer.
+func bufferBody(r *http.Request, limit int64) (*http.Request, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return r, nil
+	}
+	if _, ok := r.Context().Value(rawBodyKey).([]byte); ok {
+		return r, nil
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, limit))
+	r.Body.Close()
+	if err != nil {
+		return r, err
+	}
+
+	ctx := context.WithValue(r.Context(), rawBodyKey, data)
+	r = r.WithContext(ctx)
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	return r, nil
+}
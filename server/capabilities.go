@@ -0,0 +1,67 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Checkout extension capabilities with fields of their own.
+const (
+	capabilityFulfillment  models.CapabilityName = "dev.ucp.shopping.fulfillment"
+	capabilityDiscount     models.CapabilityName = "dev.ucp.shopping.discount"
+	capabilityBuyerConsent models.CapabilityName = "dev.ucp.shopping.buyer_consent"
+	capabilityProtection   models.CapabilityName = "dev.ucp.shopping.protection"
+)
+
+// capabilityActive reports, for checkout extensions with fields of their
+// own, whether a response exercises the extension.
+var capabilityActive = map[models.CapabilityName]func(*extensions.ExtendedCheckoutResponse) bool{
+	capabilityFulfillment: func(resp *extensions.ExtendedCheckoutResponse) bool {
+		return resp.Fulfillment != nil
+	},
+	capabilityDiscount: func(resp *extensions.ExtendedCheckoutResponse) bool {
+		return resp.Discounts != nil
+	},
+	capabilityBuyerConsent: func(resp *extensions.ExtendedCheckoutResponse) bool {
+		return resp.Buyer != nil && resp.Buyer.Consent != nil
+	},
+	capabilityProtection: func(resp *extensions.ExtendedCheckoutResponse) bool {
+		return resp.Protection != nil
+	},
+}
+
+// ActiveCapabilities returns the capabilities a checkout response declares
+// in ucp.capabilities: checkout and, of the configured extensions of
+// checkout, those the response exercises. Fulfillment, discount, buyer
+// consent, and protection are included only when the response carries
+// their data; other extensions whenever they are configured.
+//
+// Checkout responses whose handler leaves ucp.capabilities nil are filled
+// in this way from the server's configured capabilities.
+func ActiveCapabilities(configured []models.CapabilityDiscovery, resp *extensions.ExtendedCheckoutResponse) []models.CapabilityResponse {
+	var caps []models.CapabilityResponse
+	for _, c := range configured {
+		if c.Name != GroupCheckout && c.Extends != GroupCheckout {
+			continue
+		}
+		if active, ok := capabilityActive[c.Name]; ok && !active(resp) {
+			continue
+		}
+		caps = append(caps, models.CapabilityResponse{CapabilityBase: models.CapabilityBase{Name: c.Name, Version: c.Version}})
+	}
+	return caps
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// StoredCart is the part of a cart that carries over to a checkout
+// created from it with cart_id.
+type StoredCart struct {
+	// ID is the cart identifier.
+	ID string
+
+	// LineItems are the cart's items.
+	LineItems []models.LineItemCreateRequest
+
+	// Context is the buyer context the cart was priced for.
+	Context *models.Context
+
+	// Buyer is the buyer the cart was created for, if any.
+	Buyer *models.Buyer
+
+	// ConsumedBy is the checkout the cart was converted to, once it has
+	// been.
+	ConsumedBy string
+}
+
+// CartStore resolves carts for cart-to-checkout conversion.
+type CartStore interface {
+	// LoadCart returns a cart, or ErrCartNotFound.
+	LoadCart(ctx context.Context, id string) (*StoredCart, error)
+
+	// ConsumeCart marks a cart converted to checkoutID. It returns
+	// ErrCartConsumed if the cart was already converted to another
+	// checkout.
+	ConsumeCart(ctx context.Context, id, checkoutID string) error
+}
+
+var (
+	// ErrCartNotFound is returned when a cart does not exist.
+	ErrCartNotFound = errors.New("cart not found")
+
+	// ErrCartConsumed is returned when a cart was already converted to a
+	// checkout.
+	ErrCartConsumed = errors.New("cart already converted to a checkout")
+)
+
+// MemoryCartStore is an in-memory CartStore. Cart handlers save carts into
+// it as they create and update them.
+type MemoryCartStore struct {
+	mu    sync.Mutex
+	carts map[string]*StoredCart
+}
+
+// NewMemoryCartStore creates an empty in-memory cart store.
+func NewMemoryCartStore() *MemoryCartStore {
+	return &MemoryCartStore{carts: make(map[string]*StoredCart)}
+}
+
+// SaveCart stores a cart, replacing any cart with the same ID.
+func (m *MemoryCartStore) SaveCart(ctx context.Context, cart *StoredCart) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *cart
+	m.carts[cart.ID] = &cp
+	return nil
+}
+
+// DeleteCart removes a cart.
+func (m *MemoryCartStore) DeleteCart(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.carts, id)
+	return nil
+}
+
+// LoadCart implements CartStore.
+func (m *MemoryCartStore) LoadCart(ctx context.Context, id string) (*StoredCart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cart, ok := m.carts[id]
+	if !ok {
+		return nil, ErrCartNotFound
+	}
+	cp := *cart
+	return &cp, nil
+}
+
+// ConsumeCart implements CartStore.
+func (m *MemoryCartStore) ConsumeCart(ctx context.Context, id, checkoutID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cart, ok := m.carts[id]
+	if !ok {
+		return ErrCartNotFound
+	}
+	if cart.ConsumedBy != "" && cart.ConsumedBy != checkoutID {
+		return ErrCartConsumed
+	}
+	cart.ConsumedBy = checkoutID
+	return nil
+}
+
+// applyCart resolves a create request's cart_id from Config.Carts and
+// replaces the request's line items, context, and buyer with the cart's,
+// as the spec requires; a buyer's consent, which carts do not carry, is
+// kept. It returns the cart, or nil when there is nothing to apply.
+func (s *Server) applyCart(ctx context.Context, req *extensions.ExtendedCheckoutCreateRequest) (*StoredCart, error) {
+	if s.config.Carts == nil || req.CartID == "" {
+		return nil, nil
+	}
+	cart, err := s.config.Carts.LoadCart(ctx, req.CartID)
+	if err != nil {
+		return nil, cartError(err)
+	}
+	if cart.ConsumedBy != "" {
+		return nil, cartError(ErrCartConsumed)
+	}
+
+	req.LineItems = append([]models.LineItemCreateRequest(nil), cart.LineItems...)
+	if cart.Context != nil {
+		req.Context = cart.Context
+	}
+	if cart.Buyer != nil {
+		buyer := &models.BuyerWithConsentCreateRequest{
+			FirstName:   cart.Buyer.FirstName,
+			LastName:    cart.Buyer.LastName,
+			FullName:    cart.Buyer.FullName,
+			Email:       cart.Buyer.Email,
+			PhoneNumber: cart.Buyer.PhoneNumber,
+		}
+		if req.Buyer != nil {
+			buyer.Consent = req.Buyer.Consent
+		}
+		req.Buyer = buyer
+	}
+	return cart, nil
+}
+
+// consumeCart marks a converted cart consumed by the checkout created
+// from it.
+func (s *Server) consumeCart(ctx context.Context, cart *StoredCart, checkoutID string) error {
+	if cart == nil {
+		return nil
+	}
+	if err := s.config.Carts.ConsumeCart(ctx, cart.ID, checkoutID); err != nil {
+		return cartError(err)
+	}
+	return nil
+}
+
+// cartError maps CartStore errors to API errors.
+func cartError(err error) error {
+	switch {
+	case errors.Is(err, ErrCartNotFound):
+		return NotFoundError("Cart not found")
+	case errors.Is(err, ErrCartConsumed):
+		return ConflictError("Cart has already been converted to a checkout")
+	}
+	return err
+}
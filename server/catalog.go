@@ -0,0 +1,217 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// ErrItemNotFound is returned by a Catalog when an item ID is unknown.
+var ErrItemNotFound = errors.New("item not found")
+
+// CatalogItem is a product known to a merchant catalog.
+type CatalogItem struct {
+	// ID is the product identifier platforms send in line items.
+	ID string
+
+	// Title is the product title.
+	Title string
+
+	// Price is the list unit price in minor (cents) currency units.
+	Price int
+
+	// ImageURL is an optional product image.
+	ImageURL string
+}
+
+// Availability describes whether a quantity of an item can be sold.
+type Availability struct {
+	// Available is true when the requested quantity can be fulfilled.
+	Available bool
+
+	// Quantity is the quantity that can be fulfilled, if known (-1 if unlimited).
+	Quantity int
+
+	// Backordered is true when an available item ships once restocked.
+	Backordered bool
+}
+
+// Catalog provides product data and pricing for line items.
+type Catalog interface {
+	// LookupItem returns product details or ErrItemNotFound.
+	LookupItem(ctx context.Context, id string) (*CatalogItem, error)
+
+	// CheckAvailability reports whether quantity units of an item can be sold.
+	CheckAvailability(ctx context.Context, id string, quantity int) (Availability, error)
+
+	// PriceFor returns the unit price for an item given buyer context.
+	PriceFor(ctx context.Context, id string, quantity int, buyerCtx *models.Context) (int, error)
+}
+
+// MapCatalog is an in-memory Catalog with fixed prices and optional stock levels.
+type MapCatalog struct {
+	mu    sync.RWMutex
+	items map[string]CatalogItem
+	stock map[string]int
+}
+
+// NewMapCatalog creates a catalog from items. Items have unlimited stock
+// until SetStock is called.
+func NewMapCatalog(items ...CatalogItem) *MapCatalog {
+	c := &MapCatalog{
+		items: make(map[string]CatalogItem, len(items)),
+		stock: make(map[string]int),
+	}
+	for _, item := range items {
+		c.items[item.ID] = item
+	}
+	return c
+}
+
+// Add inserts or replaces an item.
+func (c *MapCatalog) Add(item CatalogItem) {
+	c.mu.Lock()
+	c.items[item.ID] = item
+	c.mu.Unlock()
+}
+
+// SetStock sets the available quantity for an item.
+func (c *MapCatalog) SetStock(id string, quantity int) {
+	c.mu.Lock()
+	c.stock[id] = quantity
+	c.mu.Unlock()
+}
+
+// LookupItem implements Catalog.
+func (c *MapCatalog) LookupItem(ctx context.Context, id string) (*CatalogItem, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	item, ok := c.items[id]
+	if !ok {
+		return nil, ErrItemNotFound
+	}
+	return &item, nil
+}
+
+// CheckAvailability implements Catalog.
+func (c *MapCatalog) CheckAvailability(ctx context.Context, id string, quantity int) (Availability, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if _, ok := c.items[id]; !ok {
+		return Availability{}, ErrItemNotFound
+	}
+	stock, limited := c.stock[id]
+	if !limited {
+		return Availability{Available: true, Quantity: -1}, nil
+	}
+	return Availability{Available: quantity <= stock, Quantity: stock}, nil
+}
+
+// PriceFor implements Catalog.
+func (c *MapCatalog) PriceFor(ctx context.Context, id string, quantity int, buyerCtx *models.Context) (int, error) {
+	item, err := c.LookupItem(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return item.Price, nil
+}
+
+// PricedLineItems is the result of pricing line items against a Catalog.
+type PricedLineItems struct {
+	// LineItems are the priced line items, excluding unknown or unavailable items.
+	LineItems []models.LineItemResponse
+
+	// Subtotal is the sum of line item subtotals in minor units.
+	Subtotal int
+
+	// Messages describe items that could not be priced.
+	Messages []models.Message
+}
+
+// PriceLineItems looks up and prices line items. Unknown items produce an
+// item_unavailable message, unavailable items a limited_quantity message if
+// some units are left and otherwise an out_of_stock message, each with a
+// JSONPath to the offending line item; such items are omitted from the
+// result. Backordered items are priced with a backordered warning. newID generates line item IDs. Errors other than
+// ErrItemNotFound abort pricing.
+func PriceLineItems(ctx context.Context, catalog Catalog, items []models.LineItemCreateRequest, buyerCtx *models.Context, newID func() string) (*PricedLineItems, error) {
+	result := &PricedLineItems{
+		LineItems: make([]models.LineItemResponse, 0, len(items)),
+	}
+
+	for i, li := range items {
+		path := fmt.Sprintf("$.line_items[%d]", i)
+
+		item, err := catalog.LookupItem(ctx, li.Item.ID)
+		if errors.Is(err, ErrItemNotFound) {
+			result.Messages = append(result.Messages, models.Message{
+				Type:     models.MessageTypeError,
+				Code:     string(models.ErrorCodeItemUnavailable),
+				Content:  fmt.Sprintf("Unknown product: %s", li.Item.ID),
+				Severity: models.SeverityRecoverable,
+				Path:     path,
+			})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		avail, err := catalog.CheckAvailability(ctx, li.Item.ID, li.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if !avail.Available {
+			if avail.Quantity > 0 {
+				result.Messages = append(result.Messages, validation.LimitedQuantityMessage(i, item.Title, avail.Quantity))
+			} else {
+				result.Messages = append(result.Messages, validation.OutOfStockMessage(i, item.Title))
+			}
+			continue
+		}
+		if avail.Backordered {
+			result.Messages = append(result.Messages, validation.BackorderedMessage(i, item.Title))
+		}
+
+		price, err := catalog.PriceFor(ctx, li.Item.ID, li.Quantity, buyerCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		lineTotal := price * li.Quantity
+		result.Subtotal += lineTotal
+		result.LineItems = append(result.LineItems, models.LineItemResponse{
+			ID: newID(),
+			Item: models.ItemResponse{
+				ID:       item.ID,
+				Title:    item.Title,
+				Price:    price,
+				ImageURL: item.ImageURL,
+			},
+			Quantity: li.Quantity,
+			Totals: []models.TotalResponse{
+				{Type: models.TotalTypeSubtotal, Amount: lineTotal},
+			},
+		})
+	}
+
+	return result, nil
+}
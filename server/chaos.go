@@ -0,0 +1,247 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// LatencyDistribution draws the delay ChaosMiddleware adds to a request.
+type LatencyDistribution func(r *rand.Rand) time.Duration
+
+// UniformLatency draws delays evenly between min and max.
+func UniformLatency(min, max time.Duration) LatencyDistribution {
+	return func(r *rand.Rand) time.Duration {
+		if max <= min {
+			return min
+		}
+		return min + time.Duration(r.Int64N(int64(max-min)))
+	}
+}
+
+// NormalLatency draws delays from a normal distribution, clamped at zero.
+func NormalLatency(mean, stddev time.Duration) LatencyDistribution {
+	return func(r *rand.Rand) time.Duration {
+		return max(0, mean+time.Duration(r.NormFloat64()*float64(stddev)))
+	}
+}
+
+// ExponentialLatency draws delays from an exponential distribution: mostly
+// short, with a long tail.
+func ExponentialLatency(mean time.Duration) LatencyDistribution {
+	return func(r *rand.Rand) time.Duration {
+		return time.Duration(r.ExpFloat64() * float64(mean))
+	}
+}
+
+// ChaosFaults are the faults ChaosMiddleware injects into a route. Rates
+// are fractions of requests, from 0 to 1.
+type ChaosFaults struct {
+	// Latency, if set, delays every request before it is handled.
+	Latency LatencyDistribution
+
+	// ErrorRate is the fraction of requests failed with ErrorStatus
+	// without reaching the handler.
+	ErrorRate float64
+
+	// ErrorStatus is the status of injected errors. Defaults to 503;
+	// 429 and 503 errors carry a Retry-After of one second.
+	ErrorStatus int
+
+	// EscalationRate is the fraction of successful checkout responses
+	// whose status is rewritten to requires_escalation, with a
+	// requires_buyer_review message. Completed and canceled checkouts are
+	// left alone, and the stored checkout is not changed.
+	EscalationRate float64
+}
+
+// ChaosConfig configures ChaosMiddleware.
+type ChaosConfig struct {
+	// Faults apply to operations without an entry in Routes.
+	Faults ChaosFaults
+
+	// Routes overrides Faults per operation.
+	Routes map[Operation]ChaosFaults
+
+	// Seed, if non-zero, makes the injected faults repeatable.
+	Seed uint64
+
+	// Clock times the injected latency. Defaults to SystemClock.
+	Clock Clock
+}
+
+// chaosEscalationCode is the code of the message added to checkouts forced
+// into requires_escalation.
+const chaosEscalationCode = "chaos_escalation"
+
+// ChaosMiddleware injects latency, errors, and escalations into a staging
+// merchant, so platforms can test how their agents cope with a slow or
+// failing merchant. Faults are chosen per operation, so register it on
+// route groups, e.g.
+//
+//	srv.Use(server.GroupCheckout, server.ChaosMiddleware(server.ChaosConfig{
+//		Faults: server.ChaosFaults{Latency: server.NormalLatency(300*time.Millisecond, 100*time.Millisecond)},
+//		Routes: map[server.Operation]server.ChaosFaults{
+//			server.OperationCompleteCheckout: {ErrorRate: 0.1, EscalationRate: 0.2},
+//		},
+//	}))
+//
+// Never register it on a production server.
+func ChaosMiddleware(config ChaosConfig) Middleware {
+	config.Clock = clockOrSystem(config.Clock)
+	seed := config.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	var mu sync.Mutex
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			op := RequestOperation(r.Context())
+			faults, ok := config.Routes[op]
+			if !ok {
+				faults = config.Faults
+			}
+
+			mu.Lock()
+			var delay time.Duration
+			if faults.Latency != nil {
+				delay = faults.Latency(rng)
+			}
+			fail := rng.Float64() < faults.ErrorRate
+			escalate := rng.Float64() < faults.EscalationRate
+			mu.Unlock()
+
+			if delay > 0 {
+				select {
+				case <-config.Clock.After(delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			if fail {
+				writeChaosError(w, faults.ErrorStatus)
+				return
+			}
+			if !escalate || !isCheckoutOperation(op) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &chaosWriter{ResponseWriter: w}
+			next.ServeHTTP(cw, r)
+			body := cw.body.Bytes()
+			if cw.statusCode == 0 {
+				cw.statusCode = http.StatusOK
+			}
+			if cw.statusCode < 300 {
+				if escalated, ok := escalateCheckout(body); ok {
+					body = escalated
+					w.Header().Del("ETag")
+					w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+				}
+			}
+			w.WriteHeader(cw.statusCode)
+			w.Write(body)
+		})
+	}
+}
+
+// isCheckoutOperation reports whether op responds with a checkout.
+func isCheckoutOperation(op Operation) bool {
+	switch op {
+	case OperationCreateCheckout, OperationGetCheckout, OperationUpdateCheckout, OperationCompleteCheckout:
+		return true
+	}
+	return false
+}
+
+// writeChaosError writes an injected error with the given status.
+func writeChaosError(w http.ResponseWriter, status int) {
+	if status == 0 {
+		status = http.StatusServiceUnavailable
+	}
+	code := models.ErrorCodeInternal
+	switch status {
+	case http.StatusTooManyRequests:
+		code = models.ErrorCodeLimitExceeded
+		w.Header().Set("Retry-After", "1")
+	case http.StatusServiceUnavailable:
+		w.Header().Set("Retry-After", "1")
+	}
+	WriteError(w, status, string(code), "Injected fault: "+http.StatusText(status))
+}
+
+// escalateCheckout rewrites a checkout response body to requires_escalation,
+// keeping its other fields as they are. It reports false for bodies that
+// are not open checkouts.
+func escalateCheckout(body []byte) ([]byte, bool) {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return nil, false
+	}
+	var status models.CheckoutStatus
+	if json.Unmarshal(fields["status"], &status) != nil || status == "" || status.IsTerminal() {
+		return nil, false
+	}
+	var messages []models.Message
+	if raw, ok := fields["messages"]; ok && json.Unmarshal(raw, &messages) != nil {
+		return nil, false
+	}
+	messages = append(messages, models.Message{
+		Type:        models.MessageTypeError,
+		Code:        chaosEscalationCode,
+		Content:     "The merchant needs the buyer to review this checkout before it can be completed.",
+		ContentType: models.ContentTypePlain,
+		Severity:    models.SeverityRequiresBuyerReview,
+	})
+	fields["status"], _ = json.Marshal(models.CheckoutStatusRequiresEscalation)
+	fields["messages"], _ = json.Marshal(messages)
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+// chaosWriter holds back a response's status and body so ChaosMiddleware
+// can rewrite them.
+type chaosWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *chaosWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *chaosWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.body.Write(b)
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// EventPublisher receives webhook events raised by the server, for
+// delivery to the subscriptions that want them. PublishEvent runs on the
+// request goroutine, so publishers should hand off to a queue.
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, event *models.WebhookEvent)
+}
+
+// EventPublisherFunc adapts a function to an EventPublisher.
+type EventPublisherFunc func(ctx context.Context, event *models.WebhookEvent)
+
+// PublishEvent implements EventPublisher.
+func (f EventPublisherFunc) PublishEvent(ctx context.Context, event *models.WebhookEvent) {
+	f(ctx, event)
+}
+
+// checkoutEvents remembers the last status seen for each open checkout, so
+// lifecycle events are raised once per transition rather than on every
+// response, and the platform that owns it, so they reach only that
+// platform. It is bounded like deltaCache.
+type checkoutEvents struct {
+	mu     sync.Mutex
+	max    int
+	status map[string]trackedCheckout
+}
+
+// trackedCheckout is an open checkout's last status and owning platform.
+type trackedCheckout struct {
+	status   models.CheckoutStatus
+	platform string
+}
+
+func newCheckoutEvents(max int) *checkoutEvents {
+	if max <= 0 {
+		max = DefaultDeltaCacheSize
+	}
+	return &checkoutEvents{max: max, status: make(map[string]trackedCheckout)}
+}
+
+// swap records a checkout's status and reports whether it changed, along
+// with the checkout's owner: the platform that first returned it, else
+// platform. Terminal checkouts are forgotten, and only reported as a
+// change if they were tracked, so repeated reads of a canceled checkout
+// raise nothing.
+func (c *checkoutEvents) swap(id string, status models.CheckoutStatus, platform string) (bool, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev, ok := c.status[id]
+	if prev.platform != "" {
+		platform = prev.platform
+	}
+	if status == models.CheckoutStatusCompleted || status == models.CheckoutStatusCanceled {
+		delete(c.status, id)
+		return ok, platform
+	}
+	if !ok && len(c.status) >= c.max {
+		for k := range c.status {
+			delete(c.status, k)
+			break
+		}
+	}
+	c.status[id] = trackedCheckout{status: status, platform: platform}
+	return !ok || prev.status != status, platform
+}
+
+// publishCheckoutEvents raises the lifecycle event for a checkout response
+// whose status changed: checkout.ready_for_complete and
+// checkout.requires_escalation on entering those statuses, and
+// checkout.expired for a checkout canceled at or after its expires_at.
+// Events go to the platform that owns the checkout, as named by platform,
+// the UCP-Agent profile of the request that returned it.
+func (s *Server) publishCheckoutEvents(ctx context.Context, platform string, checkout *extensions.ExtendedCheckoutResponse) {
+	if s.config.Events == nil || checkout == nil {
+		return
+	}
+	changed, owner := s.events.swap(checkout.ID, checkout.Status, platform)
+	if !changed {
+		return
+	}
+	switch checkout.Status {
+	case models.CheckoutStatusReadyForComplete:
+		s.publish(ctx, owner, models.WebhookEventCheckoutReadyForComplete, models.CheckoutReadyForCompleteEvent{
+			CheckoutID: checkout.ID,
+			Currency:   checkout.Currency,
+			Totals:     checkout.Totals,
+			ExpiresAt:  checkout.ExpiresAt,
+		})
+	case models.CheckoutStatusRequiresEscalation:
+		s.publish(ctx, owner, models.WebhookEventCheckoutRequiresEscalation, models.CheckoutRequiresEscalationEvent{
+			CheckoutID:  checkout.ID,
+			ContinueURL: checkout.ContinueURL,
+			Messages:    checkout.Messages,
+		})
+	case models.CheckoutStatusCanceled:
+		if checkout.ExpiresAt != nil && !s.config.Clock.Now().Before(*checkout.ExpiresAt) {
+			s.publish(ctx, owner, models.WebhookEventCheckoutExpired, models.CheckoutExpiredEvent{
+				CheckoutID: checkout.ID,
+				ExpiresAt:  checkout.ExpiresAt,
+			})
+		}
+	}
+}
+
+// PublishCheckoutExpired raises a checkout.expired event. The server raises
+// it itself when a handler returns a checkout canceled past its
+// expires_at; merchants that expire checkouts in the background, outside
+// any request, call it directly, after which the server does not raise it
+// again when the checkout is next read. The event goes to the platform
+// the server last saw the checkout returned to; one the server has not
+// seen since it started is not delivered. It does nothing without
+// Config.Events.
+func (s *Server) PublishCheckoutExpired(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse) {
+	if s.events == nil {
+		return
+	}
+	_, owner := s.events.swap(checkout.ID, models.CheckoutStatusCanceled, "")
+	s.publish(ctx, owner, models.WebhookEventCheckoutExpired, models.CheckoutExpiredEvent{
+		CheckoutID: checkout.ID,
+		ExpiresAt:  checkout.ExpiresAt,
+	})
+}
+
+// publish wraps data in an event envelope for platform and hands it to the
+// publisher.
+func (s *Server) publish(ctx context.Context, platform, eventType string, data any) {
+	if s.config.Events == nil {
+		return
+	}
+	event, err := NewWebhookEvent(eventType, data, s.config.Clock.Now())
+	if err != nil {
+		return
+	}
+	event.Platform = platform
+	s.config.Events.PublishEvent(ctx, event)
+}
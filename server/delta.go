@@ -0,0 +1,144 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/internal"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+const (
+	// ResponseVersionHeader carries the version token of the checkout a
+	// response describes when delta responses are enabled.
+	ResponseVersionHeader = "UCP-Response-Version"
+
+	// DeltaBaseHeader is sent by a client on a checkout update with the
+	// version token of the checkout it holds, requesting a delta response.
+	// The server echoes it on responses whose body is a delta.
+	DeltaBaseHeader = "UCP-Delta-Base"
+
+	// MergePatchContentType is the content type of a delta response body,
+	// an RFC 7396 JSON merge patch against the base version.
+	MergePatchContentType = "application/merge-patch+json"
+)
+
+// DefaultDeltaCacheSize is the number of checkout versions retained for
+// delta responses when Config.DeltaCacheSize is zero.
+const DefaultDeltaCacheSize = 4096
+
+// deltaVersion is the last checkout version sent to clients.
+type deltaVersion struct {
+	token string
+	doc   interface{}
+}
+
+// deltaCache holds the latest version of each checkout for computing
+// delta responses.
+type deltaCache struct {
+	mu       sync.Mutex
+	max      int
+	versions map[string]deltaVersion
+}
+
+func newDeltaCache(max int) *deltaCache {
+	if max <= 0 {
+		max = DefaultDeltaCacheSize
+	}
+	return &deltaCache{max: max, versions: make(map[string]deltaVersion)}
+}
+
+// swap records the latest version of a checkout and returns the previous
+// one. Terminal checkouts are forgotten, since they cannot be updated.
+func (c *deltaCache) swap(id string, status models.CheckoutStatus, next deltaVersion) (deltaVersion, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev, ok := c.versions[id]
+	if status == models.CheckoutStatusCompleted || status == models.CheckoutStatusCanceled {
+		delete(c.versions, id)
+		return prev, ok
+	}
+	if !ok && len(c.versions) >= c.max {
+		// Evict an arbitrary entry; its next update gets a full response.
+		for k := range c.versions {
+			delete(c.versions, k)
+			break
+		}
+	}
+	c.versions[id] = next
+	return prev, ok
+}
+
+// writeCheckout writes a checkout response after reporting missing
+// required fields and deprecated capability versions. With delta responses enabled it sets
+// ResponseVersionHeader, and answers an update carrying a DeltaBaseHeader
+// that matches the last version sent with a merge patch. A GET selecting
+// fields with FieldsParam gets just those fields.
+func (s *Server) writeCheckout(w http.ResponseWriter, r *http.Request, statusCode int, resp *extensions.ExtendedCheckoutResponse) {
+	if resp != nil && s.config.StrictMode {
+		if err := verifyCheckout(resp); err != nil {
+			s.handleError(w, err)
+			return
+		}
+	}
+	if resp != nil {
+		if resp.UCP.Capabilities == nil {
+			resp.UCP.Capabilities = ActiveCapabilities(s.config.Capabilities, resp)
+		}
+		s.applyRequiredFields(resp)
+		resp.Messages = addMessages(resp.Messages, s.markDeprecations(w, resp.UCP.Capabilities))
+		noteCapabilities(r.Context(), resp.UCP.Capabilities)
+		platform, _ := PlatformProfileURL(r)
+		s.publishCheckoutEvents(r.Context(), platform, resp)
+	}
+	if fields := selectedFields(r); fields != nil && resp != nil {
+		s.writeSelected(w, statusCode, resp, fields)
+		return
+	}
+	if s.deltas == nil || resp == nil {
+		WriteJSON(w, statusCode, resp)
+		return
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+	doc, canonical, err := internal.CanonicalJSON(data)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+	token := internal.VersionToken(canonical)
+	prev, ok := s.deltas.swap(resp.ID, resp.Status, deltaVersion{token: token, doc: doc})
+	w.Header().Set(ResponseVersionHeader, token)
+	w.Header().Add("Vary", DeltaBaseHeader)
+
+	base := r.Header.Get(DeltaBaseHeader)
+	if r.Method != http.MethodPatch || base == "" || !ok || prev.token != base {
+		WriteJSON(w, statusCode, resp)
+		return
+	}
+	w.Header().Set(DeltaBaseHeader, base)
+	w.Header().Set("Content-Type", MergePatchContentType)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(internal.MergePatch(prev.doc, doc))
+}
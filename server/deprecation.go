@@ -0,0 +1,133 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+const (
+	// DeprecationHeader carries the deprecation date (RFC 9745) of a
+	// capability version used by the response.
+	DeprecationHeader = "Deprecation"
+
+	// SunsetHeader carries the date (RFC 8594) after which a deprecated
+	// capability version used by the response stops being served.
+	SunsetHeader = "Sunset"
+)
+
+// CapabilityDeprecation marks a capability version as deprecated.
+type CapabilityDeprecation struct {
+	// Name is the deprecated capability.
+	Name models.CapabilityName
+
+	// Version is the deprecated version of the capability.
+	Version models.Version
+
+	// DeprecatedAt is when the version was deprecated. When zero, the
+	// Deprecation header is sent as "true".
+	DeprecatedAt time.Time
+
+	// Sunset is when the version stops being served. Optional.
+	Sunset time.Time
+
+	// Link is a URL documenting the deprecation and migration. Optional.
+	Link string
+}
+
+// message returns the warning Message for a response using the
+// deprecated version at the given capabilities index.
+func (d CapabilityDeprecation) message(index int) models.Message {
+	content := fmt.Sprintf("%s version %s is deprecated", d.Name, d.Version)
+	if !d.Sunset.IsZero() {
+		content += " and will be removed on " + d.Sunset.UTC().Format("2006-01-02")
+	}
+	if d.Link != "" {
+		content += "; see " + d.Link
+	}
+	return models.Message{
+		Type:    models.MessageTypeWarning,
+		Code:    models.MessageCodeDeprecatedVersion,
+		Content: content,
+		Path:    fmt.Sprintf("$.ucp.capabilities[%d]", index),
+	}
+}
+
+// markDeprecations sets Deprecation, Sunset, and Link headers for any
+// deprecated capability versions among caps and returns a warning Message
+// for each. With several, the headers carry the earliest dates.
+func (s *Server) markDeprecations(w http.ResponseWriter, caps []models.CapabilityResponse) []models.Message {
+	if len(s.config.Deprecations) == 0 {
+		return nil
+	}
+
+	var msgs []models.Message
+	var deprecatedAt, sunset time.Time
+	deprecated := false
+	for i, c := range caps {
+		for _, d := range s.config.Deprecations {
+			if d.Name != c.Name || d.Version != c.Version {
+				continue
+			}
+			deprecated = true
+			if !d.DeprecatedAt.IsZero() && (deprecatedAt.IsZero() || d.DeprecatedAt.Before(deprecatedAt)) {
+				deprecatedAt = d.DeprecatedAt
+			}
+			if !d.Sunset.IsZero() && (sunset.IsZero() || d.Sunset.Before(sunset)) {
+				sunset = d.Sunset
+			}
+			if d.Link != "" {
+				w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, d.Link))
+			}
+			msgs = append(msgs, d.message(i))
+		}
+	}
+	if !deprecated {
+		return nil
+	}
+
+	if deprecatedAt.IsZero() {
+		w.Header().Set(DeprecationHeader, "true")
+	} else {
+		w.Header().Set(DeprecationHeader, "@"+strconv.FormatInt(deprecatedAt.Unix(), 10))
+	}
+	if !sunset.IsZero() {
+		w.Header().Set(SunsetHeader, sunset.UTC().Format(http.TimeFormat))
+	}
+	return msgs
+}
+
+// addMessages appends msgs to existing, skipping any already present with
+// the same code and path.
+func addMessages(existing []models.Message, msgs []models.Message) []models.Message {
+	for _, msg := range msgs {
+		present := false
+		for _, m := range existing {
+			if m.Code == msg.Code && m.Path == msg.Path {
+				present = true
+				break
+			}
+		}
+		if !present {
+			existing = append(existing, msg)
+		}
+	}
+	return existing
+}
@@ -0,0 +1,393 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Delegations an embedded host may take over, as listed in
+// EmbeddedTransportConfig.Delegate.
+const (
+	// DelegatePaymentInstruments lets the host select and change payment
+	// instruments.
+	DelegatePaymentInstruments = "payment.instruments_change"
+
+	// DelegatePaymentCredential lets the host supply payment credentials.
+	DelegatePaymentCredential = "payment.credential"
+
+	// DelegateFulfillmentAddress lets the host change fulfillment
+	// destinations.
+	DelegateFulfillmentAddress = "fulfillment.address_change"
+)
+
+// JSON-RPC 2.0 error codes returned by EmbeddedBridge.
+const (
+	jsonrpcParseError     = -32700
+	jsonrpcInvalidRequest = -32600
+	jsonrpcMethodNotFound = -32601
+	jsonrpcInvalidParams  = -32602
+
+	// jsonrpcHandlerError carries a UCP error response as its data.
+	jsonrpcHandlerError = -32000
+)
+
+// embeddedMethod is the route an embedded JSON-RPC method dispatches to.
+type embeddedMethod struct {
+	httpMethod string
+	path       string
+	resource   string
+	withID     bool
+}
+
+// embeddedMethods maps the embedded binding's methods, named like the MCP
+// tools, to the server's checkout and cart routes.
+var embeddedMethods = map[string]embeddedMethod{
+	"create_checkout":   {http.MethodPost, "/checkout-sessions", "checkout", false},
+	"get_checkout":      {http.MethodGet, "/checkout-sessions/", "checkout", true},
+	"update_checkout":   {http.MethodPatch, "/checkout-sessions/", "checkout", true},
+	"complete_checkout": {http.MethodPost, "/checkout-sessions/%s/complete", "checkout", true},
+	"cancel_checkout":   {http.MethodPost, "/checkout-sessions/%s/cancel", "checkout", true},
+	"create_cart":       {http.MethodPost, "/carts", "cart", false},
+	"get_cart":          {http.MethodGet, "/carts/", "cart", true},
+	"update_cart":       {http.MethodPatch, "/carts/", "cart", true},
+	"delete_cart":       {http.MethodDelete, "/carts/", "cart", true},
+}
+
+// EmbeddedBridge serves the embedded transport binding: JSON-RPC 2.0
+// messages relayed from an embedded checkout, whether over postMessage
+// through a WebSocket or over any other stream. Each call is dispatched
+// through the Server's own routes, so the registered handlers, route
+// middleware, and response processing apply exactly as for REST.
+//
+// Methods are named like the MCP tools (create_checkout, get_checkout,
+// update_checkout, complete_checkout, cancel_checkout, create_cart,
+// get_cart, update_cart, and delete_cart). Params carry the resource ID
+// as "id", the request body under "checkout" or "cart", and an optional
+// "meta" object with the platform's "ucp-agent" profile and an
+// "idempotency-key". The result is the response body; error responses
+// become JSON-RPC errors with code -32000 and the UCP error body as data.
+//
+// Updates that change payment instruments, supply payment credentials, or
+// change fulfillment destinations are refused with forbidden unless the
+// checkout's embedded_config accepts the corresponding delegation.
+type EmbeddedBridge struct {
+	// Header is added to every dispatched request, e.g. the Authorization
+	// and UCP-Agent of the embedding session.
+	Header http.Header
+
+	// Delegate lists the delegations accepted for create_checkout, where
+	// there is no checkout embedded_config to consult yet.
+	Delegate []string
+
+	server *Server
+}
+
+// NewEmbeddedBridge creates an EmbeddedBridge dispatching to s.
+func NewEmbeddedBridge(s *Server) *EmbeddedBridge {
+	return &EmbeddedBridge{server: s}
+}
+
+// jsonrpcRequest is a JSON-RPC 2.0 request or notification.
+type jsonrpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// jsonrpcReply is a JSON-RPC 2.0 response.
+type jsonrpcReply struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *jsonrpcError   `json:"error,omitempty"`
+}
+
+// jsonrpcError is a JSON-RPC 2.0 error object.
+type jsonrpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// embeddedParams are the params of every embedded method.
+type embeddedParams struct {
+	ID       string          `json:"id"`
+	Checkout json.RawMessage `json:"checkout"`
+	Cart     json.RawMessage `json:"cart"`
+	Meta     struct {
+		UCPAgent *struct {
+			Profile string `json:"profile"`
+		} `json:"ucp-agent"`
+		IdempotencyKey string `json:"idempotency-key"`
+	} `json:"meta"`
+}
+
+// HandleMessage handles one JSON-RPC message or batch and returns the
+// reply to send back, or nil if there is none (for notifications).
+func (b *EmbeddedBridge) HandleMessage(ctx context.Context, msg []byte) []byte {
+	msg = bytes.TrimSpace(msg)
+	if len(msg) > 0 && msg[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(msg, &batch); err != nil || len(batch) == 0 {
+			return encodeReply(errorReply(nil, jsonrpcInvalidRequest, "invalid batch"))
+		}
+		var replies []*jsonrpcReply
+		for _, m := range batch {
+			if reply := b.handle(ctx, m); reply != nil {
+				replies = append(replies, reply)
+			}
+		}
+		if len(replies) == 0 {
+			return nil
+		}
+		data, _ := json.Marshal(replies)
+		return data
+	}
+	if reply := b.handle(ctx, msg); reply != nil {
+		return encodeReply(reply)
+	}
+	return nil
+}
+
+// Serve reads JSON-RPC messages from r until it is exhausted or ctx is
+// done, writing each reply to w followed by a newline. Messages are
+// handled in order.
+func (b *EmbeddedBridge) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	bw := bufio.NewWriter(w)
+	for ctx.Err() == nil {
+		var msg json.RawMessage
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				bw.Write(encodeReply(errorReply(nil, jsonrpcParseError, "parse error")))
+				bw.WriteByte('\n')
+				bw.Flush()
+			}
+			return err
+		}
+		reply := b.HandleMessage(ctx, msg)
+		if reply == nil {
+			continue
+		}
+		bw.Write(reply)
+		bw.WriteByte('\n')
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// handle handles one JSON-RPC message and returns its reply, or nil for a
+// notification.
+func (b *EmbeddedBridge) handle(ctx context.Context, msg json.RawMessage) *jsonrpcReply {
+	var req jsonrpcRequest
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return errorReply(nil, jsonrpcParseError, "parse error")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorReply(req.ID, jsonrpcInvalidRequest, "invalid request")
+	}
+	reply := b.call(ctx, &req)
+	if len(req.ID) == 0 {
+		return nil
+	}
+	reply.JSONRPC, reply.ID = "2.0", req.ID
+	return reply
+}
+
+// call dispatches a request to the server.
+func (b *EmbeddedBridge) call(ctx context.Context, req *jsonrpcRequest) *jsonrpcReply {
+	method, ok := embeddedMethods[req.Method]
+	if !ok {
+		return errorReply(nil, jsonrpcMethodNotFound, "method not found: "+req.Method)
+	}
+	var params embeddedParams
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorReply(nil, jsonrpcInvalidParams, "invalid params: "+err.Error())
+		}
+	}
+	if method.withID && params.ID == "" {
+		return errorReply(nil, jsonrpcInvalidParams, "invalid params: id is required")
+	}
+	body := params.Checkout
+	if method.resource == "cart" {
+		body = params.Cart
+	}
+
+	header := b.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	if agent := params.Meta.UCPAgent; agent != nil && agent.Profile != "" {
+		header.Set(UCPAgentHeader, fmt.Sprintf("profile=%q", agent.Profile))
+	}
+	if params.Meta.IdempotencyKey != "" {
+		header.Set("Idempotency-Key", params.Meta.IdempotencyKey)
+	}
+
+	if method.resource == "checkout" && len(body) > 0 {
+		if err := b.checkDelegations(ctx, header, params.ID, body); err != nil {
+			rec := httptest.NewRecorder()
+			b.server.handleError(rec, err)
+			return responseReply(rec.Code, rec.Body.Bytes())
+		}
+	}
+
+	path := method.path
+	switch {
+	case !method.withID:
+	case strings.Contains(path, "%s"):
+		path = fmt.Sprintf(path, url.PathEscape(params.ID))
+	default:
+		path += url.PathEscape(params.ID)
+	}
+	return responseReply(b.dispatch(ctx, method.httpMethod, path, header, body))
+}
+
+// responseReply converts a route response into a JSON-RPC reply.
+func responseReply(status int, body []byte) *jsonrpcReply {
+	if status >= 300 {
+		var envelope ErrorResponse
+		json.Unmarshal(body, &envelope)
+		message := envelope.Message
+		if message == "" {
+			message = http.StatusText(status)
+		}
+		reply := errorReply(nil, jsonrpcHandlerError, message)
+		if json.Valid(body) {
+			reply.Error.Data = bytes.TrimSpace(body)
+		}
+		return reply
+	}
+	if len(body) == 0 {
+		body = []byte("null")
+	}
+	return &jsonrpcReply{Result: bytes.TrimSpace(body)}
+}
+
+// dispatch serves one request through the server and returns the
+// response status and body.
+func (b *EmbeddedBridge) dispatch(ctx context.Context, method, path string, header http.Header, body []byte) (int, []byte) {
+	var reader io.Reader
+	if len(body) > 0 {
+		reader = bytes.NewReader(body)
+	}
+	req := httptest.NewRequest(method, b.server.config.BasePath+path, reader).WithContext(ctx)
+	req.Header = header.Clone()
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	b.server.ServeHTTP(rec, req)
+	return rec.Code, rec.Body.Bytes()
+}
+
+// checkDelegations refuses a checkout request body that does what the
+// checkout, or for creation the bridge, has not delegated to the host.
+func (b *EmbeddedBridge) checkDelegations(ctx context.Context, header http.Header, id string, body json.RawMessage) error {
+	var fields struct {
+		Payment *struct {
+			Instruments          []map[string]json.RawMessage `json:"instruments"`
+			SelectedInstrumentID *string                      `json:"selected_instrument_id"`
+		} `json:"payment"`
+		Fulfillment *struct {
+			Methods []struct {
+				Destinations json.RawMessage `json:"destinations"`
+			} `json:"methods"`
+		} `json:"fulfillment"`
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return BadRequestError("invalid request body: " + err.Error())
+	}
+
+	var needed []string
+	if p := fields.Payment; p != nil && (len(p.Instruments) > 0 || p.SelectedInstrumentID != nil) {
+		needed = append(needed, DelegatePaymentInstruments)
+		for _, instrument := range p.Instruments {
+			if _, ok := instrument["credential"]; ok {
+				needed = append(needed, DelegatePaymentCredential)
+				break
+			}
+		}
+	}
+	if f := fields.Fulfillment; f != nil {
+		for _, m := range f.Methods {
+			if len(m.Destinations) > 0 {
+				needed = append(needed, DelegateFulfillmentAddress)
+				break
+			}
+		}
+	}
+	if len(needed) == 0 {
+		return nil
+	}
+
+	accepted := b.Delegate
+	if id != "" {
+		status, current := b.dispatch(ctx, http.MethodGet, "/checkout-sessions/"+url.PathEscape(id), header, nil)
+		if status >= 300 {
+			return NewAPIError(status, string(models.ErrorCodeNotFound), "checkout not found")
+		}
+		var checkout struct {
+			EmbeddedConfig *models.EmbeddedTransportConfig `json:"embedded_config"`
+		}
+		json.Unmarshal(current, &checkout)
+		accepted = nil
+		if checkout.EmbeddedConfig != nil {
+			accepted = checkout.EmbeddedConfig.Delegate
+		}
+	}
+	for _, d := range needed {
+		if !slices.Contains(accepted, d) {
+			return ForbiddenError("delegation " + d + " is not accepted for this checkout")
+		}
+	}
+	return nil
+}
+
+// errorReply builds an error reply.
+func errorReply(id json.RawMessage, code int, message string) *jsonrpcReply {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &jsonrpcReply{JSONRPC: "2.0", ID: id, Error: &jsonrpcError{Code: code, Message: message}}
+}
+
+// encodeReply encodes a reply.
+func encodeReply(reply *jsonrpcReply) []byte {
+	data, _ := json.Marshal(reply)
+	return data
+}
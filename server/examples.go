@@ -0,0 +1,437 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// ExamplesPath is where the examples document is served, relative to the
+// base path.
+const ExamplesPath = "/.well-known/ucp/examples"
+
+// ExamplesConfig enables the examples endpoint and supplies the catalog
+// data examples are built around.
+type ExamplesConfig struct {
+	// Currency is the ISO 4217 currency of example amounts. Defaults to
+	// "USD".
+	Currency string
+
+	// ItemID, ItemTitle, and ItemPrice describe the example line item.
+	// Default to a placeholder item priced at 2500 minor units.
+	ItemID    string
+	ItemTitle string
+	ItemPrice int
+}
+
+// Example is a sample request and response for one operation.
+type Example struct {
+	// Capability is the capability the example demonstrates.
+	Capability models.CapabilityName `json:"capability"`
+
+	// Operation is the endpoint called.
+	Operation Operation `json:"operation"`
+
+	// Method and Path are the HTTP request line, with IDs filled in.
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// Request is the request body, if the operation takes one.
+	Request any `json:"request,omitempty"`
+
+	// Response is the response body.
+	Response any `json:"response"`
+}
+
+// ExamplesDocument is the body served at ExamplesPath.
+type ExamplesDocument struct {
+	// Version is the protocol version of the examples.
+	Version models.Version `json:"version"`
+
+	// Examples are ordered as an integration proceeds: checkout creation
+	// through completion, then orders and carts.
+	Examples []Example `json:"examples"`
+}
+
+// GenerateExamples builds example payloads for the capabilities in config,
+// using its payment handler IDs, fulfillment method types, and base path
+// so that the examples match what the server will accept. Extension
+// fields (fulfillment, discounts, buyer consent) appear only when their
+// capability is configured.
+func GenerateExamples(config Config, examples ExamplesConfig, now time.Time) *ExamplesDocument {
+	g := exampleGenerator{config: config, examples: examples, now: now.UTC().Truncate(time.Second)}
+	if g.examples.Currency == "" {
+		g.examples.Currency = "USD"
+	}
+	if g.examples.ItemID == "" {
+		g.examples.ItemID = "item_123"
+	}
+	if g.examples.ItemTitle == "" {
+		g.examples.ItemTitle = "Example Item"
+	}
+	if g.examples.ItemPrice == 0 {
+		g.examples.ItemPrice = 2500
+	}
+	for _, c := range config.Capabilities {
+		if g.caps == nil {
+			g.caps = make(map[models.CapabilityName]bool)
+		}
+		g.caps[c.Name] = true
+	}
+	return g.document()
+}
+
+// exampleGenerator holds the inputs shared by the example builders.
+type exampleGenerator struct {
+	config   Config
+	examples ExamplesConfig
+	caps     map[models.CapabilityName]bool
+	now      time.Time
+}
+
+// Example IDs used across the generated payloads.
+const (
+	exampleCheckoutID    = "chk_123"
+	exampleLineItemID    = "li_1"
+	exampleOrderID       = "ord_123"
+	exampleCartID        = "cart_123"
+	exampleInstrumentID  = "pi_1"
+	exampleMethodID      = "fm_1"
+	exampleDestinationID = "dest_1"
+	exampleGroupID       = "fg_1"
+	exampleOptionID      = "std"
+	exampleShippingPrice = 500
+	exampleDiscountCode  = "WELCOME10"
+)
+
+func (g *exampleGenerator) document() *ExamplesDocument {
+	doc := &ExamplesDocument{Version: g.config.Version, Examples: []Example{}}
+	base := g.config.BasePath
+
+	if g.caps[GroupCheckout] {
+		created := g.checkout(models.CheckoutStatusIncomplete, false)
+		doc.Examples = append(doc.Examples,
+			Example{
+				Capability: GroupCheckout,
+				Operation:  OperationCreateCheckout,
+				Method:     http.MethodPost,
+				Path:       base + "/checkout-sessions",
+				Request:    g.createRequest(),
+				Response:   created,
+			},
+			Example{
+				Capability: GroupCheckout,
+				Operation:  OperationUpdateCheckout,
+				Method:     http.MethodPatch,
+				Path:       base + "/checkout-sessions/" + exampleCheckoutID,
+				Request:    g.updateRequest(),
+				Response:   g.checkout(models.CheckoutStatusReadyForComplete, true),
+			})
+
+		completed := g.checkout(models.CheckoutStatusCompleted, true)
+		completed.Order = &models.OrderConfirmation{ID: exampleOrderID, PermalinkURL: "https://merchant.example/orders/" + exampleOrderID}
+		doc.Examples = append(doc.Examples,
+			Example{
+				Capability: GroupCheckout,
+				Operation:  OperationCompleteCheckout,
+				Method:     http.MethodPost,
+				Path:       base + "/checkout-sessions/" + exampleCheckoutID + "/complete",
+				Response:   completed,
+			},
+			Example{
+				Capability: GroupCheckout,
+				Operation:  OperationCancelCheckout,
+				Method:     http.MethodPost,
+				Path:       base + "/checkout-sessions/" + exampleCheckoutID + "/cancel",
+				Response:   g.checkout(models.CheckoutStatusCanceled, false),
+			})
+	}
+
+	if g.caps[GroupOrder] {
+		doc.Examples = append(doc.Examples, Example{
+			Capability: GroupOrder,
+			Operation:  OperationGetOrder,
+			Method:     http.MethodGet,
+			Path:       base + "/orders/" + exampleOrderID,
+			Response:   g.order(),
+		})
+	}
+
+	if g.caps[GroupCart] {
+		doc.Examples = append(doc.Examples, Example{
+			Capability: GroupCart,
+			Operation:  OperationCreateCart,
+			Method:     http.MethodPost,
+			Path:       base + "/carts",
+			Request: &models.CartCreateRequest{
+				LineItems: []models.LineItemCreateRequest{g.lineItemRequest()},
+			},
+			Response: &models.CartResponse{
+				ID:        exampleCartID,
+				LineItems: []models.LineItemResponse{g.lineItem()},
+				Currency:  g.examples.Currency,
+				Totals: []models.TotalResponse{
+					{Type: models.TotalTypeSubtotal, Amount: g.examples.ItemPrice},
+					{Type: models.TotalTypeTotal, Amount: g.examples.ItemPrice},
+				},
+				CreatedAt: &g.now,
+				UpdatedAt: &g.now,
+			},
+		})
+	}
+	return doc
+}
+
+// fulfillmentType returns the first fulfillment method type the merchant
+// allows, defaulting to shipping.
+func (g *exampleGenerator) fulfillmentType() models.FulfillmentMethodType {
+	if f := g.config.Fulfillment; f != nil && len(f.AllowsMethodCombinations) > 0 && len(f.AllowsMethodCombinations[0]) > 0 {
+		return f.AllowsMethodCombinations[0][0]
+	}
+	return models.FulfillmentMethodTypeShipping
+}
+
+// handlerID returns the first configured payment handler ID.
+func (g *exampleGenerator) handlerID() string {
+	if len(g.config.PaymentHandlers) > 0 {
+		return g.config.PaymentHandlers[0].ID
+	}
+	return "example_handler"
+}
+
+func (g *exampleGenerator) instrument() models.PaymentInstrument {
+	return models.PaymentInstrument{
+		ID:          exampleInstrumentID,
+		HandlerID:   g.handlerID(),
+		Type:        models.PaymentInstrumentTypeCard,
+		Brand:       "visa",
+		LastDigits:  "1111",
+		ExpiryMonth: 12,
+		ExpiryYear:  g.now.Year() + 2,
+		Credential: &models.PaymentCredential{
+			Type:           string(models.PaymentInstrumentTypeCard),
+			CardNumberType: models.CardNumberTypeNetworkToken,
+			Number:         "4111111111111111",
+			ExpiryMonth:    12,
+			ExpiryYear:     g.now.Year() + 2,
+		},
+	}
+}
+
+func (g *exampleGenerator) address() models.PostalAddress {
+	return models.PostalAddress{
+		FullName:        "Jane Doe",
+		StreetAddress:   "1600 Amphitheatre Pkwy",
+		AddressLocality: "Mountain View",
+		AddressRegion:   "CA",
+		PostalCode:      "94043",
+		AddressCountry:  "US",
+	}
+}
+
+func (g *exampleGenerator) lineItemRequest() models.LineItemCreateRequest {
+	return models.LineItemCreateRequest{Item: models.ItemCreateRequest{ID: g.examples.ItemID}, Quantity: 1}
+}
+
+func (g *exampleGenerator) lineItem() models.LineItemResponse {
+	return models.LineItemResponse{
+		ID:       exampleLineItemID,
+		Item:     models.ItemResponse{ID: g.examples.ItemID, Title: g.examples.ItemTitle, Price: g.examples.ItemPrice},
+		Quantity: 1,
+		Totals:   []models.TotalResponse{{Type: models.TotalTypeSubtotal, Amount: g.examples.ItemPrice}},
+	}
+}
+
+// totals returns checkout totals, including the example discount when
+// discounts are configured and the fulfillment charge once an option is
+// selected.
+func (g *exampleGenerator) totals(withFulfillment bool) []models.TotalResponse {
+	totals := []models.TotalResponse{{Type: models.TotalTypeSubtotal, Amount: g.examples.ItemPrice}}
+	total := g.examples.ItemPrice
+	if d := g.discount(); d != nil {
+		totals = append(totals, models.TotalResponse{Type: models.TotalTypeDiscount, Amount: d.Amount})
+		total -= d.Amount
+	}
+	if withFulfillment && g.caps[capabilityFulfillment] {
+		totals = append(totals, models.TotalResponse{Type: models.TotalTypeFulfillment, Amount: exampleShippingPrice})
+		total += exampleShippingPrice
+	}
+	return append(totals, models.TotalResponse{Type: models.TotalTypeTotal, Amount: total})
+}
+
+// discount returns the discount applied by exampleDiscountCode, or nil if
+// discounts are not configured.
+func (g *exampleGenerator) discount() *models.AppliedDiscount {
+	if !g.caps[capabilityDiscount] {
+		return nil
+	}
+	return &models.AppliedDiscount{Title: "10% off your first order", Amount: g.examples.ItemPrice / 10, Code: exampleDiscountCode}
+}
+
+func (g *exampleGenerator) createRequest() *extensions.ExtendedCheckoutCreateRequest {
+	req := &extensions.ExtendedCheckoutCreateRequest{
+		LineItems: []models.LineItemCreateRequest{g.lineItemRequest()},
+		Currency:  g.examples.Currency,
+		Payment: models.PaymentCreateRequest{
+			Instruments:          []models.PaymentInstrument{g.instrument()},
+			SelectedInstrumentID: exampleInstrumentID,
+		},
+		Buyer: &models.BuyerWithConsentCreateRequest{FullName: "Jane Doe", Email: "jane@example.com"},
+	}
+	if g.caps[capabilityBuyerConsent] {
+		yes := true
+		req.Buyer.Consent = &models.Consent{Marketing: &yes}
+	}
+	if g.caps[capabilityFulfillment] {
+		method := models.FulfillmentMethodCreateRequest{Type: g.fulfillmentType()}
+		if method.Type == models.FulfillmentMethodTypeShipping {
+			method.Destinations = []models.FulfillmentDestinationRequest{{PostalAddress: g.address()}}
+		}
+		req.Fulfillment = &models.FulfillmentCreateRequest{Methods: []models.FulfillmentMethodCreateRequest{method}}
+	}
+	if g.caps[capabilityDiscount] {
+		req.Discounts = &models.DiscountsCreateRequest{Codes: []string{exampleDiscountCode}}
+	}
+	return req
+}
+
+func (g *exampleGenerator) updateRequest() *extensions.ExtendedCheckoutUpdateRequest {
+	req := &extensions.ExtendedCheckoutUpdateRequest{
+		ID:        exampleCheckoutID,
+		LineItems: []models.LineItemUpdateRequest{{ID: exampleLineItemID, Item: models.ItemUpdateRequest{ID: g.examples.ItemID}, Quantity: 1}},
+		Currency:  g.examples.Currency,
+		Payment:   models.PaymentUpdateRequest{SelectedInstrumentID: exampleInstrumentID},
+	}
+	if g.caps[capabilityFulfillment] {
+		destination, option := exampleDestinationID, exampleOptionID
+		req.Fulfillment = &models.FulfillmentUpdateRequest{Methods: []models.FulfillmentMethodUpdateRequest{{
+			ID:                    exampleMethodID,
+			LineItemIDs:           []string{exampleLineItemID},
+			SelectedDestinationID: &destination,
+			Groups:                []models.FulfillmentGroupUpdateRequest{{ID: exampleGroupID, SelectedOptionID: &option}},
+		}}}
+	}
+	return req
+}
+
+// checkout builds a checkout response in the given status. selected
+// reports whether a fulfillment option has been chosen.
+func (g *exampleGenerator) checkout(status models.CheckoutStatus, selected bool) *extensions.ExtendedCheckoutResponse {
+	checkout := &extensions.ExtendedCheckoutResponse{
+		UCP:       models.ResponseCheckout{Version: g.config.Version, Capabilities: g.responseCapabilities()},
+		ID:        exampleCheckoutID,
+		LineItems: []models.LineItemResponse{g.lineItem()},
+		Status:    status,
+		Currency:  g.examples.Currency,
+		Totals:    g.totals(selected),
+		Links:     []models.Link{},
+		CreatedAt: &g.now,
+		UpdatedAt: &g.now,
+		Payment: models.PaymentResponse{
+			Handlers:             g.config.PaymentHandlers,
+			Instruments:          []models.PaymentInstrument{g.instrument()},
+			SelectedInstrumentID: exampleInstrumentID,
+		},
+	}
+	checkout.Payment.Instruments[0].Credential = nil
+	if checkout.Payment.Handlers == nil {
+		checkout.Payment.Handlers = []models.PaymentHandlerResponse{}
+	}
+	if g.caps[capabilityFulfillment] {
+		checkout.Fulfillment = g.fulfillment(selected)
+	}
+	if d := g.discount(); d != nil {
+		checkout.Discounts = &models.DiscountsResponse{Codes: []string{exampleDiscountCode}, Applied: []models.AppliedDiscount{*d}}
+	}
+	if status == models.CheckoutStatusIncomplete && g.caps[capabilityFulfillment] {
+		checkout.Messages = []models.Message{{
+			Type:     models.MessageTypeError,
+			Code:     string(models.ErrorCodeMissing),
+			Content:  "Select a fulfillment option",
+			Severity: models.SeverityRecoverable,
+			Path:     "$.fulfillment.methods[0].groups[0].selected_option_id",
+		}}
+	}
+	return checkout
+}
+
+func (g *exampleGenerator) fulfillment(selected bool) *models.FulfillmentResponse {
+	method := models.FulfillmentMethodResponse{
+		ID:          exampleMethodID,
+		Type:        g.fulfillmentType(),
+		LineItemIDs: []string{exampleLineItemID},
+		Destinations: []models.FulfillmentDestinationResponse{{
+			ID:            exampleDestinationID,
+			PostalAddress: g.address(),
+		}},
+		Groups: []models.FulfillmentGroupResponse{{
+			ID:          exampleGroupID,
+			LineItemIDs: []string{exampleLineItemID},
+			Options: []models.FulfillmentOptionResponse{{
+				ID:     exampleOptionID,
+				Title:  "Standard",
+				Totals: []models.TotalResponse{{Type: models.TotalTypeTotal, Amount: exampleShippingPrice}},
+			}},
+		}},
+	}
+	destination := exampleDestinationID
+	method.SelectedDestinationID = &destination
+	if selected {
+		option := exampleOptionID
+		method.Groups[0].SelectedOptionID = &option
+	}
+	return &models.FulfillmentResponse{Methods: []models.FulfillmentMethodResponse{method}}
+}
+
+// responseCapabilities returns the checkout capabilities a response
+// declares: checkout and the extensions of it that are configured.
+func (g *exampleGenerator) responseCapabilities() []models.CapabilityResponse {
+	var caps []models.CapabilityResponse
+	for _, c := range g.config.Capabilities {
+		if c.Name == GroupCheckout || c.Extends == GroupCheckout {
+			caps = append(caps, models.CapabilityResponse{CapabilityBase: models.CapabilityBase{Name: c.Name, Version: c.Version}})
+		}
+	}
+	return caps
+}
+
+func (g *exampleGenerator) order() *models.Order {
+	item := g.lineItem()
+	return &models.Order{
+		ID:           exampleOrderID,
+		CheckoutID:   exampleCheckoutID,
+		PermalinkURL: "https://merchant.example/orders/" + exampleOrderID,
+		LineItems: []models.OrderLineItem{{
+			ID:       item.ID,
+			Item:     item.Item,
+			Quantity: models.OrderLineItemQuantity{Total: 1},
+			Totals:   item.Totals,
+			Status:   models.OrderLineItemStatusProcessing,
+		}},
+		Currency:  g.examples.Currency,
+		Totals:    g.totals(true),
+		CreatedAt: &g.now,
+		UpdatedAt: &g.now,
+	}
+}
+
+// handleExamples serves the examples document.
+func (s *Server) handleExamples(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, GenerateExamples(s.config, *s.config.Examples, s.config.Clock.Now()))
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// FieldsParam is the query parameter with which a GET of a checkout or
+// order selects the top-level fields of the response, as a comma-separated
+// list such as fields=status,messages. The ucp and id fields are always
+// included, and unknown names are ignored. Partial checkout responses are
+// never sent as deltas.
+const FieldsParam = "fields"
+
+// selectedFields returns the fields a GET request selects with
+// FieldsParam, or nil if it selects none.
+func selectedFields(r *http.Request) map[string]bool {
+	if r.Method != http.MethodGet || !r.URL.Query().Has(FieldsParam) {
+		return nil
+	}
+	fields := map[string]bool{"ucp": true, "id": true}
+	for _, values := range r.URL.Query()[FieldsParam] {
+		for _, name := range strings.Split(values, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				fields[name] = true
+			}
+		}
+	}
+	return fields
+}
+
+// writeSelected writes the selected top-level fields of resp.
+func (s *Server) writeSelected(w http.ResponseWriter, statusCode int, resp any, fields map[string]bool) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		s.handleError(w, err)
+		return
+	}
+	for name := range doc {
+		if !fields[name] {
+			delete(doc, name)
+		}
+	}
+	WriteJSON(w, statusCode, doc)
+}
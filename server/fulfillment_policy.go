@@ -0,0 +1,198 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Fulfillment policy message codes.
+const (
+	// CodeMethodCombinationNotAllowed indicates the requested mix of
+	// fulfillment method types is not supported by the merchant.
+	CodeMethodCombinationNotAllowed = "method_combination_not_allowed"
+
+	// CodeMultiDestinationNotAllowed indicates the merchant does not allow
+	// more than one destination for a fulfillment method type.
+	CodeMultiDestinationNotAllowed = "multi_destination_not_allowed"
+)
+
+// fulfillmentMethodRef is the subset of a requested fulfillment method that
+// policy checks need.
+type fulfillmentMethodRef struct {
+	typ             models.FulfillmentMethodType
+	hasDestinations bool
+}
+
+// CheckFulfillmentCreate validates a fulfillment create request against the
+// merchant's fulfillment configuration.
+func CheckFulfillmentCreate(cfg *models.MerchantFulfillmentConfig, req *models.FulfillmentCreateRequest) []models.Message {
+	if cfg == nil || req == nil {
+		return nil
+	}
+	refs := make([]fulfillmentMethodRef, len(req.Methods))
+	for i, m := range req.Methods {
+		refs[i] = fulfillmentMethodRef{
+			typ:             m.Type,
+			hasDestinations: len(m.Destinations) > 0 || m.SelectedDestinationID != nil,
+		}
+	}
+	return checkFulfillmentPolicy(cfg, refs)
+}
+
+// CheckFulfillmentUpdate validates a fulfillment update request against the
+// merchant's fulfillment configuration. Update requests do not carry method
+// types, so they are resolved by method ID from current; methods whose type
+// cannot be resolved are skipped.
+func CheckFulfillmentUpdate(cfg *models.MerchantFulfillmentConfig, req *models.FulfillmentUpdateRequest, current *models.FulfillmentResponse) []models.Message {
+	if cfg == nil || req == nil {
+		return nil
+	}
+	types := make(map[string]models.FulfillmentMethodType)
+	if current != nil {
+		for _, m := range current.Methods {
+			types[m.ID] = m.Type
+		}
+	}
+	refs := make([]fulfillmentMethodRef, len(req.Methods))
+	for i, m := range req.Methods {
+		refs[i] = fulfillmentMethodRef{
+			typ:             types[m.ID],
+			hasDestinations: len(m.Destinations) > 0 || m.SelectedDestinationID != nil,
+		}
+	}
+	return checkFulfillmentPolicy(cfg, refs)
+}
+
+func checkFulfillmentPolicy(cfg *models.MerchantFulfillmentConfig, methods []fulfillmentMethodRef) []models.Message {
+	var messages []models.Message
+
+	present := make(map[models.FulfillmentMethodType]bool)
+	destinations := make(map[models.FulfillmentMethodType][]int)
+	for i, m := range methods {
+		if m.typ == "" {
+			continue
+		}
+		present[m.typ] = true
+		if m.hasDestinations {
+			destinations[m.typ] = append(destinations[m.typ], i)
+		}
+	}
+
+	if len(present) > 1 && len(cfg.AllowsMethodCombinations) > 0 && !combinationAllowed(cfg.AllowsMethodCombinations, present) {
+		messages = append(messages, models.Message{
+			Type:     models.MessageTypeError,
+			Code:     CodeMethodCombinationNotAllowed,
+			Content:  fmt.Sprintf("Fulfillment method combination %s is not supported", describeTypes(present)),
+			Severity: models.SeverityRecoverable,
+			Path:     "$.fulfillment.methods",
+		})
+	}
+
+	for typ, indexes := range destinations {
+		if len(indexes) <= 1 || multiDestinationAllowed(cfg.AllowsMultiDestination, typ) {
+			continue
+		}
+		for _, i := range indexes[1:] {
+			messages = append(messages, models.Message{
+				Type:     models.MessageTypeError,
+				Code:     CodeMultiDestinationNotAllowed,
+				Content:  fmt.Sprintf("Only one %s destination is supported", typ),
+				Severity: models.SeverityRecoverable,
+				Path:     fmt.Sprintf("$.fulfillment.methods[%d].destinations", i),
+			})
+		}
+	}
+
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Path < messages[j].Path })
+	return messages
+}
+
+// combinationAllowed reports whether the present types are a subset of any
+// allowed combination.
+func combinationAllowed(allowed [][]models.FulfillmentMethodType, present map[models.FulfillmentMethodType]bool) bool {
+	for _, combo := range allowed {
+		set := make(map[models.FulfillmentMethodType]bool, len(combo))
+		for _, t := range combo {
+			set[t] = true
+		}
+		ok := true
+		for t := range present {
+			if !set[t] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func multiDestinationAllowed(cfg *models.AllowsMultiDestination, typ models.FulfillmentMethodType) bool {
+	if cfg == nil {
+		return false
+	}
+	switch typ {
+	case models.FulfillmentMethodTypeShipping:
+		return cfg.Shipping
+	case models.FulfillmentMethodTypePickup:
+		return cfg.Pickup
+	}
+	return false
+}
+
+func describeTypes(present map[models.FulfillmentMethodType]bool) string {
+	names := make([]string, 0, len(present))
+	for t := range present {
+		names = append(names, string(t))
+	}
+	sort.Strings(names)
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// fulfillmentPolicyError wraps policy messages in a 400 APIError.
+func fulfillmentPolicyError(messages []models.Message) *APIError {
+	return NewAPIError(http.StatusBadRequest, string(models.ErrorCodeInvalidFulfillment), messages[0].Content).WithMessages(messages...)
+}
+
+// checkFulfillmentUpdate enforces Config.Fulfillment on an update request,
+// resolving method types from the current checkout when a GetCheckout
+// handler is registered.
+func (s *Server) checkFulfillmentUpdate(r *http.Request, id string, req *models.FulfillmentUpdateRequest) error {
+	if s.config.Fulfillment == nil || req == nil {
+		return nil
+	}
+	var current *models.FulfillmentResponse
+	if s.getCheckout != nil {
+		checkout, err := s.getCheckout(r, id)
+		if err != nil {
+			return err
+		}
+		if checkout != nil {
+			current = checkout.Fulfillment
+		}
+	}
+	if msgs := CheckFulfillmentUpdate(s.config.Fulfillment, req, current); len(msgs) > 0 {
+		return fulfillmentPolicyError(msgs)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+const geoContextKey contextKey = "geo_context"
+
+// GeoResolver derives buyer context hints from a client IP address, e.g.
+// backed by a MaxMind GeoIP2 database or a hosted lookup service.
+type GeoResolver interface {
+	// Resolve returns context hints for ip. A nil context with a nil error
+	// means the address could not be located.
+	Resolve(ctx context.Context, ip net.IP) (*models.Context, error)
+}
+
+// GeoResolverFunc adapts a function to the GeoResolver interface.
+type GeoResolverFunc func(ctx context.Context, ip net.IP) (*models.Context, error)
+
+// Resolve implements GeoResolver.
+func (f GeoResolverFunc) Resolve(ctx context.Context, ip net.IP) (*models.Context, error) {
+	return f(ctx, ip)
+}
+
+// GeoContextMiddleware resolves the client IP to a default buyer context
+// and stores it in the request context. Create checkout and create cart
+// requests that omit context receive a copy of it before reaching the
+// handler. Lookup failures are ignored, since context is only a hint.
+//
+// When trustForwarded is true, the first X-Forwarded-For address is used
+// instead of the connection's remote address.
+func GeoContextMiddleware(resolver GeoResolver, trustForwarded bool) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := ClientIP(r, trustForwarded)
+			if ip == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			geo, err := resolver.Resolve(r.Context(), ip)
+			if err != nil || geo == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := context.WithValue(r.Context(), geoContextKey, geo)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GeoContext returns a copy of the context derived by GeoContextMiddleware,
+// or nil if none was resolved.
+func GeoContext(ctx context.Context) *models.Context {
+	if geo, ok := ctx.Value(geoContextKey).(*models.Context); ok {
+		cp := *geo
+		return &cp
+	}
+	return nil
+}
+
+// ClientIP returns the client address of r, or nil if it cannot be parsed.
+func ClientIP(r *http.Request, trustForwarded bool) net.IP {
+	if trustForwarded {
+		if xff := firstHeaderValue(r.Header.Get("X-Forwarded-For")); xff != "" {
+			if ip := net.ParseIP(xff); ip != nil {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
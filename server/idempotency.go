@@ -0,0 +1,370 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// IdempotentReplayedHeader marks a response replayed for a repeated
+// Idempotency-Key.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// DefaultIdempotencyTTL is how long IdempotencyMiddleware remembers a
+// response by default.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// DefaultIdempotencyLease is how long IdempotencyMiddleware holds a key
+// for a request still in flight by default.
+const DefaultIdempotencyLease = time.Minute
+
+// maxIdempotencyKeyLength bounds the keys IdempotencyMiddleware accepts.
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyRecord is what an IdempotencyStore holds for one key: the
+// request it was first used with and, once that request finishes, its
+// response.
+type IdempotencyRecord struct {
+	// RequestHash identifies the request body the key was first used with,
+	// as returned by IdempotencyRequestHash.
+	RequestHash string `json:"request_hash"`
+
+	// StatusCode is the response status, or 0 while the first request is
+	// still being handled.
+	StatusCode int `json:"status_code,omitempty"`
+
+	// Header and Body are the response to replay.
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+
+	// ExpiresAt is when the key may be reused. While the first request is
+	// in flight it is the end of that request's lease.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IdempotencyKeyReused is the Details of an idempotency_key_reused error.
+// The hashes let a platform confirm which of its requests first used the
+// key.
+type IdempotencyKeyReused struct {
+	// OriginalHash is the RequestHash the key was first used with.
+	OriginalHash string `json:"original_hash"`
+
+	// RequestHash is the RequestHash of the rejected request.
+	RequestHash string `json:"request_hash"`
+}
+
+// Completed reports whether the record holds a response.
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// IdempotencyStore holds the records IdempotencyMiddleware replays.
+// Implementations must be safe for concurrent use; a shared store lets
+// keys hold across server replicas.
+type IdempotencyStore interface {
+	// ReserveKey stores record under key unless an unexpired record is
+	// already there, in which case it returns that record and stores
+	// nothing. It returns nil when the reservation succeeds.
+	ReserveKey(ctx context.Context, key string, record *IdempotencyRecord, now time.Time) (*IdempotencyRecord, error)
+
+	// SaveKey replaces the record under key.
+	SaveKey(ctx context.Context, key string, record *IdempotencyRecord) error
+
+	// DeleteKey removes the record under key.
+	DeleteKey(ctx context.Context, key string) error
+}
+
+// IdempotencyConfig configures IdempotencyMiddleware.
+type IdempotencyConfig struct {
+	// Store holds the records. Defaults to a MemoryIdempotencyStore, which
+	// only covers a single server process.
+	Store IdempotencyStore
+
+	// TTL is how long a key is remembered. Defaults to
+	// DefaultIdempotencyTTL.
+	TTL time.Duration
+
+	// Lease is how long a key is held while its first request is in
+	// flight, so a key whose server died mid-request is freed without
+	// waiting out the TTL. Set it above the longest request timeout: a
+	// repeat arriving after the lease runs the handler again. Defaults to
+	// DefaultIdempotencyLease.
+	Lease time.Duration
+
+	// Clock supplies the time for expiry. Defaults to SystemClock.
+	Clock Clock
+}
+
+// IdempotencyMiddleware makes POST, PUT, PATCH, and DELETE requests that
+// carry an Idempotency-Key safe to retry. The first request with a key is
+// handled normally and its response stored; repeating the key with the
+// same body replays that response, marked with Idempotent-Replayed: true,
+// without calling the handler again.
+//
+// Keys are scoped to the platform's UCP-Agent profile, the method, and the
+// path, so different platforms cannot collide. Reusing a key with a
+// different body is rejected, without calling the handler, with 409
+// idempotency_key_reused, whose IdempotencyKeyReused details carry both
+// body hashes; a key can therefore never complete two different checkouts.
+// A repeat that arrives while the first request is still running is
+// rejected with 409 idempotency_conflict until the Lease runs out; the
+// response, once stored, is kept for the TTL. Server errors and transient
+// 408, 409, and 429 responses are not stored, so the request may be
+// retried with the same key.
+//
+// Register it on the groups to protect, e.g.
+// srv.Use(GroupCheckout, IdempotencyMiddleware(IdempotencyConfig{})).
+func IdempotencyMiddleware(config IdempotencyConfig) Middleware {
+	if config.Store == nil {
+		config.Store = NewMemoryIdempotencyStore()
+	}
+	if config.TTL <= 0 {
+		config.TTL = DefaultIdempotencyTTL
+	}
+	if config.Lease <= 0 {
+		config.Lease = DefaultIdempotencyLease
+	}
+	config.Clock = clockOrSystem(config.Clock)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || !isIdempotentMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				WriteAPIError(w, BadRequestError("Idempotency-Key must be at most 255 characters"))
+				return
+			}
+
+			r, err := bufferBody(r, DefaultMaxBodyBytes)
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					WriteError(w, http.StatusRequestEntityTooLarge, string(models.ErrorCodeRequestTooLarge), "Request body exceeds maximum size")
+					return
+				}
+				WriteError(w, http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Failed to read request body")
+				return
+			}
+
+			ctx := r.Context()
+			platform, _ := PlatformProfileURL(r)
+			scoped := idempotencyScope(platform, r.Method, r.URL.Path, key)
+			now := config.Clock.Now()
+			record := &IdempotencyRecord{
+				RequestHash: IdempotencyRequestHash(RawBody(ctx)),
+				ExpiresAt:   now.Add(config.Lease),
+			}
+
+			existing, err := config.Store.ReserveKey(ctx, scoped, record, now)
+			if err != nil {
+				WriteAPIError(w, InternalError("Failed to check idempotency key: "+err.Error()))
+				return
+			}
+			if existing != nil {
+				switch {
+				case existing.RequestHash != record.RequestHash:
+					WriteAPIError(w, IdempotencyKeyReusedError("Idempotency-Key was already used with a different request body", IdempotencyKeyReused{
+						OriginalHash: existing.RequestHash,
+						RequestHash:  record.RequestHash,
+					}))
+				case !existing.Completed():
+					w.Header().Set("Retry-After", "1")
+					WriteAPIError(w, IdempotencyConflictError("A request with this Idempotency-Key is still being processed"))
+				default:
+					replayIdempotent(w, existing)
+				}
+				return
+			}
+
+			iw := &idempotencyWriter{ResponseWriter: w}
+			saved := false
+			defer func() {
+				if !saved {
+					config.Store.DeleteKey(context.WithoutCancel(ctx), scoped)
+				}
+			}()
+			next.ServeHTTP(iw, r)
+
+			if iw.statusCode == 0 {
+				iw.statusCode = http.StatusOK
+			}
+			if iw.truncated || !storableStatus(iw.statusCode) {
+				return
+			}
+			record.StatusCode = iw.statusCode
+			record.Header = iw.header
+			record.Body = iw.body.Bytes()
+			record.ExpiresAt = config.Clock.Now().Add(config.TTL)
+			saved = config.Store.SaveKey(context.WithoutCancel(ctx), scoped, record) == nil
+		})
+	}
+}
+
+// IdempotencyRequestHash returns the hash IdempotencyMiddleware compares
+// request bodies by: the hex SHA-256 of the body, prefixed "sha256:". JSON
+// bodies are compacted first, so whitespace differences between retries
+// do not count as a different request.
+func IdempotencyRequestHash(body []byte) string {
+	var compact bytes.Buffer
+	if json.Compact(&compact, body) == nil {
+		body = compact.Bytes()
+	}
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// isIdempotentMethod reports whether IdempotencyMiddleware applies to a
+// method; GET and HEAD are already safe to repeat.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// idempotencyScope returns the store key for an Idempotency-Key.
+func idempotencyScope(platform, method, path, key string) string {
+	return strings.Join([]string{platform, method, path, key}, " ")
+}
+
+// storableStatus reports whether a response is final enough to replay.
+func storableStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return false
+	}
+	return status < 500
+}
+
+// replayIdempotent writes a stored response. The current request's
+// X-Request-ID, if any, is kept.
+func replayIdempotent(w http.ResponseWriter, record *IdempotencyRecord) {
+	for name, values := range record.Header {
+		if http.CanonicalHeaderKey(name) == "X-Request-Id" {
+			continue
+		}
+		w.Header()[name] = append([]string(nil), values...)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	w.Write(record.Body)
+}
+
+// idempotencyWriter captures a response so IdempotencyMiddleware can store
+// it.
+type idempotencyWriter struct {
+	http.ResponseWriter
+	statusCode int
+	header     http.Header
+	body       bytes.Buffer
+	truncated  bool
+}
+
+func (w *idempotencyWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+		w.header = w.ResponseWriter.Header().Clone()
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if int64(w.body.Len()+len(b)) > DefaultMaxBodyBytes {
+		w.truncated = true
+	} else if !w.truncated {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore. Expired
+// records are discarded as the clock moves on.
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	records   map[string]IdempotencyRecord
+	lastSweep time.Time
+}
+
+// NewMemoryIdempotencyStore creates an empty MemoryIdempotencyStore.
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{records: make(map[string]IdempotencyRecord)}
+}
+
+// ReserveKey implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) ReserveKey(ctx context.Context, key string, record *IdempotencyRecord, now time.Time) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+	if existing, ok := s.records[key]; ok && now.Before(existing.ExpiresAt) {
+		return copyIdempotencyRecord(&existing), nil
+	}
+	s.records[key] = *copyIdempotencyRecord(record)
+	return nil, nil
+}
+
+// sweep drops expired records, at most once a minute. s.mu must be held.
+func (s *MemoryIdempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, r := range s.records {
+		if !now.Before(r.ExpiresAt) {
+			delete(s.records, key)
+		}
+	}
+}
+
+// SaveKey implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) SaveKey(ctx context.Context, key string, record *IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = *copyIdempotencyRecord(record)
+	return nil
+}
+
+// DeleteKey implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) DeleteKey(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// copyIdempotencyRecord returns a copy of r that shares no memory with it.
+func copyIdempotencyRecord(r *IdempotencyRecord) *IdempotencyRecord {
+	c := *r
+	c.Header = r.Header.Clone()
+	c.Body = append([]byte(nil), r.Body...)
+	return &c
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// idempotentRequest sends a POST with an Idempotency-Key through handler.
+func idempotentRequest(handler http.Handler, platform, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/checkout-sessions/chk_1/complete", strings.NewReader(body))
+	req.Header.Set(server.UCPAgentHeader, `profile="`+platform+`"`)
+	req.Header.Set(server.IdempotencyKeyHeader, key)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// errorCode returns the code of a UCP error response body.
+func errorCode(rec *httptest.ResponseRecorder) string {
+	var body struct {
+		Error string `json:"error"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	return body.Error
+}
+
+func TestIdempotencyMiddlewareReplays(t *testing.T) {
+	var calls atomic.Int32
+	handler := server.IdempotencyMiddleware(server.IdempotencyConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"chk_1","call":%d}`, n)
+	}))
+
+	tests := []struct {
+		name     string
+		platform string
+		key      string
+		body     string
+		replayed bool
+		response string
+	}{
+		{"first use", platformA, "key-1", `{"payment":{}}`, false, `{"id":"chk_1","call":1}`},
+		{"retry", platformA, "key-1", `{"payment":{}}`, true, `{"id":"chk_1","call":1}`},
+		{"retry with different whitespace", platformA, "key-1", `{ "payment": {} }`, true, `{"id":"chk_1","call":1}`},
+		{"same key from another platform", platformB, "key-1", `{"payment":{}}`, false, `{"id":"chk_1","call":2}`},
+		{"new key", platformA, "key-2", `{"payment":{}}`, false, `{"id":"chk_1","call":3}`},
+	}
+	for _, tt := range tests {
+		rec := idempotentRequest(handler, tt.platform, tt.key, tt.body)
+		if rec.Code != http.StatusOK || rec.Body.String() != tt.response {
+			t.Errorf("%s: %d %s, want 200 %s", tt.name, rec.Code, rec.Body, tt.response)
+		}
+		if replayed := rec.Header().Get(server.IdempotentReplayedHeader) == "true"; replayed != tt.replayed {
+			t.Errorf("%s: replayed = %v, want %v", tt.name, replayed, tt.replayed)
+		}
+	}
+}
+
+func TestIdempotencyMiddlewareRejectsInFlightRepeat(t *testing.T) {
+	entered, release := make(chan struct{}), make(chan struct{})
+	handler := server.IdempotencyMiddleware(server.IdempotencyConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+		w.Write([]byte(`{"id":"chk_1"}`))
+	}))
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- idempotentRequest(handler, platformA, "key-1", `{}`) }()
+	<-entered
+
+	rec := idempotentRequest(handler, platformA, "key-1", `{}`)
+	if rec.Code != http.StatusConflict || errorCode(rec) != "idempotency_conflict" || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("repeat while in flight: %d %s, Retry-After %q; want 409 idempotency_conflict with Retry-After",
+			rec.Code, rec.Body, rec.Header().Get("Retry-After"))
+	}
+
+	close(release)
+	if rec := <-first; rec.Code != http.StatusOK {
+		t.Fatalf("first request: %d %s", rec.Code, rec.Body)
+	}
+	if rec := idempotentRequest(handler, platformA, "key-1", `{}`); rec.Header().Get(server.IdempotentReplayedHeader) != "true" {
+		t.Errorf("repeat after completion was not replayed: %d %s", rec.Code, rec.Body)
+	}
+}
+
+func TestIdempotencyMiddlewareRejectsKeyReuse(t *testing.T) {
+	var calls atomic.Int32
+	handler := server.IdempotencyMiddleware(server.IdempotencyConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"id":"chk_1"}`))
+	}))
+	original := `{"payment":{"selected_instrument_id":"pi_1"}}`
+	idempotentRequest(handler, platformA, "key-1", original)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		code   string
+	}{
+		{"different body", `{"payment":{"selected_instrument_id":"pi_2"}}`, http.StatusConflict, "idempotency_key_reused"},
+		{"empty body", ``, http.StatusConflict, "idempotency_key_reused"},
+		{"original body", original, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		rec := idempotentRequest(handler, platformA, "key-1", tt.body)
+		if rec.Code != tt.status || errorCode(rec) != tt.code {
+			t.Errorf("%s: %d %s, want %d %s", tt.name, rec.Code, rec.Body, tt.status, tt.code)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("handler called %d times, want 1", n)
+	}
+
+	var body struct {
+		Details server.IdempotencyKeyReused `json:"details"`
+	}
+	rec := idempotentRequest(handler, platformA, "key-1", `{}`)
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if body.Details.OriginalHash != server.IdempotencyRequestHash([]byte(original)) ||
+		body.Details.RequestHash != server.IdempotencyRequestHash([]byte(`{}`)) {
+		t.Errorf("details = %+v, want the hashes of both bodies", body.Details)
+	}
+}
+
+// crashingStore is an IdempotencyStore whose DeleteKey never runs, as when
+// the server dies before cleaning up a reservation.
+type crashingStore struct{ *server.MemoryIdempotencyStore }
+
+func (crashingStore) DeleteKey(ctx context.Context, key string) error { return nil }
+
+func TestIdempotencyMiddlewareLeasesInFlightKeys(t *testing.T) {
+	clock := &fixedClock{time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	crash := true
+	handler := server.IdempotencyMiddleware(server.IdempotencyConfig{
+		Store: crashingStore{server.NewMemoryIdempotencyStore()},
+		Lease: time.Minute,
+		Clock: clock,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if crash {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"id":"chk_1"}`))
+	}))
+
+	idempotentRequest(handler, platformA, "key-1", `{}`)
+	crash = false
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		status   int
+		replayed bool
+	}{
+		{"retry within the lease", 30 * time.Second, http.StatusConflict, false},
+		{"retry after the lease", time.Minute, http.StatusOK, false},
+		{"repeat long after the lease", 2 * time.Hour, http.StatusOK, true},
+	}
+	for _, tt := range tests {
+		clock.now = clock.now.Add(tt.advance)
+		rec := idempotentRequest(handler, platformA, "key-1", `{}`)
+		if replayed := rec.Header().Get(server.IdempotentReplayedHeader) == "true"; rec.Code != tt.status || replayed != tt.replayed {
+			t.Errorf("%s: %d %s, replayed %v; want %d, replayed %v", tt.name, rec.Code, rec.Body, replayed, tt.status, tt.replayed)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// allow records that path accepts method. The first time a path is seen,
+// a method-less fallback is registered for it, which the mux only picks
+// when no method-specific route matches: it answers OPTIONS with the
+// path's Allow header and other methods with a 405 error envelope.
+func (s *Server) allow(path, method string) {
+	if s.allowed == nil {
+		s.allowed = make(map[string][]string)
+	}
+	if _, ok := s.allowed[path]; !ok {
+		s.mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			s.handleMethodNotAllowed(w, r, path)
+		})
+	}
+	methods := []string{method}
+	if method == http.MethodGet {
+		methods = append(methods, http.MethodHead)
+	}
+	for _, m := range methods {
+		if !slices.Contains(s.allowed[path], m) {
+			s.allowed[path] = append(s.allowed[path], m)
+		}
+	}
+}
+
+// handleMethodNotAllowed answers a request whose path is routed but whose
+// method is not.
+func (s *Server) handleMethodNotAllowed(w http.ResponseWriter, r *http.Request, path string) {
+	allowed := append(append([]string(nil), s.allowed[path]...), http.MethodOptions)
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	s.writeError(w, http.StatusMethodNotAllowed, string(models.ErrorCodeMethodNotAllowed),
+		"Method "+r.Method+" is not allowed; allowed methods are "+strings.Join(allowed, ", "))
+}
+
+// handleNotFound answers a request for a path with no route.
+func (s *Server) handleNotFound(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusNotFound, string(models.ErrorCodeNotFound), "No route for "+r.URL.Path)
+}
@@ -18,7 +18,10 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
 )
 
 // Middleware is a function that wraps an HTTP handler.
@@ -89,19 +92,19 @@ func APIKeyMiddleware(validKeys map[string]bool) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip auth for discovery endpoint
-			if r.URL.Path == "/.well-known/ucp" {
+			if isDiscoveryPath(r.URL.Path) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
 			apiKey := r.Header.Get("X-API-Key")
 			if apiKey == "" {
-				WriteError(w, http.StatusUnauthorized, "missing_api_key", "X-API-Key header is required")
+				WriteError(w, http.StatusUnauthorized, string(models.ErrorCodeMissingAPIKey), "X-API-Key header is required")
 				return
 			}
 
 			if !validKeys[apiKey] {
-				WriteError(w, http.StatusUnauthorized, "invalid_api_key", "Invalid API key")
+				WriteError(w, http.StatusUnauthorized, string(models.ErrorCodeInvalidAPIKey), "Invalid API key")
 				return
 			}
 
@@ -115,31 +118,31 @@ func BearerTokenMiddleware(validator func(token string) (bool, error)) Middlewar
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip auth for discovery endpoint
-			if r.URL.Path == "/.well-known/ucp" {
+			if isDiscoveryPath(r.URL.Path) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
 			auth := r.Header.Get("Authorization")
 			if auth == "" {
-				WriteError(w, http.StatusUnauthorized, "missing_authorization", "Authorization header is required")
+				WriteError(w, http.StatusUnauthorized, string(models.ErrorCodeMissingAuthorization), "Authorization header is required")
 				return
 			}
 
 			if len(auth) < 7 || auth[:7] != "Bearer " {
-				WriteError(w, http.StatusUnauthorized, "invalid_authorization", "Invalid authorization format")
+				WriteError(w, http.StatusUnauthorized, string(models.ErrorCodeInvalidAuthorization), "Invalid authorization format")
 				return
 			}
 
 			token := auth[7:]
 			valid, err := validator(token)
 			if err != nil {
-				WriteError(w, http.StatusInternalServerError, "auth_error", "Authentication error")
+				WriteError(w, http.StatusInternalServerError, string(models.ErrorCodeAuthError), "Authentication error")
 				return
 			}
 
 			if !valid {
-				WriteError(w, http.StatusUnauthorized, "invalid_token", "Invalid access token")
+				WriteError(w, http.StatusUnauthorized, string(models.ErrorCodeInvalidToken), "Invalid access token")
 				return
 			}
 
@@ -163,6 +166,12 @@ func RequestIDMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// isDiscoveryPath reports whether path addresses the discovery profile,
+// with or without a configured base path.
+func isDiscoveryPath(path string) bool {
+	return strings.HasSuffix(path, "/.well-known/ucp")
+}
+
 // GetRequestID returns the request ID from the context.
 func GetRequestID(ctx context.Context) string {
 	if id, ok := ctx.Value(requestIDKey).(string); ok {
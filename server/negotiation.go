@@ -0,0 +1,126 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+const negotiationKey contextKey = "negotiation"
+
+// ProfileResolver returns a platform's profile given its profile URL.
+type ProfileResolver func(ctx context.Context, profileURL string) (*models.UCPProfile, error)
+
+// PlatformProfileResolver returns a ProfileResolver that fetches profiles
+// through httpcache.Default, so they are refetched only as their caching
+// headers allow. A nil client uses one with a ten-second timeout.
+func PlatformProfileResolver(client *http.Client) ProfileResolver {
+	return func(ctx context.Context, profileURL string) (*models.UCPProfile, error) {
+		return fetchPlatformProfile(ctx, client, profileURL)
+	}
+}
+
+// CapabilityNegotiationConfig configures CapabilityNegotiationMiddleware.
+type CapabilityNegotiationConfig struct {
+	// Profiles resolves the calling platform's profile from the URL in its
+	// UCP-Agent header. Defaults to PlatformProfileResolver(nil).
+	Profiles ProfileResolver
+
+	// Required lists capabilities every call needs in addition to the one
+	// for the resource being called.
+	Required []models.CapabilityName
+}
+
+// CapabilityNegotiationMiddleware negotiates capabilities with the calling
+// platform on every request. It fetches the profile named by the UCP-Agent
+// header, runs a validation.CapabilityNegotiator for its capabilities
+// against Config.Capabilities, and stores the result in the request
+// context, where handlers read it with Negotiation.
+//
+// Calls on a resource the platform does not share a compatible version of
+// (dev.ucp.shopping.checkout for checkout sessions, and likewise for orders
+// and carts), or missing any of config.Required, are rejected with a 400
+// capability_not_supported error. Requests without a valid UCP-Agent header
+// are rejected with missing_header, and those whose profile cannot be
+// fetched with invalid_request. Attach it with Use, e.g.
+// srv.Use(GroupCheckout, srv.CapabilityNegotiationMiddleware(CapabilityNegotiationConfig{})).
+func (s *Server) CapabilityNegotiationMiddleware(config CapabilityNegotiationConfig) Middleware {
+	if config.Profiles == nil {
+		config.Profiles = PlatformProfileResolver(nil)
+	}
+	business := &models.UCPProfile{UCP: models.DiscoveryProfile{
+		Version:      s.config.Version,
+		Capabilities: s.config.Capabilities,
+	}}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			profileURL, err := PlatformProfileURL(r)
+			if err != nil {
+				s.writeError(w, http.StatusBadRequest, string(models.ErrorCodeMissingHeader), err.Error())
+				return
+			}
+			profile, err := config.Profiles(r.Context(), profileURL)
+			if err != nil {
+				s.writeError(w, http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Cannot negotiate capabilities: "+err.Error())
+				return
+			}
+
+			required := config.Required
+			if resource := s.resourceCapability(r); resource != "" {
+				required = append([]models.CapabilityName{resource}, required...)
+			}
+			result := validation.NewCapabilityNegotiator(profile.UCP.Capabilities).Negotiate(business, required)
+			if len(result.MissingRequired) > 0 {
+				missing := make([]string, len(result.MissingRequired))
+				for i, name := range result.MissingRequired {
+					missing[i] = string(name)
+				}
+				s.handleError(w, CapabilityNotSupportedError("Platform does not support required capabilities: "+strings.Join(missing, ", ")))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), negotiationKey, result)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Negotiation returns the result stored by CapabilityNegotiationMiddleware,
+// or nil if the request was not negotiated.
+func Negotiation(ctx context.Context) *validation.NegotiationResult {
+	result, _ := ctx.Value(negotiationKey).(*validation.NegotiationResult)
+	return result
+}
+
+// resourceCapability returns the capability of the resource a request
+// calls, or "" for other paths.
+func (s *Server) resourceCapability(r *http.Request) models.CapabilityName {
+	path := strings.TrimPrefix(r.URL.Path, s.config.BasePath)
+	switch strings.SplitN(strings.Trim(path, "/"), "/", 2)[0] {
+	case "checkout-sessions":
+		return GroupCheckout
+	case "orders":
+		return GroupOrder
+	case "carts":
+		return GroupCart
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Notification preference message codes.
+const (
+	// CodeUnsupportedNotificationChannel indicates the buyer asked for a
+	// channel the merchant does not deliver updates on.
+	CodeUnsupportedNotificationChannel = "unsupported_notification_channel"
+
+	// CodeConflictingNotificationChannels indicates the buyer opted out of
+	// updates while also asking for a channel.
+	CodeConflictingNotificationChannels = "conflicting_notification_channels"
+
+	// CodeMissingNotificationContact indicates a channel lacks the contact
+	// detail it needs, such as a phone number for SMS.
+	CodeMissingNotificationContact = "missing_notification_contact"
+
+	// CodeNotificationConsentRequired indicates a channel or marketing
+	// preference lacks the buyer's recorded consent.
+	CodeNotificationConsentRequired = "notification_consent_required"
+)
+
+// notificationBuyer is the subset of a buyer that notification checks
+// need.
+type notificationBuyer struct {
+	email, phone string
+	consent      *models.Consent
+	prefs        *models.NotificationPreferences
+}
+
+// CheckNotificationsCreate validates the notification preferences of a
+// checkout create request's buyer against the channels the merchant can
+// deliver on. NotificationChannelNone need not be listed.
+func CheckNotificationsCreate(available []models.NotificationChannel, buyer *models.BuyerWithConsentCreateRequest) []models.Message {
+	if buyer == nil || buyer.Notifications == nil {
+		return nil
+	}
+	return checkNotifications(available, notificationBuyer{
+		email: buyer.Email, phone: buyer.PhoneNumber, consent: buyer.Consent, prefs: buyer.Notifications,
+	})
+}
+
+// CheckNotificationsUpdate validates the notification preferences of a
+// checkout update request's buyer. Contact details and consent the update
+// leaves out are taken from current, the buyer on the checkout.
+func CheckNotificationsUpdate(available []models.NotificationChannel, buyer *models.BuyerWithConsentUpdateRequest, current *models.BuyerWithConsentResponse) []models.Message {
+	if buyer == nil || buyer.Notifications == nil {
+		return nil
+	}
+	b := notificationBuyer{email: buyer.Email, phone: buyer.PhoneNumber, consent: buyer.Consent, prefs: buyer.Notifications}
+	if current != nil {
+		if b.email == "" {
+			b.email = current.Email
+		}
+		if b.phone == "" {
+			b.phone = current.PhoneNumber
+		}
+		if b.consent == nil {
+			b.consent = current.Consent
+		}
+	}
+	return checkNotifications(available, b)
+}
+
+func checkNotifications(available []models.NotificationChannel, b notificationBuyer) []models.Message {
+	var messages []models.Message
+	add := func(code, path, content string) {
+		messages = append(messages, models.Message{
+			Type:     models.MessageTypeError,
+			Code:     code,
+			Content:  content,
+			Severity: models.SeverityRecoverable,
+			Path:     path,
+		})
+	}
+
+	offered := map[models.NotificationChannel]bool{models.NotificationChannelNone: true}
+	for _, ch := range available {
+		offered[ch] = true
+	}
+	optedOut := false
+	for i, ch := range b.prefs.Channels {
+		path := fmt.Sprintf("$.buyer.notifications.channels[%d]", i)
+		switch {
+		case !ch.IsValid() || !offered[ch]:
+			add(CodeUnsupportedNotificationChannel, path, fmt.Sprintf("Order updates are not available by %s", ch))
+			continue
+		case ch == models.NotificationChannelNone:
+			optedOut = true
+		case ch == models.NotificationChannelEmail && b.email == "":
+			add(CodeMissingNotificationContact, "$.buyer.email", "Email required for email updates")
+		case ch == models.NotificationChannelSMS && b.phone == "":
+			add(CodeMissingNotificationContact, "$.buyer.phone_number", "Phone number required for SMS updates")
+		}
+		if ch.RequiresConsent() && !b.prefs.Consent.Covers(ch) {
+			add(CodeNotificationConsentRequired, "$.buyer.notifications.consent",
+				fmt.Sprintf("Buyer consent is required for %s updates", ch))
+		}
+	}
+	if optedOut && len(b.prefs.Channels) > 1 {
+		add(CodeConflictingNotificationChannels, "$.buyer.notifications.channels",
+			"Opting out of updates cannot be combined with other channels")
+	}
+	if b.prefs.Marketing != nil && *b.prefs.Marketing &&
+		(b.consent == nil || b.consent.Marketing == nil || !*b.consent.Marketing) {
+		add(CodeNotificationConsentRequired, "$.buyer.consent.marketing",
+			"Marketing consent is required to receive marketing updates")
+	}
+	return messages
+}
+
+// NotificationPreferencesError wraps notification preference messages in a
+// 400 APIError.
+func NotificationPreferencesError(messages []models.Message) *APIError {
+	return NewAPIError(http.StatusBadRequest, string(models.ErrorCodeInvalidField), messages[0].Content).WithMessages(messages...)
+}
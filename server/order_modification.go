@@ -0,0 +1,111 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// RequestOrderModificationHandler handles a buyer's proposed change to an
+// order. It returns the modification accepted, rejected, or, if the
+// merchant decides later, pending. Accepted modifications list the
+// adjustments appended to the order.
+type RequestOrderModificationHandler func(r *http.Request, orderID string, req *models.OrderModificationRequest) (*models.OrderModification, error)
+
+// GetOrderModificationHandler is a function that handles order
+// modification retrieval.
+type GetOrderModificationHandler func(r *http.Request, orderID, id string) (*models.OrderModification, error)
+
+// HandleRequestOrderModification registers a handler for POST
+// /orders/{id}/modifications. Requests are checked before the handler
+// runs: address_change needs a destination, and quantity_reduction line
+// items with a positive quantity to remove.
+func (s *Server) HandleRequestOrderModification(handler RequestOrderModificationHandler) {
+	s.requestOrderModificationHandler = func(w http.ResponseWriter, r *http.Request) {
+		var req models.OrderModificationRequest
+		if err := s.decodeRequest(r, &req); err != nil {
+			s.handleError(w, err)
+			return
+		}
+		if err := validateOrderModification(&req); err != nil {
+			s.handleError(w, err)
+			return
+		}
+
+		resp, err := handler(r, r.PathValue("id"), &req)
+		if err != nil {
+			s.handleError(w, err)
+			return
+		}
+
+		WriteJSON(w, http.StatusCreated, resp)
+	}
+}
+
+// HandleGetOrderModification registers a handler for retrieving order
+// modifications.
+func (s *Server) HandleGetOrderModification(handler GetOrderModificationHandler) {
+	s.getOrderModificationHandler = func(w http.ResponseWriter, r *http.Request) {
+		resp, err := handler(r, r.PathValue("id"), r.PathValue("modification_id"))
+		if err != nil {
+			s.handleError(w, err)
+			return
+		}
+
+		WriteJSON(w, http.StatusOK, resp)
+	}
+}
+
+// validateOrderModification checks that a modification request carries
+// what its type needs.
+func validateOrderModification(req *models.OrderModificationRequest) error {
+	switch req.Type {
+	case models.OrderModificationAddressChange:
+		if req.Destination == nil {
+			return BadRequestError("address_change requires a destination")
+		}
+	case models.OrderModificationQuantityReduction:
+		if len(req.LineItems) == 0 {
+			return BadRequestError("quantity_reduction requires line_items")
+		}
+		for i, li := range req.LineItems {
+			if li.ID == "" || li.Quantity <= 0 {
+				return BadRequestError(fmt.Sprintf("line_items[%d] must have an id and a positive quantity to remove", i))
+			}
+		}
+	default:
+		return BadRequestError(fmt.Sprintf("Unknown modification type %q", req.Type))
+	}
+	return nil
+}
+
+func (s *Server) handleRequestOrderModification(w http.ResponseWriter, r *http.Request) {
+	if s.requestOrderModificationHandler != nil {
+		s.requestOrderModificationHandler(w, r)
+	} else {
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Order modification not implemented")
+	}
+}
+
+func (s *Server) handleGetOrderModification(w http.ResponseWriter, r *http.Request) {
+	if s.getOrderModificationHandler != nil {
+		s.getOrderModificationHandler(w, r)
+	} else {
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Order modification retrieval not implemented")
+	}
+}
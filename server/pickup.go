@@ -0,0 +1,288 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// DefaultPickupRadiusKM is the search radius for pickup locations when
+// Config.PickupRadiusKM is zero.
+const DefaultPickupRadiusKM = 25
+
+// PickupLocation is a store where buyers can collect orders.
+type PickupLocation struct {
+	models.RetailLocationResponse
+
+	// Hours describes the opening hours (e.g. "Mon–Sat 9:00–21:00").
+	Hours string
+
+	// ReadyAfter and ReadyBefore bound when an order placed now is ready
+	// for collection. Zero values omit the readiness window.
+	ReadyAfter  time.Duration
+	ReadyBefore time.Duration
+
+	// DistanceKM is the distance from the searched address, if known.
+	DistanceKM float64
+}
+
+// PickupLocationProvider finds pickup locations near an address.
+//
+// When Config.PickupLocations is set, the server calls it after every
+// checkout create and update handler for each pickup fulfillment method,
+// and lists the returned locations as the method's destinations.
+type PickupLocationProvider interface {
+	// ListNear returns locations within radiusKM of address, nearest first.
+	ListNear(ctx context.Context, address *models.PostalAddress, radiusKM float64) ([]PickupLocation, error)
+}
+
+// ApplyPickupLocations lists pickup locations as destinations of every
+// pickup fulfillment method, searching near the method's selected
+// location, else a selected shipping destination, else the buyer's postal
+// code, region, and country from the checkout context (or geo). A
+// selection that is no longer listed is cleared.
+//
+// For a selected location, groups without options receive a free pickup
+// option, and options without a description are described with the
+// location's hours and readiness window.
+func ApplyPickupLocations(ctx context.Context, provider PickupLocationProvider, radiusKM float64, checkout *extensions.ExtendedCheckoutResponse) error {
+	return applyPickupLocations(ctx, provider, radiusKM, time.Now(), checkout)
+}
+
+// applyPickupLocations is ApplyPickupLocations with readiness times
+// computed from now.
+func applyPickupLocations(ctx context.Context, provider PickupLocationProvider, radiusKM float64, now time.Time, checkout *extensions.ExtendedCheckoutResponse) error {
+	if checkout.Fulfillment == nil {
+		return nil
+	}
+	if radiusKM <= 0 {
+		radiusKM = DefaultPickupRadiusKM
+	}
+
+	for i := range checkout.Fulfillment.Methods {
+		m := &checkout.Fulfillment.Methods[i]
+		if m.Type != models.FulfillmentMethodTypePickup {
+			continue
+		}
+		near := pickupSearchAddress(ctx, checkout, m)
+		if near == nil {
+			continue
+		}
+
+		locations, err := provider.ListNear(ctx, near, radiusKM)
+		if err != nil {
+			return err
+		}
+		m.Destinations = make([]models.FulfillmentDestinationResponse, 0, len(locations))
+		var selected *PickupLocation
+		for j, loc := range locations {
+			m.Destinations = append(m.Destinations, models.FulfillmentDestinationResponse{
+				ID:      loc.ID,
+				Name:    loc.Name,
+				Address: loc.Address,
+			})
+			if m.SelectedDestinationID != nil && *m.SelectedDestinationID == loc.ID {
+				selected = &locations[j]
+			}
+		}
+		if selected == nil {
+			m.SelectedDestinationID = nil
+			continue
+		}
+		describePickup(m, selected, now)
+	}
+	return nil
+}
+
+// pickupSearchAddress returns the address to search for pickup locations
+// near, or nil if nothing is known about the buyer's location.
+func pickupSearchAddress(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse, pickup *models.FulfillmentMethodResponse) *models.PostalAddress {
+	if addr := selectedDestination(pickup); addr != nil {
+		return addr
+	}
+	for i := range checkout.Fulfillment.Methods {
+		m := &checkout.Fulfillment.Methods[i]
+		if m.Type == models.FulfillmentMethodTypeShipping {
+			if addr := selectedDestination(m); addr != nil {
+				return addr
+			}
+		}
+	}
+	hint := checkout.Context
+	if hint == nil {
+		hint = GeoContext(ctx)
+	}
+	if hint == nil || (hint.PostalCode == "" && hint.AddressRegion == "" && hint.AddressCountry == "") {
+		return nil
+	}
+	return &models.PostalAddress{
+		PostalCode:     hint.PostalCode,
+		AddressRegion:  hint.AddressRegion,
+		AddressCountry: hint.AddressCountry,
+	}
+}
+
+// describePickup fills the options of a pickup method for the selected
+// location.
+func describePickup(m *models.FulfillmentMethodResponse, loc *PickupLocation, now time.Time) {
+	if len(m.Groups) == 0 {
+		m.Groups = []models.FulfillmentGroupResponse{{
+			ID:          fmt.Sprintf("%s-group-1", m.ID),
+			LineItemIDs: m.LineItemIDs,
+		}}
+	}
+
+	var description []string
+	if loc.Hours != "" {
+		description = append(description, "Open "+loc.Hours+".")
+	}
+	if window := readinessWindow(loc.ReadyAfter, loc.ReadyBefore); window != "" {
+		description = append(description, "Ready for pickup "+window+".")
+	}
+
+	for j := range m.Groups {
+		g := &m.Groups[j]
+		if len(g.Options) == 0 {
+			g.Options = []models.FulfillmentOptionResponse{{
+				ID:     "pickup-" + loc.ID,
+				Title:  "Pickup at " + loc.Name,
+				Totals: []models.TotalResponse{{Type: models.TotalTypeFulfillment, Amount: 0}},
+			}}
+		}
+		for k := range g.Options {
+			opt := &g.Options[k]
+			if opt.Description == "" {
+				opt.Description = strings.Join(description, " ")
+			}
+			if opt.EarliestFulfillmentTime == nil && loc.ReadyAfter > 0 {
+				t := now.Add(loc.ReadyAfter).UTC().Truncate(time.Minute)
+				opt.EarliestFulfillmentTime = &t
+			}
+			if opt.LatestFulfillmentTime == nil && loc.ReadyBefore > 0 {
+				t := now.Add(loc.ReadyBefore).UTC().Truncate(time.Minute)
+				opt.LatestFulfillmentTime = &t
+			}
+		}
+	}
+}
+
+// readinessWindow describes a readiness window, e.g. "in 2–4 hours".
+func readinessWindow(after, before time.Duration) string {
+	switch {
+	case after <= 0 && before <= 0:
+		return ""
+	case after <= 0:
+		return "within " + humanDuration(before)
+	case before <= after:
+		return "in " + humanDuration(after)
+	}
+	a, unitA := durationUnits(after)
+	b, unitB := durationUnits(before)
+	if unitA == unitB {
+		return fmt.Sprintf("in %d–%d %ss", a, b, unitB)
+	}
+	return "in " + humanDuration(after) + "–" + humanDuration(before)
+}
+
+// humanDuration renders a duration as e.g. "1 hour" or "30 minutes".
+func humanDuration(d time.Duration) string {
+	n, unit := durationUnits(d)
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// durationUnits expresses a duration in whole days, hours, or minutes.
+func durationUnits(d time.Duration) (int64, string) {
+	const day = 24 * time.Hour
+	switch {
+	case d >= day && d%day == 0:
+		return int64(d / day), "day"
+	case d >= time.Hour && d%time.Hour == 0:
+		return int64(d / time.Hour), "hour"
+	default:
+		return int64((d + time.Minute - 1) / time.Minute), "minute"
+	}
+}
+
+// PickupDirectory is an in-memory PickupLocationProvider. Without a
+// geocoder it cannot measure distance, so ListNear ranks locations by how
+// closely their address matches: same postal code, then locality, then
+// region, within the searched country. Locations with DistanceKM set are
+// additionally filtered by the radius.
+type PickupDirectory struct {
+	locations []PickupLocation
+}
+
+// NewPickupDirectory creates a directory of locations.
+func NewPickupDirectory(locations ...PickupLocation) *PickupDirectory {
+	return &PickupDirectory{locations: locations}
+}
+
+// ListNear implements PickupLocationProvider.
+func (d *PickupDirectory) ListNear(ctx context.Context, address *models.PostalAddress, radiusKM float64) ([]PickupLocation, error) {
+	type ranked struct {
+		loc  PickupLocation
+		rank int
+	}
+	var matches []ranked
+	for _, loc := range d.locations {
+		if loc.DistanceKM > radiusKM {
+			continue
+		}
+		if rank := addressRank(address, loc.Address); rank > 0 {
+			matches = append(matches, ranked{loc, rank})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].rank > matches[j].rank
+	})
+
+	result := make([]PickupLocation, len(matches))
+	for i, m := range matches {
+		result[i] = m.loc
+	}
+	return result, nil
+}
+
+// addressRank scores how closely a location's address matches the
+// searched address: 0 for none, higher for a closer match.
+func addressRank(near, loc *models.PostalAddress) int {
+	if loc == nil {
+		return 0
+	}
+	if near.AddressCountry != "" && !strings.EqualFold(near.AddressCountry, loc.AddressCountry) {
+		return 0
+	}
+	switch {
+	case near.PostalCode != "" && near.PostalCode == loc.PostalCode:
+		return 4
+	case near.AddressLocality != "" && strings.EqualFold(near.AddressLocality, loc.AddressLocality):
+		return 3
+	case near.AddressRegion != "" && strings.EqualFold(near.AddressRegion, loc.AddressRegion):
+		return 2
+	case near.AddressCountry != "":
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,369 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// ServiceShopping is the name of the UCP shopping service.
+const ServiceShopping = "dev.ucp.shopping"
+
+// ucpSpecBase and ucpSchemaBase are where the standard capabilities'
+// specifications and schemas are published.
+const (
+	ucpSpecBase   = "https://ucp.dev/specification/"
+	ucpSchemaBase = "https://ucp.dev/schemas/"
+)
+
+// ProfileBuilder assembles and validates a merchant's discovery profile,
+// producing the Config to serve it with:
+//
+//	config, err := server.NewProfileBuilder("2026-01-11").
+//		WithCheckout().
+//		WithFulfillment().
+//		WithRESTEndpoint("https://shop.example/ucp").
+//		WithPaymentHandler(handler).
+//		Build()
+//
+// Methods record what they are given; all problems are reported together
+// by Build, Profile, or JSON.
+type ProfileBuilder struct {
+	version         models.Version
+	capabilities    []models.CapabilityDiscovery
+	services        models.Services
+	paymentHandlers []models.PaymentHandlerResponse
+	signingKeys     []models.JWK
+}
+
+// NewProfileBuilder starts a profile for a protocol version. The standard
+// capabilities added with the builder's methods are declared at the same
+// version.
+func NewProfileBuilder(version models.Version) *ProfileBuilder {
+	return &ProfileBuilder{version: version}
+}
+
+// WithCapability declares a capability.
+func (b *ProfileBuilder) WithCapability(c models.CapabilityDiscovery) *ProfileBuilder {
+	b.capabilities = append(b.capabilities, c)
+	return b
+}
+
+// standard declares one of the standard shopping capabilities.
+func (b *ProfileBuilder) standard(name models.CapabilityName, extends models.CapabilityName) *ProfileBuilder {
+	short := strings.TrimPrefix(string(name), ServiceShopping+".")
+	return b.WithCapability(models.CapabilityDiscovery{
+		CapabilityBase: models.CapabilityBase{
+			Name:    name,
+			Version: b.version,
+			Spec:    ucpSpecBase + short,
+			Schema:  ucpSchemaBase + "shopping/" + short + ".json",
+			Extends: extends,
+		},
+	})
+}
+
+// WithCheckout declares the checkout capability.
+func (b *ProfileBuilder) WithCheckout() *ProfileBuilder {
+	return b.standard(GroupCheckout, "")
+}
+
+// WithOrder declares the order capability.
+func (b *ProfileBuilder) WithOrder() *ProfileBuilder {
+	return b.standard(GroupOrder, "")
+}
+
+// WithCart declares the cart capability.
+func (b *ProfileBuilder) WithCart() *ProfileBuilder {
+	return b.standard(GroupCart, "")
+}
+
+// WithFulfillment declares the fulfillment extension of checkout.
+func (b *ProfileBuilder) WithFulfillment() *ProfileBuilder {
+	return b.standard(capabilityFulfillment, GroupCheckout)
+}
+
+// WithDiscount declares the discount extension of checkout.
+func (b *ProfileBuilder) WithDiscount() *ProfileBuilder {
+	return b.standard(capabilityDiscount, GroupCheckout)
+}
+
+// WithService declares a service under name.
+func (b *ProfileBuilder) WithService(name string, service models.UCPService) *ProfileBuilder {
+	if b.services == nil {
+		b.services = make(models.Services)
+	}
+	b.services[name] = service
+	return b
+}
+
+// WithRESTEndpoint declares the shopping service's REST binding at
+// endpoint: an absolute URL, a path resolved against the request origin,
+// or "" for the server's own base URL.
+func (b *ProfileBuilder) WithRESTEndpoint(endpoint string) *ProfileBuilder {
+	service := b.shoppingService()
+	service.Rest = &models.RestTransport{
+		Schema:   ucpSchemaBase + "services/shopping/rest.openapi.json",
+		Endpoint: endpoint,
+	}
+	return b.WithService(ServiceShopping, service)
+}
+
+// shoppingService returns the shopping service declared so far, or a new
+// one at the builder's version.
+func (b *ProfileBuilder) shoppingService() models.UCPService {
+	if service, ok := b.services[ServiceShopping]; ok {
+		return service
+	}
+	return models.UCPService{Version: b.version, Spec: ucpSpecBase + "shopping"}
+}
+
+// WithPaymentHandler declares a payment handler.
+func (b *ProfileBuilder) WithPaymentHandler(h models.PaymentHandlerResponse) *ProfileBuilder {
+	b.paymentHandlers = append(b.paymentHandlers, h)
+	return b
+}
+
+// WithSigningKeys publishes the merchant's signing keys.
+func (b *ProfileBuilder) WithSigningKeys(keys ...models.JWK) *ProfileBuilder {
+	b.signingKeys = append(b.signingKeys, keys...)
+	return b
+}
+
+// Build validates the profile and returns a Config serving it. Other
+// Config fields are left zero for the caller to fill in.
+func (b *ProfileBuilder) Build() (Config, error) {
+	if err := b.validate(); err != nil {
+		return Config{}, err
+	}
+	return Config{
+		Version:         b.version,
+		Capabilities:    append([]models.CapabilityDiscovery(nil), b.capabilities...),
+		Services:        b.cloneServices(),
+		SigningKeys:     append([]models.JWK(nil), b.signingKeys...),
+		PaymentHandlers: append([]models.PaymentHandlerResponse(nil), b.paymentHandlers...),
+	}, nil
+}
+
+// Profile validates the profile and returns the discovery document, as
+// served before relative endpoints are resolved.
+func (b *ProfileBuilder) Profile() (*models.UCPProfile, error) {
+	config, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	profile := &models.UCPProfile{
+		UCP: models.DiscoveryProfile{
+			Version:      config.Version,
+			Services:     config.Services,
+			Capabilities: config.Capabilities,
+		},
+		SigningKeys: config.SigningKeys,
+	}
+	if len(config.PaymentHandlers) > 0 {
+		profile.Payment = &models.PaymentConfig{Handlers: config.PaymentHandlers}
+	}
+	return profile, nil
+}
+
+// JSON validates the profile and returns the indented discovery document,
+// for publishing as a static file.
+func (b *ProfileBuilder) JSON() ([]byte, error) {
+	profile, err := b.Profile()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(profile, "", "  ")
+}
+
+func (b *ProfileBuilder) cloneServices() models.Services {
+	if b.services == nil {
+		return nil
+	}
+	services := make(models.Services, len(b.services))
+	for name, service := range b.services {
+		services[name] = service
+	}
+	return services
+}
+
+// validate reports every problem with the profile, joined into one error.
+func (b *ProfileBuilder) validate() error {
+	var errs []error
+	if err := validation.ValidateVersion(b.version); err != nil {
+		errs = append(errs, err)
+	}
+
+	declared := make(map[models.CapabilityName]models.CapabilityDiscovery, len(b.capabilities))
+	for _, c := range b.capabilities {
+		if _, dup := declared[c.Name]; dup {
+			errs = append(errs, fmt.Errorf("capability %s: declared more than once", c.Name))
+		}
+		declared[c.Name] = c
+	}
+	for i, c := range b.capabilities {
+		errs = append(errs, validateCapability(i, c, declared)...)
+	}
+
+	names := make([]string, 0, len(b.services))
+	for name := range b.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		errs = append(errs, validateService(name, b.services[name])...)
+	}
+
+	ids := make(map[string]bool, len(b.paymentHandlers))
+	for i, h := range b.paymentHandlers {
+		if ids[h.ID] {
+			errs = append(errs, fmt.Errorf("payment_handlers[%d]: duplicate id %s", i, h.ID))
+		}
+		ids[h.ID] = true
+		errs = append(errs, validatePaymentHandler(i, h)...)
+	}
+
+	if len(b.signingKeys) > 0 {
+		if err := validation.ValidateSigningKeyset(b.signingKeys, 0); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateCapability checks one capability declaration, including that
+// its extends chain ends at a declared root capability.
+func validateCapability(i int, c models.CapabilityDiscovery, declared map[models.CapabilityName]models.CapabilityDiscovery) []error {
+	var errs []error
+	where := strings.TrimSpace(fmt.Sprintf("capabilities[%d] %s", i, c.Name))
+	if err := validation.ValidateCapabilityName(c.Name); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", where, err))
+	}
+	if err := validation.ValidateVersion(c.Version); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", where, err))
+	}
+	if err := validateURL(c.Spec, true); err != nil {
+		errs = append(errs, fmt.Errorf("%s: spec %w", where, err))
+	}
+	if err := validateURL(c.Schema, true); err != nil {
+		errs = append(errs, fmt.Errorf("%s: schema %w", where, err))
+	}
+
+	seen := map[models.CapabilityName]bool{c.Name: true}
+	for parent := c.Extends; parent != ""; {
+		p, ok := declared[parent]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: extends %s, which is not declared", where, parent))
+			break
+		}
+		if seen[parent] {
+			errs = append(errs, fmt.Errorf("%s: extends chain loops back to %s", where, parent))
+			break
+		}
+		seen[parent] = true
+		parent = p.Extends
+	}
+	return errs
+}
+
+// validateService checks a service's name, version, and bindings.
+func validateService(name string, s models.UCPService) []error {
+	var errs []error
+	where := "service " + name
+	if err := validation.ValidateCapabilityName(models.CapabilityName(name)); err != nil {
+		errs = append(errs, fmt.Errorf("%s: invalid service name (must be reverse-domain notation)", where))
+	}
+	if err := validation.ValidateVersion(s.Version); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", where, err))
+	}
+	if err := validateURL(s.Spec, false); err != nil {
+		errs = append(errs, fmt.Errorf("%s: spec %w", where, err))
+	}
+	if s.Rest == nil && s.MCP == nil && s.A2A == nil && s.Embedded == nil {
+		errs = append(errs, fmt.Errorf("%s: declares no transport binding", where))
+	}
+	if s.Rest != nil {
+		if err := validateURL(s.Rest.Schema, true); err != nil {
+			errs = append(errs, fmt.Errorf("%s: rest schema %w", where, err))
+		}
+		if s.Rest.Endpoint != "" && !strings.HasPrefix(s.Rest.Endpoint, "/") {
+			if err := validateURL(s.Rest.Endpoint, true); err != nil {
+				errs = append(errs, fmt.Errorf("%s: rest endpoint %w", where, err))
+			}
+		}
+	}
+	if s.MCP != nil {
+		if err := validateURL(s.MCP.Endpoint, false); err != nil {
+			errs = append(errs, fmt.Errorf("%s: mcp endpoint %w", where, err))
+		}
+	}
+	if s.A2A != nil {
+		if err := validateURL(s.A2A.Endpoint, false); err != nil {
+			errs = append(errs, fmt.Errorf("%s: a2a endpoint %w", where, err))
+		}
+	}
+	return errs
+}
+
+// validatePaymentHandler checks a payment handler declaration.
+func validatePaymentHandler(i int, h models.PaymentHandlerResponse) []error {
+	var errs []error
+	where := strings.TrimSpace(fmt.Sprintf("payment_handlers[%d] %s", i, h.ID))
+	if h.ID == "" {
+		errs = append(errs, fmt.Errorf("%s: missing id", where))
+	}
+	if err := validation.ValidateCapabilityName(models.CapabilityName(h.Name)); err != nil {
+		errs = append(errs, fmt.Errorf("%s: invalid name %q (must be reverse-domain notation)", where, h.Name))
+	}
+	if err := validation.ValidateVersion(models.Version(h.Version)); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", where, err))
+	}
+	if err := validateURL(h.Spec, false); err != nil {
+		errs = append(errs, fmt.Errorf("%s: spec %w", where, err))
+	}
+	if err := validateURL(h.ConfigSchema, false); err != nil {
+		errs = append(errs, fmt.Errorf("%s: config_schema %w", where, err))
+	}
+	for j, u := range h.InstrumentSchemas {
+		if err := validateURL(u, false); err != nil {
+			errs = append(errs, fmt.Errorf("%s: instrument_schemas[%d] %w", where, j, err))
+		}
+	}
+	return errs
+}
+
+// validateURL checks that u is an absolute HTTP(S) URL, or empty if
+// optional.
+func validateURL(u string, optional bool) error {
+	if u == "" {
+		if optional {
+			return nil
+		}
+		return errors.New("is required")
+	}
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return fmt.Errorf("must be an absolute HTTP(S) URL: %q", u)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// ApplyProtection records protection plan offers and selections on a
+// checkout. Each valid selection becomes a child line item (ParentID set
+// to the protected line item) priced at the plan price per protected unit,
+// replacing plan line items from earlier calls. The subtotal and total are
+// recomputed, so call it after pricing and discounts and before tax.
+//
+// A selection may reference the protected line item by line item ID or,
+// as in create requests, by item ID. Selections naming an unknown offer or
+// line item produce a warning message and are dropped.
+func ApplyProtection(checkout *extensions.ExtendedCheckoutResponse, offers []models.ProtectionPlanOffer, selections []models.ProtectionSelection) []models.Message {
+	previous := make(map[string]bool)
+	if checkout.Protection != nil {
+		for _, o := range checkout.Protection.Offers {
+			previous[o.ID] = true
+		}
+	}
+	for _, o := range offers {
+		previous[o.ID] = true
+	}
+	items := checkout.LineItems[:0:0]
+	for _, li := range checkout.LineItems {
+		if li.ParentID != "" && previous[li.Item.ID] {
+			continue
+		}
+		items = append(items, li)
+	}
+
+	var msgs []models.Message
+	var applied []models.ProtectionSelection
+	seen := make(map[models.ProtectionSelection]bool)
+	children := make([]models.LineItemResponse, 0, len(selections))
+	for i, sel := range selections {
+		parent := findProtectedLineItem(items, sel.LineItemID)
+		offer := findProtectionOffer(offers, sel.OfferID, parent)
+		if parent != nil && offer != nil && seen[models.ProtectionSelection{LineItemID: parent.ID, OfferID: offer.ID}] {
+			continue
+		}
+		if parent == nil || offer == nil {
+			msgs = append(msgs, models.Message{
+				Type:    models.MessageTypeWarning,
+				Code:    "invalid_protection",
+				Content: fmt.Sprintf("Protection plan %s is not offered for line item %s", sel.OfferID, sel.LineItemID),
+				Path:    fmt.Sprintf("$.protection.selections[%d]", i),
+			})
+			continue
+		}
+		selection := models.ProtectionSelection{LineItemID: parent.ID, OfferID: offer.ID}
+		seen[selection] = true
+		applied = append(applied, selection)
+		children = append(children, models.LineItemResponse{
+			ID: parent.ID + "-" + offer.ID,
+			Item: models.ItemResponse{
+				ID:    offer.ID,
+				Title: offer.Title,
+				Price: offer.Price,
+			},
+			Quantity: parent.Quantity,
+			Totals: []models.TotalResponse{
+				{Type: models.TotalTypeSubtotal, Amount: offer.Price * parent.Quantity},
+			},
+			ParentID: parent.ID,
+		})
+	}
+	checkout.LineItems = append(items, children...)
+
+	if len(offers) > 0 || len(applied) > 0 {
+		checkout.Protection = &models.ProtectionResponse{Offers: offers, Selections: applied}
+	} else {
+		checkout.Protection = nil
+	}
+
+	subtotal := 0
+	for _, li := range checkout.LineItems {
+		subtotal += lineSubtotal(li)
+	}
+	checkout.Totals = setTotal(checkout.Totals, models.TotalTypeSubtotal, subtotal)
+	checkout.Totals = RecomputeTotal(checkout.Totals)
+	return msgs
+}
+
+// findProtectedLineItem returns the top-level line item with the given
+// line item ID, else the first with the given item ID.
+func findProtectedLineItem(items []models.LineItemResponse, ref string) *models.LineItemResponse {
+	for i := range items {
+		if items[i].ParentID == "" && items[i].ID == ref {
+			return &items[i]
+		}
+	}
+	for i := range items {
+		if items[i].ParentID == "" && items[i].Item.ID == ref {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+// findProtectionOffer returns the offer with the given ID for parent.
+func findProtectionOffer(offers []models.ProtectionPlanOffer, id string, parent *models.LineItemResponse) *models.ProtectionPlanOffer {
+	if parent == nil {
+		return nil
+	}
+	for i := range offers {
+		if offers[i].ID == id && (offers[i].LineItemID == parent.ID || offers[i].LineItemID == parent.Item.ID) {
+			return &offers[i]
+		}
+	}
+	return nil
+}
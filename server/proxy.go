@@ -0,0 +1,104 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const baseURLKey contextKey = "base_url"
+
+// BaseURL returns the externally visible base URL of the UCP server for the
+// current request (scheme, host, and Config.BasePath), e.g.
+// "https://shop.example/ucp". Use it to build ContinueURL and PermalinkURL
+// values that remain correct behind reverse proxies.
+func BaseURL(ctx context.Context) string {
+	if u, ok := ctx.Value(baseURLKey).(string); ok {
+		return u
+	}
+	return ""
+}
+
+// ResolveURL joins path onto BaseURL(ctx).
+func ResolveURL(ctx context.Context, path string) string {
+	return BaseURL(ctx) + "/" + strings.TrimPrefix(path, "/")
+}
+
+// baseURL computes the external base URL for a request.
+func (s *Server) baseURL(r *http.Request) string {
+	return s.origin(r) + s.config.BasePath
+}
+
+// origin returns scheme://host for a request, honoring X-Forwarded-Proto and
+// X-Forwarded-Host when TrustForwardedHeaders is enabled.
+func (s *Server) origin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := r.Host
+
+	if s.config.TrustForwardedHeaders {
+		if proto := firstHeaderValue(r.Header.Get("X-Forwarded-Proto")); proto != "" {
+			scheme = proto
+		}
+		if fwdHost := firstHeaderValue(r.Header.Get("X-Forwarded-Host")); fwdHost != "" {
+			host = fwdHost
+		}
+	}
+
+	return scheme + "://" + host
+}
+
+// resolveEndpoint fills in a REST endpoint for the discovery profile. Empty
+// endpoints resolve to the request's base URL, relative endpoints are joined
+// to the request origin, and absolute endpoints without a path receive the
+// configured base path.
+func (s *Server) resolveEndpoint(r *http.Request, endpoint string) string {
+	if endpoint == "" {
+		return s.baseURL(r)
+	}
+	if strings.HasPrefix(endpoint, "/") {
+		return s.origin(r) + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || s.config.BasePath == "" {
+		return endpoint
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = s.config.BasePath
+	}
+	return u.String()
+}
+
+// normalizeBasePath ensures a base path has a leading slash and no trailing slash.
+func normalizeBasePath(p string) string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return ""
+	}
+	return "/" + p
+}
+
+// firstHeaderValue returns the first comma-separated value of a header.
+func firstHeaderValue(v string) string {
+	if i := strings.IndexByte(v, ','); i >= 0 {
+		v = v[:i]
+	}
+	return strings.TrimSpace(v)
+}
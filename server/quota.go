@@ -0,0 +1,408 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Quota limit names reported in QuotaExceeded.
+const (
+	// QuotaOpenCheckouts limits a platform's concurrently open checkouts.
+	QuotaOpenCheckouts = "open_checkouts"
+
+	// QuotaDailyOrderValue limits the value of orders a platform completes
+	// per UTC day.
+	QuotaDailyOrderValue = "daily_order_value"
+)
+
+// QuotaLimits are the limits enforced for one platform. A zero limit is
+// not enforced.
+type QuotaLimits struct {
+	// MaxOpenCheckouts is the number of checkouts a platform may have open
+	// (not completed, canceled, or expired) at once.
+	MaxOpenCheckouts int
+
+	// MaxDailyOrderValue is the total value, in minor units, of checkouts a
+	// platform may complete per UTC day, keyed by ISO 4217 currency.
+	MaxDailyOrderValue map[string]int
+}
+
+// QuotaExceeded is the Details of a limit_exceeded error.
+type QuotaExceeded struct {
+	// Limit is the limit that was hit, QuotaOpenCheckouts or
+	// QuotaDailyOrderValue.
+	Limit string `json:"limit"`
+
+	// Max is the configured limit.
+	Max int `json:"max"`
+
+	// Current is the platform's usage before the rejected request.
+	Current int `json:"current"`
+
+	// Currency is the currency of a QuotaDailyOrderValue limit.
+	Currency string `json:"currency,omitempty"`
+}
+
+// QuotaCheckout is an open checkout tracked by a QuotaStore.
+type QuotaCheckout struct {
+	ID       string
+	Currency string
+
+	// Total is the checkout's last known total, in minor units.
+	Total int
+
+	// ExpiresAt is when the checkout stops counting as open; zero if it
+	// does not expire.
+	ExpiresAt time.Time
+}
+
+// QuotaStore holds the per-platform counters QuotaMiddleware enforces
+// limits against. Implementations must be safe for concurrent use; a
+// shared store lets limits hold across server replicas.
+type QuotaStore interface {
+	// OpenCheckouts returns the platform's tracked checkouts that have not
+	// expired at now.
+	OpenCheckouts(ctx context.Context, platform string, now time.Time) ([]QuotaCheckout, error)
+
+	// TrackCheckout records or updates an open checkout.
+	TrackCheckout(ctx context.Context, platform string, checkout QuotaCheckout) error
+
+	// ReleaseCheckout stops tracking a completed or canceled checkout.
+	ReleaseCheckout(ctx context.Context, platform, id string) error
+
+	// DailySpend returns the value of orders the platform completed in
+	// currency on day (YYYY-MM-DD, UTC).
+	DailySpend(ctx context.Context, platform, currency, day string) (int, error)
+
+	// AddSpend adds amount to the platform's spend in currency on day.
+	AddSpend(ctx context.Context, platform, currency, day string, amount int) error
+}
+
+// QuotaConfig configures QuotaMiddleware.
+type QuotaConfig struct {
+	// Limits apply to platforms without an entry in Platforms.
+	Limits QuotaLimits
+
+	// Platforms overrides Limits per platform profile URL.
+	Platforms map[string]QuotaLimits
+
+	// Store holds the counters. Defaults to a MemoryQuotaStore, which only
+	// limits a single server process.
+	Store QuotaStore
+
+	// Clock supplies the time for checkout expiry and daily windows.
+	// Defaults to SystemClock.
+	Clock Clock
+}
+
+// limits returns the limits for a platform.
+func (c *QuotaConfig) limits(platform string) QuotaLimits {
+	if l, ok := c.Platforms[platform]; ok {
+		return l
+	}
+	return c.Limits
+}
+
+// QuotaMiddleware enforces per-platform limits on checkout routes, so a
+// runaway agent cannot open unbounded sessions or spend without bound.
+// Platforms are identified by the profile URL in their UCP-Agent header;
+// requests without one share a single anonymous quota.
+//
+// The UCP-Agent header is only a claim: on its own, a caller can name a
+// fresh profile URL to get a fresh quota, or another platform's to use up
+// its quota. Register QuotaMiddleware after RequestSignatureMiddleware
+// (with CompletesOnly unset), which proves the caller holds a key from
+// the named profile on every create and completion, so quotas are keyed
+// by an authenticated platform.
+//
+// Creating a checkout is rejected once the platform has MaxOpenCheckouts
+// open, and completing one is rejected when its last known total would
+// take the platform past MaxDailyOrderValue for the day. Rejections are
+// 429 limit_exceeded errors whose details are a QuotaExceeded; daily
+// limits also set Retry-After to the start of the next UTC day. Checkouts
+// are tracked from the responses the middleware observes, and concurrent
+// completions are checked independently, so limits are a safeguard
+// rather than an exact budget.
+//
+// Register it on the checkout group, e.g.
+// srv.Use(GroupCheckout, RequestSignatureMiddleware(RequestSignatureConfig{}),
+// QuotaMiddleware(QuotaConfig{Limits: limits})).
+func QuotaMiddleware(config QuotaConfig) Middleware {
+	if config.Store == nil {
+		config.Store = NewMemoryQuotaStore()
+	}
+	config.Clock = clockOrSystem(config.Clock)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			platform, _ := PlatformProfileURL(r)
+			limits := config.limits(platform)
+			now := config.Clock.Now()
+			day := now.UTC().Format(time.DateOnly)
+
+			var err error
+			switch {
+			case r.Method == http.MethodPost && isCreateCheckoutPath(r.URL.Path):
+				err = checkOpenCheckouts(ctx, config.Store, platform, limits, now)
+			case r.Method == http.MethodPost && isCompletePath(r.URL.Path):
+				err = checkDailySpend(ctx, config.Store, platform, r.PathValue("id"), limits, now, day)
+			}
+			if err != nil {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) {
+					apiErr = InternalError("Failed to check quota: " + err.Error())
+				} else if details, ok := apiErr.Details.(QuotaExceeded); ok && details.Limit == QuotaDailyOrderValue {
+					w.Header().Set("Retry-After", strconv.Itoa(secondsUntilNextDay(now)))
+				}
+				WriteAPIError(w, apiErr)
+				return
+			}
+
+			qw := &quotaWriter{ResponseWriter: w}
+			next.ServeHTTP(qw, r)
+			if qw.statusCode >= 300 || qw.truncated {
+				return
+			}
+			var checkout quotaCheckoutResponse
+			if json.Unmarshal(qw.body.Bytes(), &checkout) != nil || checkout.ID == "" || checkout.Status == "" {
+				return
+			}
+			// Spend is counted on the completion itself, not on later reads
+			// of the completed checkout or replays of the completion.
+			completing := r.Method == http.MethodPost && isCompletePath(r.URL.Path) &&
+				qw.Header().Get(IdempotentReplayedHeader) == ""
+			trackCheckout(ctx, config.Store, platform, day, &checkout, completing)
+		})
+	}
+}
+
+// checkOpenCheckouts rejects a new checkout if the platform is at its open
+// checkout limit.
+func checkOpenCheckouts(ctx context.Context, store QuotaStore, platform string, limits QuotaLimits, now time.Time) error {
+	if limits.MaxOpenCheckouts <= 0 {
+		return nil
+	}
+	open, err := store.OpenCheckouts(ctx, platform, now)
+	if err != nil {
+		return err
+	}
+	if len(open) < limits.MaxOpenCheckouts {
+		return nil
+	}
+	return limitExceededError(
+		fmt.Sprintf("Platform has %d open checkouts; the limit is %d", len(open), limits.MaxOpenCheckouts),
+		QuotaExceeded{Limit: QuotaOpenCheckouts, Max: limits.MaxOpenCheckouts, Current: len(open)})
+}
+
+// checkDailySpend rejects completing a checkout whose total would take the
+// platform past its daily order value limit. Checkouts the store has not
+// seen are let through and counted once completed.
+func checkDailySpend(ctx context.Context, store QuotaStore, platform, id string, limits QuotaLimits, now time.Time, day string) error {
+	if len(limits.MaxDailyOrderValue) == 0 {
+		return nil
+	}
+	open, err := store.OpenCheckouts(ctx, platform, now)
+	if err != nil {
+		return err
+	}
+	for _, checkout := range open {
+		if checkout.ID != id {
+			continue
+		}
+		max, ok := limits.MaxDailyOrderValue[checkout.Currency]
+		if !ok || max <= 0 {
+			return nil
+		}
+		spent, err := store.DailySpend(ctx, platform, checkout.Currency, day)
+		if err != nil {
+			return err
+		}
+		if spent+checkout.Total <= max {
+			return nil
+		}
+		return limitExceededError(
+			fmt.Sprintf("Completing this checkout would exceed the platform's daily order value limit of %d %s", max, checkout.Currency),
+			QuotaExceeded{Limit: QuotaDailyOrderValue, Max: max, Current: spent, Currency: checkout.Currency})
+	}
+	return nil
+}
+
+// quotaCheckoutResponse is the part of a checkout response QuotaMiddleware
+// tracks.
+type quotaCheckoutResponse struct {
+	ID        string                 `json:"id"`
+	Status    models.CheckoutStatus  `json:"status"`
+	Currency  string                 `json:"currency"`
+	Totals    []models.TotalResponse `json:"totals"`
+	ExpiresAt *time.Time             `json:"expires_at"`
+}
+
+// trackCheckout updates the store from a checkout response: open
+// checkouts are tracked with their latest total, and terminal ones are
+// released. The total is added to the day's spend only when completing is
+// set, so a completed checkout counts once however often it is read.
+func trackCheckout(ctx context.Context, store QuotaStore, platform, day string, resp *quotaCheckoutResponse, completing bool) {
+	total, _ := totalAmount(resp.Totals, models.TotalTypeTotal)
+	switch resp.Status {
+	case models.CheckoutStatusCompleted:
+		store.ReleaseCheckout(ctx, platform, resp.ID)
+		if completing {
+			store.AddSpend(ctx, platform, resp.Currency, day, total)
+		}
+	case models.CheckoutStatusCanceled:
+		store.ReleaseCheckout(ctx, platform, resp.ID)
+	default:
+		checkout := QuotaCheckout{ID: resp.ID, Currency: resp.Currency, Total: total}
+		if resp.ExpiresAt != nil {
+			checkout.ExpiresAt = *resp.ExpiresAt
+		}
+		store.TrackCheckout(ctx, platform, checkout)
+	}
+}
+
+// limitExceededError creates a 429 limit_exceeded error.
+func limitExceededError(message string, details QuotaExceeded) *APIError {
+	err := NewAPIError(http.StatusTooManyRequests, string(models.ErrorCodeLimitExceeded), message)
+	err.Details = details
+	return err
+}
+
+// secondsUntilNextDay returns the seconds from now to the next UTC midnight.
+func secondsUntilNextDay(now time.Time) int {
+	next := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	return int(next.Sub(now).Seconds()) + 1
+}
+
+// isCreateCheckoutPath reports whether a request path creates a checkout.
+func isCreateCheckoutPath(path string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(path, "/"), "/checkout-sessions")
+}
+
+// quotaWriter captures a successful response body so QuotaMiddleware can
+// track the checkout it describes.
+type quotaWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+	truncated  bool
+}
+
+func (w *quotaWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *quotaWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	if w.statusCode < 300 {
+		if int64(w.body.Len()+len(b)) > DefaultMaxBodyBytes {
+			w.truncated = true
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// MemoryQuotaStore is an in-process QuotaStore. Spend for past days is
+// discarded as new days are recorded.
+type MemoryQuotaStore struct {
+	mu        sync.Mutex
+	checkouts map[string]map[string]QuotaCheckout
+	spend     map[quotaSpendKey]int
+}
+
+type quotaSpendKey struct {
+	platform, currency, day string
+}
+
+// NewMemoryQuotaStore creates an empty MemoryQuotaStore.
+func NewMemoryQuotaStore() *MemoryQuotaStore {
+	return &MemoryQuotaStore{
+		checkouts: make(map[string]map[string]QuotaCheckout),
+		spend:     make(map[quotaSpendKey]int),
+	}
+}
+
+// OpenCheckouts implements QuotaStore. Expired checkouts are dropped.
+func (s *MemoryQuotaStore) OpenCheckouts(ctx context.Context, platform string, now time.Time) ([]QuotaCheckout, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var open []QuotaCheckout
+	for id, checkout := range s.checkouts[platform] {
+		if !checkout.ExpiresAt.IsZero() && !now.Before(checkout.ExpiresAt) {
+			delete(s.checkouts[platform], id)
+			continue
+		}
+		open = append(open, checkout)
+	}
+	return open, nil
+}
+
+// TrackCheckout implements QuotaStore.
+func (s *MemoryQuotaStore) TrackCheckout(ctx context.Context, platform string, checkout QuotaCheckout) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checkouts[platform] == nil {
+		s.checkouts[platform] = make(map[string]QuotaCheckout)
+	}
+	s.checkouts[platform][checkout.ID] = checkout
+	return nil
+}
+
+// ReleaseCheckout implements QuotaStore.
+func (s *MemoryQuotaStore) ReleaseCheckout(ctx context.Context, platform, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.checkouts[platform], id)
+	return nil
+}
+
+// DailySpend implements QuotaStore.
+func (s *MemoryQuotaStore) DailySpend(ctx context.Context, platform, currency, day string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.spend[quotaSpendKey{platform, currency, day}], nil
+}
+
+// AddSpend implements QuotaStore.
+func (s *MemoryQuotaStore) AddSpend(ctx context.Context, platform, currency, day string, amount int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.spend {
+		if key.day < day {
+			delete(s.spend, key)
+		}
+	}
+	s.spend[quotaSpendKey{platform, currency, day}] += amount
+	return nil
+}
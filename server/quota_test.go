@@ -0,0 +1,175 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// quotaServer serves checkouts chk_1, chk_2, ... whose status moves to
+// completed on completion, with every route guarded by QuotaMiddleware.
+func quotaServer(store server.QuotaStore, limits server.QuotaLimits, now time.Time) *server.Server {
+	completed := map[string]bool{}
+	checkout := func(id string) *extensions.ExtendedCheckoutResponse {
+		status := models.CheckoutStatusReadyForComplete
+		if completed[id] {
+			status = models.CheckoutStatusCompleted
+		}
+		return &extensions.ExtendedCheckoutResponse{
+			ID: id, Status: status, Currency: "USD",
+			Totals: []models.TotalResponse{{Type: models.TotalTypeTotal, Amount: 3261}},
+		}
+	}
+	srv := server.NewServer(server.Config{Version: "2026-01-11"})
+	srv.Use(server.GroupCheckout, server.QuotaMiddleware(server.QuotaConfig{
+		Limits: limits, Store: store, Clock: fixedClock{now},
+	}))
+	created := 0
+	srv.HandleCreateCheckout(func(r *http.Request, req *extensions.ExtendedCheckoutCreateRequest) (*extensions.ExtendedCheckoutResponse, error) {
+		created++
+		return checkout(fmt.Sprintf("chk_%d", created)), nil
+	})
+	srv.HandleGetCheckout(func(r *http.Request, id string) (*extensions.ExtendedCheckoutResponse, error) {
+		return checkout(id), nil
+	})
+	srv.HandleCompleteCheckout(func(r *http.Request, id string) (*extensions.ExtendedCheckoutResponse, error) {
+		completed[id] = true
+		return checkout(id), nil
+	})
+	return srv
+}
+
+// quotaStep is one request to a quotaServer and its expected status.
+type quotaStep struct {
+	method, path, body string
+	status             int
+}
+
+// send makes the request as platformA.
+func (step quotaStep) send(srv *server.Server) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(step.method, step.path, strings.NewReader(step.body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(server.UCPAgentHeader, `profile="`+platformA+`"`)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	return rec
+}
+
+const createBody = `{"currency":"USD","line_items":[]}`
+
+func TestQuotaCountsCompletedSpendOnce(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := server.NewMemoryQuotaStore()
+	srv := quotaServer(store, server.QuotaLimits{MaxDailyOrderValue: map[string]int{"USD": 100000}}, now)
+
+	steps := []quotaStep{
+		{http.MethodPost, "/checkout-sessions", createBody, http.StatusCreated},
+		{http.MethodPost, "/checkout-sessions/chk_1/complete", `{}`, http.StatusOK},
+		{http.MethodGet, "/checkout-sessions/chk_1", "", http.StatusOK},
+		{http.MethodGet, "/checkout-sessions/chk_1", "", http.StatusOK},
+		{http.MethodGet, "/checkout-sessions/chk_1", "", http.StatusOK},
+	}
+	for _, step := range steps {
+		if rec := step.send(srv); rec.Code != step.status {
+			t.Fatalf("%s %s: status %d, want %d: %s", step.method, step.path, rec.Code, step.status, rec.Body)
+		}
+	}
+
+	spent, err := store.DailySpend(context.Background(), platformA, "USD", now.Format(time.DateOnly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spent != 3261 {
+		t.Errorf("daily spend = %d after one completion and three reads, want 3261", spent)
+	}
+}
+
+func TestQuotaRejections(t *testing.T) {
+	// 22:00 UTC, two hours before the daily limit resets.
+	now := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		limits     server.QuotaLimits
+		steps      []quotaStep
+		limit      string
+		retryAfter string
+	}{
+		{
+			name:   "open checkouts",
+			limits: server.QuotaLimits{MaxOpenCheckouts: 2},
+			steps: []quotaStep{
+				{http.MethodPost, "/checkout-sessions", createBody, http.StatusCreated},
+				{http.MethodPost, "/checkout-sessions", createBody, http.StatusCreated},
+				{http.MethodPost, "/checkout-sessions", createBody, http.StatusTooManyRequests},
+			},
+			limit: server.QuotaOpenCheckouts,
+		},
+		{
+			name:   "completing frees an open checkout",
+			limits: server.QuotaLimits{MaxOpenCheckouts: 1},
+			steps: []quotaStep{
+				{http.MethodPost, "/checkout-sessions", createBody, http.StatusCreated},
+				{http.MethodPost, "/checkout-sessions/chk_1/complete", `{}`, http.StatusOK},
+				{http.MethodPost, "/checkout-sessions", createBody, http.StatusCreated},
+			},
+		},
+		{
+			name:   "daily order value",
+			limits: server.QuotaLimits{MaxDailyOrderValue: map[string]int{"USD": 5000}},
+			steps: []quotaStep{
+				{http.MethodPost, "/checkout-sessions", createBody, http.StatusCreated},
+				{http.MethodPost, "/checkout-sessions", createBody, http.StatusCreated},
+				{http.MethodPost, "/checkout-sessions/chk_1/complete", `{}`, http.StatusOK},
+				{http.MethodPost, "/checkout-sessions/chk_2/complete", `{}`, http.StatusTooManyRequests},
+			},
+			limit:      server.QuotaDailyOrderValue,
+			retryAfter: "7201",
+		},
+	}
+	for _, tt := range tests {
+		srv := quotaServer(server.NewMemoryQuotaStore(), tt.limits, now)
+		var last *httptest.ResponseRecorder
+		for _, step := range tt.steps {
+			if last = step.send(srv); last.Code != step.status {
+				t.Fatalf("%s: %s %s: status %d, want %d: %s", tt.name, step.method, step.path, last.Code, step.status, last.Body)
+			}
+		}
+		if tt.limit == "" {
+			continue
+		}
+		var body struct {
+			Error   string               `json:"error"`
+			Details server.QuotaExceeded `json:"details"`
+		}
+		json.Unmarshal(last.Body.Bytes(), &body)
+		if body.Error != "limit_exceeded" || body.Details.Limit != tt.limit {
+			t.Errorf("%s: %s, want limit_exceeded for %s", tt.name, last.Body, tt.limit)
+		}
+		if got := last.Header().Get("Retry-After"); got != tt.retryAfter {
+			t.Errorf("%s: Retry-After %q, want %q", tt.name, got, tt.retryAfter)
+		}
+	}
+}
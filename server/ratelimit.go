@@ -0,0 +1,207 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// RateLimit is a token bucket: requests are let through at Rate per
+// second on average, in bursts of up to Burst.
+type RateLimit struct {
+	// Rate is the tokens added to the bucket per second. A limit with no
+	// Rate is not enforced.
+	Rate float64
+
+	// Burst is the bucket's capacity. Defaults to Rate rounded up, and at
+	// least 1.
+	Burst int
+}
+
+// RateLimitStore holds the token buckets RateLimitMiddleware draws from.
+// Implementations must be safe for concurrent use; a shared store, such as
+// redisstore.RateLimitStore, lets limits hold across server replicas.
+type RateLimitStore interface {
+	// Take removes a token from key's bucket as of now, refilling it
+	// under limit first; limit.Rate and limit.Burst are always positive.
+	// If the bucket is empty it takes nothing and returns false with the
+	// wait until a token is available. Taking and refilling must be
+	// atomic.
+	Take(ctx context.Context, key string, limit RateLimit, now time.Time) (ok bool, wait time.Duration, err error)
+}
+
+// RateLimitConfig configures RateLimitMiddleware.
+type RateLimitConfig struct {
+	// Limit applies to callers without an entry in APIKeys or Platforms.
+	Limit RateLimit
+
+	// APIKeys overrides Limit per X-API-Key value.
+	APIKeys map[string]RateLimit
+
+	// Platforms overrides Limit per platform profile URL, for requests
+	// without an API key. The profile URL is taken from the UCP-Agent
+	// header, which is authenticated only behind RequestSignatureMiddleware.
+	Platforms map[string]RateLimit
+
+	// Store holds the buckets. Defaults to a MemoryRateLimitStore, which
+	// only limits a single server process.
+	Store RateLimitStore
+
+	// Clock supplies the time buckets refill by. Defaults to SystemClock.
+	Clock Clock
+}
+
+// bucket returns the bucket key and limit for a request. API keys are
+// hashed so they are not written to a shared store.
+func (c *RateLimitConfig) bucket(r *http.Request) (string, RateLimit) {
+	if apiKey := r.Header.Get("X-API-Key"); apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		key := "key:" + hex.EncodeToString(sum[:16])
+		if l, ok := c.APIKeys[apiKey]; ok {
+			return key, l
+		}
+		return key, c.Limit
+	}
+	if platform, err := PlatformProfileURL(r); err == nil {
+		if l, ok := c.Platforms[platform]; ok {
+			return "agent:" + platform, l
+		}
+		return "agent:" + platform, c.Limit
+	}
+	return "anonymous", c.Limit
+}
+
+// RateLimitMiddleware limits each caller's request rate with a token
+// bucket. Callers are identified by their X-API-Key header or, without
+// one, by the profile URL in their UCP-Agent header; requests with
+// neither share a single anonymous bucket. Register it after
+// APIKeyMiddleware so made-up keys cannot each claim a fresh bucket.
+//
+// The UCP-Agent header is only a claim, so keying on it alone lets a
+// caller spread requests across made-up profile URLs, or drain another
+// platform's bucket by naming its profile. Where callers do not present
+// API keys, register it after RequestSignatureMiddleware so state-changing
+// requests are keyed by an authenticated platform, and set Limit for the
+// unsigned reads low enough to hold for any one caller.
+//
+// A request finding its bucket empty is rejected with a 429
+// limit_exceeded error and a Retry-After header giving the seconds until
+// the next token. A store failure is a 500 error.
+//
+// For example, srv.Use(GroupCheckout, RateLimitMiddleware(RateLimitConfig{
+// Limit: RateLimit{Rate: 5, Burst: 20}})).
+func RateLimitMiddleware(config RateLimitConfig) Middleware {
+	if config.Store == nil {
+		config.Store = NewMemoryRateLimitStore()
+	}
+	config.Clock = clockOrSystem(config.Clock)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, limit := config.bucket(r)
+			if limit.Rate <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if limit.Burst <= 0 {
+				limit.Burst = max(1, int(math.Ceil(limit.Rate)))
+			}
+			ok, wait, err := config.Store.Take(r.Context(), key, limit, config.Clock.Now())
+			if err != nil {
+				WriteAPIError(w, InternalError("Failed to check rate limit: "+err.Error()))
+				return
+			}
+			if !ok {
+				seconds := int(math.Ceil(wait.Seconds()))
+				if seconds < 1 {
+					seconds = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				WriteError(w, http.StatusTooManyRequests, string(models.ErrorCodeLimitExceeded),
+					fmt.Sprintf("Rate limit exceeded; retry in %d seconds", seconds))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MemoryRateLimitStore is an in-process RateLimitStore. Buckets that have
+// refilled are discarded as the clock moves on.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// tokenBucket is a bucket's tokens as of a time.
+type tokenBucket struct {
+	tokens float64
+	at     time.Time
+	full   time.Time
+}
+
+// NewMemoryRateLimitStore creates an empty MemoryRateLimitStore.
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*tokenBucket)}
+}
+
+// Take implements RateLimitStore.
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, limit RateLimit, now time.Time) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+
+	burst := float64(limit.Burst)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: burst, at: now}
+		s.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.at); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed.Seconds()*limit.Rate)
+		b.at = now
+	}
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+		return false, wait, nil
+	}
+	b.tokens--
+	b.full = now.Add(time.Duration((burst - b.tokens) / limit.Rate * float64(time.Second)))
+	return true, 0, nil
+}
+
+// sweep drops full buckets, at most once a minute. s.mu must be held.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	clock := &fixedClock{time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	handler := server.RateLimitMiddleware(server.RateLimitConfig{
+		Limit:     server.RateLimit{Rate: 0.5, Burst: 2},
+		Platforms: map[string]server.RateLimit{platformB: {Rate: 10}},
+		Clock:     clock,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		platform   string
+		apiKey     string
+		advance    time.Duration
+		status     int
+		retryAfter string
+	}{
+		{"burst 1", platformA, "", 0, http.StatusOK, ""},
+		{"burst 2", platformA, "", 0, http.StatusOK, ""},
+		{"bucket empty", platformA, "", 0, http.StatusTooManyRequests, "2"},
+		{"other platform has its own limit", platformB, "", 0, http.StatusOK, ""},
+		{"API key has its own bucket", platformA, "key-1", 0, http.StatusOK, ""},
+		{"still empty after a second", platformA, "", time.Second, http.StatusTooManyRequests, "1"},
+		{"refilled", platformA, "", time.Second, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		clock.now = clock.now.Add(tt.advance)
+		req := httptest.NewRequest(http.MethodPost, "/checkout-sessions", nil)
+		req.Header.Set(server.UCPAgentHeader, `profile="`+tt.platform+`"`)
+		if tt.apiKey != "" {
+			req.Header.Set("X-API-Key", tt.apiKey)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.status || rec.Header().Get("Retry-After") != tt.retryAfter {
+			t.Errorf("%s: %d, Retry-After %q; want %d, %q", tt.name, rec.Code, rec.Header().Get("Retry-After"), tt.status, tt.retryAfter)
+		}
+		if tt.status == http.StatusTooManyRequests && errorCode(rec) != "limit_exceeded" {
+			t.Errorf("%s: %s, want limit_exceeded", tt.name, rec.Body)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// RateProvider quotes fulfillment options (carrier, price, delivery window)
+// for line items sent to a destination.
+//
+// When Config.RateProvider is set, the server calls it after every checkout
+// create and update handler for each fulfillment method that has a selected
+// destination, and merges the returned options into the method's groups.
+type RateProvider interface {
+	Rates(ctx context.Context, method models.FulfillmentMethodType, lineItems []models.LineItemResponse, destination *models.PostalAddress) ([]models.FulfillmentOptionResponse, error)
+}
+
+// ApplyFulfillmentRates quotes options for every fulfillment method with a
+// selected destination. Methods without groups receive a single group
+// covering all of their line items. Existing option selections are kept when
+// the option is still offered, and the checkout's fulfillment total is set to
+// the sum of the selected options.
+func ApplyFulfillmentRates(ctx context.Context, provider RateProvider, checkout *extensions.ExtendedCheckoutResponse) error {
+	if checkout.Fulfillment == nil {
+		return nil
+	}
+
+	lineItems := make(map[string]models.LineItemResponse, len(checkout.LineItems))
+	for _, li := range checkout.LineItems {
+		lineItems[li.ID] = li
+	}
+
+	quoted := false
+	fulfillmentTotal := 0
+	for i := range checkout.Fulfillment.Methods {
+		m := &checkout.Fulfillment.Methods[i]
+		dest := selectedDestination(m)
+		if dest == nil {
+			continue
+		}
+
+		if len(m.Groups) == 0 {
+			m.Groups = []models.FulfillmentGroupResponse{{
+				ID:          fmt.Sprintf("%s-group-1", m.ID),
+				LineItemIDs: m.LineItemIDs,
+			}}
+		}
+
+		for j := range m.Groups {
+			g := &m.Groups[j]
+			items := make([]models.LineItemResponse, 0, len(g.LineItemIDs))
+			for _, id := range g.LineItemIDs {
+				if li, ok := lineItems[id]; ok {
+					items = append(items, li)
+				}
+			}
+
+			options, err := provider.Rates(ctx, m.Type, items, dest)
+			if err != nil {
+				return err
+			}
+			g.Options = options
+			quoted = true
+
+			if g.SelectedOptionID == nil {
+				continue
+			}
+			if opt := findOption(options, *g.SelectedOptionID); opt != nil {
+				fulfillmentTotal += optionAmount(*opt)
+			} else {
+				g.SelectedOptionID = nil
+			}
+		}
+	}
+
+	if quoted {
+		checkout.Totals = setTotal(checkout.Totals, models.TotalTypeFulfillment, fulfillmentTotal)
+		checkout.Totals = RecomputeTotal(checkout.Totals)
+	}
+	return nil
+}
+
+// selectedDestination returns the address of a method's selected destination.
+func selectedDestination(m *models.FulfillmentMethodResponse) *models.PostalAddress {
+	if m.SelectedDestinationID == nil {
+		return nil
+	}
+	for _, d := range m.Destinations {
+		if d.ID != *m.SelectedDestinationID {
+			continue
+		}
+		if d.Address != nil {
+			addr := *d.Address
+			return &addr
+		}
+		addr := d.PostalAddress
+		return &addr
+	}
+	return nil
+}
+
+// findOption returns the option with the given ID, or nil.
+func findOption(options []models.FulfillmentOptionResponse, id string) *models.FulfillmentOptionResponse {
+	for i := range options {
+		if options[i].ID == id {
+			return &options[i]
+		}
+	}
+	return nil
+}
+
+// optionAmount returns the price of a fulfillment option: its total entry if
+// present, otherwise its fulfillment entry.
+func optionAmount(opt models.FulfillmentOptionResponse) int {
+	amount := 0
+	for _, t := range opt.Totals {
+		switch t.Type {
+		case models.TotalTypeTotal:
+			return t.Amount
+		case models.TotalTypeFulfillment:
+			amount = t.Amount
+		}
+	}
+	return amount
+}
+
+// enrichCheckout runs the configured rate provider, pickup location
+// provider, and tax calculator against a checkout response produced by a
+// handler.
+func (s *Server) enrichCheckout(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse) error {
+	if checkout == nil {
+		return nil
+	}
+	if s.config.RateProvider != nil {
+		if err := ApplyFulfillmentRates(ctx, s.config.RateProvider, checkout); err != nil {
+			return err
+		}
+	}
+	if s.config.PickupLocations != nil {
+		if err := applyPickupLocations(ctx, s.config.PickupLocations, s.config.PickupRadiusKM, s.config.Clock.Now(), checkout); err != nil {
+			return err
+		}
+	}
+	return s.applyTax(ctx, checkout)
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// DefaultIdempotencyPrefix prefixes every key an IdempotencyStore writes.
+// Each record is one key, so records spread across a Redis Cluster.
+const DefaultIdempotencyPrefix = "ucp:idempotency:"
+
+// IdempotencyStore is a server.IdempotencyStore in Redis. Each record is a
+// JSON string that Redis expires at the record's ExpiresAt; keys are
+// reserved with SET NX, so only one replica handles a given key.
+type IdempotencyStore struct {
+	do     Do
+	prefix string
+}
+
+// IdempotencyOption configures an IdempotencyStore.
+type IdempotencyOption func(*IdempotencyStore)
+
+// WithIdempotencyPrefix replaces DefaultIdempotencyPrefix.
+func WithIdempotencyPrefix(prefix string) IdempotencyOption {
+	return func(s *IdempotencyStore) {
+		s.prefix = prefix
+	}
+}
+
+// NewIdempotencyStore creates an IdempotencyStore that sends commands with
+// do:
+//
+//	srv.Use(server.GroupCheckout, server.IdempotencyMiddleware(server.IdempotencyConfig{
+//		Store: redisstore.NewIdempotencyStore(do),
+//	}))
+func NewIdempotencyStore(do Do, opts ...IdempotencyOption) *IdempotencyStore {
+	s := &IdempotencyStore{do: do, prefix: DefaultIdempotencyPrefix}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// reserveScript stores a record unless the key holds one, returning ""
+// on success or the existing record.
+// KEYS: record. ARGV: JSON, milliseconds to live.
+const reserveScript = `
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then return '' end
+local v = redis.call('GET', KEYS[1])
+if not v then return '' end
+return v`
+
+// recordKey returns the Redis key for a scoped idempotency key, hashed
+// since it embeds the caller's profile URL and may be long.
+func (s *IdempotencyStore) recordKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return s.prefix + hex.EncodeToString(sum[:])
+}
+
+// ReserveKey implements server.IdempotencyStore. Expiry follows the Redis
+// server's clock rather than now.
+func (s *IdempotencyStore) ReserveKey(ctx context.Context, key string, record *server.IdempotencyRecord, now time.Time) (*server.IdempotencyRecord, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := s.do(ctx, "EVAL", reserveScript, 1, s.recordKey(key), string(data), ttlMillis(record.ExpiresAt.Sub(now)))
+	if err != nil {
+		return nil, err
+	}
+	existing, err := bulk(reply)
+	if err != nil || len(existing) == 0 {
+		return nil, err
+	}
+	var r server.IdempotencyRecord
+	if err := json.Unmarshal(existing, &r); err != nil {
+		return nil, fmt.Errorf("redisstore: corrupt idempotency record: %w", err)
+	}
+	return &r, nil
+}
+
+// SaveKey implements server.IdempotencyStore.
+func (s *IdempotencyStore) SaveKey(ctx context.Context, key string, record *server.IdempotencyRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = s.do(ctx, "SET", s.recordKey(key), string(data), "PX", ttlMillis(time.Until(record.ExpiresAt)))
+	return err
+}
+
+// DeleteKey implements server.IdempotencyStore.
+func (s *IdempotencyStore) DeleteKey(ctx context.Context, key string) error {
+	_, err := s.do(ctx, "DEL", s.recordKey(key))
+	return err
+}
+
+// ttlMillis returns d as a PX argument, at least one millisecond.
+func ttlMillis(d time.Duration) string {
+	return strconv.FormatInt(max(1, d.Milliseconds()), 10)
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/client"
+)
+
+const (
+	// DefaultProfilePrefix prefixes every key a ProfileCache writes.
+	DefaultProfilePrefix = "ucp:profile:"
+
+	// DefaultProfileRetention is how long a ProfileCache keeps a profile
+	// after it goes stale, so it can still be revalidated by ETag.
+	DefaultProfileRetention = 24 * time.Hour
+)
+
+// ProfileCache is a client.ProfileCache in Redis, so a platform's
+// processes fetch each merchant's discovery profile once between them.
+// Each profile is a JSON string keyed by merchant base URL.
+type ProfileCache struct {
+	do        Do
+	prefix    string
+	retention time.Duration
+}
+
+// ProfileCacheOption configures a ProfileCache.
+type ProfileCacheOption func(*ProfileCache)
+
+// WithProfilePrefix replaces DefaultProfilePrefix.
+func WithProfilePrefix(prefix string) ProfileCacheOption {
+	return func(c *ProfileCache) {
+		c.prefix = prefix
+	}
+}
+
+// WithProfileRetention replaces DefaultProfileRetention.
+func WithProfileRetention(retention time.Duration) ProfileCacheOption {
+	return func(c *ProfileCache) {
+		c.retention = retention
+	}
+}
+
+// NewProfileCache creates a ProfileCache that sends commands with do:
+//
+//	cache := redisstore.NewProfileCache(do)
+//	c := client.NewClient(merchantURL, client.WithProfileCache(cache))
+func NewProfileCache(do Do, opts ...ProfileCacheOption) *ProfileCache {
+	c := &ProfileCache{do: do, prefix: DefaultProfilePrefix, retention: DefaultProfileRetention}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get implements client.ProfileCache.
+func (c *ProfileCache) Get(ctx context.Context, baseURL string) (*client.CachedProfile, error) {
+	reply, err := c.do(ctx, "EVAL", getScript, 1, c.prefix+baseURL)
+	if err != nil {
+		return nil, err
+	}
+	data, err := bulk(reply)
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	var profile client.CachedProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("redisstore: corrupt profile for %s: %w", baseURL, err)
+	}
+	return &profile, nil
+}
+
+// Set implements client.ProfileCache. The key expires the retention
+// period after the profile goes stale.
+func (c *ProfileCache) Set(ctx context.Context, baseURL string, profile *client.CachedProfile) error {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(profile.Expires) + c.retention
+	if ttl < time.Millisecond {
+		ttl = time.Millisecond
+	}
+	_, err = c.do(ctx, "SET", c.prefix+baseURL, string(data), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// Delete implements client.ProfileCache.
+func (c *ProfileCache) Delete(ctx context.Context, baseURL string) error {
+	_, err := c.do(ctx, "DEL", c.prefix+baseURL)
+	return err
+}
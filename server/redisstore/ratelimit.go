@@ -0,0 +1,100 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// DefaultRateLimitPrefix prefixes every key a RateLimitStore writes. Each
+// bucket is one key, so buckets spread across a Redis Cluster.
+const DefaultRateLimitPrefix = "ucp:ratelimit:"
+
+// RateLimitStore is a server.RateLimitStore in Redis. Each bucket is a
+// hash of its tokens and when they were counted, refilled and drawn from
+// inside one script, and left to expire once it would be full again.
+type RateLimitStore struct {
+	do     Do
+	prefix string
+}
+
+// RateLimitOption configures a RateLimitStore.
+type RateLimitOption func(*RateLimitStore)
+
+// WithRateLimitPrefix replaces DefaultRateLimitPrefix.
+func WithRateLimitPrefix(prefix string) RateLimitOption {
+	return func(s *RateLimitStore) {
+		s.prefix = prefix
+	}
+}
+
+// NewRateLimitStore creates a RateLimitStore that sends commands with do:
+//
+//	srv.Use(server.GroupCheckout, server.RateLimitMiddleware(server.RateLimitConfig{
+//		Limit: server.RateLimit{Rate: 5, Burst: 20},
+//		Store: redisstore.NewRateLimitStore(do),
+//	}))
+func NewRateLimitStore(do Do, opts ...RateLimitOption) *RateLimitStore {
+	s := &RateLimitStore{do: do, prefix: DefaultRateLimitPrefix}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// takeScript refills a bucket to ARGV[3] and takes a token, returning 0,
+// or the milliseconds until a token is available if it is empty.
+// KEYS: bucket. ARGV: rate per second, burst, now in milliseconds.
+const takeScript = `
+local rate, burst, now = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3])
+local b = redis.call('HMGET', KEYS[1], 'tokens', 'at')
+local tokens, at = tonumber(b[1]), tonumber(b[2])
+if not tokens then tokens, at = burst, now end
+if now > at then
+	tokens = math.min(burst, tokens + (now - at) * rate / 1000)
+	at = now
+end
+local wait = 0
+if tokens < 1 then
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+else
+	tokens = tokens - 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'at', at)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) * 1000 / rate) + 1000)
+return wait`
+
+// Take implements server.RateLimitStore.
+func (s *RateLimitStore) Take(ctx context.Context, key string, limit server.RateLimit, now time.Time) (bool, time.Duration, error) {
+	reply, err := s.do(ctx, "EVAL", takeScript, 1, s.prefix+key,
+		strconv.FormatFloat(limit.Rate, 'f', -1, 64), strconv.Itoa(limit.Burst),
+		strconv.FormatInt(now.UnixMilli(), 10))
+	if err != nil {
+		return false, 0, err
+	}
+	wait, ok := reply.(int64)
+	if !ok {
+		return false, 0, fmt.Errorf("redisstore: unexpected rate limit reply %T", reply)
+	}
+	if wait > 0 {
+		return false, time.Duration(wait) * time.Millisecond, nil
+	}
+	return true, 0, nil
+}
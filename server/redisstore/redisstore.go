@@ -0,0 +1,206 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redisstore implements the server's persistence interfaces on
+// Redis, so several server instances can share one queue of webhook
+// deliveries, one set of rate limit buckets, and one set of idempotency
+// keys. It also provides a
+// client.ProfileCache, so a platform's processes can share merchants'
+// discovery profiles.
+//
+// The package does not depend on a Redis client. It sends commands
+// through a Do function, which adapts whichever client the program uses;
+// for github.com/redis/go-redis:
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: addr})
+//	store := redisstore.NewDeliveryStore(func(ctx context.Context, args ...any) (any, error) {
+//		return rdb.Do(ctx, args...).Result()
+//	})
+//	dispatcher := server.NewWebhookDispatcher(subs, signer)
+//	dispatcher.Store = store
+//
+// Every DeliveryStore key shares one hash tag, so the store works on Redis
+// Cluster.
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// DefaultPrefix prefixes every key the store writes.
+const DefaultPrefix = "ucp:{webhooks}:"
+
+// Do sends one Redis command and returns its reply: nil, an integer, a
+// string or []byte, or a []any of replies.
+type Do func(ctx context.Context, args ...any) (any, error)
+
+// DeliveryStore is a server.DeliveryStore in Redis. Each delivery is a
+// JSON string, and pending deliveries are indexed by a sorted set scored
+// by when they are next due. A claim moves the score to the end of the
+// lease, inside one script, so concurrent dispatchers never claim the
+// same delivery.
+type DeliveryStore struct {
+	do     Do
+	prefix string
+}
+
+// Option configures a DeliveryStore.
+type Option func(*DeliveryStore)
+
+// WithPrefix replaces DefaultPrefix. Keep a {hash tag} in it on Redis
+// Cluster.
+func WithPrefix(prefix string) Option {
+	return func(s *DeliveryStore) {
+		s.prefix = prefix
+	}
+}
+
+// NewDeliveryStore creates a DeliveryStore that sends commands with do.
+func NewDeliveryStore(do Do, opts ...Option) *DeliveryStore {
+	s := &DeliveryStore{do: do, prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// saveScript stores a delivery and indexes it while pending.
+// KEYS: delivery, due. ARGV: JSON, id, pending score or "".
+const saveScript = `
+redis.call('SET', KEYS[1], ARGV[1])
+if ARGV[3] == '' then
+	redis.call('ZREM', KEYS[2], ARGV[2])
+else
+	redis.call('ZADD', KEYS[2], ARGV[3], ARGV[2])
+end
+return 1`
+
+// getScript returns a delivery, or "" if there is none, so a missing key
+// is not a client-specific nil error.
+// KEYS: delivery.
+const getScript = `
+local v = redis.call('GET', KEYS[1])
+if not v then return '' end
+return v`
+
+// claimScript leases up to ARGV[3] deliveries due at ARGV[1] until
+// ARGV[2], returning their JSON.
+// KEYS: due. ARGV: now, lease end, limit, delivery key prefix.
+const claimScript = `
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[3]))
+local out = {}
+for _, id in ipairs(ids) do
+	local v = redis.call('GET', ARGV[4] .. id)
+	if v then
+		redis.call('ZADD', KEYS[1], ARGV[2], id)
+		table.insert(out, v)
+	else
+		redis.call('ZREM', KEYS[1], id)
+	end
+end
+return out`
+
+func (s *DeliveryStore) deliveryKey(id string) string { return s.prefix + "delivery:" + id }
+func (s *DeliveryStore) dueKey() string               { return s.prefix + "due" }
+
+// SaveDelivery implements server.DeliveryStore.
+func (s *DeliveryStore) SaveDelivery(ctx context.Context, d *server.WebhookDelivery) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	score := ""
+	if d.Status == server.DeliveryPending {
+		due := d.NextAttemptAt
+		if d.LeasedUntil.After(due) {
+			due = d.LeasedUntil
+		}
+		score = strconv.FormatInt(due.UnixMilli(), 10)
+	}
+	_, err = s.do(ctx, "EVAL", saveScript, 2, s.deliveryKey(d.ID), s.dueKey(), string(data), d.ID, score)
+	return err
+}
+
+// GetDelivery implements server.DeliveryStore.
+func (s *DeliveryStore) GetDelivery(ctx context.Context, id string) (*server.WebhookDelivery, error) {
+	reply, err := s.do(ctx, "EVAL", getScript, 1, s.deliveryKey(id))
+	if err != nil {
+		return nil, err
+	}
+	data, err := bulk(reply)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, server.ErrDeliveryNotFound
+	}
+	var d server.WebhookDelivery
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("redisstore: corrupt delivery %s: %w", id, err)
+	}
+	return &d, nil
+}
+
+// ClaimDeliveries implements server.DeliveryStore. Deliveries come back
+// in due order; a non-positive limit claims at most 100.
+func (s *DeliveryStore) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*server.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	until := now.Add(lease)
+	reply, err := s.do(ctx, "EVAL", claimScript, 1, s.dueKey(),
+		strconv.FormatInt(now.UnixMilli(), 10), strconv.FormatInt(until.UnixMilli(), 10),
+		strconv.Itoa(limit), s.deliveryKey(""))
+	if err != nil {
+		return nil, err
+	}
+	items, ok := reply.([]any)
+	if !ok && reply != nil {
+		return nil, fmt.Errorf("redisstore: unexpected claim reply %T", reply)
+	}
+
+	claimed := make([]*server.WebhookDelivery, 0, len(items))
+	for _, item := range items {
+		data, err := bulk(item)
+		if err != nil {
+			return nil, err
+		}
+		var d server.WebhookDelivery
+		if err := json.Unmarshal(data, &d); err != nil {
+			return nil, fmt.Errorf("redisstore: corrupt delivery: %w", err)
+		}
+		d.LeasedUntil = until
+		claimed = append(claimed, &d)
+	}
+	return claimed, nil
+}
+
+// bulk converts a bulk string reply, as clients variously return it.
+func bulk(reply any) ([]byte, error) {
+	switch v := reply.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	}
+	return nil, fmt.Errorf("redisstore: unexpected reply %T", reply)
+}
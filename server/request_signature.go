@@ -0,0 +1,232 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/httpcache"
+	"github.com/dhananjay2021/ucp-go-sdk/internal"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// SignatureHeader carries a detached JWS over the request or webhook body.
+const SignatureHeader = "X-Detached-JWT"
+
+// DefaultSignatureMaxAge is how far a request signature's iat may be from
+// the server's clock.
+const DefaultSignatureMaxAge = 5 * time.Minute
+
+// KeyResolver returns a platform's signing keys given its profile URL.
+type KeyResolver func(ctx context.Context, profileURL string) ([]models.JWK, error)
+
+// ProfileKeyResolver returns a KeyResolver that fetches the platform's
+// profile and returns its signing_keys. Profiles are fetched through
+// httpcache.Default, so keys are refreshed as the profile's caching
+// headers allow. A nil client uses one with a ten-second timeout.
+func ProfileKeyResolver(client *http.Client) KeyResolver {
+	return func(ctx context.Context, profileURL string) ([]models.JWK, error) {
+		profile, err := fetchPlatformProfile(ctx, client, profileURL)
+		if err != nil {
+			return nil, err
+		}
+		return profile.SigningKeys, nil
+	}
+}
+
+// profileClient fetches platform profiles when no client is given.
+var profileClient = &http.Client{Timeout: 10 * time.Second}
+
+// profileError reports a failed profile fetch without the underlying
+// cause, which names internal hosts and addresses and is kept for
+// errors.Unwrap only, so callers cannot use the error to probe the network.
+type profileError struct{ err error }
+
+func (e *profileError) Error() string { return "platform profile is unavailable" }
+func (e *profileError) Unwrap() error { return e.err }
+
+// fetchPlatformProfile fetches and parses a platform's profile through
+// httpcache.Default.
+func fetchPlatformProfile(ctx context.Context, client *http.Client, profileURL string) (*models.UCPProfile, error) {
+	if client == nil {
+		client = profileClient
+	}
+	data, err := httpcache.Default.Get(ctx, client, profileURL)
+	if err != nil {
+		return nil, &profileError{err}
+	}
+	var profile models.UCPProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, &profileError{err}
+	}
+	return &profile, nil
+}
+
+// RequestSignatureConfig configures RequestSignatureMiddleware.
+type RequestSignatureConfig struct {
+	// Keys resolves the calling platform's signing keys from the profile
+	// URL in its UCP-Agent header. Defaults to ProfileKeyResolver(nil).
+	Keys KeyResolver
+
+	// CompletesOnly requires signatures only on checkout completion.
+	// Other state-changing requests are still verified when signed.
+	CompletesOnly bool
+
+	// MaxAge bounds how old, or how far in the future, a signature may be.
+	// Defaults to DefaultSignatureMaxAge.
+	MaxAge time.Duration
+
+	// Clock checks signature ages. Defaults to SystemClock.
+	Clock Clock
+}
+
+// RequestSignatureMiddleware verifies that state-changing requests (POST,
+// PUT, PATCH, DELETE) carry a detached JWS in X-Detached-JWT, signed by one
+// of the keys in the calling platform's profile, as client
+// WithRequestSigning makes. The signature covers the method, request URI,
+// an iat and nonce, and a digest of the body, so it verifies only for the
+// request it was made for. Unsigned and invalidly signed requests are
+// rejected with 401, as are signatures older than MaxAge and nonces seen
+// before within it. Requests without a UCP-Agent header cannot be
+// attributed and are rejected when a signature is required.
+//
+// Nonces are remembered by the middleware instance, so behind several
+// replicas an identical request can be replayed to another replica within
+// MaxAge; pair completion with an Idempotency-Key.
+//
+// Register it on the route groups to protect, e.g.
+// srv.Use(GroupCheckout, RequestSignatureMiddleware(RequestSignatureConfig{})).
+func RequestSignatureMiddleware(config RequestSignatureConfig) Middleware {
+	if config.Keys == nil {
+		config.Keys = ProfileKeyResolver(nil)
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = DefaultSignatureMaxAge
+	}
+	config.Clock = clockOrSystem(config.Clock)
+	nonces := &nonceCache{seen: make(map[string]time.Time)}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			default:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sig := r.Header.Get(SignatureHeader)
+			required := !config.CompletesOnly || isCompletePath(r.URL.Path)
+			if sig == "" {
+				if required {
+					WriteError(w, http.StatusUnauthorized, string(models.ErrorCodeMissingSignature), SignatureHeader+" header is required")
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			profileURL, err := PlatformProfileURL(r)
+			if err != nil {
+				WriteError(w, http.StatusUnauthorized, string(models.ErrorCodeInvalidSignature), "Cannot verify signature: "+err.Error())
+				return
+			}
+			r, err = bufferBody(r, DefaultMaxBodyBytes)
+			if err != nil {
+				WriteError(w, http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Failed to read request body")
+				return
+			}
+			if err := verifyRequestSignature(r, config, nonces, profileURL, sig); err != nil {
+				WriteError(w, http.StatusUnauthorized, string(models.ErrorCodeInvalidSignature), err.Error())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// verifyRequestSignature checks sig against r and the keys published by
+// the platform at profileURL, then records its nonce.
+func verifyRequestSignature(r *http.Request, config RequestSignatureConfig, nonces *nonceCache, profileURL, sig string) error {
+	iat, nonce, err := internal.RequestClaims(sig)
+	if err != nil {
+		return fmt.Errorf("invalid request signature: %w", err)
+	}
+	now := config.Clock.Now()
+	signedAt := time.Unix(iat, 0)
+	if age := now.Sub(signedAt); age > config.MaxAge || age < -config.MaxAge {
+		return errors.New("request signature has expired")
+	}
+
+	keys, err := config.Keys(r.Context(), profileURL)
+	if err != nil {
+		return fmt.Errorf("cannot resolve platform signing keys: %w", err)
+	}
+	verifier, err := NewWebhookVerifier(keys)
+	if err != nil {
+		return fmt.Errorf("invalid platform signing keys: %w", err)
+	}
+	requestURI := r.RequestURI
+	if requestURI == "" {
+		requestURI = r.URL.RequestURI()
+	}
+	input := internal.RequestSigningInput(r.Method, requestURI, iat, nonce, RawBody(r.Context()))
+	if err := verifier.VerifySignature(sig, input); err != nil {
+		return fmt.Errorf("invalid request signature: %w", err)
+	}
+	if !nonces.add(profileURL+" "+nonce, signedAt.Add(config.MaxAge), now) {
+		return errors.New("request signature has already been used")
+	}
+	return nil
+}
+
+// nonceCache remembers the nonces of verified signatures until they
+// expire.
+type nonceCache struct {
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	lastSweep time.Time
+}
+
+// add records a nonce until expires, reporting false if it was already
+// recorded.
+func (c *nonceCache) add(nonce string, expires, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.lastSweep) >= time.Minute {
+		c.lastSweep = now
+		for n, exp := range c.seen {
+			if !now.Before(exp) {
+				delete(c.seen, n)
+			}
+		}
+	}
+	if exp, ok := c.seen[nonce]; ok && now.Before(exp) {
+		return false
+	}
+	c.seen[nonce] = expires
+	return true
+}
+
+// isCompletePath reports whether a request path completes a checkout.
+func isCompletePath(path string) bool {
+	return strings.HasSuffix(path, "/complete") && strings.Contains(path, "/checkout-sessions/")
+}
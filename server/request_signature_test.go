@@ -0,0 +1,180 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/internal"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+func TestRequestSignatureMiddleware(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk, err := internal.PublicJWK("platform-2026", &key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	handler := server.RequestSignatureMiddleware(server.RequestSignatureConfig{
+		Keys: func(ctx context.Context, profileURL string) ([]models.JWK, error) {
+			return []models.JWK{jwk}, nil
+		},
+		Clock: fixedClock{now},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	sign := func(method, uri, body string, at time.Time) string {
+		sig, err := internal.SignRequest(key, "platform-2026", method, uri, []byte(body), at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	complete1 := sign(http.MethodPost, "/checkout-sessions/chk_1/complete", "", now)
+	update := sign(http.MethodPatch, "/checkout-sessions/chk_1", `{"id":"chk_1"}`, now)
+	bodyOnly, err := internal.SignDetached(key, "platform-2026", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		sig    string
+		status int
+	}{
+		{"unsigned", http.MethodPost, "/checkout-sessions/chk_1/complete", "", "", http.StatusUnauthorized},
+		{"malformed", http.MethodPost, "/checkout-sessions/chk_1/complete", "", "not-a-jws", http.StatusUnauthorized},
+		{"body-only signature", http.MethodPost, "/checkout-sessions/chk_1/complete", "", bodyOnly, http.StatusUnauthorized},
+		{"valid", http.MethodPost, "/checkout-sessions/chk_1/complete", "", complete1, http.StatusOK},
+		{"replayed", http.MethodPost, "/checkout-sessions/chk_1/complete", "", complete1, http.StatusUnauthorized},
+		{"replayed on another checkout", http.MethodPost, "/checkout-sessions/chk_2/complete", "", complete1, http.StatusUnauthorized},
+		{"fresh signature for the other checkout", http.MethodPost, "/checkout-sessions/chk_2/complete", "",
+			sign(http.MethodPost, "/checkout-sessions/chk_2/complete", "", now), http.StatusOK},
+		{"expired", http.MethodPost, "/checkout-sessions/chk_3/complete", "",
+			sign(http.MethodPost, "/checkout-sessions/chk_3/complete", "", now.Add(-time.Hour)), http.StatusUnauthorized},
+		{"tampered body", http.MethodPatch, "/checkout-sessions/chk_1", `{"id":"chk_9"}`, update, http.StatusUnauthorized},
+		{"signed update", http.MethodPatch, "/checkout-sessions/chk_1", `{"id":"chk_1"}`, update, http.StatusOK},
+		{"reads need no signature", http.MethodGet, "/checkout-sessions/chk_1", "", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		req.Header.Set(server.UCPAgentHeader, `profile="`+platformA+`"`)
+		if tt.sig != "" {
+			req.Header.Set(server.SignatureHeader, tt.sig)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.name, rec.Code, tt.status, rec.Body)
+		}
+	}
+}
+
+// fixedClock is a Clock stopped at one instant.
+type fixedClock struct{ now time.Time }
+
+func (c fixedClock) Now() time.Time { return c.now }
+
+func (c fixedClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func TestProfileKeyResolverHidesFetchErrors(t *testing.T) {
+	internalHost := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "admin console", http.StatusForbidden)
+	}))
+	defer internalHost.Close()
+
+	for _, profileURL := range []string{internalHost.URL + "/admin", "http://127.0.0.1:1/.well-known/ucp"} {
+		_, err := server.ProfileKeyResolver(nil)(context.Background(), profileURL)
+		if err == nil {
+			t.Fatalf("%s: resolved keys, want an error", profileURL)
+		}
+		if strings.Contains(err.Error(), "127.0.0.1") || strings.Contains(err.Error(), "403") {
+			t.Errorf("%s: error %q reveals the fetch outcome", profileURL, err)
+		}
+	}
+}
+
+func TestRequestSignatureMiddlewareCompletesOnly(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk, err := internal.PublicJWK("platform-2026", &key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := server.RequestSignatureMiddleware(server.RequestSignatureConfig{
+		Keys: func(ctx context.Context, profileURL string) ([]models.JWK, error) {
+			return []models.JWK{jwk}, nil
+		},
+		CompletesOnly: true,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	sign := func(method, uri string) string {
+		sig, err := internal.SignRequest(key, "platform-2026", method, uri, nil, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		sig     string
+		noAgent bool
+		status  int
+		code    string
+	}{
+		{"unsigned update", http.MethodPatch, "/checkout-sessions/chk_1", "", false, http.StatusOK, ""},
+		{"unsigned complete", http.MethodPost, "/checkout-sessions/chk_1/complete", "", false, http.StatusUnauthorized, "missing_signature"},
+		{"update signed for another path", http.MethodPatch, "/checkout-sessions/chk_1", sign(http.MethodPatch, "/checkout-sessions/chk_2"), false, http.StatusUnauthorized, "invalid_signature"},
+		{"signed without UCP-Agent", http.MethodPost, "/checkout-sessions/chk_1/complete", sign(http.MethodPost, "/checkout-sessions/chk_1/complete"), true, http.StatusUnauthorized, "invalid_signature"},
+		{"signed complete", http.MethodPost, "/checkout-sessions/chk_1/complete", sign(http.MethodPost, "/checkout-sessions/chk_1/complete"), false, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if !tt.noAgent {
+			req.Header.Set(server.UCPAgentHeader, `profile="`+platformA+`"`)
+		}
+		if tt.sig != "" {
+			req.Header.Set(server.SignatureHeader, tt.sig)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.status || errorCode(rec) != tt.code {
+			t.Errorf("%s: %d %s, want %d %s", tt.name, rec.Code, rec.Body, tt.status, tt.code)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// RequiredField is a checkout field a merchant needs before completion.
+//
+// When listed in Config.RequiredFields, the server checks every checkout
+// response that is incomplete or ready_for_complete: a missing field adds
+// an error Message at Path (unless the handler already reported that path)
+// and moves the checkout to incomplete.
+type RequiredField struct {
+	// Path is the JSONPath of the field, reported on the Message.
+	Path string
+
+	// Content is the human-readable message shown when the field is missing.
+	Content string
+
+	// Severity is the message severity. Defaults to recoverable.
+	Severity models.Severity
+
+	// Missing reports whether the checkout lacks the field.
+	Missing func(checkout *extensions.ExtendedCheckoutResponse) bool
+}
+
+// RequireBuyerEmail requires the buyer's email address.
+func RequireBuyerEmail() RequiredField {
+	return RequiredField{
+		Path:    "$.buyer.email",
+		Content: "Email required",
+		Missing: func(c *extensions.ExtendedCheckoutResponse) bool {
+			return c.Buyer == nil || c.Buyer.Email == ""
+		},
+	}
+}
+
+// RequireBuyerPhone requires the buyer's phone number.
+func RequireBuyerPhone() RequiredField {
+	return RequiredField{
+		Path:    "$.buyer.phone_number",
+		Content: "Phone number required",
+		Missing: func(c *extensions.ExtendedCheckoutResponse) bool {
+			return c.Buyer == nil || c.Buyer.PhoneNumber == ""
+		},
+	}
+}
+
+// RequireBuyerName requires the buyer's first and last name, or full name.
+func RequireBuyerName() RequiredField {
+	return RequiredField{
+		Path:    "$.buyer.full_name",
+		Content: "Buyer name required",
+		Missing: func(c *extensions.ExtendedCheckoutResponse) bool {
+			return c.Buyer == nil || (c.Buyer.FullName == "" && (c.Buyer.FirstName == "" || c.Buyer.LastName == ""))
+		},
+	}
+}
+
+// RequirePaymentInstrument requires a selected payment instrument.
+func RequirePaymentInstrument() RequiredField {
+	return RequiredField{
+		Path:    "$.payment.selected_instrument_id",
+		Content: "Payment required",
+		Missing: func(c *extensions.ExtendedCheckoutResponse) bool {
+			return c.Payment.SelectedInstrumentID == ""
+		},
+	}
+}
+
+// RequireBillingAddress requires a billing address on the selected payment
+// instrument. A checkout without a selected instrument is also missing it.
+func RequireBillingAddress() RequiredField {
+	return RequiredField{
+		Path:    "$.payment.instruments[*].billing_address",
+		Content: "Billing address required",
+		Missing: func(c *extensions.ExtendedCheckoutResponse) bool {
+			for _, inst := range c.Payment.Instruments {
+				if inst.ID == c.Payment.SelectedInstrumentID {
+					return inst.BillingAddress == nil
+				}
+			}
+			return true
+		},
+	}
+}
+
+// RequireTermsAcceptance requires the buyer to accept the merchant's
+// terms. The protocol has no acceptance field, so accepted reports how the
+// merchant records it (for example, from its own session store). The
+// message asks for buyer review and points at the checkout links.
+func RequireTermsAcceptance(accepted func(checkout *extensions.ExtendedCheckoutResponse) bool) RequiredField {
+	return RequiredField{
+		Path:     "$.links",
+		Content:  "Buyer must accept the terms of service",
+		Severity: models.SeverityRequiresBuyerReview,
+		Missing: func(c *extensions.ExtendedCheckoutResponse) bool {
+			return !accepted(c)
+		},
+	}
+}
+
+// applyRequiredFields reports missing required fields on a checkout.
+func (s *Server) applyRequiredFields(checkout *extensions.ExtendedCheckoutResponse) {
+	if checkout == nil || len(s.config.RequiredFields) == 0 {
+		return
+	}
+	switch checkout.Status {
+	case "", models.CheckoutStatusIncomplete, models.CheckoutStatusReadyForComplete:
+	default:
+		return
+	}
+
+	reported := make(map[string]bool, len(checkout.Messages))
+	for _, msg := range checkout.Messages {
+		reported[msg.Path] = true
+	}
+	missing := false
+	for _, f := range s.config.RequiredFields {
+		if f.Missing == nil || !f.Missing(checkout) {
+			continue
+		}
+		missing = true
+		if reported[f.Path] {
+			continue
+		}
+		severity := f.Severity
+		if severity == "" {
+			severity = models.SeverityRecoverable
+		}
+		checkout.Messages = append(checkout.Messages, models.Message{
+			Type:     models.MessageTypeError,
+			Code:     string(models.ErrorCodeMissing),
+			Content:  f.Content,
+			Severity: severity,
+			Path:     f.Path,
+		})
+		reported[f.Path] = true
+	}
+	if missing {
+		checkout.Status = models.CheckoutStatusIncomplete
+	}
+}
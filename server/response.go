@@ -18,13 +18,20 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
 )
 
 // ErrorResponse represents an API error response.
+//
+// Messages carries the error in the same shape as checkout messages
+// (type/code/severity/path) so agents can handle both uniformly. Error and
+// Message are retained for clients that predate the envelope.
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Details any    `json:"details,omitempty"`
+	Error    string           `json:"error"`
+	Message  string           `json:"message"`
+	Details  any              `json:"details,omitempty"`
+	Messages []models.Message `json:"messages,omitempty"`
 }
 
 // APIError represents an error that can be returned from handlers.
@@ -33,12 +40,31 @@ type APIError struct {
 	Code       string
 	Message    string
 	Details    any
+
+	// Messages optionally overrides the messages emitted in the error
+	// envelope, e.g. to attach JSONPaths to individual problems.
+	Messages []models.Message
 }
 
 func (e *APIError) Error() string {
 	return e.Message
 }
 
+// WithMessages attaches envelope messages to the error and returns it.
+func (e *APIError) WithMessages(messages ...models.Message) *APIError {
+	e.Messages = append(e.Messages, messages...)
+	return e
+}
+
+// ErrorMessages returns the envelope messages for the error, deriving a
+// single error message from Code and Message when none were attached.
+func (e *APIError) ErrorMessages() []models.Message {
+	if len(e.Messages) > 0 {
+		return e.Messages
+	}
+	return []models.Message{errorMessage(e.StatusCode, e.Code, e.Message)}
+}
+
 // NewAPIError creates a new API error.
 func NewAPIError(statusCode int, code, message string) *APIError {
 	return &APIError{
@@ -50,32 +76,63 @@ func NewAPIError(statusCode int, code, message string) *APIError {
 
 // NotFoundError creates a 404 not found error.
 func NotFoundError(message string) *APIError {
-	return NewAPIError(http.StatusNotFound, "not_found", message)
+	return NewAPIError(http.StatusNotFound, string(models.ErrorCodeNotFound), message)
 }
 
 // BadRequestError creates a 400 bad request error.
 func BadRequestError(message string) *APIError {
-	return NewAPIError(http.StatusBadRequest, "bad_request", message)
+	return NewAPIError(http.StatusBadRequest, string(models.ErrorCodeBadRequest), message)
 }
 
 // UnauthorizedError creates a 401 unauthorized error.
 func UnauthorizedError(message string) *APIError {
-	return NewAPIError(http.StatusUnauthorized, "unauthorized", message)
+	return NewAPIError(http.StatusUnauthorized, string(models.ErrorCodeUnauthorized), message)
 }
 
 // ForbiddenError creates a 403 forbidden error.
 func ForbiddenError(message string) *APIError {
-	return NewAPIError(http.StatusForbidden, "forbidden", message)
+	return NewAPIError(http.StatusForbidden, string(models.ErrorCodeForbidden), message)
 }
 
 // ConflictError creates a 409 conflict error.
 func ConflictError(message string) *APIError {
-	return NewAPIError(http.StatusConflict, "conflict", message)
+	return NewAPIError(http.StatusConflict, string(models.ErrorCodeConflict), message)
+}
+
+// IdempotencyConflictError creates a 409 error for an idempotency key
+// reused while the original request is in flight.
+func IdempotencyConflictError(message string) *APIError {
+	return NewAPIError(http.StatusConflict, string(models.ErrorCodeIdempotencyConflict), message)
+}
+
+// IdempotencyKeyReusedError creates a 409 error for an idempotency key
+// reused with a different request body.
+func IdempotencyKeyReusedError(message string, details IdempotencyKeyReused) *APIError {
+	err := NewAPIError(http.StatusConflict, string(models.ErrorCodeIdempotencyKeyReused), message)
+	err.Details = details
+	return err
+}
+
+// CapabilityNotSupportedError creates a 400 error for a request that
+// depends on an unsupported capability.
+func CapabilityNotSupportedError(message string) *APIError {
+	return NewAPIError(http.StatusBadRequest, string(models.ErrorCodeCapabilityNotSupported), message)
+}
+
+// VersionUnsupportedError creates a 400 error for an unsupported protocol
+// version.
+func VersionUnsupportedError(message string) *APIError {
+	return NewAPIError(http.StatusBadRequest, string(models.ErrorCodeVersionUnsupported), message)
+}
+
+// PaymentDeclinedError creates a 402 error for a declined payment.
+func PaymentDeclinedError(message string) *APIError {
+	return NewAPIError(http.StatusPaymentRequired, string(models.ErrorCodePaymentDeclined), message)
 }
 
 // InternalError creates a 500 internal server error.
 func InternalError(message string) *APIError {
-	return NewAPIError(http.StatusInternalServerError, "internal_error", message)
+	return NewAPIError(http.StatusInternalServerError, string(models.ErrorCodeInternal), message)
 }
 
 // WriteJSON writes a JSON response.
@@ -90,24 +147,63 @@ func WriteJSON(w http.ResponseWriter, statusCode int, data any) {
 // WriteError writes an error response.
 func WriteError(w http.ResponseWriter, statusCode int, code, message string) {
 	WriteJSON(w, statusCode, ErrorResponse{
-		Error:   code,
-		Message: message,
+		Error:    code,
+		Message:  message,
+		Messages: []models.Message{errorMessage(statusCode, code, message)},
 	})
 }
 
+// WriteAPIError writes an APIError using the error envelope.
+func WriteAPIError(w http.ResponseWriter, apiErr *APIError) {
+	WriteJSON(w, apiErr.StatusCode, ErrorResponse{
+		Error:    apiErr.Code,
+		Message:  apiErr.Message,
+		Details:  apiErr.Details,
+		Messages: apiErr.ErrorMessages(),
+	})
+}
+
+// errorMessage maps an HTTP error onto a UCP message. Client errors are
+// recoverable by the agent; authentication failures require the buyer.
+func errorMessage(statusCode int, code, message string) models.Message {
+	severity := models.SeverityRecoverable
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		severity = models.SeverityRequiresBuyerInput
+	}
+	return models.Message{
+		Type:        models.MessageTypeError,
+		Code:        code,
+		Content:     message,
+		ContentType: models.ContentTypePlain,
+		Severity:    severity,
+	}
+}
+
+// writeError writes an error response honoring Config.LegacyErrors.
+func (s *Server) writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	s.handleError(w, NewAPIError(statusCode, code, message))
+}
+
 // handleError handles errors from handlers.
-func handleError(w http.ResponseWriter, err error) {
+func (s *Server) handleError(w http.ResponseWriter, err error) {
 	var apiErr *APIError
-	if errors.As(err, &apiErr) {
-		resp := ErrorResponse{
+	switch {
+	case errors.As(err, &apiErr):
+	case errors.Is(err, models.ErrIllegalTransition):
+		apiErr = ConflictError(err.Error())
+	default:
+		// Default to internal server error
+		apiErr = InternalError(err.Error())
+	}
+
+	if s.config.LegacyErrors {
+		WriteJSON(w, apiErr.StatusCode, ErrorResponse{
 			Error:   apiErr.Code,
 			Message: apiErr.Message,
 			Details: apiErr.Details,
-		}
-		WriteJSON(w, apiErr.StatusCode, resp)
+		})
 		return
 	}
 
-	// Default to internal server error
-	WriteError(w, http.StatusInternalServerError, "internal_error", err.Error())
+	WriteAPIError(w, apiErr)
 }
@@ -0,0 +1,150 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// schemaTarget is a schema a request body must satisfy.
+type schemaTarget struct {
+	capability models.CapabilityName
+	schemaURL  string
+}
+
+// SchemaValidationMiddleware validates checkout and cart create and update
+// request bodies against the JSON Schemas of the capabilities in
+// Config.Capabilities before they reach handlers. Bodies are validated
+// against the request variant of the resource capability's schema, named
+// by inserting ".create_req" or ".update_req" before the ".json" suffix,
+// and against the $defs entry for the resource in the same variant of each
+// configured extension of it. The schemas are resolved on first use, and
+// those that cannot be loaded are skipped.
+//
+// Invalid bodies are rejected with a 400 invalid_request error carrying an
+// invalid_field message per violation, with its JSONPath as the message
+// path. Attach it with Use, e.g. srv.Use(GroupCheckout, mw). A nil
+// validator creates a private one.
+func (s *Server) SchemaValidationMiddleware(validator *validation.SchemaValidator) Middleware {
+	if validator == nil {
+		validator = validation.NewSchemaValidator()
+	}
+	var mu sync.Mutex
+	cache := make(map[string][]schemaTarget)
+	targetsFor := func(resource models.CapabilityName, suffix string) []schemaTarget {
+		mu.Lock()
+		defer mu.Unlock()
+		key := string(resource) + suffix
+		if targets, ok := cache[key]; ok {
+			return targets
+		}
+		targets := requestSchemaTargets(validator, s.config.Capabilities, resource, suffix)
+		cache[key] = targets
+		return targets
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resource, suffix := s.requestSchema(r)
+			if resource == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			r, err := bufferBody(r, s.maxBodyBytes())
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					s.writeError(w, http.StatusRequestEntityTooLarge, string(models.ErrorCodeRequestTooLarge), "Request body exceeds maximum size")
+					return
+				}
+				s.writeError(w, http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Failed to read request body")
+				return
+			}
+			body := RawBody(r.Context())
+			if len(body) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for _, t := range targetsFor(resource, suffix) {
+				result := validator.ValidatePayload(t.schemaURL, body)
+				if result.Valid {
+					continue
+				}
+				s.handleError(w, NewAPIError(http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Request does not conform to the "+string(t.capability)+" schema").
+					WithMessages(validationMessages(http.StatusBadRequest, string(models.ErrorCodeInvalidField), result.Errors)...))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestSchema returns the resource capability and request schema suffix
+// for a request, or "" for requests whose bodies are not validated.
+func (s *Server) requestSchema(r *http.Request) (models.CapabilityName, string) {
+	path := strings.TrimPrefix(r.URL.Path, s.config.BasePath)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	var resource models.CapabilityName
+	switch parts[0] {
+	case "checkout-sessions":
+		resource = GroupCheckout
+	case "carts":
+		resource = GroupCart
+	default:
+		return "", ""
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		return resource, ".create_req"
+	case len(parts) == 2 && (r.Method == http.MethodPatch || r.Method == http.MethodPut):
+		return resource, ".update_req"
+	}
+	return "", ""
+}
+
+// requestSchemaTargets returns the request schemas for a resource: the
+// resource capability's own and the $defs entry of each extension of it.
+func requestSchemaTargets(validator *validation.SchemaValidator, capabilities []models.CapabilityDiscovery, resource models.CapabilityName, suffix string) []schemaTarget {
+	var targets []schemaTarget
+	for _, c := range capabilities {
+		if (c.Name != resource && c.Extends != resource) || !strings.HasSuffix(c.Schema, ".json") {
+			continue
+		}
+		schemaURL := strings.TrimSuffix(c.Schema, ".json") + suffix + ".json"
+		raw, err := validator.LoadSchema(schemaURL)
+		if err != nil {
+			continue
+		}
+		if c.Name != resource {
+			var schema struct {
+				Defs map[string]json.RawMessage `json:"$defs"`
+			}
+			if json.Unmarshal(raw, &schema) != nil || schema.Defs[string(resource)] == nil {
+				continue
+			}
+			schemaURL += "#/$defs/" + string(resource)
+		}
+		targets = append(targets, schemaTarget{capability: c.Name, schemaURL: schemaURL})
+	}
+	return targets
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Route groups accepted by Server.Use. Capability routes are grouped under
+// their capability name; a route may belong to more than one group.
+const (
+	// GroupCheckout covers all /checkout-sessions routes.
+	GroupCheckout models.CapabilityName = "dev.ucp.shopping.checkout"
+
+	// GroupPayment covers routes that process payment (checkout completion).
+	GroupPayment models.CapabilityName = "dev.ucp.shopping.payment"
+
+	// GroupOrder covers /orders routes.
+	GroupOrder models.CapabilityName = "dev.ucp.shopping.order"
+
+	// GroupCart covers /carts routes.
+	GroupCart models.CapabilityName = "dev.ucp.shopping.cart"
+
+	// GroupDiscovery covers the /.well-known/ucp profile.
+	GroupDiscovery models.CapabilityName = "discovery"
+
+	// GroupWebhooks covers webhook registration.
+	GroupWebhooks models.CapabilityName = "webhooks"
+)
+
+// Use attaches middleware to every route in the given group. Group
+// middleware runs inside any middleware wrapped around the Server itself;
+// for routes in several groups, groups apply in the order they are listed
+// in the route table (e.g., checkout before payment). Use must be called
+// before the server starts handling requests.
+func (s *Server) Use(group models.CapabilityName, middlewares ...Middleware) {
+	if s.middleware == nil {
+		s.middleware = make(map[models.CapabilityName][]Middleware)
+	}
+	s.middleware[group] = append(s.middleware[group], middlewares...)
+}
+
+// scoped wraps a handler with the middleware registered for its groups.
+// The chain is resolved per request so Use may be called after NewServer.
+func (s *Server) scoped(handler http.HandlerFunc, groups []models.CapabilityName) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var chain []Middleware
+		for _, g := range groups {
+			chain = append(chain, s.middleware[g]...)
+		}
+		if len(chain) == 0 {
+			handler(w, r)
+			return
+		}
+		Chain(handler, chain...).ServeHTTP(w, r)
+	}
+}
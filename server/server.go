@@ -16,11 +16,13 @@
 package server
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"net/http"
 
 	"github.com/dhananjay2021/ucp-go-sdk/extensions"
 	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
 )
 
 // Config contains server configuration.
@@ -39,6 +41,102 @@ type Config struct {
 
 	// PaymentHandlers are the supported payment handlers.
 	PaymentHandlers []models.PaymentHandlerResponse
+
+	// BasePath is an optional prefix (e.g., "/ucp") applied to all UCP routes.
+	// The discovery profile is served at both the root and the prefixed path.
+	BasePath string
+
+	// TrustForwardedHeaders makes the server honor X-Forwarded-Proto and
+	// X-Forwarded-Host when computing BaseURL. Enable only behind a trusted proxy.
+	TrustForwardedHeaders bool
+
+	// Fulfillment, when set, is enforced on incoming checkout create and
+	// update requests (allowed method combinations and multi-destination).
+	Fulfillment *models.MerchantFulfillmentConfig
+
+	// RateProvider, when set, quotes fulfillment options for selected
+	// destinations after every checkout create and update handler.
+	RateProvider RateProvider
+
+	// PickupLocations, when set, lists pickup locations near the buyer as
+	// the destinations of pickup fulfillment methods after every checkout
+	// create and update handler.
+	PickupLocations PickupLocationProvider
+
+	// PickupRadiusKM is the pickup location search radius. Defaults to
+	// DefaultPickupRadiusKM when zero.
+	PickupRadiusKM float64
+
+	// TaxCalculator, when set, computes taxes after every checkout create and
+	// update handler and writes them into the response totals.
+	TaxCalculator TaxCalculator
+
+	// LegacyErrors makes the server emit the pre-envelope error shape
+	// ({"error","message","details"}) without the messages array.
+	LegacyErrors bool
+
+	// MaxBodyBytes bounds the request body buffered for RawBody.
+	// Defaults to DefaultMaxBodyBytes when zero.
+	MaxBodyBytes int64
+
+	// DeltaResponses enables delta responses: checkout responses carry a
+	// UCP-Response-Version token, and an update sent with UCP-Delta-Base
+	// set to the latest token is answered with a JSON merge patch.
+	DeltaResponses bool
+
+	// RequiredFields lists checkout fields the merchant needs before
+	// completion; missing fields are reported as Messages on every
+	// incomplete or ready_for_complete checkout response.
+	RequiredFields []RequiredField
+
+	// UsageRecorder, when set, is told the operation, calling platform,
+	// negotiated capabilities, and outcome of every routed request.
+	UsageRecorder UsageRecorder
+
+	// Deprecations marks capability versions as deprecated. Checkout and
+	// order responses negotiated at a deprecated version carry Deprecation
+	// and Sunset headers, and checkout responses a warning Message.
+	Deprecations []CapabilityDeprecation
+
+	// StrictMode turns on every correctness check at once, to help
+	// merchants pass conformance before launch: request bodies are
+	// rejected if they contain unknown fields or (for checkout creation)
+	// miss schema-required fields; requests must carry UCP-Agent, a JSON
+	// Content-Type, and, to complete a checkout, an Idempotency-Key; and
+	// checkout responses whose totals, currency amounts, or fulfillment
+	// references do not add up fail with a 500. Off by default.
+	StrictMode bool
+
+	// ContextSanitizer, when set, sanitizes the buyer context of checkout
+	// and cart requests before they reach handlers, so LLM-authored intent
+	// text is safe to log and render.
+	ContextSanitizer *validation.Sanitizer
+
+	// Examples, when set, serves generated example payloads for the
+	// configured capabilities at ExamplesPath, so integrating platforms
+	// can see exactly what this server accepts and returns.
+	Examples *ExamplesConfig
+
+	// Events, when set, receives checkout lifecycle webhook events
+	// (checkout.ready_for_complete, checkout.requires_escalation, and
+	// checkout.expired) as checkout responses change status, so platforms
+	// driving asynchronous flows need not poll.
+	Events EventPublisher
+
+	// Carts, when set, resolves the cart_id of checkout create requests:
+	// the cart's line items, context, and buyer replace those in the
+	// payload before the handler runs, and the cart is marked consumed
+	// once the checkout is created. Without it cart_id is left to the
+	// handler.
+	Carts CartStore
+
+	// Clock is the source of time for generated timestamps such as pickup
+	// readiness. Defaults to SystemClock; tests inject a FakeClock.
+	Clock Clock
+
+	// DeltaCacheSize bounds the checkout versions retained for delta
+	// responses. Defaults to DefaultDeltaCacheSize when zero.
+	DeltaCacheSize int
 }
 
 // Server is a UCP server that handles HTTP requests.
@@ -54,11 +152,34 @@ type Server struct {
 	cancelCheckoutHandler   func(http.ResponseWriter, *http.Request)
 	getOrderHandler         func(http.ResponseWriter, *http.Request)
 
+	// Order Modification Handlers
+	requestOrderModificationHandler func(http.ResponseWriter, *http.Request)
+	getOrderModificationHandler     func(http.ResponseWriter, *http.Request)
+
+	// getCheckout is the typed checkout retrieval handler, used to resolve
+	// current checkout state for request validation.
+	getCheckout GetCheckoutHandler
+
 	// Cart Handlers
 	createCartHandler func(http.ResponseWriter, *http.Request)
 	getCartHandler    func(http.ResponseWriter, *http.Request)
 	updateCartHandler func(http.ResponseWriter, *http.Request)
 	deleteCartHandler func(http.ResponseWriter, *http.Request)
+
+	// Webhook Handlers
+	webhookRegistrationHandler func(http.ResponseWriter, *http.Request)
+
+	// middleware holds route-group middleware registered with Use.
+	middleware map[models.CapabilityName][]Middleware
+
+	// deltas holds the latest checkout versions when DeltaResponses is set.
+	deltas *deltaCache
+
+	// allowed holds the methods routed for each path pattern.
+	allowed map[string][]string
+
+	// events tracks checkout statuses when Events is set.
+	events *checkoutEvents
 }
 
 // NewServer creates a new UCP server.
@@ -68,29 +189,81 @@ func NewServer(config Config) *Server {
 		mux:    http.NewServeMux(),
 	}
 
+	s.config.BasePath = normalizeBasePath(config.BasePath)
+	s.config.Clock = clockOrSystem(config.Clock)
+	if config.DeltaResponses {
+		s.deltas = newDeltaCache(config.DeltaCacheSize)
+	}
+	if config.Events != nil {
+		s.events = newCheckoutEvents(config.DeltaCacheSize)
+	}
+
 	// Register routes
-	s.mux.HandleFunc("GET /.well-known/ucp", s.handleDiscovery)
-	s.mux.HandleFunc("POST /checkout-sessions", s.handleCreateCheckout)
-	s.mux.HandleFunc("GET /checkout-sessions/{id}", s.handleGetCheckout)
-	s.mux.HandleFunc("PATCH /checkout-sessions/{id}", s.handleUpdateCheckout)
-	s.mux.HandleFunc("POST /checkout-sessions/{id}/complete", s.handleCompleteCheckout)
-	s.mux.HandleFunc("POST /checkout-sessions/{id}/cancel", s.handleCancelCheckout)
-	s.mux.HandleFunc("GET /orders/{id}", s.handleGetOrder)
+	s.route(OperationDiscovery, "GET", "/.well-known/ucp", s.handleDiscovery, GroupDiscovery)
+	if s.config.BasePath != "" {
+		s.mux.HandleFunc("GET /.well-known/ucp", s.recorded(OperationDiscovery, s.scoped(s.handleDiscovery, []models.CapabilityName{GroupDiscovery})))
+		s.allow("/.well-known/ucp", http.MethodGet)
+	}
+	if config.Examples != nil {
+		s.route(OperationExamples, "GET", ExamplesPath, s.handleExamples, GroupDiscovery)
+	}
+	s.route(OperationCreateCheckout, "POST", "/checkout-sessions", s.handleCreateCheckout, GroupCheckout)
+	s.route(OperationGetCheckout, "GET", "/checkout-sessions/{id}", s.handleGetCheckout, GroupCheckout)
+	s.route(OperationUpdateCheckout, "PATCH", "/checkout-sessions/{id}", s.handleUpdateCheckout, GroupCheckout)
+	s.route(OperationCompleteCheckout, "POST", "/checkout-sessions/{id}/complete", s.handleCompleteCheckout, GroupCheckout, GroupPayment)
+	s.route(OperationCancelCheckout, "POST", "/checkout-sessions/{id}/cancel", s.handleCancelCheckout, GroupCheckout)
+	s.route(OperationGetOrder, "GET", "/orders/{id}", s.handleGetOrder, GroupOrder)
+	s.route(OperationRequestOrderModification, "POST", "/orders/{id}/modifications", s.handleRequestOrderModification, GroupOrder)
+	s.route(OperationGetOrderModification, "GET", "/orders/{id}/modifications/{modification_id}", s.handleGetOrderModification, GroupOrder)
 
 	// Cart routes
-	s.mux.HandleFunc("POST /carts", s.handleCreateCart)
-	s.mux.HandleFunc("GET /carts/{id}", s.handleGetCart)
-	s.mux.HandleFunc("PATCH /carts/{id}", s.handleUpdateCart)
-	s.mux.HandleFunc("DELETE /carts/{id}", s.handleDeleteCart)
+	s.route(OperationCreateCart, "POST", "/carts", s.handleCreateCart, GroupCart)
+	s.route(OperationGetCart, "GET", "/carts/{id}", s.handleGetCart, GroupCart)
+	s.route(OperationUpdateCart, "PATCH", "/carts/{id}", s.handleUpdateCart, GroupCart)
+	s.route(OperationDeleteCart, "DELETE", "/carts/{id}", s.handleDeleteCart, GroupCart)
+
+	// Webhook routes
+	s.route(OperationRegisterWebhook, "POST", "/webhooks", s.handleRegisterWebhook, GroupWebhooks)
+
+	s.mux.HandleFunc("/", s.handleNotFound)
 
 	return s
 }
 
 // ServeHTTP implements the http.Handler interface.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r, err := bufferBody(r, s.maxBodyBytes())
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			s.writeError(w, http.StatusRequestEntityTooLarge, string(models.ErrorCodeRequestTooLarge), "Request body exceeds maximum size")
+			return
+		}
+		s.writeError(w, http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Failed to read request body")
+		return
+	}
+	r = r.WithContext(context.WithValue(r.Context(), baseURLKey, s.baseURL(r)))
 	s.mux.ServeHTTP(w, r)
 }
 
+// route registers a handler for method and path under the configured base
+// path, scoped to the given route groups and recorded as op.
+func (s *Server) route(op Operation, method, path string, handler http.HandlerFunc, groups ...models.CapabilityName) {
+	if s.config.StrictMode {
+		handler = s.strictHeaders(op, handler)
+	}
+	s.mux.HandleFunc(method+" "+s.config.BasePath+path, s.recorded(op, s.scoped(handler, groups)))
+	s.allow(s.config.BasePath+path, method)
+}
+
+// maxBodyBytes returns the configured body limit or the default.
+func (s *Server) maxBodyBytes() int64 {
+	if s.config.MaxBodyBytes > 0 {
+		return s.config.MaxBodyBytes
+	}
+	return DefaultMaxBodyBytes
+}
+
 // CreateCheckoutHandler is a function that handles checkout creation.
 type CreateCheckoutHandler func(r *http.Request, req *extensions.ExtendedCheckoutCreateRequest) (*extensions.ExtendedCheckoutResponse, error)
 
@@ -125,32 +298,61 @@ type DeleteCartHandler func(r *http.Request, id string) error
 func (s *Server) HandleCreateCheckout(handler CreateCheckoutHandler) {
 	s.createCheckoutHandler = func(w http.ResponseWriter, r *http.Request) {
 		var req extensions.ExtendedCheckoutCreateRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			WriteError(w, http.StatusBadRequest, "invalid_request", "Failed to parse request body")
+		if err := s.decodeRequest(r, &req); err != nil {
+			s.handleError(w, err)
+			return
+		}
+		if s.config.StrictMode {
+			if err := s.checkCreateRequest(r, &req); err != nil {
+				s.handleError(w, err)
+				return
+			}
+		}
+		cart, err := s.applyCart(r.Context(), &req)
+		if err != nil {
+			s.handleError(w, err)
+			return
+		}
+		if req.Context == nil {
+			req.Context = GeoContext(r.Context())
+		}
+
+		if msgs := CheckFulfillmentCreate(s.config.Fulfillment, req.Fulfillment); len(msgs) > 0 {
+			s.handleError(w, fulfillmentPolicyError(msgs))
 			return
 		}
 
 		resp, err := handler(r, &req)
 		if err != nil {
-			handleError(w, err)
+			s.handleError(w, err)
 			return
 		}
 
-		WriteJSON(w, http.StatusCreated, resp)
+		if err := s.enrichCheckout(r.Context(), resp); err != nil {
+			s.handleError(w, err)
+			return
+		}
+		if err := s.consumeCart(r.Context(), cart, resp.ID); err != nil {
+			s.handleError(w, err)
+			return
+		}
+
+		s.writeCheckout(w, r, http.StatusCreated, resp)
 	}
 }
 
 // HandleGetCheckout registers a handler for retrieving checkout sessions.
 func (s *Server) HandleGetCheckout(handler GetCheckoutHandler) {
+	s.getCheckout = handler
 	s.getCheckoutHandler = func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
 		resp, err := handler(r, id)
 		if err != nil {
-			handleError(w, err)
+			s.handleError(w, err)
 			return
 		}
 
-		WriteJSON(w, http.StatusOK, resp)
+		s.writeCheckout(w, r, http.StatusOK, resp)
 	}
 }
 
@@ -159,18 +361,28 @@ func (s *Server) HandleUpdateCheckout(handler UpdateCheckoutHandler) {
 	s.updateCheckoutHandler = func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
 		var req extensions.ExtendedCheckoutUpdateRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			WriteError(w, http.StatusBadRequest, "invalid_request", "Failed to parse request body")
+		if err := s.decodeRequest(r, &req); err != nil {
+			s.handleError(w, err)
+			return
+		}
+
+		if err := s.checkFulfillmentUpdate(r, id, req.Fulfillment); err != nil {
+			s.handleError(w, err)
 			return
 		}
 
 		resp, err := handler(r, id, &req)
 		if err != nil {
-			handleError(w, err)
+			s.handleError(w, err)
 			return
 		}
 
-		WriteJSON(w, http.StatusOK, resp)
+		if err := s.enrichCheckout(r.Context(), resp); err != nil {
+			s.handleError(w, err)
+			return
+		}
+
+		s.writeCheckout(w, r, http.StatusOK, resp)
 	}
 }
 
@@ -180,11 +392,11 @@ func (s *Server) HandleCompleteCheckout(handler CompleteCheckoutHandler) {
 		id := r.PathValue("id")
 		resp, err := handler(r, id)
 		if err != nil {
-			handleError(w, err)
+			s.handleError(w, err)
 			return
 		}
 
-		WriteJSON(w, http.StatusOK, resp)
+		s.writeCheckout(w, r, http.StatusOK, resp)
 	}
 }
 
@@ -194,11 +406,11 @@ func (s *Server) HandleCancelCheckout(handler CancelCheckoutHandler) {
 		id := r.PathValue("id")
 		resp, err := handler(r, id)
 		if err != nil {
-			handleError(w, err)
+			s.handleError(w, err)
 			return
 		}
 
-		WriteJSON(w, http.StatusOK, resp)
+		s.writeCheckout(w, r, http.StatusOK, resp)
 	}
 }
 
@@ -208,10 +420,18 @@ func (s *Server) HandleGetOrder(handler GetOrderHandler) {
 		id := r.PathValue("id")
 		resp, err := handler(r, id)
 		if err != nil {
-			handleError(w, err)
+			s.handleError(w, err)
 			return
 		}
 
+		if resp != nil {
+			noteCapabilities(r.Context(), resp.UCP.Capabilities)
+			s.markDeprecations(w, resp.UCP.Capabilities)
+		}
+		if fields := selectedFields(r); fields != nil && resp != nil {
+			s.writeSelected(w, http.StatusOK, resp, fields)
+			return
+		}
 		WriteJSON(w, http.StatusOK, resp)
 	}
 }
@@ -220,14 +440,17 @@ func (s *Server) HandleGetOrder(handler GetOrderHandler) {
 func (s *Server) HandleCreateCart(handler CreateCartHandler) {
 	s.createCartHandler = func(w http.ResponseWriter, r *http.Request) {
 		var req models.CartCreateRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			WriteError(w, http.StatusBadRequest, "invalid_request", "Failed to parse request body")
+		if err := s.decodeRequest(r, &req); err != nil {
+			s.handleError(w, err)
 			return
 		}
+		if req.Context == nil {
+			req.Context = GeoContext(r.Context())
+		}
 
 		resp, err := handler(r, &req)
 		if err != nil {
-			handleError(w, err)
+			s.handleError(w, err)
 			return
 		}
 
@@ -241,7 +464,7 @@ func (s *Server) HandleGetCart(handler GetCartHandler) {
 		id := r.PathValue("id")
 		resp, err := handler(r, id)
 		if err != nil {
-			handleError(w, err)
+			s.handleError(w, err)
 			return
 		}
 
@@ -254,14 +477,14 @@ func (s *Server) HandleUpdateCart(handler UpdateCartHandler) {
 	s.updateCartHandler = func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
 		var req models.CartUpdateRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			WriteError(w, http.StatusBadRequest, "invalid_request", "Failed to parse request body")
+		if err := s.decodeRequest(r, &req); err != nil {
+			s.handleError(w, err)
 			return
 		}
 
 		resp, err := handler(r, id, &req)
 		if err != nil {
-			handleError(w, err)
+			s.handleError(w, err)
 			return
 		}
 
@@ -275,7 +498,7 @@ func (s *Server) HandleDeleteCart(handler DeleteCartHandler) {
 		id := r.PathValue("id")
 		err := handler(r, id)
 		if err != nil {
-			handleError(w, err)
+			s.handleError(w, err)
 			return
 		}
 
@@ -301,6 +524,18 @@ func (s *Server) handleDiscovery(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if len(s.config.Services) > 0 {
+		profile.UCP.Services = make(models.Services, len(s.config.Services))
+		for name, svc := range s.config.Services {
+			if svc.Rest != nil {
+				rest := *svc.Rest
+				rest.Endpoint = s.resolveEndpoint(r, rest.Endpoint)
+				svc.Rest = &rest
+			}
+			profile.UCP.Services[name] = svc
+		}
+	}
+
 	WriteJSON(w, http.StatusOK, profile)
 }
 
@@ -308,7 +543,7 @@ func (s *Server) handleCreateCheckout(w http.ResponseWriter, r *http.Request) {
 	if s.createCheckoutHandler != nil {
 		s.createCheckoutHandler(w, r)
 	} else {
-		WriteError(w, http.StatusNotImplemented, "not_implemented", "Checkout creation not implemented")
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Checkout creation not implemented")
 	}
 }
 
@@ -316,7 +551,7 @@ func (s *Server) handleGetCheckout(w http.ResponseWriter, r *http.Request) {
 	if s.getCheckoutHandler != nil {
 		s.getCheckoutHandler(w, r)
 	} else {
-		WriteError(w, http.StatusNotImplemented, "not_implemented", "Checkout retrieval not implemented")
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Checkout retrieval not implemented")
 	}
 }
 
@@ -324,7 +559,7 @@ func (s *Server) handleUpdateCheckout(w http.ResponseWriter, r *http.Request) {
 	if s.updateCheckoutHandler != nil {
 		s.updateCheckoutHandler(w, r)
 	} else {
-		WriteError(w, http.StatusNotImplemented, "not_implemented", "Checkout update not implemented")
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Checkout update not implemented")
 	}
 }
 
@@ -332,7 +567,7 @@ func (s *Server) handleCompleteCheckout(w http.ResponseWriter, r *http.Request)
 	if s.completeCheckoutHandler != nil {
 		s.completeCheckoutHandler(w, r)
 	} else {
-		WriteError(w, http.StatusNotImplemented, "not_implemented", "Checkout completion not implemented")
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Checkout completion not implemented")
 	}
 }
 
@@ -340,7 +575,7 @@ func (s *Server) handleCancelCheckout(w http.ResponseWriter, r *http.Request) {
 	if s.cancelCheckoutHandler != nil {
 		s.cancelCheckoutHandler(w, r)
 	} else {
-		WriteError(w, http.StatusNotImplemented, "not_implemented", "Checkout cancellation not implemented")
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Checkout cancellation not implemented")
 	}
 }
 
@@ -348,7 +583,7 @@ func (s *Server) handleGetOrder(w http.ResponseWriter, r *http.Request) {
 	if s.getOrderHandler != nil {
 		s.getOrderHandler(w, r)
 	} else {
-		WriteError(w, http.StatusNotImplemented, "not_implemented", "Order retrieval not implemented")
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Order retrieval not implemented")
 	}
 }
 
@@ -356,7 +591,7 @@ func (s *Server) handleCreateCart(w http.ResponseWriter, r *http.Request) {
 	if s.createCartHandler != nil {
 		s.createCartHandler(w, r)
 	} else {
-		WriteError(w, http.StatusNotImplemented, "not_implemented", "Cart creation not implemented")
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Cart creation not implemented")
 	}
 }
 
@@ -364,7 +599,7 @@ func (s *Server) handleGetCart(w http.ResponseWriter, r *http.Request) {
 	if s.getCartHandler != nil {
 		s.getCartHandler(w, r)
 	} else {
-		WriteError(w, http.StatusNotImplemented, "not_implemented", "Cart retrieval not implemented")
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Cart retrieval not implemented")
 	}
 }
 
@@ -372,7 +607,7 @@ func (s *Server) handleUpdateCart(w http.ResponseWriter, r *http.Request) {
 	if s.updateCartHandler != nil {
 		s.updateCartHandler(w, r)
 	} else {
-		WriteError(w, http.StatusNotImplemented, "not_implemented", "Cart update not implemented")
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Cart update not implemented")
 	}
 }
 
@@ -380,6 +615,6 @@ func (s *Server) handleDeleteCart(w http.ResponseWriter, r *http.Request) {
 	if s.deleteCartHandler != nil {
 		s.deleteCartHandler(w, r)
 	} else {
-		WriteError(w, http.StatusNotImplemented, "not_implemented", "Cart deletion not implemented")
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Cart deletion not implemented")
 	}
 }
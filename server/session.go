@@ -0,0 +1,101 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+)
+
+// CheckoutSession guards a stored checkout so handlers never hand out
+// pointers into shared state. Writes are copy-on-write: the mutation runs on
+// a private copy that replaces the stored checkout only if it succeeds, so
+// readers always observe a complete, consistent checkout.
+//
+//	resp, err := session.Write(func(c *extensions.ExtendedCheckoutResponse) error {
+//		if c.Status == models.CheckoutStatusCompleted {
+//			return server.ConflictError("checkout is completed")
+//		}
+//		c.Status = models.CheckoutStatusCanceled
+//		return nil
+//	})
+type CheckoutSession struct {
+	writeMu  sync.Mutex
+	checkout atomic.Pointer[extensions.ExtendedCheckoutResponse]
+}
+
+// NewCheckoutSession creates a session holding a copy of checkout.
+func NewCheckoutSession(checkout *extensions.ExtendedCheckoutResponse) (*CheckoutSession, error) {
+	cp, err := copyCheckout(checkout)
+	if err != nil {
+		return nil, err
+	}
+	s := &CheckoutSession{}
+	s.checkout.Store(cp)
+	return s, nil
+}
+
+// Read calls fn with the stored checkout. The checkout is never modified in
+// place, but fn must not modify it or retain it after returning; use
+// Snapshot for a copy that outlives the call.
+func (s *CheckoutSession) Read(fn func(checkout *extensions.ExtendedCheckoutResponse)) {
+	fn(s.checkout.Load())
+}
+
+// Snapshot returns a copy of the stored checkout that the caller owns.
+func (s *CheckoutSession) Snapshot() (*extensions.ExtendedCheckoutResponse, error) {
+	return copyCheckout(s.checkout.Load())
+}
+
+// Write calls fn with a copy of the stored checkout. If fn returns nil the
+// copy replaces the stored checkout and a further copy is returned for the
+// caller to respond with; otherwise the stored checkout is unchanged and
+// fn's error is returned. Writes to the same session are serialized;
+// concurrent reads see the previous checkout until the write succeeds.
+func (s *CheckoutSession) Write(fn func(checkout *extensions.ExtendedCheckoutResponse) error) (*extensions.ExtendedCheckoutResponse, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	working, err := copyCheckout(s.checkout.Load())
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(working); err != nil {
+		return nil, err
+	}
+	resp, err := copyCheckout(working)
+	if err != nil {
+		return nil, err
+	}
+	s.checkout.Store(working)
+	return resp, nil
+}
+
+// copyCheckout deep-copies a checkout via JSON.
+func copyCheckout(checkout *extensions.ExtendedCheckoutResponse) (*extensions.ExtendedCheckoutResponse, error) {
+	data, err := json.Marshal(checkout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy checkout: %w", err)
+	}
+	var cp extensions.ExtendedCheckoutResponse
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to copy checkout: %w", err)
+	}
+	return &cp, nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// DefaultIdempotencyTable is the table IdempotencyStore uses by default.
+const DefaultIdempotencyTable = "ucp_idempotency_keys"
+
+// reserveRetries bounds how often ReserveKey retries a key whose record
+// is deleted between its insert and its read.
+const reserveRetries = 3
+
+var _ server.IdempotencyStore = (*IdempotencyStore)(nil)
+
+// IdempotencyStore is a server.IdempotencyStore in a PostgreSQL or SQLite
+// table. Keys are reserved with INSERT ... ON CONFLICT DO NOTHING, so only
+// one server instance handles a given key. Expired records are replaced
+// when their key is reused; DeleteExpired removes the rest.
+type IdempotencyStore struct {
+	db      *sql.DB
+	dialect Dialect
+	table   string
+}
+
+// IdempotencyOption configures an IdempotencyStore.
+type IdempotencyOption func(*IdempotencyStore)
+
+// WithIdempotencyTable stores records in table, optionally
+// schema-qualified, instead of DefaultIdempotencyTable.
+func WithIdempotencyTable(table string) IdempotencyOption {
+	return func(s *IdempotencyStore) {
+		s.table = table
+	}
+}
+
+// NewIdempotencyStore creates an IdempotencyStore on db. It panics if
+// WithIdempotencyTable names an invalid identifier.
+//
+//	keys := sqlstore.NewIdempotencyStore(db, sqlstore.Postgres)
+//	if err := keys.CreateTable(ctx); err != nil { ... }
+//	srv.Use(server.GroupCheckout, server.IdempotencyMiddleware(server.IdempotencyConfig{Store: keys}))
+func NewIdempotencyStore(db *sql.DB, dialect Dialect, opts ...IdempotencyOption) *IdempotencyStore {
+	s := &IdempotencyStore{db: db, dialect: dialect, table: DefaultIdempotencyTable}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if !identifier.MatchString(s.table) {
+		panic(fmt.Sprintf("sqlstore: invalid table name %q", s.table))
+	}
+	return s
+}
+
+// CreateTable creates the idempotency table if it does not exist.
+func (s *IdempotencyStore) CreateTable(ctx context.Context) error {
+	timeType, recordType := "TIMESTAMPTZ", "JSONB"
+	if s.dialect == SQLite {
+		timeType, recordType = "INTEGER", "TEXT"
+	}
+	_, err := s.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS `+s.table+` (
+	idempotency_key TEXT PRIMARY KEY,
+	expires_at      `+timeType+` NOT NULL,
+	record          `+recordType+` NOT NULL
+)`)
+	return err
+}
+
+// ReserveKey implements server.IdempotencyStore.
+func (s *IdempotencyStore) ReserveKey(ctx context.Context, key string, record *server.IdempotencyRecord, now time.Time) (*server.IdempotencyRecord, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	id := hashKey(key)
+	for range reserveRetries {
+		if _, err := s.db.ExecContext(ctx, s.dialect.rebind(`
+DELETE FROM `+s.table+` WHERE idempotency_key = $1 AND expires_at <= $2`),
+			id, s.dialect.timeArg(&now)); err != nil {
+			return nil, err
+		}
+		res, err := s.db.ExecContext(ctx, s.dialect.rebind(`
+INSERT INTO `+s.table+` (idempotency_key, expires_at, record) VALUES ($1, $2, $3)
+ON CONFLICT (idempotency_key) DO NOTHING`),
+			id, s.dialect.timeArg(&record.ExpiresAt), string(data))
+		if err != nil {
+			return nil, err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 1 {
+			return nil, err
+		}
+
+		var existing []byte
+		err = s.db.QueryRowContext(ctx, s.dialect.rebind(`
+SELECT record FROM `+s.table+` WHERE idempotency_key = $1`), id).Scan(&existing)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var r server.IdempotencyRecord
+		if err := json.Unmarshal(existing, &r); err != nil {
+			return nil, fmt.Errorf("sqlstore: corrupt idempotency record: %w", err)
+		}
+		return &r, nil
+	}
+	return nil, fmt.Errorf("sqlstore: idempotency key kept changing while being reserved")
+}
+
+// SaveKey implements server.IdempotencyStore.
+func (s *IdempotencyStore) SaveKey(ctx context.Context, key string, record *server.IdempotencyRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, s.dialect.rebind(`
+INSERT INTO `+s.table+` (idempotency_key, expires_at, record) VALUES ($1, $2, $3)
+ON CONFLICT (idempotency_key) DO UPDATE SET
+	expires_at = EXCLUDED.expires_at,
+	record = EXCLUDED.record`),
+		hashKey(key), s.dialect.timeArg(&record.ExpiresAt), string(data))
+	return err
+}
+
+// DeleteKey implements server.IdempotencyStore.
+func (s *IdempotencyStore) DeleteKey(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, s.dialect.rebind(`
+DELETE FROM `+s.table+` WHERE idempotency_key = $1`), hashKey(key))
+	return err
+}
+
+// DeleteExpired removes the records expired at now and returns how many
+// there were. Run it periodically to keep the table small.
+func (s *IdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, s.dialect.rebind(`
+DELETE FROM `+s.table+` WHERE expires_at <= $1`), s.dialect.timeArg(&now))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// hashKey returns the stored form of a scoped idempotency key, hashed
+// since it embeds the caller's profile URL and may be long.
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect is the SQL database a Store runs on.
+type Dialect string
+
+const (
+	// Postgres is PostgreSQL.
+	Postgres Dialect = "postgres"
+
+	// SQLite is SQLite 3.35 or later.
+	SQLite Dialect = "sqlite"
+)
+
+// migrationsTable records the applied migrations.
+const migrationsTable = "ucp_schema_migrations"
+
+//go:embed migrations
+var migrations embed.FS
+
+// migration is one numbered schema change.
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrations returns the dialect's migrations in order.
+func (d Dialect) migrations() ([]migration, error) {
+	dir := path.Join("migrations", string(d))
+	entries, err := fs.ReadDir(migrations, dir)
+	if err != nil {
+		return nil, fmt.Errorf("sqlstore: unknown dialect %q", d)
+	}
+	var out []migration
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		data, err := migrations.ReadFile(path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, migration{version: version, name: e.Name(), sql: string(data)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].version < out[j].version })
+	return out, nil
+}
+
+// rebind rewrites the $n placeholders queries are written with into the
+// dialect's syntax.
+func (d Dialect) rebind(query string) string {
+	if d != SQLite {
+		return query
+	}
+	return strings.ReplaceAll(query, "$", "?")
+}
+
+// timeArg encodes t for a timestamp column: TIMESTAMPTZ on PostgreSQL and
+// Unix milliseconds on SQLite. A nil t is NULL.
+func (d Dialect) timeArg(t *time.Time) any {
+	switch {
+	case t == nil:
+		return nil
+	case d == SQLite:
+		return t.UnixMilli()
+	}
+	return t.UTC()
+}
+
+// Migrate brings the database schema up to date, applying each embedded
+// migration not yet recorded in the ucp_schema_migrations table in its own
+// transaction. It is safe to call on every start.
+func (s *Store) Migrate(ctx context.Context) error {
+	all, err := s.dialect.migrations()
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS `+migrationsTable+` (
+	version INTEGER PRIMARY KEY,
+	name    TEXT NOT NULL
+)`); err != nil {
+		return err
+	}
+
+	applied := make(map[int]bool)
+	rows, err := s.db.QueryContext(ctx, `SELECT version FROM `+migrationsTable)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			rows.Close()
+			return err
+		}
+		applied[v] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, m := range all {
+		if applied[m.version] {
+			continue
+		}
+		if err := s.apply(ctx, m); err != nil {
+			return fmt.Errorf("sqlstore: migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+// apply runs one migration and records it.
+func (s *Store) apply(ctx context.Context, m migration) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range statements(m.sql) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, s.dialect.rebind(`INSERT INTO `+migrationsTable+` (version, name) VALUES ($1, $2)`),
+		m.version, m.name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// statements splits a migration into statements at semicolons ending a
+// line, dropping comment lines, since not every driver executes several
+// statements in one call.
+func statements(script string) []string {
+	var out []string
+	var stmt strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		stmt.WriteString(line)
+		stmt.WriteString("\n")
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			if s := strings.TrimSuffix(strings.TrimSpace(stmt.String()), ";"); s != "" {
+				out = append(out, s)
+			}
+			stmt.Reset()
+		}
+	}
+	if s := strings.TrimSpace(stmt.String()); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
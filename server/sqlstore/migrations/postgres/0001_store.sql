@@ -0,0 +1,29 @@
+-- Checkouts, orders, and carts for Store.
+
+CREATE TABLE IF NOT EXISTS ucp_checkouts (
+	id         TEXT PRIMARY KEY,
+	status     TEXT NOT NULL,
+	version    BIGINT NOT NULL,
+	expires_at TIMESTAMPTZ,
+	data       JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ucp_checkouts_expiry ON ucp_checkouts (expires_at)
+	WHERE status NOT IN ('completed', 'canceled');
+
+CREATE TABLE IF NOT EXISTS ucp_orders (
+	id          TEXT PRIMARY KEY,
+	checkout_id TEXT,
+	data        JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ucp_orders_checkout ON ucp_orders (checkout_id);
+
+CREATE TABLE IF NOT EXISTS ucp_carts (
+	id         TEXT PRIMARY KEY,
+	version    BIGINT NOT NULL,
+	expires_at TIMESTAMPTZ,
+	data       JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ucp_carts_expiry ON ucp_carts (expires_at);
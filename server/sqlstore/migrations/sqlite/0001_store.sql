@@ -0,0 +1,30 @@
+-- Checkouts, orders, and carts for Store. Timestamps are Unix
+-- milliseconds.
+
+CREATE TABLE IF NOT EXISTS ucp_checkouts (
+	id         TEXT PRIMARY KEY,
+	status     TEXT NOT NULL,
+	version    INTEGER NOT NULL,
+	expires_at INTEGER,
+	data       TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ucp_checkouts_expiry ON ucp_checkouts (expires_at)
+	WHERE status NOT IN ('completed', 'canceled');
+
+CREATE TABLE IF NOT EXISTS ucp_orders (
+	id          TEXT PRIMARY KEY,
+	checkout_id TEXT,
+	data        TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ucp_orders_checkout ON ucp_orders (checkout_id);
+
+CREATE TABLE IF NOT EXISTS ucp_carts (
+	id         TEXT PRIMARY KEY,
+	version    INTEGER NOT NULL,
+	expires_at INTEGER,
+	data       TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ucp_carts_expiry ON ucp_carts (expires_at);
@@ -0,0 +1,191 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlstore implements the server's persistence interfaces through
+// database/sql, so several server instances can share one database: Store
+// keeps checkouts, orders, and carts in PostgreSQL or SQLite,
+// DeliveryStore a PostgreSQL queue of webhook deliveries, and
+// IdempotencyStore the Idempotency-Key records of either.
+//
+// The package imports no driver; register one (such as
+// github.com/jackc/pgx/v5/stdlib or github.com/lib/pq) in the program and
+// open the database with it:
+//
+//	db, err := sql.Open("pgx", dsn)
+//	st := sqlstore.NewStore(db, sqlstore.Postgres, sqlstore.WithCheckoutTTL(30*time.Minute))
+//	if err := st.Migrate(ctx); err != nil { ... }
+//	srv, _ := ucpmem.NewServer(config, ucpmem.Config{Catalog: catalog, Store: st})
+//
+//	deliveries := sqlstore.NewDeliveryStore(db)
+//	if err := deliveries.CreateTable(ctx); err != nil { ... }
+//	dispatcher := server.NewWebhookDispatcher(subs, signer)
+//	dispatcher.Store = deliveries
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// DefaultDeliveryTable is the table DeliveryStore uses by default.
+const DefaultDeliveryTable = "ucp_webhook_deliveries"
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// DeliveryStore is a server.DeliveryStore in a PostgreSQL table. Claims
+// use FOR UPDATE SKIP LOCKED, so concurrent dispatchers never claim the
+// same delivery.
+type DeliveryStore struct {
+	db    *sql.DB
+	table string
+}
+
+// Option configures a DeliveryStore.
+type Option func(*DeliveryStore)
+
+// WithTable stores deliveries in table, optionally schema-qualified,
+// instead of DefaultDeliveryTable.
+func WithTable(table string) Option {
+	return func(s *DeliveryStore) {
+		s.table = table
+	}
+}
+
+// NewDeliveryStore creates a DeliveryStore on db. It panics if WithTable
+// names an invalid identifier.
+func NewDeliveryStore(db *sql.DB, opts ...Option) *DeliveryStore {
+	s := &DeliveryStore{db: db, table: DefaultDeliveryTable}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if !identifier.MatchString(s.table) {
+		panic(fmt.Sprintf("sqlstore: invalid table name %q", s.table))
+	}
+	return s
+}
+
+// CreateTable creates the delivery table and its due index if they do not
+// exist.
+func (s *DeliveryStore) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS `+s.table+` (
+	id              TEXT PRIMARY KEY,
+	status          TEXT NOT NULL,
+	next_attempt_at TIMESTAMPTZ NOT NULL,
+	leased_until    TIMESTAMPTZ,
+	delivery        JSONB NOT NULL
+)`)
+	if err != nil {
+		return err
+	}
+	// Indexes live in their table's schema, so the name is unqualified.
+	index := s.table[strings.LastIndex(s.table, ".")+1:] + "_due"
+	_, err = s.db.ExecContext(ctx, `
+CREATE INDEX IF NOT EXISTS `+index+` ON `+s.table+` (next_attempt_at)
+	WHERE status = 'pending'`)
+	return err
+}
+
+// SaveDelivery implements server.DeliveryStore.
+func (s *DeliveryStore) SaveDelivery(ctx context.Context, d *server.WebhookDelivery) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+INSERT INTO `+s.table+` (id, status, next_attempt_at, leased_until, delivery)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (id) DO UPDATE SET
+	status = EXCLUDED.status,
+	next_attempt_at = EXCLUDED.next_attempt_at,
+	leased_until = EXCLUDED.leased_until,
+	delivery = EXCLUDED.delivery`,
+		d.ID, string(d.Status), d.NextAttemptAt, nullTime(d.LeasedUntil), string(data))
+	return err
+}
+
+// GetDelivery implements server.DeliveryStore.
+func (s *DeliveryStore) GetDelivery(ctx context.Context, id string) (*server.WebhookDelivery, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, `SELECT delivery FROM `+s.table+` WHERE id = $1`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, server.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var d server.WebhookDelivery
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("sqlstore: corrupt delivery %s: %w", id, err)
+	}
+	return &d, nil
+}
+
+// ClaimDeliveries implements server.DeliveryStore.
+func (s *DeliveryStore) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*server.WebhookDelivery, error) {
+	until := now.Add(lease)
+	rows, err := s.db.QueryContext(ctx, `
+UPDATE `+s.table+` SET leased_until = $2
+WHERE id IN (
+	SELECT id FROM `+s.table+`
+	WHERE status = 'pending' AND next_attempt_at <= $1
+		AND (leased_until IS NULL OR leased_until <= $1)
+	ORDER BY next_attempt_at, id
+	LIMIT $3
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING delivery`, now, until, sql.NullInt64{Int64: int64(limit), Valid: limit > 0})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var claimed []*server.WebhookDelivery
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var d server.WebhookDelivery
+		if err := json.Unmarshal(data, &d); err != nil {
+			return nil, fmt.Errorf("sqlstore: corrupt delivery: %w", err)
+		}
+		d.LeasedUntil = until
+		claimed = append(claimed, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(claimed, func(i, j int) bool {
+		if !claimed[i].NextAttemptAt.Equal(claimed[j].NextAttemptAt) {
+			return claimed[i].NextAttemptAt.Before(claimed[j].NextAttemptAt)
+		}
+		return claimed[i].ID < claimed[j].ID
+	})
+	return claimed, nil
+}
+
+// nullTime maps the zero time to NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
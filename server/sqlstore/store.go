@@ -0,0 +1,502 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+	"github.com/dhananjay2021/ucp-go-sdk/server/store"
+)
+
+// expiryRetries bounds how often an access retries canceling an expired
+// checkout that another instance is updating at the same time.
+const expiryRetries = 3
+
+// Store is a store.Store in PostgreSQL or SQLite. Resources are kept as
+// JSON documents with a version column: updates run fn without holding a
+// transaction and write back only if the version is unchanged, returning
+// store.ErrConflict otherwise, so any number of server instances can
+// share one database. Run Migrate before first use.
+//
+// Store also implements store.Sweeper and server.CartStore.
+type Store struct {
+	db          *sql.DB
+	dialect     Dialect
+	clock       server.Clock
+	checkoutTTL time.Duration
+	cartTTL     time.Duration
+
+	// flow cancels expired checkouts.
+	flow models.CheckoutFlow
+}
+
+var (
+	_ store.Store      = (*Store)(nil)
+	_ store.Sweeper    = (*Store)(nil)
+	_ server.CartStore = (*Store)(nil)
+)
+
+// StoreOption configures a Store.
+type StoreOption func(*Store)
+
+// WithCheckoutTTL gives checkouts created without an expires_at one ttl
+// after creation.
+func WithCheckoutTTL(ttl time.Duration) StoreOption {
+	return func(s *Store) {
+		s.checkoutTTL = ttl
+	}
+}
+
+// WithCartTTL gives carts created without an expires_at one ttl after
+// creation.
+func WithCartTTL(ttl time.Duration) StoreOption {
+	return func(s *Store) {
+		s.cartTTL = ttl
+	}
+}
+
+// WithClock sets the clock that decides expiry. Defaults to
+// server.SystemClock.
+func WithClock(clock server.Clock) StoreOption {
+	return func(s *Store) {
+		if clock != nil {
+			s.clock = clock
+		}
+	}
+}
+
+// NewStore creates a Store on db, which must be opened with a driver for
+// dialect.
+func NewStore(db *sql.DB, dialect Dialect, opts ...StoreOption) *Store {
+	s := &Store{db: db, dialect: dialect, clock: server.SystemClock}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// q rewrites a query for the store's dialect.
+func (s *Store) q(query string) string {
+	return s.dialect.rebind(query)
+}
+
+// CreateCheckout implements store.Store.
+func (s *Store) CreateCheckout(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse) error {
+	if checkout.ExpiresAt == nil && s.checkoutTTL > 0 {
+		expires := s.clock.Now().Add(s.checkoutTTL).UTC()
+		checkout.ExpiresAt = &expires
+	}
+	data, err := json.Marshal(checkout)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, s.q(`
+INSERT INTO ucp_checkouts (id, status, version, expires_at, data)
+VALUES ($1, $2, 1, $3, $4)
+ON CONFLICT (id) DO NOTHING`),
+		checkout.ID, string(checkout.Status), s.dialect.timeArg(checkout.ExpiresAt), string(data))
+	return insertResult(res, err)
+}
+
+// loadCheckout reads a checkout and its version.
+func (s *Store) loadCheckout(ctx context.Context, id string) (*extensions.ExtendedCheckoutResponse, int64, error) {
+	var version int64
+	var data []byte
+	err := s.db.QueryRowContext(ctx, s.q(`SELECT version, data FROM ucp_checkouts WHERE id = $1`), id).Scan(&version, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, store.ErrCheckoutNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	var checkout extensions.ExtendedCheckoutResponse
+	if err := json.Unmarshal(data, &checkout); err != nil {
+		return nil, 0, fmt.Errorf("sqlstore: corrupt checkout %s: %w", id, err)
+	}
+	return &checkout, version, nil
+}
+
+// saveCheckout writes checkout if its stored version is still version,
+// returning the new version or store.ErrConflict.
+func (s *Store) saveCheckout(ctx context.Context, db sqlDB, checkout *extensions.ExtendedCheckoutResponse, version int64) (int64, error) {
+	data, err := json.Marshal(checkout)
+	if err != nil {
+		return 0, err
+	}
+	res, err := db.ExecContext(ctx, s.q(`
+UPDATE ucp_checkouts SET status = $3, version = version + 1, expires_at = $4, data = $5
+WHERE id = $1 AND version = $2`),
+		checkout.ID, version, string(checkout.Status), s.dialect.timeArg(checkout.ExpiresAt), string(data))
+	if err != nil {
+		return 0, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return 0, err
+	} else if n == 0 {
+		return 0, store.ErrConflict
+	}
+	return version + 1, nil
+}
+
+// currentCheckout reads a checkout and its version, first canceling the
+// checkout if it is past its expires_at at now, and reports whether this
+// call canceled it.
+func (s *Store) currentCheckout(ctx context.Context, id string, now time.Time) (*extensions.ExtendedCheckoutResponse, int64, bool, error) {
+	for attempt := 1; ; attempt++ {
+		checkout, version, err := s.loadCheckout(ctx, id)
+		if err != nil || !expired(checkout, now) {
+			return checkout, version, false, err
+		}
+		if err := s.flow.Transition(ctx, &checkout.Status, models.CheckoutStatusCanceled); err != nil {
+			return nil, 0, false, err
+		}
+		updated := now.UTC()
+		checkout.UpdatedAt = &updated
+		version, err = s.saveCheckout(ctx, s.db, checkout, version)
+		if err == nil {
+			return checkout, version, true, nil
+		}
+		if !errors.Is(err, store.ErrConflict) || attempt == expiryRetries {
+			return nil, 0, false, err
+		}
+	}
+}
+
+// expired reports whether an unfinished checkout is past its expires_at
+// at now.
+func expired(checkout *extensions.ExtendedCheckoutResponse, now time.Time) bool {
+	return checkout.ExpiresAt != nil && !now.Before(*checkout.ExpiresAt) && !checkout.Status.IsTerminal()
+}
+
+// GetCheckout implements store.Store.
+func (s *Store) GetCheckout(ctx context.Context, id string) (*extensions.ExtendedCheckoutResponse, error) {
+	checkout, _, _, err := s.currentCheckout(ctx, id, s.clock.Now())
+	return checkout, err
+}
+
+// UpdateCheckout implements store.Store.
+func (s *Store) UpdateCheckout(ctx context.Context, id string, fn func(checkout *extensions.ExtendedCheckoutResponse) error) (*extensions.ExtendedCheckoutResponse, error) {
+	checkout, version, _, err := s.currentCheckout(ctx, id, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(checkout); err != nil {
+		return nil, err
+	}
+	if _, err := s.saveCheckout(ctx, s.db, checkout, version); err != nil {
+		return nil, err
+	}
+	return checkout, nil
+}
+
+// CompleteCheckout implements store.Store. The checkout and order are
+// written in one transaction.
+func (s *Store) CompleteCheckout(ctx context.Context, id string, fn func(checkout *extensions.ExtendedCheckoutResponse) (*models.Order, error)) (*extensions.ExtendedCheckoutResponse, error) {
+	checkout, version, _, err := s.currentCheckout(ctx, id, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	order, err := fn(checkout)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		if _, err := s.saveCheckout(ctx, s.db, checkout, version); err != nil {
+			return nil, err
+		}
+		return checkout, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if _, err := s.saveCheckout(ctx, tx, checkout, version); err != nil {
+		return nil, err
+	}
+	if err := s.insertOrder(ctx, tx, order); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return checkout, nil
+}
+
+// CreateOrder implements store.Store.
+func (s *Store) CreateOrder(ctx context.Context, order *models.Order) error {
+	return s.insertOrder(ctx, s.db, order)
+}
+
+func (s *Store) insertOrder(ctx context.Context, db sqlDB, order *models.Order) error {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return err
+	}
+	res, err := db.ExecContext(ctx, s.q(`
+INSERT INTO ucp_orders (id, checkout_id, data)
+VALUES ($1, $2, $3)
+ON CONFLICT (id) DO NOTHING`),
+		order.ID, order.CheckoutID, string(data))
+	return insertResult(res, err)
+}
+
+// GetOrder implements store.Store.
+func (s *Store) GetOrder(ctx context.Context, id string) (*models.Order, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, s.q(`SELECT data FROM ucp_orders WHERE id = $1`), id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var order models.Order
+	if err := json.Unmarshal(data, &order); err != nil {
+		return nil, fmt.Errorf("sqlstore: corrupt order %s: %w", id, err)
+	}
+	return &order, nil
+}
+
+// CreateCart implements store.Store.
+func (s *Store) CreateCart(ctx context.Context, cart *store.Cart) error {
+	if cart.ExpiresAt == "" && s.cartTTL > 0 {
+		cart.ExpiresAt = s.clock.Now().Add(s.cartTTL).UTC().Format(time.RFC3339)
+	}
+	data, err := json.Marshal(cart)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, s.q(`
+INSERT INTO ucp_carts (id, version, expires_at, data)
+VALUES ($1, 1, $2, $3)
+ON CONFLICT (id) DO NOTHING`),
+		cart.ID, s.dialect.timeArg(cartExpiry(cart)), string(data))
+	return insertResult(res, err)
+}
+
+// cartExpiry parses a cart's expires_at, or returns nil if it has none.
+func cartExpiry(cart *store.Cart) *time.Time {
+	if cart.ExpiresAt == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, cart.ExpiresAt)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// loadCart reads an unexpired cart and its version.
+func (s *Store) loadCart(ctx context.Context, id string) (*store.Cart, int64, error) {
+	var version int64
+	var data []byte
+	err := s.db.QueryRowContext(ctx, s.q(`SELECT version, data FROM ucp_carts WHERE id = $1`), id).Scan(&version, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, store.ErrCartNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	var cart store.Cart
+	if err := json.Unmarshal(data, &cart); err != nil {
+		return nil, 0, fmt.Errorf("sqlstore: corrupt cart %s: %w", id, err)
+	}
+	if expires := cartExpiry(&cart); expires != nil && !s.clock.Now().Before(*expires) {
+		return nil, 0, store.ErrCartNotFound
+	}
+	return &cart, version, nil
+}
+
+// GetCart implements store.Store. Expired carts are not found; the
+// reaper deletes them.
+func (s *Store) GetCart(ctx context.Context, id string) (*store.Cart, error) {
+	cart, _, err := s.loadCart(ctx, id)
+	return cart, err
+}
+
+// UpdateCart implements store.Store.
+func (s *Store) UpdateCart(ctx context.Context, id string, fn func(cart *store.Cart) error) (*store.Cart, error) {
+	cart, version, err := s.loadCart(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(cart); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(cart)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.db.ExecContext(ctx, s.q(`
+UPDATE ucp_carts SET version = version + 1, expires_at = $3, data = $4
+WHERE id = $1 AND version = $2`),
+		id, version, s.dialect.timeArg(cartExpiry(cart)), string(data))
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, store.ErrConflict
+	}
+	return cart, nil
+}
+
+// DeleteCart implements store.Store.
+func (s *Store) DeleteCart(ctx context.Context, id string) error {
+	if _, _, err := s.loadCart(ctx, id); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, s.q(`DELETE FROM ucp_carts WHERE id = $1`), id)
+	return err
+}
+
+// LoadCart implements server.CartStore.
+func (s *Store) LoadCart(ctx context.Context, id string) (*server.StoredCart, error) {
+	cart, err := s.GetCart(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return cart.Stored(), nil
+}
+
+// ConsumeCart implements server.CartStore.
+func (s *Store) ConsumeCart(ctx context.Context, id, checkoutID string) error {
+	_, err := s.UpdateCart(ctx, id, func(cart *store.Cart) error {
+		if cart.ConsumedBy != "" && cart.ConsumedBy != checkoutID {
+			return server.ErrCartConsumed
+		}
+		cart.ConsumedBy = checkoutID
+		return nil
+	})
+	return err
+}
+
+// ExpireCheckouts implements store.Sweeper. A checkout updated by another
+// instance during the sweep is left for the next one.
+func (s *Store) ExpireCheckouts(ctx context.Context, now time.Time) ([]*extensions.ExtendedCheckoutResponse, error) {
+	rows, err := s.db.QueryContext(ctx, s.q(`
+SELECT id FROM ucp_checkouts
+WHERE expires_at <= $1 AND status NOT IN ('completed', 'canceled')
+ORDER BY id`), s.dialect.timeArg(&now))
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var canceled []*extensions.ExtendedCheckoutResponse
+	for _, id := range ids {
+		checkout, _, ok, err := s.currentCheckout(ctx, id, now)
+		if errors.Is(err, store.ErrConflict) || errors.Is(err, store.ErrCheckoutNotFound) {
+			continue
+		}
+		if err != nil {
+			return canceled, err
+		}
+		if ok {
+			canceled = append(canceled, checkout)
+		}
+	}
+	return canceled, nil
+}
+
+// ExpireCarts implements store.Sweeper.
+func (s *Store) ExpireCarts(ctx context.Context, now time.Time) ([]*store.Cart, error) {
+	rows, err := s.db.QueryContext(ctx, s.q(`DELETE FROM ucp_carts WHERE expires_at <= $1 RETURNING data`), s.dialect.timeArg(&now))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deleted []*store.Cart
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var cart store.Cart
+		if err := json.Unmarshal(data, &cart); err != nil {
+			return nil, fmt.Errorf("sqlstore: corrupt cart: %w", err)
+		}
+		deleted = append(deleted, &cart)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i].ID < deleted[j].ID })
+	return deleted, nil
+}
+
+// CountActive implements store.Sweeper.
+func (s *Store) CountActive(ctx context.Context) (checkouts, carts int, err error) {
+	t := s.clock.Now()
+	now := s.dialect.timeArg(&t)
+	err = s.db.QueryRowContext(ctx, s.q(`
+SELECT COUNT(*) FROM ucp_checkouts
+WHERE status NOT IN ('completed', 'canceled') AND (expires_at IS NULL OR expires_at > $1)`), now).Scan(&checkouts)
+	if err != nil {
+		return 0, 0, err
+	}
+	err = s.db.QueryRowContext(ctx, s.q(`
+SELECT COUNT(*) FROM ucp_carts WHERE expires_at IS NULL OR expires_at > $1`), now).Scan(&carts)
+	if err != nil {
+		return 0, 0, err
+	}
+	return checkouts, carts, nil
+}
+
+// insertResult maps an INSERT ... ON CONFLICT DO NOTHING that inserted no
+// row to store.ErrExists.
+func insertResult(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrExists
+	}
+	return nil
+}
+
+// sqlDB is satisfied by *sql.DB and *sql.Tx.
+type sqlDB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
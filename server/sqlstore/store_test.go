@@ -0,0 +1,285 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package sqlstore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+	"github.com/dhananjay2021/ucp-go-sdk/server/sqlstore"
+	"github.com/dhananjay2021/ucp-go-sdk/server/store"
+	"github.com/dhananjay2021/ucp-go-sdk/server/ucpmem"
+)
+
+// openDB opens a fresh SQLite database.
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "ucp.db")+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// openSQLite returns a migrated Store on a fresh SQLite database.
+func openSQLite(t *testing.T, opts ...sqlstore.StoreOption) *sqlstore.Store {
+	t.Helper()
+	st := sqlstore.NewStore(openDB(t), sqlstore.SQLite, opts...)
+	if err := st.Migrate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+func request() *http.Request {
+	return httptest.NewRequest(http.MethodPost, "/checkout-sessions", nil)
+}
+
+func TestMerchantsShareStore(t *testing.T) {
+	st := openSQLite(t)
+	config := ucpmem.Config{
+		Catalog: server.NewMapCatalog(server.CatalogItem{ID: "PROD-001", Title: "Headphones", Price: 14999}),
+		Store:   st,
+	}
+	// Two instances behind one database, as after a restart or scale-out.
+	merchants := []*ucpmem.Merchant{ucpmem.New(server.Config{}, config), ucpmem.New(server.Config{}, config)}
+
+	ids := make(map[string]bool)
+	checkouts := make([]*extensions.ExtendedCheckoutResponse, len(merchants))
+	for i, m := range merchants {
+		checkout, err := m.CreateCheckout(request(), &extensions.ExtendedCheckoutCreateRequest{
+			Currency:  "USD",
+			LineItems: []models.LineItemCreateRequest{{Item: models.ItemCreateRequest{ID: "PROD-001"}, Quantity: 1}},
+			Buyer:     &models.BuyerWithConsentCreateRequest{Email: "buyer@example.com"},
+			Payment:   models.PaymentCreateRequest{SelectedInstrumentID: "pi_1"},
+		})
+		if err != nil {
+			t.Fatalf("merchant %d: CreateCheckout: %v", i, err)
+		}
+		if ids[checkout.ID] {
+			t.Fatalf("merchant %d reissued checkout ID %s", i, checkout.ID)
+		}
+		ids[checkout.ID] = true
+		checkouts[i] = checkout
+	}
+
+	// Each instance completes the other's checkout and serves its order.
+	for i, m := range merchants {
+		completed, err := m.CompleteCheckout(request(), checkouts[1-i].ID)
+		if err != nil || completed.Order == nil {
+			t.Fatalf("merchant %d: CompleteCheckout = %+v, %v", i, completed, err)
+		}
+		if ids[completed.Order.ID] {
+			t.Fatalf("merchant %d reissued order ID %s", i, completed.Order.ID)
+		}
+		ids[completed.Order.ID] = true
+		if _, err := merchants[1-i].GetOrder(request(), completed.Order.ID); err != nil {
+			t.Errorf("merchant %d: GetOrder: %v", 1-i, err)
+		}
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	st := openSQLite(t)
+	if err := st.Migrate(ctx); err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+	checkout := &extensions.ExtendedCheckoutResponse{ID: "chk_1", Currency: "USD", Status: models.CheckoutStatusIncomplete}
+	if err := st.CreateCheckout(ctx, checkout); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := st.UpdateCheckout(ctx, "chk_1", func(c *extensions.ExtendedCheckoutResponse) error {
+		c.Status = models.CheckoutStatusReadyForComplete
+		return nil
+	})
+	if err != nil || updated.Status != models.CheckoutStatusReadyForComplete {
+		t.Fatalf("UpdateCheckout = %+v, %v", updated, err)
+	}
+
+	completed, err := st.CompleteCheckout(ctx, "chk_1", func(c *extensions.ExtendedCheckoutResponse) (*models.Order, error) {
+		c.Status = models.CheckoutStatusCompleted
+		c.Order = &models.OrderConfirmation{ID: "ord_1"}
+		return &models.Order{ID: "ord_1", CheckoutID: c.ID, Currency: c.Currency}, nil
+	})
+	if err != nil || completed.Status != models.CheckoutStatusCompleted {
+		t.Fatalf("CompleteCheckout = %+v, %v", completed, err)
+	}
+
+	got, err := st.GetCheckout(ctx, "chk_1")
+	if err != nil || got.Status != models.CheckoutStatusCompleted || got.Order == nil || got.Order.ID != "ord_1" {
+		t.Errorf("GetCheckout = %+v, %v; want completed with ord_1", got, err)
+	}
+	order, err := st.GetOrder(ctx, "ord_1")
+	if err != nil || order.CheckoutID != "chk_1" {
+		t.Errorf("GetOrder = %+v, %v; want the order for chk_1", order, err)
+	}
+	if _, err := st.GetCheckout(ctx, "chk_missing"); !errors.Is(err, store.ErrCheckoutNotFound) {
+		t.Errorf("GetCheckout(missing) = %v, want ErrCheckoutNotFound", err)
+	}
+	if _, err := st.GetOrder(ctx, "ord_missing"); !errors.Is(err, store.ErrOrderNotFound) {
+		t.Errorf("GetOrder(missing) = %v, want ErrOrderNotFound", err)
+	}
+}
+
+func TestStoreRejectsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	st := openSQLite(t)
+	if err := st.CreateCheckout(ctx, &extensions.ExtendedCheckoutResponse{ID: "chk_1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateOrder(ctx, &models.Order{ID: "ord_1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateCart(ctx, &store.Cart{CartResponse: models.CartResponse{ID: "cart_1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"checkout", st.CreateCheckout(ctx, &extensions.ExtendedCheckoutResponse{ID: "chk_1"})},
+		{"order", st.CreateOrder(ctx, &models.Order{ID: "ord_1"})},
+		{"cart", st.CreateCart(ctx, &store.Cart{CartResponse: models.CartResponse{ID: "cart_1"}})},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, store.ErrExists) {
+			t.Errorf("duplicate %s: %v, want ErrExists", tt.name, tt.err)
+		}
+	}
+
+	// An order that collides rolls back the checkout saved with it.
+	if err := st.CreateCheckout(ctx, &extensions.ExtendedCheckoutResponse{ID: "chk_2", Status: models.CheckoutStatusReadyForComplete}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := st.CompleteCheckout(ctx, "chk_2", func(c *extensions.ExtendedCheckoutResponse) (*models.Order, error) {
+		c.Status = models.CheckoutStatusCompleted
+		return &models.Order{ID: "ord_1", CheckoutID: c.ID}, nil
+	})
+	if !errors.Is(err, store.ErrExists) {
+		t.Errorf("CompleteCheckout with a taken order ID = %v, want ErrExists", err)
+	}
+	if got, err := st.GetCheckout(ctx, "chk_2"); err != nil || got.Status != models.CheckoutStatusReadyForComplete {
+		t.Errorf("checkout after the failed completion = %+v, %v; want it unchanged", got, err)
+	}
+}
+
+func TestStoreRejectsStaleUpdates(t *testing.T) {
+	ctx := context.Background()
+	st := openSQLite(t)
+	if err := st.CreateCheckout(ctx, &extensions.ExtendedCheckoutResponse{ID: "chk_1", Status: models.CheckoutStatusIncomplete}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateCart(ctx, &store.Cart{CartResponse: models.CartResponse{ID: "cart_1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each update is overtaken by another one, as from a second instance,
+	// after reading its copy.
+	setEmail := func(email string) func(*extensions.ExtendedCheckoutResponse) error {
+		return func(c *extensions.ExtendedCheckoutResponse) error {
+			c.Buyer = &models.BuyerWithConsentResponse{Email: email}
+			return nil
+		}
+	}
+	_, err := st.UpdateCheckout(ctx, "chk_1", func(c *extensions.ExtendedCheckoutResponse) error {
+		if _, err := st.UpdateCheckout(ctx, "chk_1", setEmail("winner@example.com")); err != nil {
+			t.Fatal(err)
+		}
+		return setEmail("loser@example.com")(c)
+	})
+	if !errors.Is(err, store.ErrConflict) {
+		t.Errorf("stale UpdateCheckout = %v, want ErrConflict", err)
+	}
+	_, err = st.CompleteCheckout(ctx, "chk_1", func(c *extensions.ExtendedCheckoutResponse) (*models.Order, error) {
+		if _, err := st.UpdateCheckout(ctx, "chk_1", setEmail("winner@example.com")); err != nil {
+			t.Fatal(err)
+		}
+		return &models.Order{ID: "ord_1", CheckoutID: c.ID}, nil
+	})
+	if !errors.Is(err, store.ErrConflict) {
+		t.Errorf("stale CompleteCheckout = %v, want ErrConflict", err)
+	}
+	if _, err := st.GetOrder(ctx, "ord_1"); !errors.Is(err, store.ErrOrderNotFound) {
+		t.Errorf("order of the stale completion = %v, want ErrOrderNotFound", err)
+	}
+	if got, _ := st.GetCheckout(ctx, "chk_1"); got.Buyer == nil || got.Buyer.Email != "winner@example.com" {
+		t.Errorf("checkout = %+v, want the winning update", got)
+	}
+
+	_, err = st.UpdateCart(ctx, "cart_1", func(c *store.Cart) error {
+		if _, err := st.UpdateCart(ctx, "cart_1", func(*store.Cart) error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+		return nil
+	})
+	if !errors.Is(err, store.ErrConflict) {
+		t.Errorf("stale UpdateCart = %v, want ErrConflict", err)
+	}
+}
+
+func TestIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	keys := sqlstore.NewIdempotencyStore(openDB(t), sqlstore.SQLite)
+	if err := keys.CreateTable(ctx); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	record := func(hash string, expires time.Duration) *server.IdempotencyRecord {
+		return &server.IdempotencyRecord{RequestHash: hash, ExpiresAt: now.Add(expires)}
+	}
+
+	if existing, err := keys.ReserveKey(ctx, "k", record("a", time.Minute), now); existing != nil || err != nil {
+		t.Fatalf("first ReserveKey = %+v, %v; want a reservation", existing, err)
+	}
+	if existing, err := keys.ReserveKey(ctx, "k", record("b", time.Minute), now); err != nil || existing == nil || existing.RequestHash != "a" {
+		t.Errorf("ReserveKey of a held key = %+v, %v; want the first record", existing, err)
+	}
+	saved := record("a", time.Hour)
+	saved.StatusCode, saved.Body = http.StatusOK, []byte(`{"id":"chk_1"}`)
+	if err := keys.SaveKey(ctx, "k", saved); err != nil {
+		t.Fatal(err)
+	}
+	later := now.Add(30 * time.Minute)
+	if existing, err := keys.ReserveKey(ctx, "k", record("c", time.Minute), later); err != nil || existing == nil || string(existing.Body) != `{"id":"chk_1"}` {
+		t.Errorf("ReserveKey of a saved key = %+v, %v; want the saved response", existing, err)
+	}
+	expired := now.Add(2 * time.Hour)
+	if existing, err := keys.ReserveKey(ctx, "k", record("d", 3*time.Hour), expired); existing != nil || err != nil {
+		t.Errorf("ReserveKey of an expired key = %+v, %v; want a reservation", existing, err)
+	}
+	if err := keys.DeleteKey(ctx, "k"); err != nil {
+		t.Fatal(err)
+	}
+	if existing, err := keys.ReserveKey(ctx, "k", record("e", time.Minute), now); existing != nil || err != nil {
+		t.Errorf("ReserveKey after DeleteKey = %+v, %v; want a reservation", existing, err)
+	}
+}
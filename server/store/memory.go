@@ -0,0 +1,432 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// Memory is an in-memory Store. Each checkout is held in a
+// server.CheckoutSession, so updates to different checkouts proceed
+// concurrently. An unfinished checkout whose expires_at has passed is
+// canceled when it is next accessed, and an expired cart is deleted.
+//
+// Memory also implements Sweeper and Purger, so a Reaper can expire
+// resources in the background and delete finished checkouts after its
+// Retention, and server.CartStore, so it can back server.Config.Carts for
+// cart-to-checkout conversion. Without a Reaper, finished checkouts are
+// kept for the life of the process; orders always are. Use a persistent
+// store, such as sqlstore.Store, for long-running production servers.
+type Memory struct {
+	clock       server.Clock
+	checkoutTTL time.Duration
+	cartTTL     time.Duration
+
+	// flow cancels expired checkouts.
+	flow models.CheckoutFlow
+
+	mu        sync.Mutex
+	checkouts map[string]*server.CheckoutSession
+	orders    map[string]*models.Order
+	carts     map[string]*Cart
+}
+
+var (
+	_ Store            = (*Memory)(nil)
+	_ Sweeper          = (*Memory)(nil)
+	_ Purger           = (*Memory)(nil)
+	_ server.CartStore = (*Memory)(nil)
+)
+
+// MemoryOption configures a Memory store.
+type MemoryOption func(*Memory)
+
+// WithCheckoutTTL gives checkouts created without an expires_at one ttl
+// after creation.
+func WithCheckoutTTL(ttl time.Duration) MemoryOption {
+	return func(m *Memory) {
+		m.checkoutTTL = ttl
+	}
+}
+
+// WithCartTTL gives carts created without an expires_at one ttl after
+// creation.
+func WithCartTTL(ttl time.Duration) MemoryOption {
+	return func(m *Memory) {
+		m.cartTTL = ttl
+	}
+}
+
+// WithClock sets the clock that decides expiry. Defaults to
+// server.SystemClock.
+func WithClock(clock server.Clock) MemoryOption {
+	return func(m *Memory) {
+		if clock != nil {
+			m.clock = clock
+		}
+	}
+}
+
+// NewMemory creates an empty in-memory store. Without TTL options,
+// resources expire only if created with an expires_at.
+func NewMemory(opts ...MemoryOption) *Memory {
+	m := &Memory{
+		clock:     server.SystemClock,
+		checkouts: make(map[string]*server.CheckoutSession),
+		orders:    make(map[string]*models.Order),
+		carts:     make(map[string]*Cart),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// CreateCheckout implements Store.
+func (m *Memory) CreateCheckout(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse) error {
+	if checkout.ExpiresAt == nil && m.checkoutTTL > 0 {
+		expires := m.clock.Now().Add(m.checkoutTTL).UTC()
+		checkout.ExpiresAt = &expires
+	}
+	session, err := server.NewCheckoutSession(checkout)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.checkouts[checkout.ID]; ok {
+		return ErrExists
+	}
+	m.checkouts[checkout.ID] = session
+	return nil
+}
+
+// session returns a checkout's session, first canceling the checkout if
+// it has expired.
+func (m *Memory) session(ctx context.Context, id string) (*server.CheckoutSession, error) {
+	m.mu.Lock()
+	session, ok := m.checkouts[id]
+	m.mu.Unlock()
+	if !ok {
+		return nil, ErrCheckoutNotFound
+	}
+
+	if _, err := m.expire(ctx, session, m.clock.Now()); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// expire cancels the checkout in session if it is unfinished and past its
+// expires_at at now, returning the canceled checkout, or nil if it was not
+// due.
+func (m *Memory) expire(ctx context.Context, session *server.CheckoutSession, now time.Time) (*extensions.ExtendedCheckoutResponse, error) {
+	var due bool
+	session.Read(func(checkout *extensions.ExtendedCheckoutResponse) {
+		due = expired(checkout, now)
+	})
+	if !due {
+		return nil, nil
+	}
+	canceled := false
+	checkout, err := session.Write(func(checkout *extensions.ExtendedCheckoutResponse) error {
+		if !expired(checkout, now) {
+			return nil
+		}
+		if err := m.flow.Transition(ctx, &checkout.Status, models.CheckoutStatusCanceled); err != nil {
+			return err
+		}
+		updated := now.UTC()
+		checkout.UpdatedAt = &updated
+		canceled = true
+		return nil
+	})
+	if err != nil || !canceled {
+		return nil, err
+	}
+	return checkout, nil
+}
+
+// expired reports whether an unfinished checkout is past its expires_at
+// at now.
+func expired(checkout *extensions.ExtendedCheckoutResponse, now time.Time) bool {
+	return checkout.ExpiresAt != nil && !now.Before(*checkout.ExpiresAt) && !checkout.Status.IsTerminal()
+}
+
+// GetCheckout implements Store.
+func (m *Memory) GetCheckout(ctx context.Context, id string) (*extensions.ExtendedCheckoutResponse, error) {
+	session, err := m.session(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return session.Snapshot()
+}
+
+// UpdateCheckout implements Store.
+func (m *Memory) UpdateCheckout(ctx context.Context, id string, fn func(checkout *extensions.ExtendedCheckoutResponse) error) (*extensions.ExtendedCheckoutResponse, error) {
+	session, err := m.session(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return session.Write(fn)
+}
+
+// CompleteCheckout implements Store.
+func (m *Memory) CompleteCheckout(ctx context.Context, id string, fn func(checkout *extensions.ExtendedCheckoutResponse) (*models.Order, error)) (*extensions.ExtendedCheckoutResponse, error) {
+	session, err := m.session(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return session.Write(func(checkout *extensions.ExtendedCheckoutResponse) error {
+		order, err := fn(checkout)
+		if err != nil || order == nil {
+			return err
+		}
+		return m.CreateOrder(ctx, order)
+	})
+}
+
+// CreateOrder implements Store.
+func (m *Memory) CreateOrder(ctx context.Context, order *models.Order) error {
+	cp, err := clone(order)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.orders[order.ID]; ok {
+		return ErrExists
+	}
+	m.orders[order.ID] = cp
+	return nil
+}
+
+// GetOrder implements Store.
+func (m *Memory) GetOrder(ctx context.Context, id string) (*models.Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	order, ok := m.orders[id]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	return clone(order)
+}
+
+// CreateCart implements Store.
+func (m *Memory) CreateCart(ctx context.Context, cart *Cart) error {
+	if cart.ExpiresAt == "" && m.cartTTL > 0 {
+		cart.ExpiresAt = m.clock.Now().Add(m.cartTTL).UTC().Format(time.RFC3339)
+	}
+	cp, err := clone(cart)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.carts[cart.ID]; ok {
+		return ErrExists
+	}
+	m.carts[cart.ID] = cp
+	return nil
+}
+
+// cart returns a stored cart, deleting it if it has expired. m.mu must be
+// held.
+func (m *Memory) cart(id string) (*Cart, error) {
+	cart, ok := m.carts[id]
+	if !ok {
+		return nil, ErrCartNotFound
+	}
+	if cartExpired(cart, m.clock.Now()) {
+		delete(m.carts, id)
+		return nil, ErrCartNotFound
+	}
+	return cart, nil
+}
+
+// cartExpired reports whether a cart is past its expires_at at now.
+func cartExpired(cart *Cart, now time.Time) bool {
+	if cart.ExpiresAt == "" {
+		return false
+	}
+	expires, err := time.Parse(time.RFC3339, cart.ExpiresAt)
+	return err == nil && !now.Before(expires)
+}
+
+// GetCart implements Store.
+func (m *Memory) GetCart(ctx context.Context, id string) (*Cart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cart, err := m.cart(id)
+	if err != nil {
+		return nil, err
+	}
+	return clone(cart)
+}
+
+// UpdateCart implements Store.
+func (m *Memory) UpdateCart(ctx context.Context, id string, fn func(cart *Cart) error) (*Cart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cart, err := m.cart(id)
+	if err != nil {
+		return nil, err
+	}
+	working, err := clone(cart)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(working); err != nil {
+		return nil, err
+	}
+	resp, err := clone(working)
+	if err != nil {
+		return nil, err
+	}
+	m.carts[id] = working
+	return resp, nil
+}
+
+// DeleteCart implements Store.
+func (m *Memory) DeleteCart(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, err := m.cart(id); err != nil {
+		return err
+	}
+	delete(m.carts, id)
+	return nil
+}
+
+// LoadCart implements server.CartStore.
+func (m *Memory) LoadCart(ctx context.Context, id string) (*server.StoredCart, error) {
+	cart, err := m.GetCart(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return cart.Stored(), nil
+}
+
+// ConsumeCart implements server.CartStore.
+func (m *Memory) ConsumeCart(ctx context.Context, id, checkoutID string) error {
+	_, err := m.UpdateCart(ctx, id, func(cart *Cart) error {
+		if cart.ConsumedBy != "" && cart.ConsumedBy != checkoutID {
+			return server.ErrCartConsumed
+		}
+		cart.ConsumedBy = checkoutID
+		return nil
+	})
+	return err
+}
+
+// ExpireCheckouts implements Sweeper.
+func (m *Memory) ExpireCheckouts(ctx context.Context, now time.Time) ([]*extensions.ExtendedCheckoutResponse, error) {
+	var canceled []*extensions.ExtendedCheckoutResponse
+	for _, session := range m.sessions() {
+		checkout, err := m.expire(ctx, session, now)
+		if err != nil {
+			return canceled, err
+		}
+		if checkout != nil {
+			canceled = append(canceled, checkout)
+		}
+	}
+	sort.Slice(canceled, func(i, j int) bool { return canceled[i].ID < canceled[j].ID })
+	return canceled, nil
+}
+
+// PurgeCheckouts implements Purger. A checkout with no updated_at counts
+// as updated when it was created.
+func (m *Memory) PurgeCheckouts(ctx context.Context, cutoff time.Time) (int, error) {
+	// Sessions are read without m.mu, which CompleteCheckout takes while
+	// holding a session.
+	var finished []string
+	for id, session := range m.sessions() {
+		session.Read(func(checkout *extensions.ExtendedCheckoutResponse) {
+			updated := checkout.UpdatedAt
+			if updated == nil {
+				updated = checkout.CreatedAt
+			}
+			if checkout.Status.IsTerminal() && (updated == nil || updated.Before(cutoff)) {
+				finished = append(finished, id)
+			}
+		})
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range finished {
+		delete(m.checkouts, id)
+	}
+	return len(finished), nil
+}
+
+// sessions returns a copy of the checkout sessions by ID.
+func (m *Memory) sessions() map[string]*server.CheckoutSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sessions := make(map[string]*server.CheckoutSession, len(m.checkouts))
+	for id, session := range m.checkouts {
+		sessions[id] = session
+	}
+	return sessions
+}
+
+// ExpireCarts implements Sweeper.
+func (m *Memory) ExpireCarts(ctx context.Context, now time.Time) ([]*Cart, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deleted []*Cart
+	for id, cart := range m.carts {
+		if cartExpired(cart, now) {
+			delete(m.carts, id)
+			deleted = append(deleted, cart)
+		}
+	}
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i].ID < deleted[j].ID })
+	return deleted, nil
+}
+
+// CountActive implements Sweeper.
+func (m *Memory) CountActive(ctx context.Context) (checkouts, carts int, err error) {
+	now := m.clock.Now()
+	for _, session := range m.sessions() {
+		session.Read(func(checkout *extensions.ExtendedCheckoutResponse) {
+			if !checkout.Status.IsTerminal() && !expired(checkout, now) {
+				checkouts++
+			}
+		})
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, cart := range m.carts {
+		if !cartExpired(cart, now) {
+			carts++
+		}
+	}
+	return checkouts, carts, nil
+}
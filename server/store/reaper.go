@@ -0,0 +1,224 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+// DefaultReapInterval is how often a Reaper sweeps by default.
+const DefaultReapInterval = time.Minute
+
+// DefaultRetention is how long a Reaper keeps finished checkouts by
+// default.
+const DefaultRetention = 24 * time.Hour
+
+// ReaperStats are a Reaper's gauges, for capacity planning.
+type ReaperStats struct {
+	// ActiveCheckouts and ActiveCarts are the unexpired, unfinished
+	// checkouts and unexpired carts as of the last sweep.
+	ActiveCheckouts int
+	ActiveCarts     int
+
+	// ExpiredCheckoutsLastHour and ExpiredCartsLastHour count the
+	// checkouts and carts the reaper expired in the past hour.
+	ExpiredCheckoutsLastHour int
+	ExpiredCartsLastHour     int
+
+	// LastSweep is when the last sweep finished; zero before the first.
+	LastSweep time.Time
+}
+
+// Reaper expires stale checkouts and carts in the background, so they are
+// released even if no platform reads them again, and deletes finished
+// checkouts once their Retention has passed:
+//
+//	st := store.NewMemory(store.WithCheckoutTTL(30 * time.Minute))
+//	srv, _ := ucpmem.NewServer(config, ucpmem.Config{Catalog: catalog, Store: st})
+//	reaper := store.NewReaper(st, srv)
+//	reaper.OnCheckoutExpired = func(ctx context.Context, c *extensions.ExtendedCheckoutResponse) {
+//		inventory.Release(ctx, c.ID)
+//	}
+//	go reaper.Run(ctx)
+//
+// Checkouts the store cancels on access, between sweeps, are reported by
+// the server's own checkout.expired event rather than by the reaper.
+type Reaper struct {
+	// Store is swept for expired resources.
+	Store Sweeper
+
+	// Server, if set, raises a checkout.expired webhook event for each
+	// checkout the reaper cancels (see server.Server.PublishCheckoutExpired).
+	Server *server.Server
+
+	// OnCheckoutExpired, if set, is called for each checkout the reaper
+	// cancels, for example to free the inventory reserved for it.
+	OnCheckoutExpired func(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse)
+
+	// OnCartExpired, if set, is called for each cart the reaper deletes.
+	OnCartExpired func(ctx context.Context, cart *Cart)
+
+	// Interval is the time between sweeps. Defaults to
+	// DefaultReapInterval.
+	Interval time.Duration
+
+	// Retention is how long completed and canceled checkouts are kept
+	// after their last update, in stores that implement Purger. Defaults
+	// to DefaultRetention; a negative Retention keeps them.
+	Retention time.Duration
+
+	// Clock decides expiry and schedules sweeps. Defaults to
+	// server.SystemClock.
+	Clock server.Clock
+
+	mu       sync.Mutex
+	stats    ReaperStats
+	expiries []reapedBatch
+}
+
+// reapedBatch is what one sweep expired, kept for an hour for the
+// per-hour gauges.
+type reapedBatch struct {
+	at        time.Time
+	checkouts int
+	carts     int
+}
+
+// NewReaper creates a reaper sweeping st and raising expiry events
+// through srv, which may be nil.
+func NewReaper(st Sweeper, srv *server.Server) *Reaper {
+	return &Reaper{Store: st, Server: srv}
+}
+
+// Run sweeps every Interval until ctx is canceled, returning ctx.Err().
+// Failed sweeps are logged and retried at the next interval.
+func (r *Reaper) Run(ctx context.Context) error {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = DefaultReapInterval
+	}
+	for {
+		if err := r.Sweep(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("ucp: failed to expire stale sessions: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.clock().After(interval):
+		}
+	}
+}
+
+// Sweep expires checkouts and carts that are due, runs the callbacks and
+// events for each, purges finished checkouts past their retention, and
+// updates the gauges.
+func (r *Reaper) Sweep(ctx context.Context) error {
+	now := r.clock().Now()
+	checkouts, err := r.Store.ExpireCheckouts(ctx, now)
+	for _, checkout := range checkouts {
+		if r.Server != nil {
+			r.Server.PublishCheckoutExpired(ctx, checkout)
+		}
+		if r.OnCheckoutExpired != nil {
+			r.OnCheckoutExpired(ctx, checkout)
+		}
+	}
+	if err != nil {
+		r.record(now, len(checkouts), 0)
+		return err
+	}
+
+	carts, err := r.Store.ExpireCarts(ctx, now)
+	if r.OnCartExpired != nil {
+		for _, cart := range carts {
+			r.OnCartExpired(ctx, cart)
+		}
+	}
+	r.record(now, len(checkouts), len(carts))
+	if err != nil {
+		return err
+	}
+
+	if purger, ok := r.Store.(Purger); ok && r.Retention >= 0 {
+		retention := r.Retention
+		if retention == 0 {
+			retention = DefaultRetention
+		}
+		if _, err := purger.PurgeCheckouts(ctx, now.Add(-retention)); err != nil {
+			return err
+		}
+	}
+
+	active, activeCarts, err := r.Store.CountActive(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.stats.ActiveCheckouts = active
+	r.stats.ActiveCarts = activeCarts
+	r.stats.LastSweep = now
+	r.mu.Unlock()
+	return nil
+}
+
+// record notes what a sweep at now expired.
+func (r *Reaper) record(now time.Time, checkouts, carts int) {
+	if checkouts == 0 && carts == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prune(now)
+	r.expiries = append(r.expiries, reapedBatch{at: now, checkouts: checkouts, carts: carts})
+}
+
+// prune drops batches more than an hour before now. r.mu must be held.
+func (r *Reaper) prune(now time.Time) {
+	since := now.Add(-time.Hour)
+	kept := r.expiries[:0]
+	for _, b := range r.expiries {
+		if b.at.After(since) {
+			kept = append(kept, b)
+		}
+	}
+	r.expiries = kept
+}
+
+// Stats returns the reaper's gauges.
+func (r *Reaper) Stats() ReaperStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prune(r.clock().Now())
+	stats := r.stats
+	for _, b := range r.expiries {
+		stats.ExpiredCheckoutsLastHour += b.checkouts
+		stats.ExpiredCartsLastHour += b.carts
+	}
+	return stats
+}
+
+func (r *Reaper) clock() server.Clock {
+	if r.Clock == nil {
+		return server.SystemClock
+	}
+	return r.Clock
+}
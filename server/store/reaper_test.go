@@ -0,0 +1,80 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+	"github.com/dhananjay2021/ucp-go-sdk/server/store"
+)
+
+func TestReaperPurgesFinishedCheckouts(t *testing.T) {
+	ctx := context.Background()
+	clock := server.NewFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	st := store.NewMemory(store.WithClock(clock))
+	reaper := store.NewReaper(st, nil)
+	reaper.Clock = clock
+	reaper.Retention = time.Hour
+
+	for _, status := range []models.CheckoutStatus{
+		models.CheckoutStatusIncomplete, models.CheckoutStatusCompleted, models.CheckoutStatusCanceled,
+	} {
+		now := clock.Now()
+		if err := st.CreateCheckout(ctx, &extensions.ExtendedCheckoutResponse{ID: string(status), Status: status, UpdatedAt: &now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := st.CreateOrder(ctx, &models.Order{ID: "ord_1", CheckoutID: string(models.CheckoutStatusCompleted)}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		kept    []models.CheckoutStatus
+		purged  []models.CheckoutStatus
+	}{
+		{"within retention", 30 * time.Minute,
+			[]models.CheckoutStatus{models.CheckoutStatusIncomplete, models.CheckoutStatusCompleted, models.CheckoutStatusCanceled}, nil},
+		{"past retention", time.Hour,
+			[]models.CheckoutStatus{models.CheckoutStatusIncomplete},
+			[]models.CheckoutStatus{models.CheckoutStatusCompleted, models.CheckoutStatusCanceled}},
+	}
+	for _, tt := range tests {
+		clock.Advance(tt.advance)
+		if err := reaper.Sweep(ctx); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range tt.kept {
+			if _, err := st.GetCheckout(ctx, string(id)); err != nil {
+				t.Errorf("%s: %s checkout: %v, want it kept", tt.name, id, err)
+			}
+		}
+		for _, id := range tt.purged {
+			if _, err := st.GetCheckout(ctx, string(id)); !errors.Is(err, store.ErrCheckoutNotFound) {
+				t.Errorf("%s: %s checkout: %v, want it purged", tt.name, id, err)
+			}
+		}
+	}
+	if _, err := st.GetOrder(ctx, "ord_1"); err != nil {
+		t.Errorf("order of a purged checkout: %v, want it kept", err)
+	}
+}
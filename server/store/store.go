@@ -0,0 +1,179 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package store persists a merchant's checkouts, orders, and carts.
+//
+// Store is the interface handlers program against; Memory is an in-memory
+// implementation with TTL-based expiry. Checkout and cart updates take a
+// function that mutates a private copy, which is stored only if the
+// function succeeds, so a failed update never leaves a half-written
+// checkout behind:
+//
+//	st := store.NewMemory(store.WithCheckoutTTL(30 * time.Minute))
+//	checkout, err := st.UpdateCheckout(ctx, id, func(c *extensions.ExtendedCheckoutResponse) error {
+//		c.Buyer = buyer
+//		return nil
+//	})
+//
+// The in-memory merchant in server/ucpmem stores through a Store, so a
+// merchant gets a working UCP server by supplying only a catalog and,
+// optionally, tax and rate callbacks.
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+var (
+	// ErrCheckoutNotFound is returned when a checkout does not exist.
+	ErrCheckoutNotFound = errors.New("checkout not found")
+
+	// ErrOrderNotFound is returned when an order does not exist.
+	ErrOrderNotFound = errors.New("order not found")
+
+	// ErrCartNotFound is returned when a cart does not exist or has
+	// expired. It is server.ErrCartNotFound, so a Store can back
+	// server.Config.Carts.
+	ErrCartNotFound = server.ErrCartNotFound
+
+	// ErrExists is returned when creating a resource whose ID is taken.
+	ErrExists = errors.New("resource already exists")
+
+	// ErrConflict is returned when an update loses a race with a
+	// concurrent update to the same resource, for stores that use
+	// optimistic concurrency rather than serializing updates.
+	ErrConflict = errors.New("resource was modified concurrently")
+)
+
+// Cart is a stored cart: the response returned to platforms and the
+// request fields that carry over to a checkout created from it.
+type Cart struct {
+	models.CartResponse
+
+	// Context is the buyer context the cart was priced for.
+	Context *models.Context `json:"context,omitempty"`
+
+	// Buyer is the buyer the cart was created for, if any.
+	Buyer *models.Buyer `json:"buyer,omitempty"`
+
+	// ConsumedBy is the checkout the cart was converted to, once it has
+	// been.
+	ConsumedBy string `json:"consumed_by,omitempty"`
+}
+
+// Stored returns the part of the cart that carries over to a checkout, as
+// server.CartStore returns it.
+func (c *Cart) Stored() *server.StoredCart {
+	items := make([]models.LineItemCreateRequest, len(c.LineItems))
+	for i, li := range c.LineItems {
+		items[i] = models.LineItemCreateRequest{Item: models.ItemCreateRequest{ID: li.Item.ID}, Quantity: li.Quantity}
+	}
+	return &server.StoredCart{
+		ID:         c.ID,
+		LineItems:  items,
+		Context:    c.Context,
+		Buyer:      c.Buyer,
+		ConsumedBy: c.ConsumedBy,
+	}
+}
+
+// Store persists checkouts, orders, and carts. Implementations must be
+// safe for concurrent use, hand out copies rather than pointers into
+// stored state, and cancel open checkouts whose expires_at has passed
+// when they are next read or written.
+type Store interface {
+	// CreateCheckout stores a new checkout. It returns ErrExists if the ID
+	// is taken.
+	CreateCheckout(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse) error
+
+	// GetCheckout returns a checkout, or ErrCheckoutNotFound.
+	GetCheckout(ctx context.Context, id string) (*extensions.ExtendedCheckoutResponse, error)
+
+	// UpdateCheckout calls fn with a copy of a checkout and stores the
+	// copy if fn returns nil, returning it. Updates to one checkout are
+	// serialized, or fail with ErrConflict if another update won.
+	UpdateCheckout(ctx context.Context, id string, fn func(checkout *extensions.ExtendedCheckoutResponse) error) (*extensions.ExtendedCheckoutResponse, error)
+
+	// CompleteCheckout is UpdateCheckout for completion: fn returns the
+	// order it placed, which is stored together with the checkout, or nil
+	// if the order is placed later.
+	CompleteCheckout(ctx context.Context, id string, fn func(checkout *extensions.ExtendedCheckoutResponse) (*models.Order, error)) (*extensions.ExtendedCheckoutResponse, error)
+
+	// CreateOrder stores a new order. It returns ErrExists if the ID is
+	// taken.
+	CreateOrder(ctx context.Context, order *models.Order) error
+
+	// GetOrder returns an order, or ErrOrderNotFound.
+	GetOrder(ctx context.Context, id string) (*models.Order, error)
+
+	// CreateCart stores a new cart. It returns ErrExists if the ID is
+	// taken.
+	CreateCart(ctx context.Context, cart *Cart) error
+
+	// GetCart returns a cart, or ErrCartNotFound.
+	GetCart(ctx context.Context, id string) (*Cart, error)
+
+	// UpdateCart calls fn with a copy of a cart and stores the copy if fn
+	// returns nil, returning it. Like UpdateCheckout, it may fail with
+	// ErrConflict.
+	UpdateCart(ctx context.Context, id string, fn func(cart *Cart) error) (*Cart, error)
+
+	// DeleteCart removes a cart, or returns ErrCartNotFound.
+	DeleteCart(ctx context.Context, id string) error
+}
+
+// Sweeper is implemented by stores that can expire resources in bulk, for
+// a Reaper to run in the background.
+type Sweeper interface {
+	// ExpireCheckouts cancels every unfinished checkout whose expires_at
+	// is at or before now and returns the canceled checkouts.
+	ExpireCheckouts(ctx context.Context, now time.Time) ([]*extensions.ExtendedCheckoutResponse, error)
+
+	// ExpireCarts deletes every cart whose expires_at is at or before now
+	// and returns the deleted carts.
+	ExpireCarts(ctx context.Context, now time.Time) ([]*Cart, error)
+
+	// CountActive returns the number of unexpired, unfinished checkouts
+	// and unexpired carts.
+	CountActive(ctx context.Context) (checkouts, carts int, err error)
+}
+
+// Purger is implemented by stores that can delete finished checkouts, for
+// a Reaper to keep them from growing without bound.
+type Purger interface {
+	// PurgeCheckouts deletes every completed or canceled checkout last
+	// updated before cutoff and returns how many it deleted.
+	PurgeCheckouts(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// clone deep-copies v via JSON.
+func clone[T any](v *T) (*T, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to copy: %w", err)
+	}
+	var cp T
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("store: failed to copy: %w", err)
+	}
+	return &cp, nil
+}
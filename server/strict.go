@@ -0,0 +1,162 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// IdempotencyKeyHeader is the header carrying a request's idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// decodeRequest decodes a JSON request body into v. In strict mode fields
+// that v does not define are rejected. Buyer context is sanitized when
+// Config.ContextSanitizer is set.
+func (s *Server) decodeRequest(r *http.Request, v any) error {
+	dec := json.NewDecoder(r.Body)
+	if s.config.StrictMode {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return NewAPIError(http.StatusBadRequest, string(models.ErrorCodeUnknownField), "Unknown field "+field)
+		}
+		return NewAPIError(http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Failed to parse request body")
+	}
+	if s.config.ContextSanitizer != nil {
+		sanitizeRequestContext(s.config.ContextSanitizer, v)
+	}
+	return nil
+}
+
+// sanitizeRequestContext sanitizes the buyer context of a decoded checkout
+// or cart request in place.
+func sanitizeRequestContext(sanitizer *validation.Sanitizer, v any) {
+	switch req := v.(type) {
+	case *extensions.ExtendedCheckoutCreateRequest:
+		req.Context = sanitizer.Context(req.Context)
+	case *extensions.ExtendedCheckoutUpdateRequest:
+		req.Context = sanitizer.Context(req.Context)
+	case *models.CartCreateRequest:
+		req.Context = sanitizer.Context(req.Context)
+	case *models.CartUpdateRequest:
+		req.Context = sanitizer.Context(req.Context)
+	}
+}
+
+// strictHeaders enforces the headers strict mode requires for op: a
+// UCP-Agent identifying the platform on every operation but discovery, a
+// JSON Content-Type on requests with a body, and an Idempotency-Key on
+// checkout completion.
+func (s *Server) strictHeaders(op Operation, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if op != OperationDiscovery {
+			if _, err := PlatformProfileURL(r); err != nil {
+				s.handleError(w, NewAPIError(http.StatusBadRequest, string(models.ErrorCodeMissingHeader), err.Error()))
+				return
+			}
+		}
+		if len(bytes.TrimSpace(RawBody(r.Context()))) > 0 {
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if mediaType != "application/json" {
+				s.writeError(w, http.StatusUnsupportedMediaType, string(models.ErrorCodeUnsupportedMediaType), "Content-Type must be application/json")
+				return
+			}
+		}
+		if op == OperationCompleteCheckout && r.Header.Get(IdempotencyKeyHeader) == "" {
+			s.writeError(w, http.StatusBadRequest, string(models.ErrorCodeMissingHeader), "Idempotency-Key header is required to complete a checkout")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// checkCreateRequest validates a checkout create request against the
+// checkout schema's required fields and the context hint formats.
+func (s *Server) checkCreateRequest(r *http.Request, req *extensions.ExtendedCheckoutCreateRequest) error {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(RawBody(r.Context()), &doc); err != nil {
+		return NewAPIError(http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Failed to parse request body")
+	}
+	result := validation.ValidateCheckoutRequest(doc)
+	if req.CartID != "" {
+		result = &validation.ValidationResult{Valid: true}
+	}
+	errs := append(result.Errors, validation.ValidateContext(req.Context).Errors...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return NewAPIError(http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Request does not conform to the checkout schema").
+		WithMessages(validationMessages(http.StatusBadRequest, string(models.ErrorCodeInvalidField), errs)...)
+}
+
+// verifyCheckout checks that a checkout response is internally consistent
+// before it is sent: totals add up, amounts agree with the currency, and
+// fulfillment references resolve. An inconsistent response is a merchant
+// bug, reported as a 500 so it surfaces before launch.
+func verifyCheckout(checkout *extensions.ExtendedCheckoutResponse) error {
+	var errs []validation.ValidationError
+	errs = append(errs, validation.ValidateCheckoutCurrency(checkout).Errors...)
+	errs = append(errs, validation.ValidateFulfillment(checkout).Errors...)
+
+	recomputed := RecomputeTotal(append([]models.TotalResponse(nil), checkout.Totals...))
+	want, _ := totalAmount(recomputed, models.TotalTypeTotal)
+	if got, ok := totalAmount(checkout.Totals, models.TotalTypeTotal); !ok {
+		errs = append(errs, validation.ValidationError{Field: "$.totals", Message: "total is missing"})
+	} else if got != want {
+		errs = append(errs, validation.ValidationError{
+			Field:   "$.totals",
+			Message: fmt.Sprintf("total %d does not equal the sum of its components %d", got, want),
+		})
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return NewAPIError(http.StatusInternalServerError, string(models.ErrorCodeNonconformantResponse), "Checkout response failed strict mode verification").
+		WithMessages(validationMessages(http.StatusInternalServerError, string(models.ErrorCodeNonconformantResponse), errs)...)
+}
+
+// totalAmount returns the amount of the first total of the given type.
+func totalAmount(totals []models.TotalResponse, typ models.TotalType) (int, bool) {
+	for _, t := range totals {
+		if t.Type == typ {
+			return t.Amount, true
+		}
+	}
+	return 0, false
+}
+
+// validationMessages converts validation errors to error envelope messages.
+func validationMessages(statusCode int, code string, errs []validation.ValidationError) []models.Message {
+	messages := make([]models.Message, len(errs))
+	for i, e := range errs {
+		messages[i] = errorMessage(statusCode, code, e.Message)
+		messages[i].Path = e.Field
+		if messages[i].Path != "" && !strings.HasPrefix(messages[i].Path, "$") {
+			messages[i].Path = "$." + messages[i].Path
+		}
+	}
+	return messages
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// TaxCalculator computes taxes for a set of line items shipped to a destination.
+//
+// When Config.TaxCalculator is set, the server invokes it after every checkout
+// create and update handler and writes the result into the response totals,
+// so handlers only need to price items and fulfillment.
+type TaxCalculator interface {
+	// Calculate returns the tax owed. destination may be nil when neither a
+	// fulfillment destination nor a context hint is available.
+	Calculate(ctx context.Context, lineItems []models.LineItemResponse, destination *models.PostalAddress) (*TaxResult, error)
+}
+
+// TaxResult is the outcome of a tax calculation.
+type TaxResult struct {
+	// Total is the total tax in minor units.
+	Total int
+
+	// Lines is the per-line-item breakdown.
+	Lines []LineTax
+}
+
+// LineTax is the tax attributed to a single line item.
+type LineTax struct {
+	// LineItemID is the line item ID.
+	LineItemID string
+
+	// Amount is the tax in minor units.
+	Amount int
+}
+
+// FlatRateTaxCalculator is a reference TaxCalculator applying a fixed rate,
+// optionally overridden per country or country/region.
+type FlatRateTaxCalculator struct {
+	// RateBasisPoints is the default rate in basis points (875 = 8.75%).
+	RateBasisPoints int
+
+	// Rates overrides the default rate keyed by country ("US") or
+	// country and region ("US-CA"); the more specific key wins.
+	Rates map[string]int
+}
+
+// Calculate implements TaxCalculator.
+func (c *FlatRateTaxCalculator) Calculate(ctx context.Context, lineItems []models.LineItemResponse, destination *models.PostalAddress) (*TaxResult, error) {
+	rate := c.rateFor(destination)
+	result := &TaxResult{}
+	for _, li := range lineItems {
+		amount := lineSubtotal(li) * rate / 10000
+		result.Total += amount
+		result.Lines = append(result.Lines, LineTax{LineItemID: li.ID, Amount: amount})
+	}
+	return result, nil
+}
+
+// rateFor returns the rate applicable to a destination.
+func (c *FlatRateTaxCalculator) rateFor(destination *models.PostalAddress) int {
+	if destination == nil || len(c.Rates) == 0 {
+		return c.RateBasisPoints
+	}
+	country := strings.ToUpper(destination.AddressCountry)
+	if rate, ok := c.Rates[country+"-"+strings.ToUpper(destination.AddressRegion)]; ok {
+		return rate
+	}
+	if rate, ok := c.Rates[country]; ok {
+		return rate
+	}
+	return c.RateBasisPoints
+}
+
+// ApplyTax writes a tax result into a checkout: each line item's tax total is
+// replaced, the checkout tax total is replaced, and the grand total is
+// recomputed.
+func ApplyTax(checkout *extensions.ExtendedCheckoutResponse, result *TaxResult) {
+	byLine := make(map[string]int, len(result.Lines))
+	for _, lt := range result.Lines {
+		byLine[lt.LineItemID] += lt.Amount
+	}
+	for i := range checkout.LineItems {
+		li := &checkout.LineItems[i]
+		li.Totals = setTotal(li.Totals, models.TotalTypeTax, byLine[li.ID])
+	}
+	checkout.Totals = setTotal(checkout.Totals, models.TotalTypeTax, result.Total)
+	checkout.Totals = RecomputeTotal(checkout.Totals)
+}
+
+// CheckoutDestination returns the address taxes and rates should be computed
+// for: the selected destination of the first fulfillment method that has
+// one, falling back to the checkout's context hints.
+func CheckoutDestination(checkout *extensions.ExtendedCheckoutResponse) *models.PostalAddress {
+	if checkout.Fulfillment != nil {
+		for i := range checkout.Fulfillment.Methods {
+			if dest := selectedDestination(&checkout.Fulfillment.Methods[i]); dest != nil {
+				return dest
+			}
+		}
+	}
+	if checkout.Context != nil {
+		return &models.PostalAddress{
+			AddressCountry: checkout.Context.AddressCountry,
+			AddressRegion:  checkout.Context.AddressRegion,
+			PostalCode:     checkout.Context.PostalCode,
+		}
+	}
+	return nil
+}
+
+// applyTax runs the configured TaxCalculator against a checkout response.
+func (s *Server) applyTax(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse) error {
+	if s.config.TaxCalculator == nil {
+		return nil
+	}
+	result, err := s.config.TaxCalculator.Calculate(ctx, checkout.LineItems, CheckoutDestination(checkout))
+	if err != nil {
+		return err
+	}
+	ApplyTax(checkout, result)
+	return nil
+}
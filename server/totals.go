@@ -0,0 +1,53 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import "github.com/dhananjay2021/ucp-go-sdk/models"
+
+// RecomputeTotal sets the total entry to subtotal + tax + fulfillment + fees
+// minus discounts, as models.ComputeTotal, appending it if missing.
+func RecomputeTotal(totals []models.TotalResponse) []models.TotalResponse {
+	return setTotal(totals, models.TotalTypeTotal, models.ComputeTotal(totals))
+}
+
+// setTotal replaces the amount of the first total of the given type, or
+// inserts one before the grand total if none exists.
+func setTotal(totals []models.TotalResponse, typ models.TotalType, amount int) []models.TotalResponse {
+	for i := range totals {
+		if totals[i].Type == typ {
+			totals[i].Amount = amount
+			return totals
+		}
+	}
+	entry := models.TotalResponse{Type: typ, Amount: amount}
+	for i := range totals {
+		if totals[i].Type == models.TotalTypeTotal {
+			totals = append(totals[:i+1], totals[i:]...)
+			totals[i] = entry
+			return totals
+		}
+	}
+	return append(totals, entry)
+}
+
+// lineSubtotal returns a line item's subtotal, falling back to price times quantity.
+func lineSubtotal(li models.LineItemResponse) int {
+	for _, t := range li.Totals {
+		if t.Type == models.TotalTypeSubtotal {
+			return t.Amount
+		}
+	}
+	return li.Item.Price * li.Quantity
+}
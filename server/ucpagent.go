@@ -0,0 +1,55 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// UCPAgentHeader is the header identifying the calling platform.
+const UCPAgentHeader = "UCP-Agent"
+
+// ErrMissingUCPAgent is returned when a request has no UCP-Agent header.
+var ErrMissingUCPAgent = errors.New("missing UCP-Agent header")
+
+// ParseUCPAgent extracts the profile URL from a UCP-Agent header value of
+// the form profile="https://platform.example/.well-known/ucp".
+func ParseUCPAgent(value string) (string, error) {
+	if value == "" {
+		return "", ErrMissingUCPAgent
+	}
+	for _, part := range strings.Split(value, ";") {
+		for _, param := range strings.Split(part, ",") {
+			key, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "profile") {
+				continue
+			}
+			val = strings.Trim(strings.TrimSpace(val), `"`)
+			if val == "" {
+				break
+			}
+			return val, nil
+		}
+	}
+	return "", errors.New("UCP-Agent header has no profile parameter")
+}
+
+// PlatformProfileURL returns the calling platform's profile URL from the
+// request's UCP-Agent header.
+func PlatformProfileURL(r *http.Request) (string, error) {
+	return ParseUCPAgent(r.Header.Get(UCPAgentHeader))
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ucpmem
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+	"github.com/dhananjay2021/ucp-go-sdk/server/store"
+)
+
+func (m *Merchant) priceCart(ctx context.Context, cart *models.CartResponse, items []models.LineItemCreateRequest, buyerCtx *models.Context) error {
+	priced, err := server.PriceLineItems(ctx, m.config.Catalog, items, buyerCtx, func() string { return m.id("li") })
+	if err != nil {
+		return err
+	}
+	cart.LineItems = priced.LineItems
+	cart.Messages = priced.Messages
+	cart.Totals = server.RecomputeTotal([]models.TotalResponse{{Type: models.TotalTypeSubtotal, Amount: priced.Subtotal}})
+	return nil
+}
+
+// CreateCart implements server.CreateCartHandler.
+func (m *Merchant) CreateCart(r *http.Request, req *models.CartCreateRequest) (*models.CartResponse, error) {
+	now := m.now()
+	cart := &store.Cart{
+		CartResponse: models.CartResponse{ID: m.id("cart"), Currency: m.config.Currency, CreatedAt: now, UpdatedAt: now},
+		Context:      req.Context,
+		Buyer:        req.Buyer,
+	}
+	if err := m.priceCart(r.Context(), &cart.CartResponse, req.LineItems, req.Context); err != nil {
+		return nil, err
+	}
+	if err := m.store.CreateCart(r.Context(), cart); err != nil {
+		return nil, storeError(err)
+	}
+	return &cart.CartResponse, nil
+}
+
+// GetCart implements server.GetCartHandler.
+func (m *Merchant) GetCart(r *http.Request, id string) (*models.CartResponse, error) {
+	cart, err := m.store.GetCart(r.Context(), id)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return &cart.CartResponse, nil
+}
+
+// UpdateCart implements server.UpdateCartHandler. Line items are replaced.
+func (m *Merchant) UpdateCart(r *http.Request, id string, req *models.CartUpdateRequest) (*models.CartResponse, error) {
+	cart, err := m.store.UpdateCart(r.Context(), id, func(cart *store.Cart) error {
+		if err := m.priceCart(r.Context(), &cart.CartResponse, req.LineItems, req.Context); err != nil {
+			return err
+		}
+		cart.UpdatedAt = m.now()
+		cart.Context = req.Context
+		cart.Buyer = req.Buyer
+		return nil
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return &cart.CartResponse, nil
+}
+
+// DeleteCart implements server.DeleteCartHandler.
+func (m *Merchant) DeleteCart(r *http.Request, id string) error {
+	return storeError(m.store.DeleteCart(r.Context(), id))
+}
@@ -0,0 +1,520 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ucpmem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+	"github.com/dhananjay2021/ucp-go-sdk/server/store"
+)
+
+// reprice prices line items and recomputes discounts, totals, rates, taxes,
+// status, and declared capabilities, then runs the hook for stage.
+func (m *Merchant) reprice(ctx context.Context, stage Stage, checkout *extensions.ExtendedCheckoutResponse, items []models.LineItemCreateRequest, codes []string) error {
+	priced, err := server.PriceLineItems(ctx, m.config.Catalog, items, checkout.Context, func() string { return m.id("li") })
+	if err != nil {
+		return err
+	}
+	checkout.LineItems = priced.LineItems
+	checkout.Messages = priced.Messages
+
+	totals := []models.TotalResponse{{Type: models.TotalTypeSubtotal, Amount: priced.Subtotal}}
+	if discount := m.applyDiscounts(checkout, codes, priced.Subtotal); discount > 0 {
+		totals = append(totals, models.TotalResponse{Type: models.TotalTypeDiscount, Amount: discount})
+	}
+	checkout.Totals = server.RecomputeTotal(totals)
+
+	if checkout.Fulfillment != nil && m.config.RateProvider != nil {
+		linkFulfillment(checkout)
+		if err := server.ApplyFulfillmentRates(ctx, m.config.RateProvider, checkout); err != nil {
+			return err
+		}
+		if selectDefaultOptions(checkout) {
+			if err := server.ApplyFulfillmentRates(ctx, m.config.RateProvider, checkout); err != nil {
+				return err
+			}
+		}
+	}
+	if m.config.TaxCalculator != nil {
+		tax, err := m.config.TaxCalculator.Calculate(ctx, checkout.LineItems, server.CheckoutDestination(checkout))
+		if err != nil {
+			return err
+		}
+		server.ApplyTax(checkout, tax)
+	}
+
+	if err := m.updateStatus(ctx, checkout); err != nil {
+		return err
+	}
+	checkout.UCP.Capabilities = server.ActiveCapabilities(m.capabilities, checkout)
+	return m.runHook(ctx, stage, checkout)
+}
+
+// applyDiscounts records the submitted codes on the checkout and returns
+// the total discount. Unknown codes produce a warning.
+func (m *Merchant) applyDiscounts(checkout *extensions.ExtendedCheckoutResponse, codes []string, subtotal int) int {
+	if len(codes) == 0 {
+		checkout.Discounts = nil
+		return 0
+	}
+
+	checkout.Discounts = &models.DiscountsResponse{Codes: codes}
+	total := 0
+	for i, code := range codes {
+		d := m.findDiscount(code)
+		if d == nil {
+			checkout.Messages = append(checkout.Messages, models.Message{
+				Type:    models.MessageTypeWarning,
+				Code:    "discount_code_invalid",
+				Content: fmt.Sprintf("Discount code %q is not valid", code),
+				Path:    fmt.Sprintf("$.discounts.codes[%d]", i),
+			})
+			continue
+		}
+
+		amount := d.AmountOff
+		if d.PercentOff > 0 {
+			amount = subtotal * d.PercentOff / 100
+		}
+		if amount > subtotal-total {
+			amount = subtotal - total
+		}
+		total += amount
+		applied := models.AppliedDiscount{
+			Title:  d.Title,
+			Amount: amount,
+			Code:   d.Code,
+			Method: models.AllocationMethodAcross,
+		}
+		applied.Allocations, _ = models.AllocateDiscount(applied, checkout.LineItems)
+		checkout.Discounts.Applied = append(checkout.Discounts.Applied, applied)
+	}
+	return total
+}
+
+// updateStatus derives the checkout status from its contents: ready for
+// completion once buyer email and payment are present and no error
+// messages remain, as models.DeriveCheckoutStatus.
+func (m *Merchant) updateStatus(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse) error {
+	if checkout.Buyer == nil || checkout.Buyer.Email == "" {
+		checkout.Messages = append(checkout.Messages, models.Message{
+			Type: models.MessageTypeError, Code: string(models.ErrorCodeMissing), Content: "Email required",
+			Severity: models.SeverityRecoverable, Path: "$.buyer.email",
+		})
+	}
+	if checkout.Payment.SelectedInstrumentID == "" {
+		checkout.Messages = append(checkout.Messages, models.Message{
+			Type: models.MessageTypeError, Code: string(models.ErrorCodeMissing), Content: "Payment required",
+			Severity: models.SeverityRecoverable, Path: "$.payment.selected_instrument_id",
+		})
+	}
+
+	return m.flow.Transition(ctx, &checkout.Status, models.DeriveCheckoutStatus(checkout.Messages))
+}
+
+func buyerResponse(firstName, lastName, fullName, email, phone string, consent *models.Consent, notifications *models.NotificationPreferences) *models.BuyerWithConsentResponse {
+	return &models.BuyerWithConsentResponse{
+		FirstName: firstName, LastName: lastName, FullName: fullName,
+		Email: email, PhoneNumber: phone, Consent: consent, Notifications: notifications,
+	}
+}
+
+// CreateCheckout implements server.CreateCheckoutHandler. A cart_id
+// replaces the request's line items, context, and buyer with the cart's.
+func (m *Merchant) CreateCheckout(r *http.Request, req *extensions.ExtendedCheckoutCreateRequest) (*extensions.ExtendedCheckoutResponse, error) {
+	if req.CartID != "" {
+		if err := m.applyCart(r.Context(), req); err != nil {
+			return nil, err
+		}
+	}
+
+	now := m.now()
+	checkout := &extensions.ExtendedCheckoutResponse{
+		UCP:       models.ResponseCheckout{Version: m.version},
+		ID:        m.id("chk"),
+		Currency:  req.Currency,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: m.expiry(*now),
+		Context:   req.Context,
+		Links:     m.config.Links,
+		Payment: models.PaymentResponse{
+			Handlers:             m.handlers,
+			Instruments:          req.Payment.Instruments,
+			SelectedInstrumentID: req.Payment.SelectedInstrumentID,
+		},
+	}
+	if req.Buyer != nil {
+		if messages := server.CheckNotificationsCreate(m.config.NotificationChannels, req.Buyer); len(messages) > 0 {
+			return nil, server.NotificationPreferencesError(messages)
+		}
+		checkout.Buyer = buyerResponse(req.Buyer.FirstName, req.Buyer.LastName, req.Buyer.FullName,
+			req.Buyer.Email, req.Buyer.PhoneNumber, req.Buyer.Consent, req.Buyer.Notifications)
+	}
+
+	if req.Fulfillment != nil {
+		checkout.Fulfillment = m.createFulfillment(req.Fulfillment, checkout)
+	}
+
+	var codes []string
+	if req.Discounts != nil {
+		codes = req.Discounts.Codes
+	}
+	if err := m.reprice(r.Context(), StageCreate, checkout, req.LineItems, codes); err != nil {
+		return nil, err
+	}
+
+	if err := m.store.CreateCheckout(r.Context(), checkout); err != nil {
+		return nil, storeError(err)
+	}
+	return checkout, nil
+}
+
+// applyCart replaces the request's line items, context, and buyer with
+// those of the referenced cart.
+func (m *Merchant) applyCart(ctx context.Context, req *extensions.ExtendedCheckoutCreateRequest) error {
+	cart, err := m.store.GetCart(ctx, req.CartID)
+	if err != nil {
+		return storeError(err)
+	}
+	req.LineItems = make([]models.LineItemCreateRequest, len(cart.LineItems))
+	for i, li := range cart.LineItems {
+		req.LineItems[i] = models.LineItemCreateRequest{Item: models.ItemCreateRequest{ID: li.Item.ID}, Quantity: li.Quantity}
+	}
+
+	if cart.Context != nil {
+		req.Context = cart.Context
+	}
+	if cart.Buyer != nil {
+		req.Buyer = &models.BuyerWithConsentCreateRequest{
+			FirstName: cart.Buyer.FirstName, LastName: cart.Buyer.LastName, FullName: cart.Buyer.FullName,
+			Email: cart.Buyer.Email, PhoneNumber: cart.Buyer.PhoneNumber,
+		}
+	}
+	return nil
+}
+
+// expiry returns when a checkout created at now expires, or nil if
+// checkouts do not expire.
+func (m *Merchant) expiry(now time.Time) *time.Time {
+	if m.config.CheckoutTTL <= 0 {
+		return nil
+	}
+	t := now.Add(m.config.CheckoutTTL)
+	return &t
+}
+
+// GetCheckout implements server.GetCheckoutHandler, placing the order for
+// a checkout left complete_in_progress by the hook.
+func (m *Merchant) GetCheckout(r *http.Request, id string) (*extensions.ExtendedCheckoutResponse, error) {
+	checkout, err := m.store.GetCheckout(r.Context(), id)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	if checkout.Status != models.CheckoutStatusCompleteInProgress {
+		return checkout, nil
+	}
+	checkout, err = m.store.CompleteCheckout(r.Context(), id, func(checkout *extensions.ExtendedCheckoutResponse) (*models.Order, error) {
+		if checkout.Status != models.CheckoutStatusCompleteInProgress {
+			return nil, nil // a concurrent request placed the order
+		}
+		return m.placeOrder(r.Context(), checkout)
+	})
+	if errors.Is(err, store.ErrConflict) {
+		// A concurrent request placed the order first.
+		checkout, err = m.store.GetCheckout(r.Context(), id)
+	}
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return checkout, nil
+}
+
+// UpdateCheckout implements server.UpdateCheckoutHandler. Omitted line
+// items and discounts keep their current values.
+func (m *Merchant) UpdateCheckout(r *http.Request, id string, req *extensions.ExtendedCheckoutUpdateRequest) (*extensions.ExtendedCheckoutResponse, error) {
+	checkout, err := m.store.UpdateCheckout(r.Context(), id, func(checkout *extensions.ExtendedCheckoutResponse) error {
+		if err := m.applyUpdate(r.Context(), checkout, req); err != nil {
+			return err
+		}
+		checkout.UpdatedAt = m.now()
+		return nil
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return checkout, nil
+}
+
+// applyUpdate applies an update request to a working copy of a checkout.
+func (m *Merchant) applyUpdate(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse, req *extensions.ExtendedCheckoutUpdateRequest) error {
+	if !checkout.Status.IsOpen() {
+		return server.ConflictError("checkout is " + string(checkout.Status))
+	}
+
+	if req.Buyer != nil {
+		if messages := server.CheckNotificationsUpdate(m.config.NotificationChannels, req.Buyer, checkout.Buyer); len(messages) > 0 {
+			return server.NotificationPreferencesError(messages)
+		}
+		checkout.Buyer = buyerResponse(req.Buyer.FirstName, req.Buyer.LastName, req.Buyer.FullName,
+			req.Buyer.Email, req.Buyer.PhoneNumber, req.Buyer.Consent, req.Buyer.Notifications)
+	}
+	if req.Context != nil {
+		checkout.Context = req.Context
+	}
+	if req.Payment.SelectedInstrumentID != "" {
+		checkout.Payment.SelectedInstrumentID = req.Payment.SelectedInstrumentID
+		checkout.Payment.Instruments = req.Payment.Instruments
+	}
+	if req.Fulfillment != nil {
+		checkout.Fulfillment = m.fulfillment(req.Fulfillment, checkout)
+	}
+
+	items := make([]models.LineItemCreateRequest, 0, len(req.LineItems))
+	for _, li := range req.LineItems {
+		items = append(items, models.LineItemCreateRequest{Item: models.ItemCreateRequest{ID: li.Item.ID}, Quantity: li.Quantity})
+	}
+	if len(items) == 0 {
+		for _, li := range checkout.LineItems {
+			items = append(items, models.LineItemCreateRequest{Item: models.ItemCreateRequest{ID: li.Item.ID}, Quantity: li.Quantity})
+		}
+	}
+
+	var codes []string
+	if req.Discounts != nil {
+		codes = req.Discounts.Codes
+	} else if checkout.Discounts != nil {
+		codes = checkout.Discounts.Codes
+	}
+	return m.reprice(ctx, StageUpdate, checkout, items, codes)
+}
+
+// fulfillment builds methods from an update request. Line item references
+// are stored as product IDs until linkFulfillment resolves them after
+// repricing, since line item IDs are reassigned on every reprice. Methods
+// keep the type of the existing method with the same ID, else shipping.
+func (m *Merchant) fulfillment(req *models.FulfillmentUpdateRequest, current *extensions.ExtendedCheckoutResponse) *models.FulfillmentResponse {
+	productIDs := make(map[string]string, len(current.LineItems))
+	for _, li := range current.LineItems {
+		productIDs[li.ID] = li.Item.ID
+	}
+	types := make(map[string]models.FulfillmentMethodType)
+	if current.Fulfillment != nil {
+		for _, method := range current.Fulfillment.Methods {
+			types[method.ID] = method.Type
+		}
+	}
+
+	resp := &models.FulfillmentResponse{}
+	for _, method := range req.Methods {
+		out := models.FulfillmentMethodResponse{ID: method.ID, Type: models.FulfillmentMethodTypeShipping}
+		if typ, ok := types[method.ID]; ok {
+			out.Type = typ
+		}
+		if out.ID == "" {
+			out.ID = m.id("method")
+		}
+		for _, ref := range method.LineItemIDs {
+			if pid, ok := productIDs[ref]; ok {
+				ref = pid
+			}
+			out.LineItemIDs = append(out.LineItemIDs, ref)
+		}
+		for _, d := range method.Destinations {
+			destID := d.ID
+			if destID == "" {
+				destID = m.id("dest")
+			}
+			out.Destinations = append(out.Destinations, models.FulfillmentDestinationResponse{
+				PostalAddress: d.PostalAddress, ID: destID, Address: d.Address, Name: d.Name,
+			})
+		}
+		if method.SelectedDestinationID != nil {
+			out.SelectedDestinationID = method.SelectedDestinationID
+		} else if len(out.Destinations) > 0 {
+			out.SelectedDestinationID = &out.Destinations[0].ID
+		}
+		for _, g := range method.Groups {
+			out.Groups = append(out.Groups, models.FulfillmentGroupResponse{ID: g.ID, SelectedOptionID: g.SelectedOptionID})
+		}
+		resp.Methods = append(resp.Methods, out)
+	}
+	return resp
+}
+
+// createFulfillment builds methods from a create request.
+func (m *Merchant) createFulfillment(req *models.FulfillmentCreateRequest, current *extensions.ExtendedCheckoutResponse) *models.FulfillmentResponse {
+	upd := &models.FulfillmentUpdateRequest{}
+	for _, method := range req.Methods {
+		um := models.FulfillmentMethodUpdateRequest{
+			LineItemIDs:           method.LineItemIDs,
+			Destinations:          method.Destinations,
+			SelectedDestinationID: method.SelectedDestinationID,
+		}
+		for _, g := range method.Groups {
+			um.Groups = append(um.Groups, models.FulfillmentGroupUpdateRequest{SelectedOptionID: g.SelectedOptionID})
+		}
+		upd.Methods = append(upd.Methods, um)
+	}
+
+	resp := m.fulfillment(upd, current)
+	for i, method := range req.Methods {
+		if method.Type != "" {
+			resp.Methods[i].Type = method.Type
+		}
+	}
+	return resp
+}
+
+// linkFulfillment maps method line item references (product or line item
+// IDs) onto the current line items, defaulting to all items.
+func linkFulfillment(checkout *extensions.ExtendedCheckoutResponse) {
+	for i := range checkout.Fulfillment.Methods {
+		method := &checkout.Fulfillment.Methods[i]
+		var ids []string
+		for _, ref := range method.LineItemIDs {
+			for _, li := range checkout.LineItems {
+				if li.ID == ref || li.Item.ID == ref {
+					ids = append(ids, li.ID)
+					break
+				}
+			}
+		}
+		if len(ids) == 0 {
+			for _, li := range checkout.LineItems {
+				ids = append(ids, li.ID)
+			}
+		}
+		method.LineItemIDs = ids
+
+		if len(method.Groups) == 0 {
+			method.Groups = []models.FulfillmentGroupResponse{{ID: method.ID + "-group-1"}}
+		}
+		for j := range method.Groups {
+			g := &method.Groups[j]
+			if g.ID == "" {
+				g.ID = fmt.Sprintf("%s-group-%d", method.ID, j+1)
+			}
+			g.LineItemIDs = ids
+		}
+	}
+}
+
+// selectDefaultOptions selects the first quoted option for groups without
+// a selection, reporting whether any selection changed.
+func selectDefaultOptions(checkout *extensions.ExtendedCheckoutResponse) bool {
+	changed := false
+	for i := range checkout.Fulfillment.Methods {
+		for j := range checkout.Fulfillment.Methods[i].Groups {
+			g := &checkout.Fulfillment.Methods[i].Groups[j]
+			if g.SelectedOptionID == nil && len(g.Options) > 0 {
+				first := g.Options[0].ID
+				g.SelectedOptionID = &first
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// CompleteCheckout implements server.CompleteCheckoutHandler.
+func (m *Merchant) CompleteCheckout(r *http.Request, id string) (*extensions.ExtendedCheckoutResponse, error) {
+	checkout, err := m.store.CompleteCheckout(r.Context(), id, func(checkout *extensions.ExtendedCheckoutResponse) (*models.Order, error) {
+		if checkout.Status != models.CheckoutStatusReadyForComplete {
+			return nil, server.BadRequestError("checkout is not ready for completion")
+		}
+		if err := m.runHook(r.Context(), StageComplete, checkout); err != nil {
+			return nil, err
+		}
+		checkout.UpdatedAt = m.now()
+		if checkout.Status != models.CheckoutStatusReadyForComplete {
+			return nil, nil
+		}
+		return m.placeOrder(r.Context(), checkout)
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return checkout, nil
+}
+
+// placeOrder marks a checkout completed and returns its order for the
+// store to save.
+func (m *Merchant) placeOrder(ctx context.Context, checkout *extensions.ExtendedCheckoutResponse) (*models.Order, error) {
+	if err := m.flow.Transition(ctx, &checkout.Status, models.CheckoutStatusCompleted); err != nil {
+		return nil, err
+	}
+	orderID := m.id("ord")
+	now := m.now()
+	lineItems := make([]models.OrderLineItem, len(checkout.LineItems))
+	for i, li := range checkout.LineItems {
+		lineItems[i] = models.OrderLineItem{
+			ID:       li.ID,
+			Item:     li.Item,
+			Quantity: models.OrderLineItemQuantity{Total: li.Quantity},
+			Totals:   li.Totals,
+			Status:   models.OrderLineItemStatusProcessing,
+		}
+	}
+	order := &models.Order{
+		UCP: models.ResponseOrder{
+			Version:      m.version,
+			Capabilities: m.orderCaps,
+		},
+		ID:           orderID,
+		CheckoutID:   checkout.ID,
+		PermalinkURL: server.ResolveURL(ctx, "/orders/"+orderID),
+		LineItems:    lineItems,
+		Currency:     checkout.Currency,
+		Totals:       checkout.Totals,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	checkout.UpdatedAt = now
+	checkout.Messages = nil
+	checkout.Order = &models.OrderConfirmation{ID: orderID, PermalinkURL: order.PermalinkURL}
+	return order, nil
+}
+
+// CancelCheckout implements server.CancelCheckoutHandler.
+func (m *Merchant) CancelCheckout(r *http.Request, id string) (*extensions.ExtendedCheckoutResponse, error) {
+	checkout, err := m.store.UpdateCheckout(r.Context(), id, func(checkout *extensions.ExtendedCheckoutResponse) error {
+		if err := m.flow.Transition(r.Context(), &checkout.Status, models.CheckoutStatusCanceled); err != nil {
+			return err
+		}
+		checkout.UpdatedAt = m.now()
+		return nil
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return checkout, nil
+}
+
+// GetOrder implements server.GetOrderHandler.
+func (m *Merchant) GetOrder(r *http.Request, id string) (*models.Order, error) {
+	order, err := m.store.GetOrder(r.Context(), id)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return order, nil
+}
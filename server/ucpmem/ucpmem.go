@@ -0,0 +1,266 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ucpmem is an in-memory reference implementation of the UCP
+// checkout, order, and cart handlers.
+//
+// A Merchant prices line items from a server.Catalog, applies discount
+// codes, quotes fulfillment through a server.RateProvider, charges tax
+// through a server.TaxCalculator, and stores checkouts, orders, and carts
+// in a store.Store, in memory unless configured otherwise. Register
+// installs every handler on a server.Server; handlers can then be
+// overridden one at a time, delegating to the Merchant's methods where
+// useful:
+//
+//	m := ucpmem.New(config, ucpmem.Config{Catalog: catalog, TaxCalculator: tax})
+//	srv := server.NewServer(config)
+//	m.Register(srv)
+//	srv.HandleCompleteCheckout(func(r *http.Request, id string) (*extensions.ExtendedCheckoutResponse, error) {
+//		if err := chargePayment(r.Context(), id); err != nil {
+//			return nil, err
+//		}
+//		return m.CompleteCheckout(r, id)
+//	})
+package ucpmem
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+	"github.com/dhananjay2021/ucp-go-sdk/server/store"
+)
+
+// Stage is a point in the checkout lifecycle at which the Hook runs.
+type Stage string
+
+const (
+	// StageCreate runs after a new checkout is priced.
+	StageCreate Stage = "create"
+
+	// StageUpdate runs after an updated checkout is repriced.
+	StageUpdate Stage = "update"
+
+	// StageComplete runs before an order is placed.
+	StageComplete Stage = "complete"
+)
+
+// Hook inspects or adjusts a checkout at a lifecycle stage. Returning an
+// error fails the request and leaves the stored checkout unchanged.
+//
+// At StageCreate and StageUpdate the hook runs after status is derived, so
+// it may override status and messages. At StageComplete the order is only
+// placed if the status is still ready_for_complete; a hook may instead set
+// requires_escalation, or complete_in_progress to defer the order until
+// the next GetCheckout. Status changes the checkout state machine does
+// not allow (see models.CheckoutFlow) fail the request.
+type Hook func(ctx context.Context, stage Stage, checkout *extensions.ExtendedCheckoutResponse) error
+
+// Discount is a code-based discount. Exactly one of PercentOff or
+// AmountOff should be set.
+type Discount struct {
+	Code       string
+	Title      string
+	PercentOff int
+	AmountOff  int
+}
+
+// Config configures a Merchant.
+type Config struct {
+	// Catalog prices line items. Required.
+	Catalog server.Catalog
+
+	// TaxCalculator charges tax; no tax is charged when nil.
+	TaxCalculator server.TaxCalculator
+
+	// RateProvider quotes fulfillment options; fulfillment is not priced
+	// when nil.
+	RateProvider server.RateProvider
+
+	// Discounts lists the accepted discount codes (case-insensitive).
+	Discounts []Discount
+
+	// Currency is used for carts. Defaults to USD.
+	Currency string
+
+	// Links are attached to every checkout (terms of service, privacy).
+	Links []models.Link
+
+	// NotificationChannels lists the channels order updates can be sent
+	// on. Buyers asking for other channels are rejected with
+	// server.CheckNotificationsCreate and server.CheckNotificationsUpdate.
+	NotificationChannels []models.NotificationChannel
+
+	// Hook runs at each checkout lifecycle stage.
+	Hook Hook
+
+	// Clock stamps created and updated resources and decides expiry.
+	// Defaults to the server config's Clock, else server.SystemClock.
+	Clock server.Clock
+
+	// CheckoutTTL, when set, gives each checkout an expires_at that long
+	// after creation. An open checkout past its expiry is canceled the
+	// next time it is accessed.
+	CheckoutTTL time.Duration
+
+	// Store persists checkouts, orders, and carts. Defaults to a
+	// store.Memory using Clock.
+	Store store.Store
+
+	// NewID generates resource IDs from a prefix such as "chk". Defaults
+	// to random, unguessable IDs, which Merchants sharing a Store rely on
+	// never to collide; tests may substitute a deterministic sequence.
+	NewID func(prefix string) string
+}
+
+// Merchant is an in-memory UCP merchant. It holds no lock of its own:
+// requests run concurrently, and concurrent writes to one checkout or cart
+// are serialized by the Store or fail with a 409.
+type Merchant struct {
+	config       Config
+	version      models.Version
+	capabilities []models.CapabilityDiscovery
+	orderCaps    []models.CapabilityResponse
+	handlers     []models.PaymentHandlerResponse
+
+	store store.Store
+
+	// flow enforces legal checkout status transitions.
+	flow models.CheckoutFlow
+}
+
+// New creates a Merchant. The protocol version and payment handlers are
+// taken from serverConfig. Checkout responses declare the configured
+// checkout capabilities they exercise (see server.ActiveCapabilities),
+// and order responses every capability named after or extending order.
+func New(serverConfig server.Config, config Config) *Merchant {
+	if config.Currency == "" {
+		config.Currency = "USD"
+	}
+	if config.Clock == nil {
+		config.Clock = serverConfig.Clock
+	}
+	if config.Clock == nil {
+		config.Clock = server.SystemClock
+	}
+	if config.Store == nil {
+		config.Store = store.NewMemory(store.WithClock(config.Clock))
+	}
+	if config.NewID == nil {
+		config.NewID = randomID
+	}
+	m := &Merchant{
+		config:       config,
+		version:      serverConfig.Version,
+		capabilities: serverConfig.Capabilities,
+		handlers:     serverConfig.PaymentHandlers,
+		store:        config.Store,
+	}
+	for _, c := range serverConfig.Capabilities {
+		if c.Name == server.GroupOrder || c.Extends == server.GroupOrder {
+			m.orderCaps = append(m.orderCaps, models.CapabilityResponse{CapabilityBase: models.CapabilityBase{Name: c.Name, Version: c.Version}})
+		}
+	}
+	return m
+}
+
+// NewServer creates a server.Server with a Merchant's handlers registered.
+func NewServer(serverConfig server.Config, config Config) (*server.Server, *Merchant) {
+	m := New(serverConfig, config)
+	srv := server.NewServer(serverConfig)
+	m.Register(srv)
+	return srv, m
+}
+
+// Register installs all of the Merchant's handlers on srv.
+func (m *Merchant) Register(srv *server.Server) {
+	srv.HandleCreateCheckout(m.CreateCheckout)
+	srv.HandleGetCheckout(m.GetCheckout)
+	srv.HandleUpdateCheckout(m.UpdateCheckout)
+	srv.HandleCompleteCheckout(m.CompleteCheckout)
+	srv.HandleCancelCheckout(m.CancelCheckout)
+	srv.HandleGetOrder(m.GetOrder)
+	srv.HandleCreateCart(m.CreateCart)
+	srv.HandleGetCart(m.GetCart)
+	srv.HandleUpdateCart(m.UpdateCart)
+	srv.HandleDeleteCart(m.DeleteCart)
+}
+
+// id returns a new resource ID with prefix.
+func (m *Merchant) id(prefix string) string {
+	return m.config.NewID(prefix)
+}
+
+// randomID returns a random, unguessable ID with prefix, so Merchants
+// sharing a store never reissue one another's IDs.
+func randomID(prefix string) string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return prefix + "-" + hex.EncodeToString(b)
+}
+
+// now returns the current time for created_at and updated_at stamps.
+func (m *Merchant) now() *time.Time {
+	t := m.config.Clock.Now().UTC()
+	return &t
+}
+
+// runHook runs the configured Hook, rejecting status changes the checkout
+// state machine does not allow.
+func (m *Merchant) runHook(ctx context.Context, stage Stage, checkout *extensions.ExtendedCheckoutResponse) error {
+	if m.config.Hook == nil {
+		return nil
+	}
+	before := checkout.Status
+	if err := m.config.Hook(ctx, stage, checkout); err != nil {
+		return err
+	}
+	if !before.CanTransitionTo(checkout.Status) {
+		return &models.TransitionError{From: before, To: checkout.Status}
+	}
+	return nil
+}
+
+func (m *Merchant) findDiscount(code string) *Discount {
+	for i := range m.config.Discounts {
+		if strings.EqualFold(m.config.Discounts[i].Code, code) {
+			return &m.config.Discounts[i]
+		}
+	}
+	return nil
+}
+
+// storeError maps the store's not-found errors to 404 responses, and lost
+// update races and taken IDs to 409.
+func storeError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrConflict):
+		return server.ConflictError("resource was modified concurrently; retry the request")
+	case errors.Is(err, store.ErrExists):
+		return server.ConflictError("resource already exists; retry the request")
+	case errors.Is(err, store.ErrCheckoutNotFound):
+		return server.NotFoundError("checkout not found")
+	case errors.Is(err, store.ErrOrderNotFound):
+		return server.NotFoundError("order not found")
+	case errors.Is(err, store.ErrCartNotFound):
+		return server.NotFoundError("cart not found")
+	}
+	return err
+}
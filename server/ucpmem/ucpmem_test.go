@@ -0,0 +1,94 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ucpmem_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+	"github.com/dhananjay2021/ucp-go-sdk/server/ucpmem"
+)
+
+// blockingCatalog holds LookupItem for items in block until release is
+// closed, after signaling entered.
+type blockingCatalog struct {
+	*server.MapCatalog
+	block            string
+	entered, release chan struct{}
+}
+
+func (c *blockingCatalog) LookupItem(ctx context.Context, id string) (*server.CatalogItem, error) {
+	if id == c.block {
+		close(c.entered)
+		<-c.release
+	}
+	return c.MapCatalog.LookupItem(ctx, id)
+}
+
+func TestMerchantServesRequestsConcurrently(t *testing.T) {
+	catalog := &blockingCatalog{
+		MapCatalog: server.NewMapCatalog(
+			server.CatalogItem{ID: "PROD-001", Title: "Headphones", Price: 14999},
+			server.CatalogItem{ID: "PROD-SLOW", Title: "Slow", Price: 1000},
+		),
+		block:   "PROD-SLOW",
+		entered: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	m := ucpmem.New(server.Config{}, ucpmem.Config{Catalog: catalog})
+	create := func(item string) (*extensions.ExtendedCheckoutResponse, error) {
+		return m.CreateCheckout(httptest.NewRequest(http.MethodPost, "/checkout-sessions", nil), &extensions.ExtendedCheckoutCreateRequest{
+			Currency:  "USD",
+			LineItems: []models.LineItemCreateRequest{{Item: models.ItemCreateRequest{ID: item}, Quantity: 1}},
+		})
+	}
+	existing, err := create("PROD-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slow := make(chan error)
+	go func() {
+		_, err := create("PROD-SLOW")
+		slow <- err
+	}()
+	<-catalog.entered
+
+	// A slow catalog lookup must not hold up other requests.
+	got := make(chan error)
+	go func() {
+		_, err := m.GetCheckout(httptest.NewRequest(http.MethodGet, "/checkout-sessions/"+existing.ID, nil), existing.ID)
+		got <- err
+	}()
+	select {
+	case err := <-got:
+		if err != nil {
+			t.Errorf("GetCheckout: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("GetCheckout waited for an unrelated create")
+	}
+
+	close(catalog.release)
+	if err := <-slow; err != nil {
+		t.Errorf("slow CreateCheckout: %v", err)
+	}
+}
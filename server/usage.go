@@ -0,0 +1,204 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Operation identifies a UCP endpoint in usage records.
+type Operation string
+
+const (
+	// OperationDiscovery is a discovery profile fetch.
+	OperationDiscovery Operation = "discovery"
+
+	// OperationCreateCheckout is checkout session creation.
+	OperationCreateCheckout Operation = "create_checkout"
+
+	// OperationGetCheckout is checkout session retrieval.
+	OperationGetCheckout Operation = "get_checkout"
+
+	// OperationUpdateCheckout is a checkout session update.
+	OperationUpdateCheckout Operation = "update_checkout"
+
+	// OperationCompleteCheckout is checkout session completion.
+	OperationCompleteCheckout Operation = "complete_checkout"
+
+	// OperationCancelCheckout is checkout session cancellation.
+	OperationCancelCheckout Operation = "cancel_checkout"
+
+	// OperationGetOrder is order retrieval.
+	OperationGetOrder Operation = "get_order"
+
+	// OperationRequestOrderModification is an order modification request.
+	OperationRequestOrderModification Operation = "request_order_modification"
+
+	// OperationGetOrderModification is order modification retrieval.
+	OperationGetOrderModification Operation = "get_order_modification"
+
+	// OperationCreateCart is cart creation.
+	OperationCreateCart Operation = "create_cart"
+
+	// OperationGetCart is cart retrieval.
+	OperationGetCart Operation = "get_cart"
+
+	// OperationUpdateCart is a cart update.
+	OperationUpdateCart Operation = "update_cart"
+
+	// OperationDeleteCart is cart deletion.
+	OperationDeleteCart Operation = "delete_cart"
+
+	// OperationRegisterWebhook is webhook registration.
+	OperationRegisterWebhook Operation = "register_webhook"
+
+	// OperationExamples is an examples document fetch.
+	OperationExamples Operation = "examples"
+)
+
+// Usage describes one handled request.
+type Usage struct {
+	// Operation is the endpoint that was called.
+	Operation Operation
+
+	// Platform is the calling platform's profile URL from the UCP-Agent
+	// header, or empty if the header is missing or malformed.
+	Platform string
+
+	// Capabilities are the active capabilities declared in a checkout or
+	// order response; nil for errors and other resources.
+	Capabilities []models.CapabilityResponse
+
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+
+	// ErrorCode is the error code of a failed response, if any.
+	ErrorCode string
+
+	// Duration is the time spent handling the request.
+	Duration time.Duration
+}
+
+// UsageRecorder receives a Usage for every request the server routes,
+// including requests rejected by route-group middleware. RecordUsage runs
+// on the request goroutine after the response is written, so slow
+// recorders should hand off to a queue.
+type UsageRecorder interface {
+	RecordUsage(ctx context.Context, usage Usage)
+}
+
+// UsageRecorderFunc adapts a function to a UsageRecorder.
+type UsageRecorderFunc func(ctx context.Context, usage Usage)
+
+// RecordUsage implements UsageRecorder.
+func (f UsageRecorderFunc) RecordUsage(ctx context.Context, usage Usage) {
+	f(ctx, usage)
+}
+
+const usageKey contextKey = "usage"
+
+const operationKey contextKey = "operation"
+
+// RequestOperation returns the operation a routed request is for, or ""
+// outside the server's routes.
+func RequestOperation(ctx context.Context) Operation {
+	op, _ := ctx.Value(operationKey).(Operation)
+	return op
+}
+
+// maxErrorBodyBytes bounds the error body inspected for an error code.
+const maxErrorBodyBytes = 64 << 10
+
+// usageWriter captures the status and error body of a response.
+type usageWriter struct {
+	http.ResponseWriter
+	statusCode int
+	errBody    bytes.Buffer
+}
+
+func (w *usageWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *usageWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	if w.statusCode >= 400 && w.errBody.Len() < maxErrorBodyBytes {
+		w.errBody.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// recorded wraps a route handler to tag requests with op and report their
+// usage to the configured UsageRecorder.
+func (s *Server) recorded(op Operation, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(context.WithValue(r.Context(), operationKey, op))
+		if s.config.UsageRecorder == nil {
+			handler(w, r)
+			return
+		}
+
+		start := time.Now()
+		usage := &Usage{Operation: op}
+		usage.Platform, _ = PlatformProfileURL(r)
+		uw := &usageWriter{ResponseWriter: w}
+		handler(uw, r.WithContext(context.WithValue(r.Context(), usageKey, usage)))
+
+		usage.Duration = time.Since(start)
+		usage.StatusCode = uw.statusCode
+		if usage.StatusCode == 0 {
+			usage.StatusCode = http.StatusOK
+		}
+		if usage.StatusCode >= 400 {
+			usage.Capabilities = nil
+			usage.ErrorCode = errorCode(uw.errBody.Bytes())
+		}
+		s.config.UsageRecorder.RecordUsage(r.Context(), *usage)
+	}
+}
+
+// noteCapabilities records the capabilities of a response for usage
+// reporting.
+func noteCapabilities(ctx context.Context, caps []models.CapabilityResponse) {
+	if usage, ok := ctx.Value(usageKey).(*Usage); ok {
+		usage.Capabilities = caps
+	}
+}
+
+// errorCode extracts the code from an error response body.
+func errorCode(body []byte) string {
+	var resp ErrorResponse
+	if json.Unmarshal(body, &resp) != nil {
+		return ""
+	}
+	if resp.Error != "" {
+		return resp.Error
+	}
+	if len(resp.Messages) > 0 {
+		return resp.Messages[0].Code
+	}
+	return ""
+}
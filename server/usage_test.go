@@ -0,0 +1,56 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+func TestUsageRecorderAllowsNilResponses(t *testing.T) {
+	recorded := 0
+	srv := server.NewServer(server.Config{
+		Version: "2026-01-11",
+		UsageRecorder: server.UsageRecorderFunc(func(ctx context.Context, usage server.Usage) {
+			recorded++
+		}),
+	})
+	srv.HandleGetCheckout(func(r *http.Request, id string) (*extensions.ExtendedCheckoutResponse, error) {
+		return nil, nil
+	})
+	srv.HandleGetOrder(func(r *http.Request, id string) (*models.Order, error) {
+		return nil, nil
+	})
+
+	for _, path := range []string{"/checkout-sessions/chk_1", "/orders/ord_1"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(server.UCPAgentHeader, `profile="`+platformA+`"`)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != "null" {
+			t.Errorf("GET %s: got %d %q, want 200 null", path, rec.Code, rec.Body)
+		}
+	}
+	if recorded != 2 {
+		t.Errorf("recorded %d usages, want 2", recorded)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"strings"
 
 	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
 )
 
 // WebhookVerifier verifies webhook signatures.
@@ -36,8 +37,12 @@ type WebhookVerifier struct {
 	keys map[string]crypto.PublicKey
 }
 
-// NewWebhookVerifier creates a new webhook verifier from JWKs.
+// NewWebhookVerifier creates a new webhook verifier from JWKs. The keyset
+// must pass validation.ValidateSigningKeyset with the default key limit.
 func NewWebhookVerifier(jwks []models.JWK) (*WebhookVerifier, error) {
+	if err := validation.ValidateSigningKeyset(jwks, 0); err != nil {
+		return nil, err
+	}
 	v := &WebhookVerifier{
 		keys: make(map[string]crypto.PublicKey),
 	}
@@ -56,11 +61,15 @@ func NewWebhookVerifier(jwks []models.JWK) (*WebhookVerifier, error) {
 // VerifyRequest verifies the signature of an HTTP request.
 func (v *WebhookVerifier) VerifyRequest(r *http.Request, body []byte) error {
 	// Get the signature header
-	sig := r.Header.Get("X-Detached-JWT")
+	sig := r.Header.Get(SignatureHeader)
 	if sig == "" {
-		return errors.New("missing X-Detached-JWT header")
+		return errors.New("missing " + SignatureHeader + " header")
 	}
+	return v.VerifySignature(sig, body)
+}
 
+// VerifySignature verifies a detached JWS over body.
+func (v *WebhookVerifier) VerifySignature(sig string, body []byte) error {
 	// Parse the detached JWS
 	parts := strings.Split(sig, ".")
 	if len(parts) != 3 {
@@ -0,0 +1,444 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Webhook dispatcher defaults.
+const (
+	DefaultWebhookMaxAttempts    = 8
+	DefaultWebhookInitialBackoff = time.Second
+	DefaultWebhookMaxBackoff     = time.Hour
+	DefaultWebhookPollInterval   = time.Second
+	DefaultWebhookWorkers        = 4
+)
+
+// DeliveryStatus is the state of a webhook delivery.
+type DeliveryStatus string
+
+const (
+	// DeliveryPending is a delivery waiting for its next attempt.
+	DeliveryPending DeliveryStatus = "pending"
+
+	// DeliveryDelivered is a delivery the receiver accepted.
+	DeliveryDelivered DeliveryStatus = "delivered"
+
+	// DeliveryFailed is a delivery that was rejected permanently or ran
+	// out of attempts.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event on its way to one subscription.
+type WebhookDelivery struct {
+	ID             string               `json:"id"`
+	SubscriptionID string               `json:"subscription_id"`
+	URL            string               `json:"url"`
+	Event          *models.WebhookEvent `json:"event"`
+	Status         DeliveryStatus       `json:"status"`
+
+	// Attempts counts the POSTs made so far.
+	Attempts int `json:"attempts"`
+
+	// NextAttemptAt is when a pending delivery is next due.
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+
+	// LeasedUntil is set by DeliveryStore.ClaimDeliveries while a
+	// dispatcher is attempting the delivery.
+	LeasedUntil time.Time `json:"leased_until,omitempty"`
+
+	// LastStatusCode is the HTTP status of the last attempt, or zero if
+	// it failed before a response.
+	LastStatusCode int `json:"last_status_code,omitempty"`
+
+	// LastError describes why the last attempt failed.
+	LastError string `json:"last_error,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DeliveryStore persists webhook deliveries, so queued events survive
+// restarts and several dispatchers can share one queue.
+type DeliveryStore interface {
+	// SaveDelivery inserts or replaces a delivery by ID.
+	SaveDelivery(ctx context.Context, delivery *WebhookDelivery) error
+
+	// GetDelivery returns a delivery, or ErrDeliveryNotFound.
+	GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+
+	// ClaimDeliveries returns up to limit pending deliveries due at now,
+	// oldest first, leasing each until now+lease so no other dispatcher
+	// claims it meanwhile.
+	ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error)
+}
+
+// ErrDeliveryNotFound is returned when a delivery does not exist.
+var ErrDeliveryNotFound = errors.New("delivery not found")
+
+// ErrEventPlatformMissing is returned by Enqueue for an event without a
+// Platform, which no subscription may receive.
+var ErrEventPlatformMissing = errors.New("webhook event has no platform")
+
+// MemoryDeliveryStore is an in-memory DeliveryStore.
+type MemoryDeliveryStore struct {
+	mu         sync.Mutex
+	deliveries map[string]*WebhookDelivery
+}
+
+// NewMemoryDeliveryStore creates an empty in-memory delivery store.
+func NewMemoryDeliveryStore() *MemoryDeliveryStore {
+	return &MemoryDeliveryStore{deliveries: make(map[string]*WebhookDelivery)}
+}
+
+// SaveDelivery implements DeliveryStore.
+func (m *MemoryDeliveryStore) SaveDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *delivery
+	m.deliveries[delivery.ID] = &cp
+	return nil
+}
+
+// GetDelivery implements DeliveryStore.
+func (m *MemoryDeliveryStore) GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.deliveries[id]
+	if !ok {
+		return nil, ErrDeliveryNotFound
+	}
+	cp := *d
+	return &cp, nil
+}
+
+// ClaimDeliveries implements DeliveryStore.
+func (m *MemoryDeliveryStore) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []*WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.Status == DeliveryPending && !d.NextAttemptAt.After(now) && !d.LeasedUntil.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	out := make([]*WebhookDelivery, len(due))
+	for i, d := range due {
+		d.LeasedUntil = now.Add(lease)
+		cp := *d
+		out[i] = &cp
+	}
+	return out, nil
+}
+
+// WebhookDispatcher delivers webhook events to subscribed platforms. It
+// implements EventPublisher, so it can be set as Config.Events: each
+// published event is queued once per subscription of the event's Platform
+// that wants it, then signed and POSTed by Run, retrying failures with
+// exponential backoff.
+//
+// Receivers are retried on transport errors, 408, 429, and 5xx responses;
+// any other non-2xx response fails the delivery at once.
+type WebhookDispatcher struct {
+	// Subscriptions lists the platforms to deliver to.
+	Subscriptions SubscriptionStore
+
+	// Signer signs and sends each delivery.
+	Signer *WebhookSigner
+
+	// Store persists the delivery queue. Defaults to a
+	// MemoryDeliveryStore.
+	Store DeliveryStore
+
+	// MaxAttempts bounds the POSTs per delivery. Defaults to
+	// DefaultWebhookMaxAttempts.
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry, doubled for each
+	// retry after it up to MaxBackoff. They default to
+	// DefaultWebhookInitialBackoff and DefaultWebhookMaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// PollInterval is how often Run checks the store for due deliveries
+	// when nothing new is published. Defaults to
+	// DefaultWebhookPollInterval.
+	PollInterval time.Duration
+
+	// Workers bounds concurrent POSTs. Defaults to DefaultWebhookWorkers.
+	Workers int
+
+	// OnDelivery, if set, is called after every attempt with the
+	// delivery's new state: delivered, failed, or pending with the retry
+	// scheduled.
+	OnDelivery func(WebhookDelivery)
+
+	// Clock schedules retries. Defaults to SystemClock.
+	Clock Clock
+
+	wake chan struct{}
+	once sync.Once
+}
+
+// NewWebhookDispatcher creates a dispatcher delivering to the
+// subscriptions in subs, with an in-memory queue and default retry
+// policy.
+func NewWebhookDispatcher(subs SubscriptionStore, signer *WebhookSigner) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		Subscriptions: subs,
+		Signer:        signer,
+		Store:         NewMemoryDeliveryStore(),
+	}
+}
+
+// PublishEvent implements EventPublisher. Events that cannot be queued
+// are logged.
+func (d *WebhookDispatcher) PublishEvent(ctx context.Context, event *models.WebhookEvent) {
+	if _, err := d.Enqueue(ctx, event); err != nil {
+		log.Printf("ucp: failed to queue webhook %s: %v", event.ID, err)
+	}
+}
+
+// Enqueue queues event for every subscription registered by the event's
+// Platform that wants its type, and returns the new deliveries. Other
+// platforms' subscriptions never see it.
+func (d *WebhookDispatcher) Enqueue(ctx context.Context, event *models.WebhookEvent) ([]*WebhookDelivery, error) {
+	if event.Platform == "" {
+		return nil, ErrEventPlatformMissing
+	}
+	subs, err := d.Subscriptions.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].ID < subs[j].ID })
+
+	now := clockOrSystem(d.Clock).Now().UTC()
+	var queued []*WebhookDelivery
+	for _, sub := range subs {
+		if sub.PlatformProfile != event.Platform || !sub.Wants(event.Type) {
+			continue
+		}
+		id, err := randomToken()
+		if err != nil {
+			return queued, err
+		}
+		delivery := &WebhookDelivery{
+			ID:             "whdel_" + id,
+			SubscriptionID: sub.ID,
+			URL:            sub.URL,
+			Event:          event,
+			Status:         DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := d.store().SaveDelivery(ctx, delivery); err != nil {
+			return queued, err
+		}
+		queued = append(queued, delivery)
+	}
+	if len(queued) > 0 {
+		d.signal()
+	}
+	return queued, nil
+}
+
+// Run delivers queued events until ctx is canceled, returning ctx.Err().
+func (d *WebhookDispatcher) Run(ctx context.Context) error {
+	d.init()
+	clock := clockOrSystem(d.Clock)
+	poll := d.PollInterval
+	if poll <= 0 {
+		poll = DefaultWebhookPollInterval
+	}
+	for {
+		for d.deliverDue(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-d.wake:
+		case <-clock.After(poll):
+		}
+	}
+}
+
+// deliverDue attempts one batch of due deliveries and reports whether the
+// batch was full, meaning more may be waiting.
+func (d *WebhookDispatcher) deliverDue(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	workers := d.Workers
+	if workers <= 0 {
+		workers = DefaultWebhookWorkers
+	}
+	now := clockOrSystem(d.Clock).Now()
+	lease := time.Minute
+	if c := d.Signer.HTTPClient; c != nil && c.Timeout > 0 {
+		lease = 2 * c.Timeout
+	}
+	batch, err := d.store().ClaimDeliveries(ctx, now, lease, workers)
+	if err != nil {
+		log.Printf("ucp: failed to claim webhook deliveries: %v", err)
+		return false
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range batch {
+		wg.Add(1)
+		go func(delivery *WebhookDelivery) {
+			defer wg.Done()
+			d.attempt(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+	return len(batch) == workers
+}
+
+// attempt POSTs a delivery once and records the outcome.
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery *WebhookDelivery) {
+	err := d.Signer.SendWebhook(ctx, delivery.URL, delivery.Event)
+	if err != nil && ctx.Err() != nil {
+		// Shutting down: leave the delivery for its lease to expire.
+		return
+	}
+
+	now := clockOrSystem(d.Clock).Now().UTC()
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+	delivery.LeasedUntil = time.Time{}
+	delivery.LastStatusCode = 0
+	delivery.LastError = ""
+
+	var deliveryErr *WebhookDeliveryError
+	switch {
+	case err == nil:
+		delivery.Status = DeliveryDelivered
+	case errors.As(err, &deliveryErr) && !retryableStatus(deliveryErr.StatusCode):
+		delivery.Status = DeliveryFailed
+	case delivery.Attempts >= d.maxAttempts():
+		delivery.Status = DeliveryFailed
+	default:
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	}
+	if err != nil {
+		delivery.LastError = err.Error()
+		if deliveryErr != nil {
+			delivery.LastStatusCode = deliveryErr.StatusCode
+		}
+	} else {
+		delivery.LastStatusCode = http.StatusOK
+	}
+
+	if err := d.store().SaveDelivery(ctx, delivery); err != nil {
+		log.Printf("ucp: failed to save webhook delivery %s: %v", delivery.ID, err)
+	}
+	if d.OnDelivery != nil {
+		d.OnDelivery(*delivery)
+	}
+}
+
+func (d *WebhookDispatcher) maxAttempts() int {
+	if d.MaxAttempts <= 0 {
+		return DefaultWebhookMaxAttempts
+	}
+	return d.MaxAttempts
+}
+
+// backoff returns the wait before the retry following attempt n.
+func (d *WebhookDispatcher) backoff(n int) time.Duration {
+	wait, max := d.InitialBackoff, d.MaxBackoff
+	if wait <= 0 {
+		wait = DefaultWebhookInitialBackoff
+	}
+	if max <= 0 {
+		max = DefaultWebhookMaxBackoff
+	}
+	for i := 1; i < n && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait
+}
+
+// retryableStatus reports whether a receiver's status is worth retrying.
+func retryableStatus(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500
+}
+
+// init applies the lazily created defaults.
+func (d *WebhookDispatcher) init() {
+	d.once.Do(func() {
+		if d.Store == nil {
+			d.Store = NewMemoryDeliveryStore()
+		}
+		d.wake = make(chan struct{}, 1)
+	})
+}
+
+func (d *WebhookDispatcher) store() DeliveryStore {
+	d.init()
+	return d.Store
+}
+
+// signal wakes Run to deliver newly queued events.
+func (d *WebhookDispatcher) signal() {
+	d.init()
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// NewWebhookEvent wraps data in a webhook event envelope with a fresh ID.
+// Set the event's Platform to the owning platform before publishing it.
+func NewWebhookEvent(eventType string, data any, createdAt time.Time) (*models.WebhookEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	id, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	return &models.WebhookEvent{
+		ID:        "evt_" + id,
+		Type:      eventType,
+		CreatedAt: createdAt.UTC(),
+		Data:      payload,
+	}, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+const (
+	platformA = "https://a.example/.well-known/ucp"
+	platformB = "https://b.example/.well-known/ucp"
+)
+
+func TestWebhookDispatcherKeepsPlatformsApart(t *testing.T) {
+	ctx := context.Background()
+	subs := server.NewMemorySubscriptionStore()
+	for _, sub := range []*server.WebhookSubscription{
+		{ID: "whsub_a", URL: "https://a.example/hooks", PlatformProfile: platformA},
+		{ID: "whsub_b", URL: "https://b.example/hooks", PlatformProfile: platformB},
+		{ID: "whsub_anon", URL: "https://anon.example/hooks"},
+	} {
+		subs.SaveSubscription(ctx, sub)
+	}
+	dispatcher := server.NewWebhookDispatcher(subs, nil)
+
+	// Each platform creates a checkout that is ready to complete, raising
+	// a checkout.ready_for_complete event owned by that platform.
+	var events []*models.WebhookEvent
+	srv := server.NewServer(server.Config{
+		Version: "2026-01-11",
+		Events: server.EventPublisherFunc(func(ctx context.Context, event *models.WebhookEvent) {
+			events = append(events, event)
+		}),
+	})
+	srv.HandleCreateCheckout(func(r *http.Request, req *extensions.ExtendedCheckoutCreateRequest) (*extensions.ExtendedCheckoutResponse, error) {
+		id := "chk_a"
+		if strings.Contains(r.Header.Get(server.UCPAgentHeader), "b.example") {
+			id = "chk_b"
+		}
+		return &extensions.ExtendedCheckoutResponse{ID: id, Status: models.CheckoutStatusReadyForComplete, Currency: "USD"}, nil
+	})
+	for _, platform := range []string{platformA, platformB} {
+		req := httptest.NewRequest(http.MethodPost, "/checkout-sessions", strings.NewReader(`{"currency":"USD","line_items":[]}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(server.UCPAgentHeader, `profile="`+platform+`"`)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("create as %s: status %d: %s", platform, rec.Code, rec.Body)
+		}
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+
+	for i, want := range []struct{ platform, sub string }{{platformA, "whsub_a"}, {platformB, "whsub_b"}} {
+		event := events[i]
+		if event.Platform != want.platform {
+			t.Errorf("event %d: platform %q, want %q", i, event.Platform, want.platform)
+		}
+		queued, err := dispatcher.Enqueue(ctx, event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(queued) != 1 || queued[0].SubscriptionID != want.sub {
+			var got []string
+			for _, d := range queued {
+				got = append(got, d.SubscriptionID)
+			}
+			t.Errorf("event for %s delivered to %v, want [%s]", want.platform, got, want.sub)
+		}
+	}
+}
+
+func TestWebhookDispatcherRejectsEventWithoutPlatform(t *testing.T) {
+	ctx := context.Background()
+	subs := server.NewMemorySubscriptionStore()
+	subs.SaveSubscription(ctx, &server.WebhookSubscription{ID: "whsub_a", URL: "https://a.example/hooks", PlatformProfile: platformA})
+	dispatcher := server.NewWebhookDispatcher(subs, nil)
+
+	event, err := server.NewWebhookEvent(models.WebhookEventOrderCreated, map[string]string{"id": "ord_1"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued, err := dispatcher.Enqueue(ctx, event)
+	if !errors.Is(err, server.ErrEventPlatformMissing) || len(queued) != 0 {
+		t.Fatalf("Enqueue = %d deliveries, %v; want ErrEventPlatformMissing", len(queued), err)
+	}
+}
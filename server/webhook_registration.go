@@ -0,0 +1,379 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// WebhookRegistrationRequest is the body of POST /webhooks.
+type WebhookRegistrationRequest struct {
+	// URL is the platform's callback URL.
+	URL string `json:"url"`
+
+	// Events optionally restricts delivery to these event types.
+	// Empty means all events.
+	Events []string `json:"events,omitempty"`
+}
+
+// WebhookSubscription is a verified platform webhook registration.
+type WebhookSubscription struct {
+	// ID is the subscription identifier.
+	ID string `json:"id"`
+
+	// URL is the verified callback URL.
+	URL string `json:"url"`
+
+	// Events restricts delivery to these event types (empty means all).
+	Events []string `json:"events,omitempty"`
+
+	// PlatformProfile is the registering platform's profile URL from UCP-Agent.
+	PlatformProfile string `json:"platform_profile,omitempty"`
+
+	// CreatedAt is when the subscription was verified.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Wants reports whether the subscription should receive an event type.
+func (s *WebhookSubscription) Wants(eventType string) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, e := range s.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookChallenge is sent to a callback URL during registration. The
+// platform must respond 200 with the same challenge value.
+type WebhookChallenge struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+}
+
+// SubscriptionStore persists webhook subscriptions.
+type SubscriptionStore interface {
+	SaveSubscription(ctx context.Context, sub *WebhookSubscription) error
+	GetSubscription(ctx context.Context, id string) (*WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+}
+
+// ErrSubscriptionNotFound is returned when a subscription does not exist.
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+
+// MemorySubscriptionStore is an in-memory SubscriptionStore.
+type MemorySubscriptionStore struct {
+	mu   sync.RWMutex
+	subs map[string]*WebhookSubscription
+}
+
+// NewMemorySubscriptionStore creates an empty in-memory subscription store.
+func NewMemorySubscriptionStore() *MemorySubscriptionStore {
+	return &MemorySubscriptionStore{subs: make(map[string]*WebhookSubscription)}
+}
+
+// SaveSubscription implements SubscriptionStore.
+func (m *MemorySubscriptionStore) SaveSubscription(ctx context.Context, sub *WebhookSubscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *sub
+	m.subs[sub.ID] = &cp
+	return nil
+}
+
+// GetSubscription implements SubscriptionStore.
+func (m *MemorySubscriptionStore) GetSubscription(ctx context.Context, id string) (*WebhookSubscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	sub, ok := m.subs[id]
+	if !ok {
+		return nil, ErrSubscriptionNotFound
+	}
+	cp := *sub
+	return &cp, nil
+}
+
+// ListSubscriptions implements SubscriptionStore.
+func (m *MemorySubscriptionStore) ListSubscriptions(ctx context.Context) ([]*WebhookSubscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]*WebhookSubscription, 0, len(m.subs))
+	for _, sub := range m.subs {
+		cp := *sub
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+// DeleteSubscription implements SubscriptionStore.
+func (m *MemorySubscriptionStore) DeleteSubscription(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.subs[id]; !ok {
+		return ErrSubscriptionNotFound
+	}
+	delete(m.subs, id)
+	return nil
+}
+
+// WebhookRegistrar verifies and stores platform webhook registrations.
+//
+// Registrations must name the platform's profile in a UCP-Agent header. On
+// registration the registrar POSTs a WebhookChallenge to the callback URL
+// and requires the platform to echo the challenge, with a valid
+// X-Detached-JWT signature from one of the signing keys in that profile,
+// so only the platform itself can subscribe to its events.
+type WebhookRegistrar struct {
+	// Store persists verified subscriptions.
+	Store SubscriptionStore
+
+	// HTTPClient performs challenge requests and fetches platform
+	// profiles. Defaults to a client with a 10 second timeout that only
+	// dials public addresses, since any caller names the callback URL.
+	// Challenge requests never follow redirects, whatever the client.
+	HTTPClient *http.Client
+
+	// KeyResolver returns the registering platform's signing keys from the
+	// profile named in its UCP-Agent header. Defaults to
+	// ProfileKeyResolver(HTTPClient).
+	KeyResolver KeyResolver
+
+	// RequireHTTPS rejects non-HTTPS callback URLs.
+	RequireHTTPS bool
+
+	// Clock stamps subscription creation times. Defaults to SystemClock.
+	Clock Clock
+}
+
+// NewWebhookRegistrar creates a registrar backed by store.
+func NewWebhookRegistrar(store SubscriptionStore) *WebhookRegistrar {
+	return &WebhookRegistrar{
+		Store:        store,
+		HTTPClient:   publicClient(),
+		RequireHTTPS: true,
+	}
+}
+
+// Register verifies a callback URL via challenge-response and stores the
+// subscription for the platform whose profile is at profileURL.
+func (reg *WebhookRegistrar) Register(ctx context.Context, profileURL string, req *WebhookRegistrationRequest) (*WebhookSubscription, error) {
+	if profileURL == "" {
+		return nil, NewAPIError(http.StatusBadRequest, string(models.ErrorCodeMissingHeader), "UCP-Agent profile is required to register webhooks")
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, BadRequestError("url must be an absolute HTTP(S) URL")
+	}
+	if reg.RequireHTTPS && u.Scheme != "https" {
+		return nil, BadRequestError("url must use https")
+	}
+
+	nonce, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	// The cause is not returned: it would tell callers what answers at
+	// addresses of their choosing.
+	if err := reg.challenge(ctx, profileURL, req.URL, nonce); err != nil {
+		return nil, NewAPIError(http.StatusUnprocessableEntity, string(models.ErrorCodeChallengeFailed), "challenge verification failed")
+	}
+
+	id, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	sub := &WebhookSubscription{
+		ID:              "whsub_" + id,
+		URL:             req.URL,
+		Events:          req.Events,
+		PlatformProfile: profileURL,
+		CreatedAt:       clockOrSystem(reg.Clock).Now().UTC(),
+	}
+	if err := reg.Store.SaveSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// challenge sends a nonce to the callback URL and verifies the echo.
+func (reg *WebhookRegistrar) challenge(ctx context.Context, profileURL, callbackURL, nonce string) error {
+	payload, err := json.Marshal(WebhookChallenge{Type: "challenge", Challenge: nonce})
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	client := reg.HTTPClient
+	if client == nil {
+		client = publicClient()
+	}
+	noRedirects := *client
+	noRedirects.CheckRedirect = refuseRedirect
+	resp, err := noRedirects.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("challenge request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return fmt.Errorf("failed to read challenge response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("challenge response status %d", resp.StatusCode)
+	}
+
+	var echo WebhookChallenge
+	if err := json.Unmarshal(body, &echo); err != nil || echo.Challenge != nonce {
+		return errors.New("challenge response did not echo the challenge")
+	}
+
+	resolve := reg.KeyResolver
+	if resolve == nil {
+		resolve = ProfileKeyResolver(client)
+	}
+	keys, err := resolve(ctx, profileURL)
+	if err != nil {
+		return fmt.Errorf("failed to resolve platform signing keys: %w", err)
+	}
+	verifier, err := NewWebhookVerifier(keys)
+	if err != nil {
+		return err
+	}
+	sig := resp.Header.Get(SignatureHeader)
+	if sig == "" {
+		return errors.New("challenge response is not signed")
+	}
+	if err := verifier.VerifySignature(sig, body); err != nil {
+		return fmt.Errorf("challenge signature invalid: %w", err)
+	}
+	return nil
+}
+
+// errNotPublic is returned when dialing an address that is not publicly
+// routable.
+var errNotPublic = errors.New("address is not public")
+
+// nonPublic lists special-purpose IPv4 ranges that netip does not classify.
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// publicClient returns a client with a 10 second timeout that refuses to
+// connect to loopback, private, link-local, and other non-public
+// addresses, checked after DNS resolution, and does not follow redirects.
+func publicClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: dialPublic}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: 10 * time.Second, Transport: transport, CheckRedirect: refuseRedirect}
+}
+
+// dialPublic is a net.Dialer Control hook allowing only public unicast
+// addresses.
+func dialPublic(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return errNotPublic
+	}
+	for _, p := range nonPublic {
+		if p.Contains(ip) {
+			return errNotPublic
+		}
+	}
+	return nil
+}
+
+// refuseRedirect is a CheckRedirect hook that fails every redirect.
+func refuseRedirect(req *http.Request, via []*http.Request) error {
+	return errors.New("redirects are not followed")
+}
+
+// HandleWebhookRegistration enables POST /webhooks using the given registrar.
+func (s *Server) HandleWebhookRegistration(reg *WebhookRegistrar) {
+	s.webhookRegistrationHandler = func(w http.ResponseWriter, r *http.Request) {
+		var req WebhookRegistrationRequest
+		if err := s.decodeRequest(r, &req); err != nil {
+			s.handleError(w, err)
+			return
+		}
+
+		profileURL, err := PlatformProfileURL(r)
+		if err != nil {
+			s.handleError(w, NewAPIError(http.StatusBadRequest, string(models.ErrorCodeMissingHeader), err.Error()))
+			return
+		}
+		sub, err := reg.Register(r.Context(), profileURL, &req)
+		if err != nil {
+			s.handleError(w, err)
+			return
+		}
+
+		WriteJSON(w, http.StatusCreated, sub)
+	}
+}
+
+func (s *Server) handleRegisterWebhook(w http.ResponseWriter, r *http.Request) {
+	if s.webhookRegistrationHandler != nil {
+		s.webhookRegistrationHandler(w, r)
+	} else {
+		s.writeError(w, http.StatusNotImplemented, string(models.ErrorCodeNotImplemented), "Webhook registration not implemented")
+	}
+}
+
+// randomToken returns 16 random bytes hex-encoded.
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
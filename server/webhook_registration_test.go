@@ -0,0 +1,130 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+	"github.com/dhananjay2021/ucp-go-sdk/server"
+)
+
+func TestWebhookRegistrarRequiresSignedEcho(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := server.NewWebhookSigner(key, "platform-2026")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The callback echoes the challenge, signing it when sign is set.
+	sign := false
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if sign {
+			jws, err := signer.Sign(body)
+			if err != nil {
+				t.Error(err)
+			}
+			w.Header().Set(server.SignatureHeader, jws)
+		}
+		w.Write(body)
+	}))
+	defer callback.Close()
+
+	reg := server.NewWebhookRegistrar(server.NewMemorySubscriptionStore())
+	reg.RequireHTTPS = false
+	// The test servers listen on loopback, which the default client refuses.
+	reg.HTTPClient = callback.Client()
+	reg.KeyResolver = func(ctx context.Context, profileURL string) ([]models.JWK, error) {
+		if profileURL != platformA {
+			return nil, errors.New("unknown platform")
+		}
+		return signer.JWKs(), nil
+	}
+	req := &server.WebhookRegistrationRequest{URL: callback.URL}
+
+	tests := []struct {
+		name    string
+		profile string
+		sign    bool
+		status  int
+	}{
+		{"no UCP-Agent profile", "", true, http.StatusBadRequest},
+		{"unsigned echo", platformA, false, http.StatusUnprocessableEntity},
+		{"echo signed for another platform", platformB, true, http.StatusUnprocessableEntity},
+		{"signed echo", platformA, true, 0},
+	}
+	for _, tt := range tests {
+		sign = tt.sign
+		sub, err := reg.Register(context.Background(), tt.profile, req)
+		if tt.status == 0 {
+			if err != nil || sub.PlatformProfile != tt.profile {
+				t.Errorf("%s: got %+v, %v; want a subscription for %s", tt.name, sub, err, tt.profile)
+			}
+			continue
+		}
+		var apiErr *server.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+			t.Errorf("%s: got %+v, %v; want status %d", tt.name, sub, err, tt.status)
+		}
+	}
+}
+
+func TestWebhookRegistrarRefusesInternalCallbacks(t *testing.T) {
+	var internalHits atomic.Int32
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalHits.Add(1)
+		io.Copy(w, r.Body)
+	}))
+	defer internal.Close()
+	redirect := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusTemporaryRedirect))
+	defer redirect.Close()
+
+	tests := []struct {
+		name   string
+		client *http.Client
+		url    string
+	}{
+		{"loopback with the default client", nil, internal.URL},
+		{"redirect", internal.Client(), redirect.URL},
+	}
+	for _, tt := range tests {
+		reg := server.NewWebhookRegistrar(server.NewMemorySubscriptionStore())
+		reg.RequireHTTPS = false
+		if tt.client != nil {
+			reg.HTTPClient = tt.client
+		}
+		_, err := reg.Register(context.Background(), platformA, &server.WebhookRegistrationRequest{URL: tt.url})
+		var apiErr *server.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Message != "challenge verification failed" {
+			t.Errorf("%s: got %v, want a generic 422", tt.name, err)
+		}
+	}
+	if n := internalHits.Load(); n != 0 {
+		t.Errorf("internal server received %d challenges, want 0", n)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/internal"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// WebhookSigner signs outgoing webhooks with a detached JWS in
+// X-Detached-JWT, the form WebhookVerifier checks.
+//
+// A signer holds several keys so they can be rotated without dropped
+// deliveries: add the new key and publish JWKs in the profile's
+// signing_keys, activate it once platforms have refreshed, then remove
+// the old one.
+type WebhookSigner struct {
+	// HTTPClient sends webhooks. Defaults to a client with a 10 second
+	// timeout.
+	HTTPClient *http.Client
+
+	mu     sync.RWMutex
+	keys   map[string]crypto.Signer
+	kids   []string
+	active string
+}
+
+// NewWebhookSigner creates a signer that signs with an ECDSA P-256 (ES256)
+// or RSA (RS256) private key identified by kid.
+func NewWebhookSigner(key crypto.Signer, kid string) (*WebhookSigner, error) {
+	s := &WebhookSigner{
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		keys:       make(map[string]crypto.Signer),
+	}
+	if err := s.AddKey(key, kid); err != nil {
+		return nil, err
+	}
+	s.active = kid
+	return s, nil
+}
+
+// AddKey adds a key without signing with it yet, so it can be published
+// before Activate switches to it. Adding an existing kid replaces its key.
+func (s *WebhookSigner) AddKey(key crypto.Signer, kid string) error {
+	if kid == "" {
+		return errors.New("webhook signing key needs a kid")
+	}
+	if _, err := internal.PublicJWK(kid, key.Public()); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[kid]; !ok {
+		s.kids = append(s.kids, kid)
+	}
+	s.keys[kid] = key
+	return nil
+}
+
+// Activate makes kid the key new signatures use.
+func (s *WebhookSigner) Activate(kid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[kid]; !ok {
+		return fmt.Errorf("unknown webhook signing key %q", kid)
+	}
+	s.active = kid
+	return nil
+}
+
+// RemoveKey retires a key. The active key cannot be removed.
+func (s *WebhookSigner) RemoveKey(kid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if kid == s.active {
+		return fmt.Errorf("cannot remove active webhook signing key %q", kid)
+	}
+	if _, ok := s.keys[kid]; !ok {
+		return nil
+	}
+	delete(s.keys, kid)
+	for i, k := range s.kids {
+		if k == kid {
+			s.kids = append(s.kids[:i], s.kids[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Kid returns the ID of the active key.
+func (s *WebhookSigner) Kid() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+// JWKs returns the public keys of every key the signer holds, in the order
+// they were added, for the profile's signing_keys.
+func (s *WebhookSigner) JWKs() []models.JWK {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	jwks := make([]models.JWK, 0, len(s.kids))
+	for _, kid := range s.kids {
+		jwk, err := internal.PublicJWK(kid, s.keys[kid].Public())
+		if err != nil {
+			continue
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks
+}
+
+// Sign returns a detached JWS over body, signed with the active key.
+func (s *WebhookSigner) Sign(body []byte) (string, error) {
+	s.mu.RLock()
+	key, kid := s.keys[s.active], s.active
+	s.mu.RUnlock()
+	return internal.SignDetached(key, kid, body)
+}
+
+// SignRequest sets the X-Detached-JWT header of a webhook request.
+func (s *WebhookSigner) SignRequest(r *http.Request, body []byte) error {
+	sig, err := s.Sign(body)
+	if err != nil {
+		return err
+	}
+	r.Header.Set(SignatureHeader, sig)
+	return nil
+}
+
+// WebhookDeliveryError reports a webhook the receiver did not accept with
+// a 2xx status.
+type WebhookDeliveryError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *WebhookDeliveryError) Error() string {
+	return fmt.Sprintf("webhook to %s rejected with status %d", e.URL, e.StatusCode)
+}
+
+// SendWebhook POSTs a signed event to url. A non-2xx response is returned
+// as a *WebhookDeliveryError.
+func (s *WebhookSigner) SendWebhook(ctx context.Context, url string, event *models.WebhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := s.SignRequest(req, body); err != nil {
+		return fmt.Errorf("failed to sign webhook: %w", err)
+	}
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &WebhookDeliveryError{URL: url, StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	return nil
+}
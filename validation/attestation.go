@@ -0,0 +1,134 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/internal"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// ConformanceSample is a recorded merchant response to check, such as a
+// checkout or order payload.
+type ConformanceSample struct {
+	// Name identifies the check in the attestation (e.g. "create_checkout").
+	Name string
+
+	// Payload is the response body. Its ucp.capabilities select the
+	// schemas it is checked against.
+	Payload []byte
+}
+
+// ConformanceCheck is the outcome of one conformance check.
+type ConformanceCheck struct {
+	Name   string            `json:"name"`
+	Passed bool              `json:"passed"`
+	Errors []ValidationError `json:"errors,omitempty"`
+}
+
+// ConformanceAttestation is a machine-readable conformance badge: the
+// result of running conformance checks against a merchant's responses.
+type ConformanceAttestation struct {
+	// Merchant is the merchant's profile URL.
+	Merchant string `json:"merchant"`
+
+	// SpecVersion is the UCP version the merchant was checked against.
+	SpecVersion models.Version `json:"spec_version"`
+
+	// Checks lists every check run, passed and failed.
+	Checks []ConformanceCheck `json:"checks"`
+
+	// Passed and Failed count the checks.
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+
+	// IssuedAt is when the checks were run.
+	IssuedAt time.Time `json:"issued_at"`
+}
+
+// SignedConformanceAttestation is an attestation with a detached JWS over
+// its exact bytes, suitable for publishing next to the merchant profile.
+type SignedConformanceAttestation struct {
+	Attestation json.RawMessage `json:"attestation"`
+	Signature   string          `json:"signature"`
+}
+
+// SignatureVerifier verifies a detached JWS over a body.
+// *server.WebhookVerifier implements it.
+type SignatureVerifier interface {
+	VerifySignature(sig string, body []byte) error
+}
+
+// RunConformance checks each sample with ValidateConformance against the
+// capabilities it declares and returns the resulting attestation.
+func (v *SchemaValidator) RunConformance(merchant string, version models.Version, samples []ConformanceSample) *ConformanceAttestation {
+	a := &ConformanceAttestation{
+		Merchant:    merchant,
+		SpecVersion: version,
+		IssuedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+	for _, sample := range samples {
+		var envelope struct {
+			UCP struct {
+				Capabilities []models.CapabilityResponse `json:"capabilities"`
+			} `json:"ucp"`
+		}
+		result := v.ValidateJSON(sample.Payload)
+		if result.Valid {
+			json.Unmarshal(sample.Payload, &envelope)
+			result = v.ValidateConformance(sample.Payload, envelope.UCP.Capabilities)
+		}
+
+		a.Checks = append(a.Checks, ConformanceCheck{Name: sample.Name, Passed: result.Valid, Errors: result.Errors})
+		if result.Valid {
+			a.Passed++
+		} else {
+			a.Failed++
+		}
+	}
+	return a
+}
+
+// Sign signs the attestation with an ECDSA P-256 or RSA key whose public
+// half is published in the merchant profile's signing_keys under kid.
+func (a *ConformanceAttestation) Sign(key crypto.Signer, kid string) (*SignedConformanceAttestation, error) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode attestation: %w", err)
+	}
+	sig, err := internal.SignDetached(key, kid, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedConformanceAttestation{Attestation: data, Signature: sig}, nil
+}
+
+// Verify checks the signature with verifier, typically a
+// server.WebhookVerifier built from the merchant profile's signing keys,
+// and returns the decoded attestation.
+func (s *SignedConformanceAttestation) Verify(verifier SignatureVerifier) (*ConformanceAttestation, error) {
+	if err := verifier.VerifySignature(s.Signature, s.Attestation); err != nil {
+		return nil, fmt.Errorf("invalid attestation signature: %w", err)
+	}
+	var a ConformanceAttestation
+	if err := json.Unmarshal(s.Attestation, &a); err != nil {
+		return nil, fmt.Errorf("failed to decode attestation: %w", err)
+	}
+	return &a, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// maxRefDepth bounds $ref and allOf resolution in collectShape.
+const maxRefDepth = 16
+
+// ValidateConformance checks the top level of a response payload against
+// the schemas of the capabilities it declares in ucp.capabilities. Schemas
+// are fetched with LoadSchema and cached. It reports:
+//
+//   - properties required by a declared capability's schema that are missing
+//   - top-level fields not declared by any capability's schema
+//
+// Only local $ref and allOf composition are followed. For extension
+// capabilities, the $defs entry named after the extended capability is
+// used when present. Capabilities without a schema URL are skipped, and
+// undeclared fields are only reported if at least one schema was loaded.
+func (v *SchemaValidator) ValidateConformance(data []byte, capabilities []models.CapabilityResponse) *ValidationResult {
+	result := &ValidationResult{Valid: true}
+	addError := func(field, format string, args ...interface{}) {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		addError("$", "invalid JSON: %s", err)
+		return result
+	}
+
+	declared := map[string]bool{"ucp": true}
+	loaded := 0
+	for _, c := range capabilities {
+		if c.Schema == "" {
+			continue
+		}
+		raw, err := v.LoadSchema(c.Schema)
+		if err != nil {
+			addError("$.ucp.capabilities", "%s: %s", c.Name, err)
+			continue
+		}
+		var schema map[string]interface{}
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			addError("$.ucp.capabilities", "%s: invalid schema: %s", c.Name, err)
+			continue
+		}
+		loaded++
+
+		root := schema
+		if c.Extends != "" {
+			if defs, ok := schema["$defs"].(map[string]interface{}); ok {
+				if def, ok := defs[string(c.Extends)].(map[string]interface{}); ok {
+					root = def
+				}
+			}
+		}
+
+		properties, required := collectShape(schema, root, 0)
+		for name := range properties {
+			declared[name] = true
+		}
+		for _, name := range required {
+			if _, ok := payload[name]; !ok {
+				addError("$."+name, "required by %s but missing", c.Name)
+			}
+		}
+	}
+
+	if loaded == 0 {
+		return result
+	}
+
+	var undeclared []string
+	for name := range payload {
+		if !declared[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		addError("$."+name, "not declared by any capability in ucp.capabilities")
+	}
+
+	return result
+}
+
+// collectShape returns the top-level property names and required list of a
+// schema node, following local $ref and allOf within doc.
+func collectShape(doc, node map[string]interface{}, depth int) (map[string]bool, []string) {
+	properties := make(map[string]bool)
+	var required []string
+	if node == nil || depth > maxRefDepth {
+		return properties, required
+	}
+
+	if ref, ok := node["$ref"].(string); ok {
+		if target := resolveLocalRef(doc, ref); target != nil {
+			p, r := collectShape(doc, target, depth+1)
+			for name := range p {
+				properties[name] = true
+			}
+			required = append(required, r...)
+		}
+	}
+
+	if props, ok := node["properties"].(map[string]interface{}); ok {
+		for name := range props {
+			properties[name] = true
+		}
+	}
+	if req, ok := node["required"].([]interface{}); ok {
+		for _, name := range req {
+			if s, ok := name.(string); ok {
+				required = append(required, s)
+			}
+		}
+	}
+
+	if all, ok := node["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			if m, ok := sub.(map[string]interface{}); ok {
+				p, r := collectShape(doc, m, depth+1)
+				for name := range p {
+					properties[name] = true
+				}
+				required = append(required, r...)
+			}
+		}
+	}
+
+	return properties, required
+}
+
+// resolveLocalRef resolves a "#/a/b" JSON pointer within doc.
+func resolveLocalRef(doc map[string]interface{}, ref string) map[string]interface{} {
+	if !strings.HasPrefix(ref, "#") {
+		return nil
+	}
+	node := doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		next, ok := node[part].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
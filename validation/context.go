@@ -0,0 +1,82 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+var (
+	countryPattern  = regexp.MustCompile(`^[A-Z]{2}$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+	// localePattern matches the common BCP 47 shape: a language subtag
+	// followed by optional script, region, and variant subtags.
+	localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z]{4})?(-([A-Za-z]{2}|[0-9]{3}))?(-([A-Za-z0-9]{5,8}|[0-9][A-Za-z0-9]{3}))*$`)
+)
+
+// ValidateContext checks the format of buyer context hints. All fields are
+// optional; only populated fields are checked. Timezones are resolved with
+// time.LoadLocation, so they depend on the host's time zone database.
+//
+// Field paths in the result are JSONPaths relative to the request root.
+func ValidateContext(c *models.Context) *ValidationResult {
+	result := &ValidationResult{Valid: true}
+	if c == nil {
+		return result
+	}
+
+	addError := func(field, format string, args ...interface{}) {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "$.context." + field,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if c.AddressCountry != "" && !countryPattern.MatchString(c.AddressCountry) {
+		addError("address_country", "must be an ISO 3166-1 alpha-2 code, got %q", c.AddressCountry)
+	}
+	if c.Locale != "" && !localePattern.MatchString(c.Locale) {
+		addError("locale", "must be a BCP 47 language tag, got %q", c.Locale)
+	}
+	if c.Currency != "" && !currencyPattern.MatchString(c.Currency) {
+		addError("currency", "must be an ISO 4217 currency code, got %q", c.Currency)
+	}
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil || c.Timezone == "Local" {
+			addError("timezone", "must be an IANA time zone, got %q", c.Timezone)
+		}
+	}
+
+	switch c.DeviceClass {
+	case "", models.DeviceClassDesktop, models.DeviceClassMobile, models.DeviceClassTablet,
+		models.DeviceClassVoice, models.DeviceClassOther:
+	default:
+		addError("device_class", "unknown device class %q", c.DeviceClass)
+	}
+
+	switch c.Channel {
+	case "", models.ChannelWeb, models.ChannelApp, models.ChannelChat, models.ChannelVoice:
+	default:
+		addError("channel", "unknown channel %q", c.Channel)
+	}
+
+	return result
+}
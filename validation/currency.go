@@ -0,0 +1,214 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dhananjay2021/ucp-go-sdk/display"
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// currencyCodeToken matches a standalone three-letter uppercase code.
+var currencyCodeToken = regexp.MustCompile(`\b[A-Z]{3}\b`)
+
+// currencyChecker accumulates currency consistency errors.
+type currencyChecker struct {
+	currency string
+	result   *ValidationResult
+}
+
+func (c *currencyChecker) addError(field, format string, args ...interface{}) {
+	c.result.Valid = false
+	c.result.Errors = append(c.result.Errors, ValidationError{
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// ValidateCheckoutCurrency checks that the amounts of a checkout are
+// consistent with its declared currency. Amounts carry no currency of their
+// own, so mixed-currency bugs are detected by their symptoms: line item
+// subtotals that are not price times quantity, checkout subtotals and
+// discount totals that do not add up, and display text naming another
+// currency's code or symbol.
+//
+// Field paths in the result are JSONPaths relative to the checkout root.
+func ValidateCheckoutCurrency(checkout *extensions.ExtendedCheckoutResponse) *ValidationResult {
+	c := &currencyChecker{currency: checkout.Currency, result: &ValidationResult{Valid: true}}
+	if !currencyPattern.MatchString(checkout.Currency) {
+		c.addError("$.currency", "must be an ISO 4217 currency code, got %q", checkout.Currency)
+		return c.result
+	}
+
+	lineSubtotals := 0
+	for i, li := range checkout.LineItems {
+		path := fmt.Sprintf("$.line_items[%d]", i)
+		if amount, ok := totalOf(li.Totals, models.TotalTypeSubtotal); ok {
+			if want := li.Item.Price * li.Quantity; amount != want {
+				c.addError(path+".totals", "subtotal %d does not equal price %d × quantity %d", amount, li.Item.Price, li.Quantity)
+			}
+			lineSubtotals += amount
+		} else {
+			lineSubtotals += li.Item.Price * li.Quantity
+		}
+		c.checkDisplayText(path+".totals", li.Totals)
+	}
+
+	if amount, ok := totalOf(checkout.Totals, models.TotalTypeSubtotal); ok && len(checkout.LineItems) > 0 && amount != lineSubtotals {
+		c.addError("$.totals", "subtotal %d does not equal the sum of line item subtotals %d", amount, lineSubtotals)
+	}
+	if checkout.Discounts != nil && len(checkout.Discounts.Applied) > 0 {
+		applied := 0
+		for _, d := range checkout.Discounts.Applied {
+			applied += d.Amount
+		}
+		if amount, ok := totalOf(checkout.Totals, models.TotalTypeDiscount); ok && amount != applied {
+			c.addError("$.totals", "discount total %d does not equal the sum of applied discounts %d", amount, applied)
+		}
+	}
+	c.checkDisplayText("$.totals", checkout.Totals)
+
+	if checkout.Fulfillment != nil {
+		for i, m := range checkout.Fulfillment.Methods {
+			for j, g := range m.Groups {
+				for k, opt := range g.Options {
+					path := fmt.Sprintf("$.fulfillment.methods[%d].groups[%d].options[%d].totals", i, j, k)
+					c.checkDisplayText(path, opt.Totals)
+				}
+			}
+		}
+	}
+	return c.result
+}
+
+// ValidateOrderCurrency checks that an order agrees with the checkout it
+// was placed from: the same currency and grand total, and adjustments that
+// do not return more than the order total.
+//
+// Field paths in the result are JSONPaths relative to the order root.
+func ValidateOrderCurrency(order *models.Order, checkout *extensions.ExtendedCheckoutResponse) *ValidationResult {
+	c := &currencyChecker{currency: checkout.Currency, result: &ValidationResult{Valid: true}}
+	if order.Currency != "" && order.Currency != checkout.Currency {
+		c.addError("$.currency", "order currency %q does not match checkout currency %q", order.Currency, checkout.Currency)
+	}
+
+	orderTotal, hasOrderTotal := totalOf(order.Totals, models.TotalTypeTotal)
+	if checkoutTotal, ok := totalOf(checkout.Totals, models.TotalTypeTotal); ok && hasOrderTotal && orderTotal != checkoutTotal {
+		c.addError("$.totals", "order total %d does not match checkout total %d", orderTotal, checkoutTotal)
+	}
+	c.checkDisplayText("$.totals", order.Totals)
+
+	adjusted := 0
+	for i, adj := range order.Adjustments {
+		if adj.Status == models.AdjustmentStatusFailed {
+			continue
+		}
+		adjusted += adj.Amount
+		if hasOrderTotal && adjusted > orderTotal {
+			c.addError(fmt.Sprintf("$.adjustments[%d].amount", i), "adjustments total %d exceeds order total %d", adjusted, orderTotal)
+			break
+		}
+	}
+	return c.result
+}
+
+// checkDisplayText flags display text naming a currency other than the
+// checkout's, by ISO code or by a symbol that is not part of the
+// checkout currency's own symbol.
+func (c *currencyChecker) checkDisplayText(path string, totals []models.TotalResponse) {
+	own := display.CurrencySymbol(c.currency)
+	for i, t := range totals {
+		if t.DisplayText == "" {
+			continue
+		}
+		field := fmt.Sprintf("%s[%d].display_text", path, i)
+		if other := foreignCurrency(t.DisplayText, c.currency, own); other != "" {
+			c.addError(field, "display text %q refers to %s in a %s checkout", t.DisplayText, other, c.currency)
+		}
+	}
+}
+
+// currencySymbol is a currency's display symbol with a matcher for it.
+type currencySymbol struct {
+	code    string
+	symbol  string
+	pattern *regexp.Regexp
+}
+
+// knownCurrencies is the set of currency codes with display data.
+var knownCurrencies = func() map[string]bool {
+	known := make(map[string]bool)
+	for _, code := range display.Currencies() {
+		known[code] = true
+	}
+	return known
+}()
+
+// currencySymbols lists the known currency symbols, longest first so "CA$"
+// is recognized before "A$" and "$". Letter symbols such as "kr" only
+// match as whole words.
+var currencySymbols = func() []currencySymbol {
+	var symbols []currencySymbol
+	for _, code := range display.Currencies() {
+		symbol := display.CurrencySymbol(code)
+		expr := regexp.QuoteMeta(symbol)
+		if regexp.MustCompile(`^[A-Za-z]+$`).MatchString(symbol) {
+			expr = `\b` + expr + `\b`
+		}
+		symbols = append(symbols, currencySymbol{code: code, symbol: symbol, pattern: regexp.MustCompile(expr)})
+	}
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return len(symbols[i].symbol) > len(symbols[j].symbol)
+	})
+	return symbols
+}()
+
+// foreignCurrency returns the code of a currency other than currency that
+// text refers to, or "" if there is none.
+func foreignCurrency(text, currency, ownSymbol string) string {
+	for _, code := range currencyCodeToken.FindAllString(text, -1) {
+		if code != currency && knownCurrencies[code] {
+			return code
+		}
+	}
+	for _, cs := range currencySymbols {
+		if !cs.pattern.MatchString(text) {
+			continue
+		}
+		if cs.code == currency || strings.Contains(ownSymbol, cs.symbol) {
+			// Remove the checkout's own symbol so shorter symbols inside
+			// it ("$" in "CA$") are not matched on their own.
+			text = cs.pattern.ReplaceAllString(text, " ")
+			continue
+		}
+		return cs.code
+	}
+	return ""
+}
+
+// totalOf returns the amount of the first total of the given type.
+func totalOf(totals []models.TotalResponse, typ models.TotalType) (int, bool) {
+	for _, t := range totals {
+		if t.Type == typ {
+			return t.Amount, true
+		}
+	}
+	return 0, false
+}
@@ -20,6 +20,7 @@
 //   - Capability negotiation between platforms and businesses
 //   - Version compatibility checking
 //   - Schema composition for extensions
+//   - Stock message conventions (out_of_stock, limited_quantity, backordered)
 //
 // The validation logic ensures that all UCP messages conform to the
 // official specification.
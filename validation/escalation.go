@@ -0,0 +1,87 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import "github.com/dhananjay2021/ucp-go-sdk/models"
+
+// AgentAction is what an agent should do next given a checkout's messages.
+type AgentAction string
+
+const (
+	// AgentActionProceed means every issue is recoverable via the API and the
+	// agent may continue automatically.
+	AgentActionProceed AgentAction = "proceed"
+
+	// AgentActionAwaitBuyerInput means the agent must pause and collect
+	// information from the buyer that the merchant's API cannot accept.
+	AgentActionAwaitBuyerInput AgentAction = "await_buyer_input"
+
+	// AgentActionHandoff means the buyer must review and authorize the
+	// purchase directly (typically via continue_url) before an order is placed.
+	AgentActionHandoff AgentAction = "handoff"
+)
+
+// EscalationDecision is the result of classifying checkout messages.
+type EscalationDecision struct {
+	// Action is the most restrictive action required by the messages.
+	Action AgentAction
+
+	// Blocking lists the messages that caused Action when it is not
+	// AgentActionProceed.
+	Blocking []models.Message
+}
+
+// ClassifyMessages applies the UCP escalation rules to a checkout's messages:
+// any requires_buyer_review message requires a handoff, otherwise any
+// requires_buyer_input message requires pausing for the buyer, otherwise the
+// agent may proceed. Error messages without a severity are treated as
+// recoverable; warnings and info messages never block.
+func ClassifyMessages(messages []models.Message) EscalationDecision {
+	var review, input []models.Message
+	for _, m := range messages {
+		switch m.Severity {
+		case models.SeverityRequiresBuyerReview:
+			review = append(review, m)
+		case models.SeverityRequiresBuyerInput:
+			input = append(input, m)
+		}
+	}
+
+	switch {
+	case len(review) > 0:
+		return EscalationDecision{Action: AgentActionHandoff, Blocking: review}
+	case len(input) > 0:
+		return EscalationDecision{Action: AgentActionAwaitBuyerInput, Blocking: input}
+	default:
+		return EscalationDecision{Action: AgentActionProceed}
+	}
+}
+
+// CanProceed reports whether an agent may continue automatically.
+func CanProceed(messages []models.Message) bool {
+	return ClassifyMessages(messages).Action == AgentActionProceed
+}
+
+// RecoverableErrors returns the error messages an agent is expected to fix
+// through the API before the checkout can complete.
+func RecoverableErrors(messages []models.Message) []models.Message {
+	var out []models.Message
+	for _, m := range messages {
+		if m.Type == models.MessageTypeError && (m.Severity == "" || m.Severity == models.SeverityRecoverable) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+)
+
+// ValidateFulfillment cross-checks the fulfillment section of a checkout:
+//
+//   - method line_item_ids reference existing line items
+//   - each line item is covered by at most one method
+//   - selected_destination_id references one of the method's destinations
+//   - group line_item_ids belong to the group's method
+//   - selected_option_id references one of the group's options
+//
+// Field paths in the result are JSONPaths into the checkout.
+func ValidateFulfillment(checkout *extensions.ExtendedCheckoutResponse) *ValidationResult {
+	result := &ValidationResult{Valid: true}
+	if checkout == nil || checkout.Fulfillment == nil {
+		return result
+	}
+
+	addError := func(field, format string, args ...interface{}) {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	lineItems := make(map[string]bool, len(checkout.LineItems))
+	for _, li := range checkout.LineItems {
+		lineItems[li.ID] = true
+	}
+
+	coveredBy := make(map[string]int)
+	for i, m := range checkout.Fulfillment.Methods {
+		methodPath := fmt.Sprintf("$.fulfillment.methods[%d]", i)
+
+		methodItems := make(map[string]bool, len(m.LineItemIDs))
+		for j, id := range m.LineItemIDs {
+			path := fmt.Sprintf("%s.line_item_ids[%d]", methodPath, j)
+			methodItems[id] = true
+			if !lineItems[id] {
+				addError(path, "references unknown line item %q", id)
+				continue
+			}
+			if prev, ok := coveredBy[id]; ok && prev != i {
+				addError(path, "line item %q is already covered by $.fulfillment.methods[%d]", id, prev)
+				continue
+			}
+			coveredBy[id] = i
+		}
+
+		if m.SelectedDestinationID != nil {
+			found := false
+			for _, d := range m.Destinations {
+				if d.ID == *m.SelectedDestinationID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				addError(methodPath+".selected_destination_id", "references unknown destination %q", *m.SelectedDestinationID)
+			}
+		}
+
+		for j, g := range m.Groups {
+			groupPath := fmt.Sprintf("%s.groups[%d]", methodPath, j)
+			for k, id := range g.LineItemIDs {
+				if !methodItems[id] {
+					addError(fmt.Sprintf("%s.line_item_ids[%d]", groupPath, k), "line item %q is not part of the method", id)
+				}
+			}
+			if g.SelectedOptionID == nil {
+				continue
+			}
+			found := false
+			for _, opt := range g.Options {
+				if opt.ID == *g.SelectedOptionID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				addError(groupPath+".selected_option_id", "references unknown option %q", *g.SelectedOptionID)
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// maxValidateBodyBytes limits the size of /validate requests.
+const maxValidateBodyBytes = 4 << 20
+
+// ValidateRequest is the body of a POST /validate request.
+//
+// With SchemaURL, the payload is fully validated against that schema with
+// ValidatePayload. With Capability, it is checked against the schema the
+// payload declares for that capability in ucp.capabilities. With neither,
+// it is checked against every capability the payload declares.
+type ValidateRequest struct {
+	SchemaURL  string                `json:"schema_url,omitempty"`
+	Capability models.CapabilityName `json:"capability,omitempty"`
+	Payload    json.RawMessage       `json:"payload"`
+}
+
+// validateError is the error body for malformed /validate requests.
+type validateError struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// NewHTTPHandler returns an HTTP handler exposing validation as a service
+// backed by a new SchemaValidator created with opts. See
+// SchemaValidator.HTTPHandler.
+func NewHTTPHandler(opts ...SchemaValidatorOption) http.Handler {
+	return NewSchemaValidator(opts...).HTTPHandler()
+}
+
+// HTTPHandler returns an HTTP handler serving POST /validate, which
+// accepts a ValidateRequest and responds with a ValidationResult, so that
+// non-Go services can reuse the validator and its schema cache.
+//
+// Requests name schema URLs, so the validator must not fetch wherever a
+// caller points it. Unless it was created WithSchemaFetchAllowlist,
+// HTTPHandler restricts it, for all its uses, to schemas preloaded with
+// LoadSchemaFromBytes.
+func (v *SchemaValidator) HTTPHandler() http.Handler {
+	v.mu.Lock()
+	v.fetchRestricted = true
+	v.mu.Unlock()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /validate", v.handleValidate)
+	return mux
+}
+
+func (v *SchemaValidator) handleValidate(w http.ResponseWriter, r *http.Request) {
+	var req ValidateRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxValidateBodyBytes)).Decode(&req); err != nil {
+		writeValidateError(w, http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "Failed to parse request body")
+		return
+	}
+	if len(req.Payload) == 0 {
+		writeValidateError(w, http.StatusBadRequest, string(models.ErrorCodeInvalidRequest), "payload is required")
+		return
+	}
+
+	if result := v.ValidateJSON(req.Payload); !result.Valid {
+		writeValidateJSON(w, http.StatusOK, result)
+		return
+	}
+
+	if req.SchemaURL != "" {
+		writeValidateJSON(w, http.StatusOK, v.ValidatePayload(req.SchemaURL, req.Payload))
+		return
+	}
+
+	capabilities := declaredCapabilities(req.Payload)
+	if req.Capability != "" {
+		var selected []models.CapabilityResponse
+		for _, c := range capabilities {
+			if c.Name == req.Capability {
+				selected = append(selected, c)
+			}
+		}
+		if len(selected) == 0 {
+			writeValidateError(w, http.StatusBadRequest, "unknown_capability", "payload does not declare capability "+string(req.Capability))
+			return
+		}
+		capabilities = selected
+	}
+
+	writeValidateJSON(w, http.StatusOK, v.ValidateConformance(req.Payload, capabilities))
+}
+
+// declaredCapabilities returns the ucp.capabilities listed in a payload.
+func declaredCapabilities(payload []byte) []models.CapabilityResponse {
+	var envelope struct {
+		UCP struct {
+			Capabilities []models.CapabilityResponse `json:"capabilities"`
+		} `json:"ucp"`
+	}
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil
+	}
+	return envelope.UCP.Capabilities
+}
+
+func writeValidateJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeValidateError(w http.ResponseWriter, status int, code, message string) {
+	writeValidateJSON(w, status, validateError{Error: code, Message: message})
+}
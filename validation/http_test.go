@@ -0,0 +1,73 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/httpcache"
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+func TestHTTPHandlerFetchesOnlyAllowedSchemas(t *testing.T) {
+	var fetches atomic.Int32
+	schemas := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Write([]byte(`{"type":"object","required":["id"]}`))
+	}))
+	defer schemas.Close()
+
+	// A preloaded schema whose $ref points at the schema server.
+	preloaded := `{"$ref":"` + schemas.URL + `/internal/admin.json"}`
+
+	tests := []struct {
+		name      string
+		opts      []validation.SchemaValidatorOption
+		schemaURL string
+		fetches   int32
+		valid     bool
+	}{
+		{"default refuses schema_url", nil, schemas.URL + "/internal/admin.json", 0, false},
+		{"default refuses $ref from a preloaded schema", nil, "https://ucp.dev/schemas/preloaded.json", 0, false},
+		{"prefix elsewhere on the host", []validation.SchemaValidatorOption{
+			validation.WithSchemaFetchAllowlist(schemas.URL + "/public/"),
+		}, schemas.URL + "/internal/admin.json", 0, false},
+		{"allowed prefix", []validation.SchemaValidatorOption{
+			validation.WithSchemaFetchAllowlist(schemas.URL + "/internal/"),
+		}, schemas.URL + "/internal/admin.json", 1, true},
+	}
+	for _, tt := range tests {
+		fetches.Store(0)
+		v := validation.NewSchemaValidator(append(tt.opts, validation.WithSchemaCache(httpcache.New()))...)
+		v.LoadSchemaFromBytes("https://ucp.dev/schemas/preloaded.json", []byte(preloaded))
+
+		body, _ := json.Marshal(validation.ValidateRequest{SchemaURL: tt.schemaURL, Payload: json.RawMessage(`{"id":"chk_1"}`)})
+		rec := httptest.NewRecorder()
+		v.HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(string(body))))
+
+		var result validation.ValidationResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+			t.Fatalf("%s: %v: %s", tt.name, err, rec.Body)
+		}
+		if got := fetches.Load(); got != tt.fetches || result.Valid != tt.valid {
+			t.Errorf("%s: %d fetches, valid %v; want %d, %v: %+v", tt.name, got, result.Valid, tt.fetches, tt.valid, result.Errors)
+		}
+	}
+}
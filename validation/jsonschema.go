@@ -0,0 +1,887 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxSchemaDepth bounds nested schema evaluation, so that $ref cycles
+// which never descend into the instance fail instead of recursing forever.
+const maxSchemaDepth = 256
+
+// ValidatePayload validates data against the JSON Schema (draft 2020-12)
+// at schemaURL. Referenced schemas are resolved against the base URI of
+// the referring schema and loaded with LoadSchema, so schemas preloaded
+// with LoadSchemaFromBytes under their URL are used without fetching.
+// Parsed schemas are cached until the next LoadSchemaFromBytes, or until
+// the cache grows past a fixed bound.
+//
+// Every failing keyword is reported as a ValidationError with the JSON
+// Pointer of the failing instance location, its JSONPath-style Field, and
+// the keyword. The format keyword is an annotation unless the validator
+// was created WithFormatAssertions, and $dynamicRef is resolved like $ref.
+func (v *SchemaValidator) ValidatePayload(schemaURL string, data []byte) *ValidationResult {
+	result := &ValidationResult{Valid: true}
+
+	instance, err := decodeJSONNumbers(data)
+	if err != nil {
+		result.Valid = false
+		result.Errors = []ValidationError{{Field: "$", Message: fmt.Sprintf("invalid JSON: %s", err)}}
+		return result
+	}
+
+	root, base, err := v.resolveRef("", schemaURL)
+	if err != nil {
+		result.Valid = false
+		result.Errors = []ValidationError{{Field: "$", Keyword: "$ref", Message: err.Error()}}
+		return result
+	}
+
+	e := &schemaEvaluator{v: v}
+	if errs, _ := e.eval(root, base, instance, instanceLocation{field: "$"}, 0); len(errs) > 0 {
+		result.Valid = false
+		result.Errors = errs
+	}
+	return result
+}
+
+// WithFormatAssertions makes ValidatePayload enforce the format keyword for
+// date-time, date, time, email, uri, uri-reference, uuid, ipv4, and ipv6.
+// Other formats remain annotations.
+func WithFormatAssertions() SchemaValidatorOption {
+	return func(v *SchemaValidator) {
+		v.assertFormats = true
+	}
+}
+
+// decodeJSONNumbers decodes a single JSON value, keeping numbers as
+// json.Number so that large and decimal values compare exactly.
+func decodeJSONNumbers(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+	return value, nil
+}
+
+// resolveRef resolves a schema reference against a base URI, loading the
+// referenced document if needed. It returns the schema and the base URI
+// to evaluate it with.
+func (v *SchemaValidator) resolveRef(base, ref string) (any, string, error) {
+	target, err := resolveURI(base, ref)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid $ref %q: %w", ref, err)
+	}
+	fragment := target.Fragment
+	target.Fragment, target.RawFragment = "", ""
+	uri := target.String()
+
+	v.mu.RLock()
+	node, ok := v.schemaIndex[uri]
+	v.mu.RUnlock()
+	if !ok {
+		if node, err = v.loadSchemaDocument(uri); err != nil {
+			return nil, "", err
+		}
+	}
+
+	switch {
+	case fragment == "":
+		return node, uri, nil
+	case strings.HasPrefix(fragment, "/"):
+		node, base, ok := resolvePointer(node, uri, fragment)
+		if !ok {
+			return nil, "", fmt.Errorf("$ref %q: no schema at #%s", ref, fragment)
+		}
+		return node, base, nil
+	}
+
+	v.mu.RLock()
+	node, ok = v.schemaIndex[uri+"#"+fragment]
+	v.mu.RUnlock()
+	if !ok {
+		return nil, "", fmt.Errorf("$ref %q: unknown anchor %q", ref, fragment)
+	}
+	return node, uri, nil
+}
+
+// loadSchemaDocument loads, parses, and indexes the schema document at uri.
+func (v *SchemaValidator) loadSchemaDocument(uri string) (any, error) {
+	raw, err := v.LoadSchema(uri)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decodeJSONNumbers(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %w", uri, err)
+	}
+
+	index := map[string]any{uri: doc}
+	indexSchema(doc, uri, index)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.schemaIndex == nil || len(v.schemaIndex)+len(index) > maxSchemaIndexEntries {
+		v.schemaIndex = make(map[string]any)
+	}
+	for k, node := range index {
+		if _, ok := v.schemaIndex[k]; !ok {
+			v.schemaIndex[k] = node
+		}
+	}
+	return doc, nil
+}
+
+// Keywords whose values are subschemas, for indexing $id and $anchor.
+var (
+	schemaMapKeywords   = []string{"$defs", "definitions", "properties", "patternProperties", "dependentSchemas"}
+	schemaArrayKeywords = []string{"allOf", "anyOf", "oneOf", "prefixItems"}
+	schemaKeywords      = []string{
+		"items", "contains", "additionalProperties", "propertyNames", "not", "if", "then", "else",
+		"unevaluatedItems", "unevaluatedProperties",
+	}
+)
+
+// indexSchema records the embedded resources ($id) and anchors ($anchor,
+// $dynamicAnchor) of a schema under their absolute URIs.
+func indexSchema(node any, base string, index map[string]any) {
+	s, ok := node.(map[string]any)
+	if !ok {
+		return
+	}
+	if id, ok := s["$id"].(string); ok {
+		if u, err := resolveURI(base, id); err == nil {
+			u.Fragment, u.RawFragment = "", ""
+			base = u.String()
+			index[base] = s
+		}
+	}
+	for _, keyword := range []string{"$anchor", "$dynamicAnchor"} {
+		if anchor, ok := s[keyword].(string); ok {
+			index[base+"#"+anchor] = s
+		}
+	}
+
+	for _, keyword := range schemaMapKeywords {
+		if m, ok := s[keyword].(map[string]any); ok {
+			for _, sub := range m {
+				indexSchema(sub, base, index)
+			}
+		}
+	}
+	for _, keyword := range schemaArrayKeywords {
+		if a, ok := s[keyword].([]any); ok {
+			for _, sub := range a {
+				indexSchema(sub, base, index)
+			}
+		}
+	}
+	for _, keyword := range schemaKeywords {
+		indexSchema(s[keyword], base, index)
+	}
+}
+
+// resolvePointer resolves a JSON Pointer fragment within a schema,
+// tracking the base URI through any $id on the way.
+func resolvePointer(node any, base, pointer string) (any, string, bool) {
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch n := node.(type) {
+		case map[string]any:
+			if id, ok := n["$id"].(string); ok {
+				if u, err := resolveURI(base, id); err == nil {
+					u.Fragment, u.RawFragment = "", ""
+					base = u.String()
+				}
+			}
+			next, ok := n[token]
+			if !ok {
+				return nil, "", false
+			}
+			node = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, "", false
+			}
+			node = n[i]
+		default:
+			return nil, "", false
+		}
+	}
+	return node, base, true
+}
+
+// resolveURI resolves ref against base.
+func resolveURI(base, ref string) (*url.URL, error) {
+	r, err := url.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+	return b.ResolveReference(r), nil
+}
+
+// instanceLocation is a position in the validated instance, kept both as
+// a JSON Pointer and as a JSONPath-style field.
+type instanceLocation struct {
+	pointer string
+	field   string
+}
+
+func (l instanceLocation) key(name string) instanceLocation {
+	return instanceLocation{
+		pointer: l.pointer + "/" + strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1"),
+		field:   l.field + "." + name,
+	}
+}
+
+func (l instanceLocation) index(i int) instanceLocation {
+	return instanceLocation{
+		pointer: l.pointer + "/" + strconv.Itoa(i),
+		field:   fmt.Sprintf("%s[%d]", l.field, i),
+	}
+}
+
+// evaluated records the properties and items a schema evaluated, for
+// unevaluatedProperties and unevaluatedItems.
+type evaluated struct {
+	props    map[string]bool
+	items    map[int]bool
+	allItems bool
+}
+
+func (ev *evaluated) prop(name string) {
+	if ev.props == nil {
+		ev.props = make(map[string]bool)
+	}
+	ev.props[name] = true
+}
+
+func (ev *evaluated) item(i int) {
+	if ev.items == nil {
+		ev.items = make(map[int]bool)
+	}
+	ev.items[i] = true
+}
+
+func (ev *evaluated) merge(other *evaluated) {
+	if other == nil {
+		return
+	}
+	for name := range other.props {
+		ev.prop(name)
+	}
+	for i := range other.items {
+		ev.item(i)
+	}
+	ev.allItems = ev.allItems || other.allItems
+}
+
+// schemaEvaluator evaluates one instance against a schema.
+type schemaEvaluator struct {
+	v *SchemaValidator
+}
+
+// eval validates inst against schema, returning the errors and the
+// properties and items the schema evaluated.
+func (e *schemaEvaluator) eval(schema any, base string, inst any, loc instanceLocation, depth int) ([]ValidationError, *evaluated) {
+	var errs []ValidationError
+	fail := func(keyword, format string, args ...any) {
+		errs = append(errs, ValidationError{
+			Field:   loc.field,
+			Pointer: loc.pointer,
+			Keyword: keyword,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+	ev := &evaluated{}
+
+	s, ok := schema.(map[string]any)
+	if !ok {
+		if b, ok := schema.(bool); ok && !b {
+			fail("false", "no value is allowed here")
+		}
+		return errs, ev
+	}
+	if depth > maxSchemaDepth {
+		fail("$ref", "schema nesting exceeds %d levels", maxSchemaDepth)
+		return errs, ev
+	}
+	if id, ok := s["$id"].(string); ok {
+		if u, err := resolveURI(base, id); err == nil {
+			u.Fragment, u.RawFragment = "", ""
+			base = u.String()
+		}
+	}
+
+	// apply evaluates a subschema in place, keeping its errors and
+	// annotations.
+	apply := func(sub any, subBase string) bool {
+		subErrs, subEv := e.eval(sub, subBase, inst, loc, depth+1)
+		errs = append(errs, subErrs...)
+		ev.merge(subEv)
+		return len(subErrs) == 0
+	}
+	// try evaluates a subschema in place without reporting its errors.
+	try := func(sub any) (bool, *evaluated) {
+		subErrs, subEv := e.eval(sub, base, inst, loc, depth+1)
+		return len(subErrs) == 0, subEv
+	}
+
+	for _, keyword := range []string{"$ref", "$dynamicRef"} {
+		ref, ok := s[keyword].(string)
+		if !ok {
+			continue
+		}
+		target, targetBase, err := e.v.resolveRef(base, ref)
+		if err != nil {
+			fail(keyword, "%s", err)
+			continue
+		}
+		apply(target, targetBase)
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(inst, t) {
+		fail("type", "expected %s, got %s", describeType(t), jsonType(inst))
+	}
+	if enum, ok := s["enum"].([]any); ok {
+		found := false
+		for _, option := range enum {
+			if jsonEqual(inst, option) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("enum", "must be one of %s", compactJSON(enum))
+		}
+	}
+	if c, ok := s["const"]; ok && !jsonEqual(inst, c) {
+		fail("const", "must be %s", compactJSON(c))
+	}
+
+	switch value := inst.(type) {
+	case json.Number:
+		e.number(s, value, fail)
+	case string:
+		e.string(s, value, fail)
+	case []any:
+		errs = append(errs, e.array(s, base, value, loc, depth, ev, fail)...)
+	case map[string]any:
+		errs = append(errs, e.object(s, base, value, loc, depth, ev, fail)...)
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			apply(sub, base)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]any); ok {
+		matched := false
+		for _, sub := range anyOf {
+			if valid, subEv := try(sub); valid {
+				matched = true
+				ev.merge(subEv)
+			}
+		}
+		if !matched {
+			fail("anyOf", "does not match any of the allowed schemas")
+		}
+	}
+	if oneOf, ok := s["oneOf"].([]any); ok {
+		matched := 0
+		for _, sub := range oneOf {
+			if valid, subEv := try(sub); valid {
+				matched++
+				ev.merge(subEv)
+			}
+		}
+		switch {
+		case matched == 0:
+			fail("oneOf", "does not match any of the allowed schemas")
+		case matched > 1:
+			fail("oneOf", "matches %d schemas, expected exactly one", matched)
+		}
+	}
+	if not, ok := s["not"]; ok {
+		if valid, _ := try(not); valid {
+			fail("not", "must not match the disallowed schema")
+		}
+	}
+	if cond, ok := s["if"]; ok {
+		valid, condEv := try(cond)
+		if valid {
+			ev.merge(condEv)
+			if then, ok := s["then"]; ok {
+				apply(then, base)
+			}
+		} else if otherwise, ok := s["else"]; ok {
+			apply(otherwise, base)
+		}
+	}
+
+	// The unevaluated keywords see the annotations of every keyword
+	// above, so they are evaluated last.
+	switch value := inst.(type) {
+	case []any:
+		if sub, ok := s["unevaluatedItems"]; ok && !ev.allItems {
+			for i, item := range value {
+				if !ev.items[i] {
+					subErrs, _ := e.evalChild("unevaluatedItems", sub, base, item, loc.index(i), depth)
+					errs = append(errs, subErrs...)
+				}
+			}
+			ev.allItems = true
+		}
+	case map[string]any:
+		if sub, ok := s["unevaluatedProperties"]; ok {
+			for _, name := range sortedKeys(value) {
+				if !ev.props[name] {
+					subErrs, _ := e.evalChild("unevaluatedProperties", sub, base, value[name], loc.key(name), depth)
+					errs = append(errs, subErrs...)
+					ev.prop(name)
+				}
+			}
+		}
+	}
+	return errs, ev
+}
+
+// evalChild evaluates the subschema of keyword against a child of the
+// instance. A false subschema is reported against keyword itself, since
+// "unknown field" says more than "no value is allowed here".
+func (e *schemaEvaluator) evalChild(keyword string, sub any, base string, inst any, loc instanceLocation, depth int) ([]ValidationError, *evaluated) {
+	if b, ok := sub.(bool); ok && !b {
+		message := "unexpected item"
+		if strings.HasSuffix(keyword, "Properties") {
+			message = "unknown field"
+		}
+		return []ValidationError{{Field: loc.field, Pointer: loc.pointer, Keyword: keyword, Message: message}}, nil
+	}
+	return e.eval(sub, base, inst, loc, depth+1)
+}
+
+// number applies the numeric keywords.
+func (e *schemaEvaluator) number(s map[string]any, n json.Number, fail func(string, string, ...any)) {
+	value, ok := ratOf(n)
+	if !ok {
+		return
+	}
+	bound := func(keyword string, violated func(cmp int) bool, relation string) {
+		limit, ok := ratOf(s[keyword])
+		if ok && violated(value.Cmp(limit)) {
+			fail(keyword, "must be %s %s", relation, s[keyword])
+		}
+	}
+	bound("minimum", func(c int) bool { return c < 0 }, ">=")
+	bound("maximum", func(c int) bool { return c > 0 }, "<=")
+	bound("exclusiveMinimum", func(c int) bool { return c <= 0 }, ">")
+	bound("exclusiveMaximum", func(c int) bool { return c >= 0 }, "<")
+
+	if divisor, ok := ratOf(s["multipleOf"]); ok && divisor.Sign() > 0 {
+		if !new(big.Rat).Quo(value, divisor).IsInt() {
+			fail("multipleOf", "must be a multiple of %s", s["multipleOf"])
+		}
+	}
+}
+
+// string applies the string keywords.
+func (e *schemaEvaluator) string(s map[string]any, str string, fail func(string, string, ...any)) {
+	length := utf8.RuneCountInString(str)
+	if min, ok := intOf(s["minLength"]); ok && length < min {
+		fail("minLength", "must be at least %d characters", min)
+	}
+	if max, ok := intOf(s["maxLength"]); ok && length > max {
+		fail("maxLength", "must be at most %d characters", max)
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		re, err := e.v.pattern(pattern)
+		switch {
+		case err != nil:
+			fail("pattern", "invalid pattern %q in schema", pattern)
+		case !re.MatchString(str):
+			fail("pattern", "must match pattern %q", pattern)
+		}
+	}
+	if format, ok := s["format"].(string); ok && e.v.assertFormats {
+		if check, ok := formatCheckers[format]; ok && !check(str) {
+			fail("format", "must be a valid %s", format)
+		}
+	}
+}
+
+// array applies the array keywords other than unevaluatedItems.
+func (e *schemaEvaluator) array(s map[string]any, base string, items []any, loc instanceLocation, depth int, ev *evaluated, fail func(string, string, ...any)) []ValidationError {
+	var errs []ValidationError
+	if min, ok := intOf(s["minItems"]); ok && len(items) < min {
+		fail("minItems", "must have at least %d items", min)
+	}
+	if max, ok := intOf(s["maxItems"]); ok && len(items) > max {
+		fail("maxItems", "must have at most %d items", max)
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+	duplicates:
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if jsonEqual(items[i], items[j]) {
+					fail("uniqueItems", "items %d and %d are equal", i, j)
+					break duplicates
+				}
+			}
+		}
+	}
+
+	prefix, _ := s["prefixItems"].([]any)
+	for i, sub := range prefix {
+		if i >= len(items) {
+			break
+		}
+		subErrs, _ := e.evalChild("prefixItems", sub, base, items[i], loc.index(i), depth)
+		errs = append(errs, subErrs...)
+		ev.item(i)
+	}
+	if sub, ok := s["items"]; ok {
+		for i := len(prefix); i < len(items); i++ {
+			subErrs, _ := e.evalChild("items", sub, base, items[i], loc.index(i), depth)
+			errs = append(errs, subErrs...)
+		}
+		ev.allItems = true
+	}
+
+	if sub, ok := s["contains"]; ok {
+		matched := 0
+		for i, item := range items {
+			if subErrs, _ := e.eval(sub, base, item, loc.index(i), depth+1); len(subErrs) == 0 {
+				matched++
+				ev.item(i)
+			}
+		}
+		min, ok := intOf(s["minContains"])
+		if !ok {
+			min = 1
+		}
+		if matched < min {
+			fail("contains", "must contain at least %d matching items, found %d", min, matched)
+		}
+		if max, ok := intOf(s["maxContains"]); ok && matched > max {
+			fail("maxContains", "must contain at most %d matching items, found %d", max, matched)
+		}
+	}
+	return errs
+}
+
+// object applies the object keywords other than unevaluatedProperties.
+func (e *schemaEvaluator) object(s map[string]any, base string, obj map[string]any, loc instanceLocation, depth int, ev *evaluated, fail func(string, string, ...any)) []ValidationError {
+	var errs []ValidationError
+	if min, ok := intOf(s["minProperties"]); ok && len(obj) < min {
+		fail("minProperties", "must have at least %d properties", min)
+	}
+	if max, ok := intOf(s["maxProperties"]); ok && len(obj) > max {
+		fail("maxProperties", "must have at most %d properties", max)
+	}
+	if required, ok := s["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := obj[name]; !present {
+					errs = append(errs, ValidationError{
+						Field:   loc.key(name).field,
+						Pointer: loc.key(name).pointer,
+						Keyword: "required",
+						Message: "required field is missing",
+					})
+				}
+			}
+		}
+	}
+	if dependent, ok := s["dependentRequired"].(map[string]any); ok {
+		for _, name := range sortedKeys(dependent) {
+			if _, present := obj[name]; !present {
+				continue
+			}
+			needs, _ := dependent[name].([]any)
+			for _, need := range needs {
+				if need, ok := need.(string); ok {
+					if _, present := obj[need]; !present {
+						fail("dependentRequired", "%s is required when %s is present", need, name)
+					}
+				}
+			}
+		}
+	}
+
+	properties, _ := s["properties"].(map[string]any)
+	patterns, _ := s["patternProperties"].(map[string]any)
+	additional, hasAdditional := s["additionalProperties"]
+	names, hasNames := s["propertyNames"]
+
+	for _, name := range sortedKeys(obj) {
+		value := obj[name]
+		at := loc.key(name)
+		covered := false
+
+		if sub, ok := properties[name]; ok {
+			subErrs, _ := e.eval(sub, base, value, at, depth+1)
+			errs = append(errs, subErrs...)
+			covered = true
+		}
+		for _, pattern := range sortedKeys(patterns) {
+			re, err := e.v.pattern(pattern)
+			if err != nil {
+				fail("patternProperties", "invalid pattern %q in schema", pattern)
+				continue
+			}
+			if re.MatchString(name) {
+				subErrs, _ := e.eval(patterns[pattern], base, value, at, depth+1)
+				errs = append(errs, subErrs...)
+				covered = true
+			}
+		}
+		if !covered && hasAdditional {
+			subErrs, _ := e.evalChild("additionalProperties", additional, base, value, at, depth)
+			errs = append(errs, subErrs...)
+			covered = true
+		}
+		if covered {
+			ev.prop(name)
+		}
+		if hasNames {
+			if subErrs, _ := e.eval(names, base, name, at, depth+1); len(subErrs) > 0 {
+				fail("propertyNames", "property name %q is not allowed", name)
+			}
+		}
+	}
+
+	if dependent, ok := s["dependentSchemas"].(map[string]any); ok {
+		for _, name := range sortedKeys(dependent) {
+			if _, present := obj[name]; present {
+				subErrs, subEv := e.eval(dependent[name], base, obj, loc, depth+1)
+				errs = append(errs, subErrs...)
+				ev.merge(subEv)
+			}
+		}
+	}
+	return errs
+}
+
+// pattern compiles a schema regular expression, caching the result.
+// Patterns use RE2 syntax, which covers the ECMA-262 features schemas
+// commonly use but not lookaround or backreferences.
+func (v *SchemaValidator) pattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := v.patterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	v.patterns.Store(pattern, re)
+	return re, nil
+}
+
+// jsonType returns the JSON Schema type name of a decoded value.
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if r, ok := ratOf(v); ok && r.IsInt() {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// matchesType reports whether value matches a type keyword, which is a
+// type name or a list of them.
+func matchesType(value any, t any) bool {
+	actual := jsonType(value)
+	matches := func(name any) bool {
+		return name == actual || (name == "number" && actual == "integer")
+	}
+	if list, ok := t.([]any); ok {
+		for _, name := range list {
+			if matches(name) {
+				return true
+			}
+		}
+		return false
+	}
+	return matches(t)
+}
+
+// describeType formats a type keyword for messages.
+func describeType(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, len(list))
+		for i, name := range list {
+			names[i] = fmt.Sprint(name)
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// jsonEqual reports whether two decoded JSON values are equal, comparing
+// numbers by value.
+func jsonEqual(a, b any) bool {
+	switch x := a.(type) {
+	case json.Number:
+		ra, ok := ratOf(x)
+		rb, okb := ratOf(b)
+		return ok && okb && ra.Cmp(rb) == 0
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jsonEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, xv := range x {
+			yv, ok := y[k]
+			if !ok || !jsonEqual(xv, yv) {
+				return false
+			}
+		}
+		return true
+	}
+	if _, ok := b.(json.Number); ok {
+		return false
+	}
+	return a == b
+}
+
+// ratOf converts a decoded JSON number to an exact rational.
+func ratOf(value any) (*big.Rat, bool) {
+	switch n := value.(type) {
+	case json.Number:
+		return new(big.Rat).SetString(string(n))
+	case float64:
+		r := new(big.Rat)
+		if r.SetFloat64(n) == nil {
+			return nil, false
+		}
+		return r, true
+	}
+	return nil, false
+}
+
+// intOf converts a decoded JSON number to an int, for length and count
+// keywords.
+func intOf(value any) (int, bool) {
+	r, ok := ratOf(value)
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return 0, false
+	}
+	return int(r.Num().Int64()), true
+}
+
+// compactJSON formats a schema value for messages.
+func compactJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// sortedKeys returns the keys of m in order, so errors are reported
+// deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// formatCheckers validate the formats asserted WithFormatAssertions.
+var formatCheckers = map[string]func(string) bool{
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, strings.ToUpper(s))
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse("15:04:05.999999999Z07:00", strings.ToUpper(s))
+		return err == nil
+	},
+	"email": func(s string) bool {
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.IsAbs()
+	},
+	"uri-reference": func(s string) bool {
+		_, err := url.Parse(s)
+		return err == nil
+	},
+	"uuid": uuidPattern.MatchString,
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	},
+	"ipv6": func(s string) bool {
+		return net.ParseIP(s) != nil && strings.Contains(s, ":")
+	},
+}
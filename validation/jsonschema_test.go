@@ -0,0 +1,204 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/dhananjay2021/ucp-go-sdk/validation"
+)
+
+// offline keeps schema loading to preloaded documents.
+var offline = validation.WithSchemaFetchAllowlist()
+
+// schemaCase validates instance against schema and expects the listed
+// failing keywords, in order; none means valid.
+type schemaCase struct {
+	name     string
+	schema   string
+	instance string
+	keywords []string
+}
+
+func runSchemaCases(t *testing.T, v *validation.SchemaValidator, tests []schemaCase) {
+	t.Helper()
+	for i, tt := range tests {
+		url := fmt.Sprintf("https://example.com/schemas/case%d.json", i)
+		v.LoadSchemaFromBytes(url, []byte(tt.schema))
+		result := v.ValidatePayload(url, []byte(tt.instance))
+		var keywords []string
+		for _, err := range result.Errors {
+			keywords = append(keywords, err.Keyword)
+		}
+		if result.Valid != (len(tt.keywords) == 0) || !reflect.DeepEqual(keywords, tt.keywords) {
+			t.Errorf("%s: valid %v, errors %+v; want keywords %v", tt.name, result.Valid, result.Errors, tt.keywords)
+		}
+	}
+}
+
+func TestValidatePayloadKeywords(t *testing.T) {
+	runSchemaCases(t, validation.NewSchemaValidator(offline), []schemaCase{
+		// Type, enum, and const.
+		{"type", `{"type":"string"}`, `1`, []string{"type"}},
+		{"type list", `{"type":["string","null"]}`, `null`, nil},
+		{"integer accepts 1.0", `{"type":"integer"}`, `1.0`, nil},
+		{"integer rejects 1.5", `{"type":"integer"}`, `1.5`, []string{"type"}},
+		{"enum", `{"enum":["a","b"]}`, `"c"`, []string{"enum"}},
+		{"enum compares numbers by value", `{"enum":[1]}`, `1.0`, nil},
+		{"const object", `{"const":{"a":[1,2]}}`, `{"a":[1,2]}`, nil},
+		{"const mismatch", `{"const":{"a":[1,2]}}`, `{"a":[2,1]}`, []string{"const"}},
+		{"false schema", `false`, `{}`, []string{"false"}},
+		{"true schema", `true`, `{}`, nil},
+
+		// Numbers.
+		{"minimum", `{"minimum":1}`, `0`, []string{"minimum"}},
+		{"maximum", `{"maximum":1}`, `1`, nil},
+		{"exclusiveMinimum", `{"exclusiveMinimum":1}`, `1`, []string{"exclusiveMinimum"}},
+		{"exclusiveMaximum", `{"exclusiveMaximum":1}`, `0.99`, nil},
+		{"multipleOf decimal", `{"multipleOf":0.01}`, `19.99`, nil},
+		{"multipleOf", `{"multipleOf":3}`, `10`, []string{"multipleOf"}},
+		{"big integer", `{"maximum":9007199254740993}`, `9007199254740994`, []string{"maximum"}},
+
+		// Strings.
+		{"minLength counts runes", `{"minLength":2}`, `"é"`, []string{"minLength"}},
+		{"maxLength", `{"maxLength":2}`, `"abc"`, []string{"maxLength"}},
+		{"pattern", `{"pattern":"^[A-Z]{3}$"}`, `"usd"`, []string{"pattern"}},
+		{"pattern unanchored", `{"pattern":"b"}`, `"abc"`, nil},
+		{"format is an annotation", `{"format":"email"}`, `"not an email"`, nil},
+
+		// Arrays.
+		{"minItems", `{"minItems":1}`, `[]`, []string{"minItems"}},
+		{"maxItems", `{"maxItems":1}`, `[1,2]`, []string{"maxItems"}},
+		{"uniqueItems", `{"uniqueItems":true}`, `[{"a":1},{"a":1.0}]`, []string{"uniqueItems"}},
+		{"items", `{"items":{"type":"integer"}}`, `[1,"2"]`, []string{"type"}},
+		{"prefixItems", `{"prefixItems":[{"type":"string"},{"type":"integer"}]}`, `["a","b",true]`, []string{"type"}},
+		{"items after prefixItems", `{"prefixItems":[{"type":"string"}],"items":false}`, `["a",1]`, []string{"items"}},
+		{"contains", `{"contains":{"const":2}}`, `[1,3]`, []string{"contains"}},
+		{"minContains", `{"contains":{"const":2},"minContains":2}`, `[2,1,2]`, nil},
+		{"maxContains", `{"contains":{"const":2},"maxContains":1}`, `[2,2]`, []string{"maxContains"}},
+
+		// Objects.
+		{"required", `{"required":["a","b"]}`, `{"a":1}`, []string{"required"}},
+		{"properties", `{"properties":{"a":{"type":"string"}}}`, `{"a":1,"b":1}`, []string{"type"}},
+		{"patternProperties", `{"patternProperties":{"^x-":{"type":"string"}}}`, `{"x-a":1,"y":1}`, []string{"type"}},
+		{"additionalProperties", `{"properties":{"a":{}},"patternProperties":{"^x-":{}},"additionalProperties":false}`,
+			`{"a":1,"x-b":2,"c":3}`, []string{"additionalProperties"}},
+		{"propertyNames", `{"propertyNames":{"maxLength":3}}`, `{"abcd":1}`, []string{"propertyNames"}},
+		{"minProperties", `{"minProperties":1}`, `{}`, []string{"minProperties"}},
+		{"maxProperties", `{"maxProperties":1}`, `{"a":1,"b":2}`, []string{"maxProperties"}},
+		{"dependentRequired", `{"dependentRequired":{"card":["expiry"]}}`, `{"card":"4111"}`, []string{"dependentRequired"}},
+		{"dependentSchemas", `{"dependentSchemas":{"card":{"required":["expiry"]}}}`, `{"card":"4111"}`, []string{"required"}},
+
+		// Applicators.
+		{"allOf", `{"allOf":[{"type":"object"},{"required":["a"]}]}`, `{}`, []string{"required"}},
+		{"anyOf", `{"anyOf":[{"type":"string"},{"type":"integer"}]}`, `true`, []string{"anyOf"}},
+		{"anyOf match", `{"anyOf":[{"type":"string"},{"minLength":1}]}`, `"a"`, nil},
+		{"oneOf none", `{"oneOf":[{"type":"string"},{"type":"integer"}]}`, `true`, []string{"oneOf"}},
+		{"oneOf several", `{"oneOf":[{"type":"string"},{"minLength":1}]}`, `"a"`, []string{"oneOf"}},
+		{"oneOf exactly one", `{"oneOf":[{"type":"string"},{"type":"integer"}]}`, `1`, nil},
+		{"not", `{"not":{"type":"null"}}`, `null`, []string{"not"}},
+		{"if then", `{"if":{"properties":{"type":{"const":"card"}}},"then":{"required":["token"]},"else":{"required":["id"]}}`,
+			`{"type":"card"}`, []string{"required"}},
+		{"if else", `{"if":{"properties":{"type":{"const":"card"}}},"then":{"required":["token"]},"else":{"required":["id"]}}`,
+			`{"type":"wallet","id":"w_1"}`, nil},
+		{"then without if", `{"then":false}`, `1`, nil},
+
+		// Unevaluated locations see through applicators.
+		{"unevaluatedProperties", `{"allOf":[{"properties":{"a":{}}}],"unevaluatedProperties":false}`,
+			`{"a":1,"b":2}`, []string{"unevaluatedProperties"}},
+		{"unevaluatedProperties through anyOf", `{"anyOf":[{"properties":{"a":{}}},{"properties":{"b":{}}}],"unevaluatedProperties":false}`,
+			`{"a":1,"b":2}`, nil},
+		{"unevaluatedProperties ignores failed if", `{"if":{"properties":{"a":{"const":1}}},"unevaluatedProperties":false}`,
+			`{"a":2}`, []string{"unevaluatedProperties"}},
+		{"unevaluatedProperties through then", `{"if":{"properties":{"a":{"const":1}}},"then":{"properties":{"b":{}}},"unevaluatedProperties":false}`,
+			`{"a":1,"b":2}`, nil},
+		{"unevaluatedItems", `{"prefixItems":[{}],"unevaluatedItems":false}`, `[1,2]`, []string{"unevaluatedItems"}},
+		{"unevaluatedItems after contains", `{"contains":{"const":2},"unevaluatedItems":false}`, `[2,2]`, nil},
+		{"unevaluatedItems after items", `{"allOf":[{"items":true}],"unevaluatedItems":false}`, `[1,2]`, nil},
+	})
+}
+
+func TestValidatePayloadFormats(t *testing.T) {
+	runSchemaCases(t, validation.NewSchemaValidator(offline, validation.WithFormatAssertions()), []schemaCase{
+		{"date-time", `{"format":"date-time"}`, `"2026-01-11T12:00:00Z"`, nil},
+		{"date-time offset", `{"format":"date-time"}`, `"2026-01-11T12:00:00.5+05:30"`, nil},
+		{"bad date-time", `{"format":"date-time"}`, `"2026-01-11 12:00"`, []string{"format"}},
+		{"date", `{"format":"date"}`, `"2026-02-30"`, []string{"format"}},
+		{"time", `{"format":"time"}`, `"12:00:00Z"`, nil},
+		{"email", `{"format":"email"}`, `"buyer@example.com"`, nil},
+		{"bad email", `{"format":"email"}`, `"Buyer <buyer@example.com>"`, []string{"format"}},
+		{"uri", `{"format":"uri"}`, `"https://example.com/a?b"`, nil},
+		{"relative uri", `{"format":"uri"}`, `"/a"`, []string{"format"}},
+		{"uri-reference", `{"format":"uri-reference"}`, `"/a"`, nil},
+		{"uuid", `{"format":"uuid"}`, `"0f8fad5b-d9cb-469f-a165-70867728950e"`, nil},
+		{"bad uuid", `{"format":"uuid"}`, `"0f8fad5b"`, []string{"format"}},
+		{"ipv4", `{"format":"ipv4"}`, `"192.0.2.1"`, nil},
+		{"bad ipv4", `{"format":"ipv4"}`, `"::1"`, []string{"format"}},
+		{"ipv6", `{"format":"ipv6"}`, `"2001:db8::1"`, nil},
+		{"unknown format", `{"format":"x-custom"}`, `"anything"`, nil},
+		{"format on a number", `{"format":"email"}`, `1`, nil},
+	})
+}
+
+func TestValidatePayloadRefs(t *testing.T) {
+	v := validation.NewSchemaValidator(offline)
+	v.LoadSchemaFromBytes("https://example.com/schemas/types.json", []byte(`{
+		"$defs": {
+			"amount": {"type": "integer", "minimum": 0},
+			"currency": {"$anchor": "currency", "type": "string", "pattern": "^[A-Z]{3}$"}
+		}
+	}`))
+	v.LoadSchemaFromBytes("https://example.com/schemas/nested/ids.json", []byte(`{
+		"$id": "https://example.com/schemas/nested/ids.json",
+		"$defs": {"id": {"$ref": "../types.json#/$defs/currency"}}
+	}`))
+
+	runSchemaCases(t, v, []schemaCase{
+		{"ref by pointer across documents", `{"properties":{"amount":{"$ref":"types.json#/$defs/amount"}}}`,
+			`{"amount":-1}`, []string{"minimum"}},
+		{"ref by anchor across documents", `{"properties":{"currency":{"$ref":"types.json#currency"}}}`,
+			`{"currency":"usd"}`, []string{"pattern"}},
+		{"ref relative to the referenced document", `{"$ref":"nested/ids.json#/$defs/id"}`, `"EUR"`, nil},
+		{"ref within the document", `{"$defs":{"a":{"type":"string"}},"items":{"$ref":"#/$defs/a"}}`, `["a",1]`, []string{"type"}},
+		{"ref with an escaped pointer", `{"$defs":{"a/b":{"type":"string"}},"$ref":"#/$defs/a~1b"}`, `1`, []string{"type"}},
+		{"ref beside other keywords", `{"$ref":"types.json#/$defs/amount","maximum":10}`, `11`, []string{"maximum"}},
+		{"embedded $id changes the base", `{"$defs":{"x":{"$id":"https://example.com/schemas/nested/x.json","$ref":"ids.json#/$defs/id"}},"$ref":"#/$defs/x"}`,
+			`"eur"`, []string{"pattern"}},
+		{"missing document", `{"$ref":"https://example.com/schemas/missing.json"}`, `1`, []string{"$ref"}},
+		{"missing pointer", `{"$ref":"types.json#/$defs/nothing"}`, `1`, []string{"$ref"}},
+		{"recursive ref", `{"properties":{"child":{"$ref":"#"}},"required":["id"]}`, `{"id":1,"child":{"id":2,"child":{}}}`, []string{"required"}},
+		{"ref cycle", `{"$defs":{"a":{"$ref":"#/$defs/b"},"b":{"$ref":"#/$defs/a"}},"$ref":"#/$defs/a"}`, `1`, []string{"$ref"}},
+	})
+}
+
+func TestValidatePayloadLocations(t *testing.T) {
+	v := validation.NewSchemaValidator(offline)
+	v.LoadSchemaFromBytes("https://example.com/schemas/checkout.json", []byte(`{
+		"properties": {
+			"line_items": {"items": {"required": ["quantity"], "properties": {"a/b": {"type": "integer"}}}}
+		}
+	}`))
+	result := v.ValidatePayload("https://example.com/schemas/checkout.json", []byte(`{"line_items":[{"quantity":1},{"a/b":"x"}]}`))
+
+	want := []validation.ValidationError{
+		{Field: "$.line_items[1].quantity", Pointer: "/line_items/1/quantity", Keyword: "required", Message: "required field is missing"},
+		{Field: "$.line_items[1].a/b", Pointer: "/line_items/1/a~1b", Keyword: "type", Message: "expected integer, got string"},
+	}
+	if result.Valid || !reflect.DeepEqual(result.Errors, want) {
+		t.Errorf("errors = %+v, want %+v", result.Errors, want)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// MinRSAKeyBits is the smallest RSA modulus ValidateJWK accepts.
+const MinRSAKeyBits = 2048
+
+// DefaultMaxSigningKeys bounds the keys in a signing keyset when
+// ValidateSigningKeyset is given no limit: enough for the current key, its
+// successor, and one being retired during rotation.
+const DefaultMaxSigningKeys = 3
+
+// ecCurves maps the approved JWK curves to their ECDH parameters, used to
+// check that a point is on the curve, and the algorithm each signs with.
+var ecCurves = map[string]struct {
+	curve ecdh.Curve
+	size  int
+	alg   string
+}{
+	"P-256": {ecdh.P256(), 32, "ES256"},
+	"P-384": {ecdh.P384(), 48, "ES384"},
+	"P-521": {ecdh.P521(), 66, "ES512"},
+}
+
+// rsaAlgs are the approved algorithms for RSA keys.
+var rsaAlgs = map[string]bool{
+	"RS256": true, "RS384": true, "RS512": true,
+	"PS256": true, "PS384": true, "PS512": true,
+}
+
+// ValidateJWK checks that jwk is a well-formed public signing key: it has
+// a kid, the parameters its kty requires, an approved curve or an RSA
+// modulus of at least MinRSAKeyBits, and, when given, use "sig" and an alg
+// matching the key.
+func ValidateJWK(jwk models.JWK) error {
+	if jwk.Kid == "" {
+		return errors.New("invalid JWK: missing kid")
+	}
+	if err := validateJWK(jwk); err != nil {
+		return fmt.Errorf("invalid JWK %s: %w", jwk.Kid, err)
+	}
+	return nil
+}
+
+func validateJWK(jwk models.JWK) error {
+	if jwk.Use != "" && jwk.Use != "sig" {
+		return fmt.Errorf("use must be sig, got %q", jwk.Use)
+	}
+	switch jwk.Kty {
+	case "EC":
+		return validateECJWK(jwk)
+	case "RSA":
+		return validateRSAJWK(jwk)
+	case "":
+		return errors.New("missing kty")
+	default:
+		return fmt.Errorf("unsupported kty %q", jwk.Kty)
+	}
+}
+
+func validateECJWK(jwk models.JWK) error {
+	if jwk.N != "" || jwk.E != "" {
+		return errors.New("EC key must not carry RSA parameters")
+	}
+	params, ok := ecCurves[jwk.Crv]
+	if !ok {
+		if jwk.Crv == "" {
+			return errors.New("EC key missing crv")
+		}
+		return fmt.Errorf("unsupported curve %q", jwk.Crv)
+	}
+	if jwk.Alg != "" && jwk.Alg != params.alg {
+		return fmt.Errorf("alg %s does not match curve %s (want %s)", jwk.Alg, jwk.Crv, params.alg)
+	}
+
+	x, err := decodeJWKParam("x", jwk.X, params.size)
+	if err != nil {
+		return err
+	}
+	y, err := decodeJWKParam("y", jwk.Y, params.size)
+	if err != nil {
+		return err
+	}
+	point := append(append([]byte{4}, x...), y...)
+	if _, err := params.curve.NewPublicKey(point); err != nil {
+		return fmt.Errorf("point is not on curve %s", jwk.Crv)
+	}
+	return nil
+}
+
+func validateRSAJWK(jwk models.JWK) error {
+	if jwk.Crv != "" || jwk.X != "" || jwk.Y != "" {
+		return errors.New("RSA key must not carry EC parameters")
+	}
+	if jwk.Alg != "" && !rsaAlgs[jwk.Alg] {
+		return fmt.Errorf("unsupported alg %q for RSA key", jwk.Alg)
+	}
+
+	n, err := decodeJWKParam("n", jwk.N, 0)
+	if err != nil {
+		return err
+	}
+	if bits := new(big.Int).SetBytes(n).BitLen(); bits < MinRSAKeyBits {
+		return fmt.Errorf("RSA modulus is %d bits, need at least %d", bits, MinRSAKeyBits)
+	}
+	e, err := decodeJWKParam("e", jwk.E, 0)
+	if err != nil {
+		return err
+	}
+	exp := new(big.Int).SetBytes(e)
+	if exp.Cmp(big.NewInt(3)) < 0 || exp.Bit(0) == 0 || exp.BitLen() > 31 {
+		return fmt.Errorf("RSA exponent %s is not an odd value of at least 3", exp)
+	}
+	return nil
+}
+
+// decodeJWKParam decodes a base64url key parameter, requiring exactly
+// size bytes when size is positive.
+func decodeJWKParam(name, value string, size int) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("missing %s", name)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not base64url: %w", name, err)
+	}
+	if size > 0 && len(b) != size {
+		return nil, fmt.Errorf("%s is %d bytes, want %d", name, len(b), size)
+	}
+	return b, nil
+}
+
+// ValidateSigningKeyset checks a profile's signing keys: each must pass
+// ValidateJWK, kids must be unique, and there may be at most max keys
+// (DefaultMaxSigningKeys if max is not positive). Every problem found is
+// reported, joined into one error.
+func ValidateSigningKeyset(keys []models.JWK, max int) error {
+	if max <= 0 {
+		max = DefaultMaxSigningKeys
+	}
+	var errs []error
+	if len(keys) > max {
+		errs = append(errs, fmt.Errorf("keyset has %d signing keys, at most %d are allowed", len(keys), max))
+	}
+	seen := make(map[string]bool, len(keys))
+	for i, jwk := range keys {
+		if err := ValidateJWK(jwk); err != nil {
+			errs = append(errs, fmt.Errorf("signing_keys[%d]: %w", i, err))
+		}
+		if jwk.Kid == "" {
+			continue
+		}
+		if seen[jwk.Kid] {
+			errs = append(errs, fmt.Errorf("signing_keys[%d]: duplicate kid %s", i, jwk.Kid))
+		}
+		seen[jwk.Kid] = true
+	}
+	return errors.Join(errs...)
+}
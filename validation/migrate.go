@@ -0,0 +1,221 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// ErrNoMigrationPath is returned when no chain of registered rules leads
+// from one protocol version to another.
+var ErrNoMigrationPath = errors.New("no migration path")
+
+// MigrationFunc transforms a decoded JSON payload in place from one
+// protocol version to the next.
+type MigrationFunc func(doc map[string]interface{}) error
+
+// Migrator converts payloads between protocol versions, typically down to
+// the version negotiation landed on, using rules registered per version
+// pair. It is safe for concurrent use.
+type Migrator struct {
+	mu    sync.RWMutex
+	rules map[models.Version]map[models.Version][]MigrationFunc
+}
+
+// NewMigrator creates a migrator with no rules.
+func NewMigrator() *Migrator {
+	return &Migrator{rules: make(map[models.Version]map[models.Version][]MigrationFunc)}
+}
+
+// DefaultMigrator is the migrator used by RegisterMigration and
+// MigratePayload.
+var DefaultMigrator = NewMigrator()
+
+// RegisterMigration registers rules on DefaultMigrator.
+func RegisterMigration(from, to models.Version, rules ...MigrationFunc) {
+	DefaultMigrator.Register(from, to, rules...)
+}
+
+// MigratePayload converts a payload with DefaultMigrator.
+func MigratePayload(data []byte, from, to models.Version) ([]byte, error) {
+	return DefaultMigrator.Migrate(data, from, to)
+}
+
+// Register adds rules converting payloads from one version to another.
+// Rules for the same pair run in registration order. Pairs need not be
+// adjacent releases; Migrate chains them as needed.
+func (m *Migrator) Register(from, to models.Version, rules ...MigrationFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.rules[from] == nil {
+		m.rules[from] = make(map[models.Version][]MigrationFunc)
+	}
+	m.rules[from][to] = append(m.rules[from][to], rules...)
+}
+
+// Migrate converts a JSON object payload from one protocol version to
+// another by applying the shortest chain of registered rules, and updates
+// ucp.version if the payload carries one. A payload already at the target
+// version is returned unchanged.
+func (m *Migrator) Migrate(data []byte, from, to models.Version) ([]byte, error) {
+	if !from.IsValid() || !to.IsValid() {
+		return nil, fmt.Errorf("invalid version: %q to %q", from, to)
+	}
+	if from == to {
+		return data, nil
+	}
+
+	steps := m.path(from, to)
+	if steps == nil {
+		return nil, fmt.Errorf("%w from %s to %s", ErrNoMigrationPath, from, to)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	for _, step := range steps {
+		for _, rule := range step.rules {
+			if err := rule(doc); err != nil {
+				return nil, fmt.Errorf("migrate %s to %s: %w", step.from, step.to, err)
+			}
+		}
+	}
+	if ucp, ok := doc["ucp"].(map[string]interface{}); ok {
+		if _, ok := ucp["version"]; ok {
+			ucp["version"] = string(to)
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// migrationStep is one registered version pair on a migration path.
+type migrationStep struct {
+	from, to models.Version
+	rules    []MigrationFunc
+}
+
+// path finds the shortest chain of registered pairs from one version to
+// another by breadth-first search, visiting versions in sorted order so
+// the chosen path is deterministic. It returns nil if there is none.
+func (m *Migrator) path(from, to models.Version) []migrationStep {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	prev := map[models.Version]models.Version{from: ""}
+	queue := []models.Version{from}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if v == to {
+			break
+		}
+		next := make([]models.Version, 0, len(m.rules[v]))
+		for n := range m.rules[v] {
+			next = append(next, n)
+		}
+		sort.Slice(next, func(i, j int) bool { return next[i] < next[j] })
+		for _, n := range next {
+			if _, seen := prev[n]; !seen {
+				prev[n] = v
+				queue = append(queue, n)
+			}
+		}
+	}
+	if _, ok := prev[to]; !ok {
+		return nil
+	}
+
+	var steps []migrationStep
+	for v := to; v != from; v = prev[v] {
+		p := prev[v]
+		steps = append(steps, migrationStep{from: p, to: v, rules: m.rules[p][v]})
+	}
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps
+}
+
+// DropFields returns a rule that deletes fields an older version lacks.
+// Paths are dot-separated object keys; a "[]" suffix descends into every
+// element of an array, e.g. "line_items[].parent_id" or "protection".
+func DropFields(paths ...string) MigrationFunc {
+	return func(doc map[string]interface{}) error {
+		for _, path := range paths {
+			parent, key := splitFieldPath(path)
+			eachObject(doc, parent, func(obj map[string]interface{}) {
+				delete(obj, key)
+			})
+		}
+		return nil
+	}
+}
+
+// RenameField returns a rule that moves a field to a new key in the same
+// object. Both paths use the DropFields syntax and must share a parent.
+func RenameField(from, to string) MigrationFunc {
+	return func(doc map[string]interface{}) error {
+		parent, oldKey := splitFieldPath(from)
+		toParent, newKey := splitFieldPath(to)
+		if strings.Join(parent, ".") != strings.Join(toParent, ".") {
+			return fmt.Errorf("cannot rename %s to %s: different parents", from, to)
+		}
+		eachObject(doc, parent, func(obj map[string]interface{}) {
+			if v, ok := obj[oldKey]; ok {
+				delete(obj, oldKey)
+				obj[newKey] = v
+			}
+		})
+		return nil
+	}
+}
+
+// splitFieldPath splits a field path into its parent segments and final
+// key.
+func splitFieldPath(path string) ([]string, string) {
+	segments := strings.Split(path, ".")
+	return segments[:len(segments)-1], segments[len(segments)-1]
+}
+
+// eachObject calls fn for every object reached by following segments from
+// node. Missing or mistyped intermediate values are skipped.
+func eachObject(node interface{}, segments []string, fn func(map[string]interface{})) {
+	obj, ok := node.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if len(segments) == 0 {
+		fn(obj)
+		return
+	}
+	key, isArray := strings.CutSuffix(segments[0], "[]")
+	child := obj[key]
+	if !isArray {
+		eachObject(child, segments[1:], fn)
+		return
+	}
+	items, _ := child.([]interface{})
+	for _, item := range items {
+		eachObject(item, segments[1:], fn)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// ProfileDiff describes how a discovery profile changed between two
+// fetches.
+type ProfileDiff struct {
+	// Added lists capabilities present only in the current profile.
+	Added []models.CapabilityName
+
+	// Removed lists capabilities present only in the previous profile.
+	Removed []models.CapabilityName
+
+	// Upgraded lists capabilities whose version increased.
+	Upgraded []CapabilityVersionChange
+
+	// Downgraded lists capabilities whose version decreased.
+	Downgraded []CapabilityVersionChange
+
+	// Changed lists capabilities present in both at the same version
+	// whose declaration (schema, spec, extends, or config) differs.
+	Changed []models.CapabilityName
+
+	// Endpoints lists service transport endpoints that moved, appeared,
+	// or went away.
+	Endpoints []EndpointChange
+
+	// KeysAdded lists the key IDs of new signing keys.
+	KeysAdded []string
+
+	// KeysRemoved lists the key IDs of withdrawn signing keys.
+	KeysRemoved []string
+
+	// KeysRotated lists key IDs whose key material changed.
+	KeysRotated []string
+}
+
+// CapabilityVersionChange is a capability whose version changed.
+type CapabilityVersionChange struct {
+	Name     models.CapabilityName
+	Previous models.Version
+	Current  models.Version
+}
+
+// EndpointChange is a service transport whose endpoint changed. Previous
+// is empty for a new transport, and Current for a removed one.
+type EndpointChange struct {
+	// Service is the service name, such as "dev.ucp.shopping".
+	Service string
+
+	// Transport is "rest", "mcp", or "a2a".
+	Transport string
+
+	Previous string
+	Current  string
+}
+
+// Empty reports whether the diff records no change.
+func (d *ProfileDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 &&
+		len(d.Upgraded) == 0 && len(d.Downgraded) == 0 && len(d.Changed) == 0 &&
+		len(d.Endpoints) == 0 &&
+		len(d.KeysAdded) == 0 && len(d.KeysRemoved) == 0 && len(d.KeysRotated) == 0
+}
+
+// CapabilityChanged reports whether the diff adds, removes, or changes
+// any capability, which calls for renegotiating.
+func (d *ProfileDiff) CapabilityChanged() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 ||
+		len(d.Upgraded) > 0 || len(d.Downgraded) > 0 || len(d.Changed) > 0
+}
+
+// KeysChanged reports whether the diff adds, removes, or rotates any
+// signing key, which calls for re-pinning keys.
+func (d *ProfileDiff) KeysChanged() bool {
+	return len(d.KeysAdded) > 0 || len(d.KeysRemoved) > 0 || len(d.KeysRotated) > 0
+}
+
+// DiffProfiles compares two discovery profiles. Capabilities are matched
+// by name, services by name and transport, and signing keys by kid. A nil
+// profile is treated as empty. Lists are in profile order, with removals
+// in the previous profile's order, and endpoints sorted by service.
+func DiffProfiles(previous, current *models.UCPProfile) *ProfileDiff {
+	if previous == nil {
+		previous = &models.UCPProfile{}
+	}
+	if current == nil {
+		current = &models.UCPProfile{}
+	}
+	diff := &ProfileDiff{}
+	diffCapabilities(diff, previous.UCP.Capabilities, current.UCP.Capabilities)
+	diffEndpoints(diff, previous.UCP.Services, current.UCP.Services)
+	diffKeys(diff, previous.SigningKeys, current.SigningKeys)
+	return diff
+}
+
+func diffCapabilities(diff *ProfileDiff, previous, current []models.CapabilityDiscovery) {
+	before := make(map[models.CapabilityName]models.CapabilityDiscovery, len(previous))
+	for _, c := range previous {
+		before[c.Name] = c
+	}
+	after := make(map[models.CapabilityName]bool, len(current))
+	for _, c := range current {
+		after[c.Name] = true
+		prev, ok := before[c.Name]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, c.Name)
+		case prev.Version != c.Version && prev.Version.IsValid() && c.Version.IsValid():
+			change := CapabilityVersionChange{Name: c.Name, Previous: prev.Version, Current: c.Version}
+			if compareVersions(prev.Version, c.Version) < 0 {
+				diff.Upgraded = append(diff.Upgraded, change)
+			} else {
+				diff.Downgraded = append(diff.Downgraded, change)
+			}
+		case !reflect.DeepEqual(prev, c):
+			diff.Changed = append(diff.Changed, c.Name)
+		}
+	}
+	for _, c := range previous {
+		if !after[c.Name] {
+			diff.Removed = append(diff.Removed, c.Name)
+		}
+	}
+}
+
+// serviceEndpoints flattens a service's transport endpoints by transport.
+func serviceEndpoints(s models.UCPService) map[string]string {
+	endpoints := make(map[string]string)
+	if s.Rest != nil {
+		endpoints["rest"] = s.Rest.Endpoint
+	}
+	if s.MCP != nil {
+		endpoints["mcp"] = s.MCP.Endpoint
+	}
+	if s.A2A != nil {
+		endpoints["a2a"] = s.A2A.Endpoint
+	}
+	return endpoints
+}
+
+func diffEndpoints(diff *ProfileDiff, previous, current models.Services) {
+	names := make(map[string]bool)
+	for name := range previous {
+		names[name] = true
+	}
+	for name := range current {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		before := serviceEndpoints(previous[name])
+		after := serviceEndpoints(current[name])
+		for _, transport := range []string{"rest", "mcp", "a2a"} {
+			prev, hadPrev := before[transport]
+			cur, hasCur := after[transport]
+			if hadPrev == hasCur && prev == cur {
+				continue
+			}
+			diff.Endpoints = append(diff.Endpoints, EndpointChange{
+				Service:   name,
+				Transport: transport,
+				Previous:  prev,
+				Current:   cur,
+			})
+		}
+	}
+}
+
+func diffKeys(diff *ProfileDiff, previous, current []models.JWK) {
+	before := make(map[string]models.JWK, len(previous))
+	for _, k := range previous {
+		before[k.Kid] = k
+	}
+	after := make(map[string]bool, len(current))
+	for _, k := range current {
+		after[k.Kid] = true
+		prev, ok := before[k.Kid]
+		switch {
+		case !ok:
+			diff.KeysAdded = append(diff.KeysAdded, k.Kid)
+		case prev != k:
+			diff.KeysRotated = append(diff.KeysRotated, k.Kid)
+		}
+	}
+	for _, k := range previous {
+		if !after[k.Kid] {
+			diff.KeysRemoved = append(diff.KeysRemoved, k.Kid)
+		}
+	}
+}
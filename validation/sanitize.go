@@ -0,0 +1,216 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// DefaultMaxTextLength bounds sanitized free text, in characters.
+const DefaultMaxTextLength = 500
+
+// DefaultRedaction replaces text matched by PII redaction.
+const DefaultRedaction = "[redacted]"
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	ssnPattern   = regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)
+
+	// cardPattern matches 13 to 19 digits, optionally grouped by spaces or
+	// dashes; matches are only redacted if they pass the Luhn check.
+	cardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+
+	// phonePattern matches ten-digit numbers with common separators and an
+	// optional country code, but not digits inside longer numbers.
+	phonePattern = regexp.MustCompile(`(?:\+\d{1,3}[ .-]?|\(|\b)\d{3}\)?[ .-]?\d{3}[ .-]?\d{4}\b`)
+)
+
+// Sanitizer cleans LLM-authored free text, such as Context.Intent, before
+// it is sent, logged, or rendered. It is safe for concurrent use.
+type Sanitizer struct {
+	maxLength int
+	redactPII bool
+	redaction string
+}
+
+// SanitizerOption configures a Sanitizer.
+type SanitizerOption func(*Sanitizer)
+
+// WithMaxTextLength bounds sanitized text to n characters. Defaults to
+// DefaultMaxTextLength; zero or less disables the bound.
+func WithMaxTextLength(n int) SanitizerOption {
+	return func(s *Sanitizer) {
+		s.maxLength = n
+	}
+}
+
+// WithPIIRedaction replaces email addresses, phone numbers, payment card
+// numbers, and US social security numbers with DefaultRedaction. Pattern
+// matching is best effort; it reduces accidental disclosure but is not a
+// guarantee.
+func WithPIIRedaction() SanitizerOption {
+	return func(s *Sanitizer) {
+		s.redactPII = true
+	}
+}
+
+// WithRedaction sets the replacement for redacted PII.
+func WithRedaction(replacement string) SanitizerOption {
+	return func(s *Sanitizer) {
+		s.redaction = replacement
+	}
+}
+
+// NewSanitizer creates a Sanitizer. Without options it strips control and
+// invisible formatting characters, collapses whitespace, and bounds text
+// to DefaultMaxTextLength.
+func NewSanitizer(opts ...SanitizerOption) *Sanitizer {
+	s := &Sanitizer{maxLength: DefaultMaxTextLength, redaction: DefaultRedaction}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DefaultSanitizer is the sanitizer used by SanitizeText and
+// SanitizeContext. It does not redact PII.
+var DefaultSanitizer = NewSanitizer()
+
+// SanitizeText sanitizes text with DefaultSanitizer.
+func SanitizeText(text string) string {
+	return DefaultSanitizer.Text(text)
+}
+
+// SanitizeContext sanitizes a context with DefaultSanitizer.
+func SanitizeContext(c *models.Context) *models.Context {
+	return DefaultSanitizer.Context(c)
+}
+
+// Text returns text as valid UTF-8 with control and invisible formatting
+// characters (including bidirectional overrides and zero-width characters)
+// removed, runs of whitespace collapsed to single spaces, PII redacted if
+// configured, and the result truncated to the length bound.
+func (s *Sanitizer) Text(text string) string {
+	text = strings.ToValidUTF8(text, "")
+
+	var b strings.Builder
+	b.Grow(len(text))
+	space := false
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			space = b.Len() > 0
+			continue
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r):
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	text = b.String()
+
+	if s.redactPII {
+		text = s.redact(text)
+	}
+	if s.maxLength > 0 {
+		text = truncateRunes(text, s.maxLength)
+	}
+	return text
+}
+
+// Context returns a sanitized copy of c, or nil if c is nil. Intent gets
+// the full Text treatment; the other hints are short codes, so they only
+// have control characters and surrounding whitespace removed. Format
+// checks are left to ValidateContext.
+func (s *Sanitizer) Context(c *models.Context) *models.Context {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	out.Intent = s.Text(c.Intent)
+	out.AddressCountry = stripControl(c.AddressCountry)
+	out.AddressRegion = stripControl(c.AddressRegion)
+	out.PostalCode = stripControl(c.PostalCode)
+	out.Locale = stripControl(c.Locale)
+	out.Currency = stripControl(c.Currency)
+	out.Timezone = stripControl(c.Timezone)
+	return &out
+}
+
+// redact replaces PII patterns in text.
+func (s *Sanitizer) redact(text string) string {
+	text = emailPattern.ReplaceAllString(text, s.redaction)
+	text = ssnPattern.ReplaceAllString(text, s.redaction)
+	text = cardPattern.ReplaceAllStringFunc(text, func(match string) string {
+		if luhnValid(match) {
+			return s.redaction
+		}
+		return match
+	})
+	return phonePattern.ReplaceAllString(text, s.redaction)
+}
+
+// stripControl removes control and formatting characters and trims
+// surrounding whitespace.
+func stripControl(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(text, ""))
+	return strings.TrimSpace(text)
+}
+
+// truncateRunes bounds text to n runes, trimming any trailing space left
+// by the cut.
+func truncateRunes(text string, n int) string {
+	count := 0
+	for i := range text {
+		if count == n {
+			return strings.TrimRight(text[:i], " ")
+		}
+		count++
+	}
+	return text
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum.
+func luhnValid(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -20,7 +20,20 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
+
+	"github.com/dhananjay2021/ucp-go-sdk/httpcache"
+)
+
+const (
+	// maxSchemaBytes bounds the size of a fetched schema document.
+	maxSchemaBytes = 4 << 20
+
+	// maxSchemaIndexEntries bounds the parsed schemas ValidatePayload
+	// keeps; the index is rebuilt from scratch once it grows past this.
+	maxSchemaIndexEntries = 4096
 )
 
 // SchemaValidator validates JSON data against UCP schemas.
@@ -28,20 +41,109 @@ type SchemaValidator struct {
 	schemaCache map[string][]byte
 	mu          sync.RWMutex
 	httpClient  *http.Client
+	httpCache   *httpcache.Cache
+
+	// schemaIndex holds parsed schemas for ValidatePayload by absolute
+	// URI, including embedded $id resources and anchors.
+	schemaIndex map[string]any
+
+	// patterns caches compiled pattern keywords.
+	patterns sync.Map
+
+	assertFormats bool
+
+	// fetchRestricted limits fetching to URLs under fetchPrefixes.
+	fetchRestricted bool
+	fetchPrefixes   []*url.URL
+}
+
+// SchemaValidatorOption configures a SchemaValidator.
+type SchemaValidatorOption func(*SchemaValidator)
+
+// WithSchemaHTTPClient sets the HTTP client used to fetch schemas. Fetches
+// still go through the validator's HTTP cache.
+func WithSchemaHTTPClient(client *http.Client) SchemaValidatorOption {
+	return func(v *SchemaValidator) {
+		v.httpClient = client
+	}
+}
+
+// WithSchemaCache sets the HTTP cache for schema fetches. Defaults to
+// httpcache.Default.
+func WithSchemaCache(cache *httpcache.Cache) SchemaValidatorOption {
+	return func(v *SchemaValidator) {
+		v.httpCache = cache
+	}
+}
+
+// WithSchemaFetchAllowlist limits the schemas the validator fetches to
+// those under the given URL prefixes, such as "https://ucp.dev/schemas/",
+// matched by scheme, host, and path. Schemas preloaded with
+// LoadSchemaFromBytes are always used. With no prefixes, only preloaded
+// schemas are used.
+func WithSchemaFetchAllowlist(prefixes ...string) SchemaValidatorOption {
+	return func(v *SchemaValidator) {
+		v.fetchRestricted = true
+		for _, p := range prefixes {
+			if u, err := url.Parse(p); err == nil && u.Host != "" {
+				v.fetchPrefixes = append(v.fetchPrefixes, u)
+			}
+		}
+	}
 }
 
 // NewSchemaValidator creates a new schema validator.
-func NewSchemaValidator() *SchemaValidator {
-	return &SchemaValidator{
+func NewSchemaValidator(opts ...SchemaValidatorOption) *SchemaValidator {
+	v := &SchemaValidator{
 		schemaCache: make(map[string][]byte),
-		httpClient:  &http.Client{},
+		httpCache:   httpcache.Default,
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	v.httpClient = v.httpCache.Client(v.httpClient)
+	checkRedirect := v.httpClient.CheckRedirect
+	v.httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !v.fetchAllowed(req.URL) {
+			return fmt.Errorf("redirect to %s is not in the schema fetch allowlist", req.URL)
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return fmt.Errorf("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return v
+}
+
+// fetchAllowed reports whether the validator may fetch u.
+func (v *SchemaValidator) fetchAllowed(u *url.URL) bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if !v.fetchRestricted {
+		return true
+	}
+	for _, p := range v.fetchPrefixes {
+		if u.Scheme == p.Scheme && strings.EqualFold(u.Host, p.Host) && strings.HasPrefix(u.Path, p.Path) {
+			return true
+		}
+	}
+	return false
 }
 
 // ValidationError represents a schema validation error.
 type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
+
+	// Pointer is the JSON Pointer of the failing instance location, set
+	// by ValidatePayload.
+	Pointer string `json:"pointer,omitempty"`
+
+	// Keyword is the schema keyword that failed, set by ValidatePayload.
+	Keyword string `json:"keyword,omitempty"`
 }
 
 func (e *ValidationError) Error() string {
@@ -57,48 +159,53 @@ type ValidationResult struct {
 	Errors []ValidationError `json:"errors,omitempty"`
 }
 
-// LoadSchema loads a schema from a URL and caches it.
-func (v *SchemaValidator) LoadSchema(url string) ([]byte, error) {
+// LoadSchema returns the schema at a URL. Schemas preloaded with
+// LoadSchemaFromBytes are returned as is; others are fetched through the
+// validator's HTTP cache, which honors Cache-Control and ETag, if the
+// validator's fetch allowlist permits.
+func (v *SchemaValidator) LoadSchema(schemaURL string) ([]byte, error) {
 	v.mu.RLock()
-	if schema, ok := v.schemaCache[url]; ok {
+	if schema, ok := v.schemaCache[schemaURL]; ok {
 		v.mu.RUnlock()
 		return schema, nil
 	}
 	v.mu.RUnlock()
 
-	// Fetch schema
-	resp, err := v.httpClient.Get(url)
+	u, err := url.Parse(schemaURL)
+	if err != nil || !v.fetchAllowed(u) {
+		return nil, fmt.Errorf("schema %s is not preloaded and not in the fetch allowlist", schemaURL)
+	}
+	resp, err := v.httpClient.Get(schemaURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch schema from %s: %w", url, err)
+		return nil, fmt.Errorf("failed to fetch schema from %s: %w", schemaURL, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch schema from %s: status %d", url, resp.StatusCode)
+		return nil, fmt.Errorf("failed to fetch schema from %s: status %d", schemaURL, resp.StatusCode)
 	}
 
-	schema, err := io.ReadAll(resp.Body)
+	schema, err := io.ReadAll(io.LimitReader(resp.Body, maxSchemaBytes+1))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read schema from %s: %w", url, err)
+		return nil, fmt.Errorf("failed to read schema from %s: %w", schemaURL, err)
+	}
+	if len(schema) > maxSchemaBytes {
+		return nil, fmt.Errorf("schema %s exceeds %d bytes", schemaURL, maxSchemaBytes)
 	}
-
-	// Cache the schema
-	v.mu.Lock()
-	v.schemaCache[url] = schema
-	v.mu.Unlock()
-
 	return schema, nil
 }
 
 // LoadSchemaFromBytes loads a schema from bytes and caches it under a key.
+// Use the schema's URL as the key for $ref resolution to find it.
 func (v *SchemaValidator) LoadSchemaFromBytes(key string, schema []byte) {
 	v.mu.Lock()
 	v.schemaCache[key] = schema
+	v.schemaIndex = nil
 	v.mu.Unlock()
 }
 
-// ValidateJSON performs basic JSON validation.
-// Note: For full JSON Schema validation, use a library like github.com/santhosh-tekuri/jsonschema/v5
+// ValidateJSON performs basic JSON validation. Use ValidatePayload to
+// validate against a schema.
 func (v *SchemaValidator) ValidateJSON(data []byte) *ValidationResult {
 	var parsed interface{}
 	if err := json.Unmarshal(data, &parsed); err != nil {
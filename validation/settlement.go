@@ -0,0 +1,66 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/dhananjay2021/ucp-go-sdk/extensions"
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// ValidateSettlement checks an order's settlement: it must be in the
+// order's currency, capture no more than the order total, and have
+// non-negative splits that sum exactly to the captured amount. Orders
+// without a settlement are valid.
+//
+// Field paths in the result are JSONPaths relative to the order root.
+func ValidateSettlement(order *extensions.ExtendedOrder) *ValidationResult {
+	result := &ValidationResult{Valid: true}
+	settlement := order.Settlement
+	if settlement == nil {
+		return result
+	}
+	addError := func(field, format string, args ...interface{}) {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if order.Currency != "" && settlement.Currency != order.Currency {
+		addError("$.settlement.currency", "settlement currency %q does not match order currency %q", settlement.Currency, order.Currency)
+	}
+	if settlement.CapturedAmount < 0 {
+		addError("$.settlement.captured_amount", "captured amount %d is negative", settlement.CapturedAmount)
+	}
+	if total, ok := totalOf(order.Totals, models.TotalTypeTotal); ok && settlement.CapturedAmount > total {
+		addError("$.settlement.captured_amount", "captured amount %d exceeds order total %d", settlement.CapturedAmount, total)
+	}
+
+	for i, split := range settlement.Splits {
+		if split.Amount < 0 {
+			addError(fmt.Sprintf("$.settlement.splits[%d].amount", i), "split amount %d is negative", split.Amount)
+		}
+		if split.Type == "" {
+			addError(fmt.Sprintf("$.settlement.splits[%d].type", i), "split type is required")
+		}
+	}
+	if sum := settlement.SplitTotal(); sum != settlement.CapturedAmount {
+		addError("$.settlement.splits", "splits sum to %d, captured amount is %d", sum, settlement.CapturedAmount)
+	}
+	return result
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 UCP Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/dhananjay2021/ucp-go-sdk/models"
+)
+
+// Stock messages describe line items that cannot be sold as requested.
+// Merchants should build them with OutOfStockMessage, LimitedQuantityMessage,
+// and BackorderedMessage so that platforms can act on them with
+// StockIssues, e.g. by offering to reduce a quantity or substitute an item:
+//
+//   - out_of_stock: an error; the item cannot be sold at all.
+//   - limited_quantity: an error on the line item's quantity; its content
+//     starts with "Only N available".
+//   - backordered: a warning; the item is sold but ships once restocked.
+//
+// Each carries a path to the line item, $.line_items[i], with
+// limited_quantity pointing at $.line_items[i].quantity.
+
+// stockPath matches the path of a stock message.
+var stockPath = regexp.MustCompile(`^\$\.line_items\[(\d+)\](\.quantity)?$`)
+
+// limitedQuantityPrefix is how limited_quantity content begins.
+const limitedQuantityPrefix = "Only %d available"
+
+// OutOfStockMessage returns the out_of_stock message for the line item at
+// index.
+func OutOfStockMessage(index int, title string) models.Message {
+	return models.Message{
+		Type:     models.MessageTypeError,
+		Code:     string(models.ErrorCodeOutOfStock),
+		Content:  fmt.Sprintf("%s is out of stock", title),
+		Severity: models.SeverityRecoverable,
+		Path:     fmt.Sprintf("$.line_items[%d]", index),
+	}
+}
+
+// LimitedQuantityMessage returns the limited_quantity message for the line
+// item at index, of which only available units can be sold.
+func LimitedQuantityMessage(index int, title string, available int) models.Message {
+	return models.Message{
+		Type:     models.MessageTypeError,
+		Code:     string(models.ErrorCodeLimitedQuantity),
+		Content:  fmt.Sprintf(limitedQuantityPrefix+" of %s", available, title),
+		Severity: models.SeverityRecoverable,
+		Path:     fmt.Sprintf("$.line_items[%d].quantity", index),
+	}
+}
+
+// BackorderedMessage returns the backordered message for the line item at
+// index.
+func BackorderedMessage(index int, title string) models.Message {
+	return models.Message{
+		Type:    models.MessageTypeWarning,
+		Code:    string(models.ErrorCodeBackordered),
+		Content: fmt.Sprintf("%s is on backorder and ships once restocked", title),
+		Path:    fmt.Sprintf("$.line_items[%d]", index),
+	}
+}
+
+// StockIssue is a stock message read back for a platform.
+type StockIssue struct {
+	// Code is out_of_stock, limited_quantity, or backordered.
+	Code models.ErrorCode
+
+	// LineItem is the index of the line item in the request.
+	LineItem int
+
+	// Available is the quantity that can be sold, or -1 if unknown. It is
+	// 0 for out_of_stock.
+	Available int
+
+	// Message is the message the issue was read from.
+	Message models.Message
+}
+
+// StockIssues returns the stock messages among messages as issues, in
+// order. Messages that do not follow the conventions are skipped; use
+// ValidateStockMessages to find them.
+func StockIssues(messages []models.Message) []StockIssue {
+	var issues []StockIssue
+	for _, m := range messages {
+		if issue, ok := parseStockMessage(m); ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// parseStockMessage reads a stock message.
+func parseStockMessage(m models.Message) (StockIssue, bool) {
+	code := models.ErrorCode(m.Code)
+	match := stockPath.FindStringSubmatch(m.Path)
+	if match == nil || (match[2] != "") != (code == models.ErrorCodeLimitedQuantity) {
+		return StockIssue{}, false
+	}
+	index, _ := strconv.Atoi(match[1])
+	issue := StockIssue{Code: code, LineItem: index, Available: -1, Message: m}
+	switch code {
+	case models.ErrorCodeOutOfStock:
+		issue.Available = 0
+	case models.ErrorCodeLimitedQuantity:
+		if _, err := fmt.Sscanf(m.Content, limitedQuantityPrefix, &issue.Available); err != nil {
+			issue.Available = -1
+		}
+	case models.ErrorCodeBackordered:
+	default:
+		return StockIssue{}, false
+	}
+	return issue, true
+}
+
+// ValidateStockMessages checks that the stock messages among messages
+// follow the conventions: the type and severity for their code and a
+// path to a line item, with limited_quantity content stating the
+// available quantity. Field paths in the result are JSONPaths into the
+// response.
+func ValidateStockMessages(messages []models.Message) *ValidationResult {
+	result := &ValidationResult{Valid: true}
+	addError := func(field, format string, args ...interface{}) {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for i, m := range messages {
+		field := fmt.Sprintf("$.messages[%d]", i)
+		code := models.ErrorCode(m.Code)
+
+		var wantType models.MessageType
+		var wantPath string
+		switch code {
+		case models.ErrorCodeOutOfStock:
+			wantType, wantPath = models.MessageTypeError, "$.line_items[i]"
+		case models.ErrorCodeLimitedQuantity:
+			wantType, wantPath = models.MessageTypeError, "$.line_items[i].quantity"
+		case models.ErrorCodeBackordered:
+			wantType, wantPath = models.MessageTypeWarning, "$.line_items[i]"
+		default:
+			continue
+		}
+
+		if m.Type != wantType {
+			addError(field+".type", "%s message must be of type %s, got %q", code, wantType, m.Type)
+		}
+		if wantType == models.MessageTypeError && m.Severity != "" && m.Severity != models.SeverityRecoverable {
+			addError(field+".severity", "%s message must be %s, got %q", code, models.SeverityRecoverable, m.Severity)
+		}
+		issue, ok := parseStockMessage(m)
+		if !ok {
+			addError(field+".path", "%s message must have path %s, got %q", code, wantPath, m.Path)
+			continue
+		}
+		if code == models.ErrorCodeLimitedQuantity && issue.Available < 0 {
+			addError(field+".content", "limited_quantity content must start with %q", "Only N available")
+		}
+	}
+	return result
+}